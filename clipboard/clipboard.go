@@ -0,0 +1,43 @@
+package clipboard
+
+// Clipboard is a clipboard that can hold text.
+type Clipboard interface {
+	// ReadText returns the text currently held by the clipboard.
+	ReadText() (string, error)
+
+	// WriteText replaces the text held by the clipboard with s.
+	WriteText(s string) error
+}
+
+// MemoryClipboard is a Clipboard that holds text in memory.
+type MemoryClipboard struct {
+	text string
+}
+
+var current Clipboard = &MemoryClipboard{}
+
+// SetClipboard sets the clipboard used by ReadText and WriteText to c.
+func SetClipboard(c Clipboard) {
+	current = c
+}
+
+// ReadText returns the text currently held by the clipboard.
+func ReadText() (string, error) {
+	return current.ReadText()
+}
+
+// WriteText replaces the text held by the clipboard with s.
+func WriteText(s string) error {
+	return current.WriteText(s)
+}
+
+// ReadText implements Clipboard.
+func (m *MemoryClipboard) ReadText() (string, error) {
+	return m.text, nil
+}
+
+// WriteText implements Clipboard.
+func (m *MemoryClipboard) WriteText(s string) error {
+	m.text = s
+	return nil
+}
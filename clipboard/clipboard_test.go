@@ -0,0 +1,20 @@
+package clipboard
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWriteText_ReadText(t *testing.T) {
+	is := is.New(t)
+
+	SetClipboard(&MemoryClipboard{})
+
+	err := WriteText("foo")
+	is.NoErr(err)
+
+	s, err := ReadText()
+	is.NoErr(err)
+	is.Equal(s, "foo")
+}
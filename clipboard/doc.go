@@ -0,0 +1,5 @@
+// Package clipboard provides an abstraction of the system clipboard that widgets use to copy and paste text.
+//
+// Ebiten does not provide access to the system clipboard. By default, an in-memory clipboard is used that
+// is not shared with other applications. Applications may call SetClipboard to use a different implementation.
+package clipboard
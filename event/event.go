@@ -4,8 +4,9 @@ import internalevent "github.com/blizzy78/ebitenui/internal/event"
 
 // Event encapsulates an arbitrary event that event handlers may be interested in.
 type Event struct {
-	idCounter uint32
-	handlers  []handler
+	idCounter  uint32
+	generation uint32
+	handlers   []*handler
 }
 
 // A HandlerFunc is a function that receives and handles an event. When firing an event using
@@ -16,8 +17,11 @@ type HandlerFunc func(args interface{})
 type RemoveHandlerFunc func()
 
 type handler struct {
-	id uint32
-	h  HandlerFunc
+	id       uint32
+	h        HandlerFunc
+	priority int
+	once     bool
+	removed  bool
 }
 
 type deferredEvent struct {
@@ -26,33 +30,74 @@ type deferredEvent struct {
 }
 
 type deferredAddHandler struct {
-	event   *Event
-	handler handler
+	event      *Event
+	handler    *handler
+	generation uint32
 }
 
 // AddHandler registers event handler h with e. It returns a function to remove h from e if desired.
 func (e *Event) AddHandler(h HandlerFunc) RemoveHandlerFunc {
+	return e.addHandler(h, 0, false)
+}
+
+// AddHandlerOnce registers event handler h with e. h is removed from e after it has handled an event.
+// It returns a function to remove h from e before that if desired.
+func (e *Event) AddHandlerOnce(h HandlerFunc) RemoveHandlerFunc {
+	return e.addHandler(h, 0, true)
+}
+
+// AddHandlerPriority registers event handler h with e, using priority p. Handlers with higher priority handle
+// events first, handlers with equal priority handle events in the order they were added. AddHandler uses
+// priority 0. It returns a function to remove h from e if desired.
+func (e *Event) AddHandlerPriority(h HandlerFunc, p int) RemoveHandlerFunc {
+	return e.addHandler(h, p, false)
+}
+
+func (e *Event) addHandler(h HandlerFunc, priority int, once bool) RemoveHandlerFunc {
 	e.idCounter++
 
-	id := e.idCounter
+	hd := &handler{
+		id:       e.idCounter,
+		h:        h,
+		priority: priority,
+		once:     once,
+	}
 
 	internalevent.AddDeferred(&deferredAddHandler{
-		event: e,
-		handler: handler{
-			id: id,
-			h:  h,
-		},
+		event:      e,
+		handler:    hd,
+		generation: e.generation,
 	})
 
 	return func() {
-		e.removeHandler(id)
+		e.removeHandler(hd)
 	}
 }
 
-func (e *Event) removeHandler(id uint32) {
+// HasHandlers returns whether any handlers are registered with e. Handlers registered using AddHandler etc.
+// that have not been added yet are not considered.
+func (e *Event) HasHandlers() bool {
+	return len(e.handlers) > 0
+}
+
+// RemoveAllHandlers removes all handlers from e, including handlers that have been registered using
+// widget options. Handlers registered using AddHandler etc. that have not been added yet are removed as well.
+func (e *Event) RemoveAllHandlers() {
+	for _, h := range e.handlers {
+		h.removed = true
+	}
+
+	e.handlers = nil
+	e.generation++
+}
+
+func (e *Event) removeHandler(hd *handler) {
+	// remove handler even if it has not been added yet
+	hd.removed = true
+
 	index := -1
 	for i, h := range e.handlers {
-		if h.id == id {
+		if h == hd {
 			index = i
 			break
 		}
@@ -62,7 +107,10 @@ func (e *Event) removeHandler(id uint32) {
 		return
 	}
 
-	e.handlers = append(e.handlers[:index], e.handlers[index+1:]...)
+	// don't modify the slice in place, it may currently be iterated over
+	handlers := make([]*handler, 0, len(e.handlers)-1)
+	handlers = append(handlers, e.handlers[:index]...)
+	e.handlers = append(handlers, e.handlers[index+1:]...)
 }
 
 // Fire fires an event to all registered handlers. Arbitrary event arguments may be passed
@@ -79,6 +127,14 @@ func (e *Event) Fire(args interface{}) {
 
 func (e *Event) handle(args interface{}) {
 	for _, h := range e.handlers {
+		if h.removed {
+			continue
+		}
+
+		if h.once {
+			e.removeHandler(h)
+		}
+
 		h.h(args)
 	}
 }
@@ -90,15 +146,27 @@ func (e *deferredEvent) Do() {
 
 // Do implements DeferredAction.
 func (a *deferredAddHandler) Do() {
-	a.event.handlers = append(a.event.handlers, a.handler)
+	e := a.event
+	if a.handler.removed || a.generation != e.generation {
+		return
+	}
+
+	// insert after all handlers with the same or higher priority
+	index := len(e.handlers)
+	for i, h := range e.handlers {
+		if h.priority < a.handler.priority {
+			index = i
+			break
+		}
+	}
+
+	handlers := make([]*handler, 0, len(e.handlers)+1)
+	handlers = append(handlers, e.handlers[:index]...)
+	handlers = append(handlers, a.handler)
+	e.handlers = append(handlers, e.handlers[index:]...)
 }
 
 // AddEventHandlerOneShot registers event handler h with e. When e fires an event, h is removed from e immediately.
 func AddEventHandlerOneShot(e *Event, h HandlerFunc) {
-	var r RemoveHandlerFunc
-	rh := func(args interface{}) {
-		r()
-		h(args)
-	}
-	r = e.AddHandler(rh)
+	e.AddHandlerOnce(h)
 }
@@ -0,0 +1,109 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestEvent_AddHandlerPriority(t *testing.T) {
+	is := is.New(t)
+
+	e := &Event{}
+
+	var order []string
+	e.AddHandler(func(args interface{}) {
+		order = append(order, "a")
+	})
+	e.AddHandlerPriority(func(args interface{}) {
+		order = append(order, "b")
+	}, 10)
+	e.AddHandler(func(args interface{}) {
+		order = append(order, "c")
+	})
+	e.AddHandlerPriority(func(args interface{}) {
+		order = append(order, "d")
+	}, -1)
+
+	e.Fire(nil)
+	ExecuteDeferred()
+
+	is.Equal(order, []string{"b", "a", "c", "d"})
+}
+
+func TestEvent_AddHandlerOnce(t *testing.T) {
+	is := is.New(t)
+
+	e := &Event{}
+
+	numOnce := 0
+	e.AddHandlerOnce(func(args interface{}) {
+		numOnce++
+	})
+
+	num := 0
+	e.AddHandler(func(args interface{}) {
+		num++
+	})
+
+	e.Fire(nil)
+	e.Fire(nil)
+	ExecuteDeferred()
+
+	is.Equal(numOnce, 1)
+	is.Equal(num, 2)
+}
+
+func TestEvent_RemoveHandler_BeforeAdded(t *testing.T) {
+	is := is.New(t)
+
+	e := &Event{}
+
+	num := 0
+	remove := e.AddHandler(func(args interface{}) {
+		num++
+	})
+	remove()
+
+	e.Fire(nil)
+	ExecuteDeferred()
+
+	is.Equal(num, 0)
+}
+
+func TestEvent_RemoveAllHandlers(t *testing.T) {
+	is := is.New(t)
+
+	e := &Event{}
+
+	num := 0
+	e.AddHandler(func(args interface{}) {
+		num++
+	})
+	ExecuteDeferred()
+
+	e.AddHandler(func(args interface{}) {
+		num++
+	})
+
+	e.RemoveAllHandlers()
+
+	e.Fire(nil)
+	ExecuteDeferred()
+
+	is.Equal(num, 0)
+}
+
+func TestEvent_HasHandlers(t *testing.T) {
+	is := is.New(t)
+
+	e := &Event{}
+	is.True(!e.HasHandlers())
+
+	remove := e.AddHandler(func(args interface{}) {})
+	ExecuteDeferred()
+	is.True(e.HasHandlers())
+
+	remove()
+	is.True(!e.HasHandlers())
+}
@@ -3,12 +3,10 @@ module github.com/blizzy78/ebitenui
 go 1.15
 
 require (
-	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20201108214237-06ea97f0c265 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
-	github.com/hajimehoshi/ebiten/v2 v2.0.2
+	github.com/hajimehoshi/ebiten/v2 v2.1.7
 	github.com/matryer/is v1.4.0
 	github.com/stretchr/testify v1.6.1
 	golang.org/x/exp v0.0.0-20201221025956-e89b829e73ea // indirect
-	golang.org/x/image v0.0.0-20201208152932-35266b937fa6
-	golang.org/x/sys v0.0.0-20201223074533-0d417f636930 // indirect
+	golang.org/x/image v0.0.0-20210220032944-ac19c3e999fb
 )
@@ -0,0 +1,392 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// An Animation is a sequence of frames decoded from an animated image file, such as a GIF or APNG file.
+type Animation struct {
+	// Frames are the fully composed frames of the animation, all having the same size.
+	Frames []*ebiten.Image
+
+	// Durations are the durations to show each frame for, at the same index as in Frames.
+	Durations []time.Duration
+
+	// LoopCount is the number of times the animation should be played. If 0, it should loop forever.
+	LoopCount int
+}
+
+// defaultFrameDuration is used for frames that do not specify a duration, the same as web browsers do.
+const defaultFrameDuration = 100 * time.Millisecond
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// DecodeGIF decodes an animated GIF from r. If scale is greater than 0 and less than 1, the frames are
+// downscaled by that factor.
+func DecodeGIF(r io.Reader, scale float64) (*Animation, error) {
+	frames, durations, loops, err := decodeGIFFrames(r)
+	if err != nil {
+		return nil, err
+	}
+	return newAnimation(frames, durations, loops, scale), nil
+}
+
+// DecodeAPNG decodes an animated PNG from r. Non-animated PNG files are decoded as a single frame. If scale
+// is greater than 0 and less than 1, the frames are downscaled by that factor.
+func DecodeAPNG(r io.Reader, scale float64) (*Animation, error) {
+	frames, durations, loops, err := decodeAPNGFrames(r)
+	if err != nil {
+		return nil, err
+	}
+	return newAnimation(frames, durations, loops, scale), nil
+}
+
+// NineSlice returns a NineSlice that cycles through a's frames, having column widths w and row heights h.
+func (a *Animation) NineSlice(w [3]int, h [3]int) *NineSlice {
+	frames := make([]*NineSlice, len(a.Frames))
+	for i, f := range a.Frames {
+		frames[i] = NewNineSlice(f, w, h)
+	}
+	return NewNineSliceAnimatedDurations(frames, a.Durations)
+}
+
+// Stretched returns a NineSlice that cycles through a's frames, stretching each frame as a whole. This is useful
+// to show an Animation using a Graphic widget.
+func (a *Animation) Stretched() *NineSlice {
+	w, h := a.Size()
+	return a.NineSlice([3]int{0, w, 0}, [3]int{0, h, 0})
+}
+
+// Size returns the size of a's frames.
+func (a *Animation) Size() (int, int) {
+	if len(a.Frames) == 0 {
+		return 0, 0
+	}
+	return a.Frames[0].Size()
+}
+
+func newAnimation(frames []image.Image, durations []time.Duration, loops int, scale float64) *Animation {
+	a := &Animation{
+		Frames:    make([]*ebiten.Image, len(frames)),
+		Durations: durations,
+		LoopCount: loops,
+	}
+
+	for i, f := range frames {
+		a.Frames[i] = downscale(ebiten.NewImageFromImage(f), scale)
+	}
+
+	return a
+}
+
+func downscale(i *ebiten.Image, scale float64) *ebiten.Image {
+	if scale <= 0 || scale >= 1 {
+		return i
+	}
+
+	w, h := i.Size()
+	sw, sh := int(float64(w)*scale), int(float64(h)*scale)
+	if sw < 1 {
+		sw = 1
+	}
+	if sh < 1 {
+		sh = 1
+	}
+
+	s := ebiten.NewImage(sw, sh)
+	opts := ebiten.DrawImageOptions{
+		Filter: ebiten.FilterLinear,
+	}
+	opts.GeoM.Scale(float64(sw)/float64(w), float64(sh)/float64(h))
+	s.DrawImage(i, &opts)
+
+	i.Dispose()
+
+	return s
+}
+
+func decodeGIFFrames(r io.Reader) ([]image.Image, []time.Duration, int, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if len(g.Image) == 0 {
+		return nil, nil, 0, fmt.Errorf("GIF has no frames")
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, g.Config.Width, g.Config.Height))
+	if canvas.Rect.Empty() {
+		canvas = image.NewRGBA(g.Image[0].Bounds())
+	}
+
+	frames := make([]image.Image, len(g.Image))
+	durations := make([]time.Duration, len(g.Image))
+
+	for i, p := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		draw.Draw(canvas, p.Bounds(), p, p.Bounds().Min, draw.Over)
+		frames[i] = cloneRGBA(canvas)
+
+		durations[i] = defaultFrameDuration
+		if i < len(g.Delay) && g.Delay[i] > 1 {
+			durations[i] = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, p.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	loops := g.LoopCount
+	switch {
+	case loops < 0:
+		loops = 1
+	case loops > 0:
+		loops++
+	}
+
+	return frames, durations, loops, nil
+}
+
+// apngFrame is a frame control chunk of an APNG file, together with the image data that follows it.
+type apngFrame struct {
+	width    int
+	height   int
+	x        int
+	y        int
+	duration time.Duration
+	dispose  byte
+	blend    byte
+	data     [][]byte
+}
+
+const (
+	apngDisposeNone       = 0
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+
+	apngBlendSource = 0
+)
+
+func decodeAPNGFrames(r io.Reader) ([]image.Image, []time.Duration, int, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if !bytes.HasPrefix(b, pngSignature) {
+		return nil, nil, 0, fmt.Errorf("not a PNG file")
+	}
+
+	var (
+		ihdr     []byte
+		shared   [][]byte
+		animated bool
+		loops    int
+		frames   []*apngFrame
+	)
+
+	for p := len(pngSignature); p+12 <= len(b); {
+		length := int(binary.BigEndian.Uint32(b[p:]))
+		if length < 0 || p+12+length > len(b) {
+			return nil, nil, 0, fmt.Errorf("truncated PNG chunk")
+		}
+
+		typ := string(b[p+4 : p+8])
+		data := b[p+8 : p+8+length]
+		chunk := b[p : p+12+length]
+		p += 12 + length
+
+		switch typ {
+		case "IHDR":
+			ihdr = data
+
+		case "acTL":
+			if len(data) < 8 {
+				return nil, nil, 0, fmt.Errorf("invalid acTL chunk")
+			}
+			animated = true
+			loops = int(binary.BigEndian.Uint32(data[4:]))
+
+		case "fcTL":
+			f, err := parseAPNGFrameControl(data)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			frames = append(frames, f)
+
+		case "IDAT":
+			// IDAT only belongs to the animation if it is preceded by a frame control chunk
+			if len(frames) > 0 {
+				frames[len(frames)-1].data = append(frames[len(frames)-1].data, data)
+			}
+
+		case "fdAT":
+			if len(data) < 4 || len(frames) == 0 {
+				return nil, nil, 0, fmt.Errorf("invalid fdAT chunk")
+			}
+			frames[len(frames)-1].data = append(frames[len(frames)-1].data, data[4:])
+
+		case "IEND":
+			p = len(b)
+
+		default:
+			// ancillary chunks such as PLTE or tRNS are needed to decode each frame
+			if len(frames) == 0 {
+				shared = append(shared, chunk)
+			}
+		}
+	}
+
+	if !animated || len(frames) == 0 {
+		i, err := png.Decode(bytes.NewReader(b))
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		return []image.Image{i}, []time.Duration{defaultFrameDuration}, 0, nil
+	}
+
+	if len(ihdr) < 8 {
+		return nil, nil, 0, fmt.Errorf("invalid IHDR chunk")
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, int(binary.BigEndian.Uint32(ihdr)), int(binary.BigEndian.Uint32(ihdr[4:]))))
+
+	images := make([]image.Image, len(frames))
+	durations := make([]time.Duration, len(frames))
+
+	for i, f := range frames {
+		fi, err := png.Decode(bytes.NewReader(f.png(ihdr, shared)))
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		rect := image.Rect(f.x, f.y, f.x+f.width, f.y+f.height)
+
+		dispose := f.dispose
+		if i == 0 && dispose == apngDisposePrevious {
+			dispose = apngDisposeBackground
+		}
+
+		var previous *image.RGBA
+		if dispose == apngDisposePrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		op := draw.Over
+		if f.blend == apngBlendSource {
+			op = draw.Src
+		}
+		draw.Draw(canvas, rect, fi, fi.Bounds().Min, op)
+
+		images[i] = cloneRGBA(canvas)
+		durations[i] = f.duration
+
+		switch dispose {
+		case apngDisposeBackground:
+			draw.Draw(canvas, rect, image.Transparent, image.Point{}, draw.Src)
+		case apngDisposePrevious:
+			canvas = previous
+		}
+	}
+
+	return images, durations, loops, nil
+}
+
+func parseAPNGFrameControl(data []byte) (*apngFrame, error) {
+	if len(data) < 26 {
+		return nil, fmt.Errorf("invalid fcTL chunk")
+	}
+
+	f := &apngFrame{
+		width:   int(binary.BigEndian.Uint32(data[4:])),
+		height:  int(binary.BigEndian.Uint32(data[8:])),
+		x:       int(binary.BigEndian.Uint32(data[12:])),
+		y:       int(binary.BigEndian.Uint32(data[16:])),
+		dispose: data[24],
+		blend:   data[25],
+	}
+
+	num := time.Duration(binary.BigEndian.Uint16(data[20:]))
+	den := time.Duration(binary.BigEndian.Uint16(data[22:]))
+	if den == 0 {
+		den = 100
+	}
+
+	f.duration = num * time.Second / den
+	if f.duration <= 0 {
+		f.duration = defaultFrameDuration
+	}
+
+	return f, nil
+}
+
+// png returns a standalone PNG file containing only f, using header ihdr and chunks shared by all frames.
+func (f *apngFrame) png(ihdr []byte, shared [][]byte) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(pngSignature)
+
+	h := append([]byte(nil), ihdr...)
+	binary.BigEndian.PutUint32(h, uint32(f.width))
+	binary.BigEndian.PutUint32(h[4:], uint32(f.height))
+	writePNGChunk(&buf, "IHDR", h)
+
+	for _, c := range shared {
+		buf.Write(c)
+	}
+
+	for _, d := range f.data {
+		writePNGChunk(&buf, "IDAT", d)
+	}
+
+	writePNGChunk(&buf, "IEND", nil)
+
+	return buf.Bytes()
+}
+
+func writePNGChunk(w *bytes.Buffer, typ string, data []byte) {
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(data)))
+	w.Write(n[:])
+
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write([]byte(typ))
+	_, _ = crc.Write(data)
+
+	w.WriteString(typ)
+	w.Write(data)
+
+	binary.BigEndian.PutUint32(n[:], crc.Sum32())
+	w.Write(n[:])
+}
+
+func cloneRGBA(i *image.RGBA) *image.RGBA {
+	c := image.NewRGBA(i.Rect)
+	copy(c.Pix, i.Pix)
+	return c
+}
@@ -0,0 +1,106 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDecodeGIF(t *testing.T) {
+	is := is.New(t)
+
+	pal := color.Palette{color.Transparent, color.White, color.Black}
+	f1 := image.NewPaletted(image.Rect(0, 0, 4, 4), pal)
+	f1.Set(0, 0, color.White)
+	f2 := image.NewPaletted(image.Rect(1, 1, 3, 3), pal)
+	f2.Set(1, 1, color.Black)
+
+	buf := bytes.Buffer{}
+	is.NoErr(gif.EncodeAll(&buf, &gif.GIF{
+		Image: []*image.Paletted{f1, f2},
+		Delay: []int{5, 0},
+	}))
+
+	a, err := DecodeGIF(&buf, 0.5)
+	is.NoErr(err)
+	is.Equal(len(a.Frames), 2)
+	is.Equal(a.Durations, []time.Duration{50 * time.Millisecond, defaultFrameDuration})
+	is.Equal(a.LoopCount, 0)
+
+	w, h := a.Size()
+	is.Equal(w, 2)
+	is.Equal(h, 2)
+}
+
+func TestDecodeAPNG(t *testing.T) {
+	is := is.New(t)
+
+	frames, durations, _, err := decodeAPNGFrames(bytes.NewReader(newAPNG(t)))
+	is.NoErr(err)
+	is.Equal(len(frames), 2)
+	is.Equal(durations, []time.Duration{100 * time.Millisecond, defaultFrameDuration})
+
+	// second frame is blended over the first
+	is.Equal(color.NRGBAModel.Convert(frames[1].At(0, 0)), color.NRGBA{255, 255, 255, 255})
+	is.Equal(color.NRGBAModel.Convert(frames[1].At(2, 2)), color.NRGBA{0, 0, 0, 255})
+}
+
+func newAPNG(t *testing.T) []byte {
+	t.Helper()
+
+	enc := func(i image.Image) (ihdr []byte, idat []byte) {
+		buf := bytes.Buffer{}
+		if err := png.Encode(&buf, i); err != nil {
+			t.Fatal(err)
+		}
+		b := buf.Bytes()
+		for p := 8; p < len(b); {
+			n := int(binary.BigEndian.Uint32(b[p:]))
+			switch string(b[p+4 : p+8]) {
+			case "IHDR":
+				ihdr = b[p+8 : p+8+n]
+			case "IDAT":
+				idat = append(idat, b[p+8:p+8+n]...)
+			}
+			p += 12 + n
+		}
+		return
+	}
+	i1 := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	i1.Set(0, 0, color.White)
+	i2 := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	i2.Set(0, 0, color.Black)
+	ihdr, d1 := enc(i1)
+	_, d2 := enc(i2)
+	fctl := func(seq, w, h, x, y int, num, den uint16) []byte {
+		b := make([]byte, 26)
+		binary.BigEndian.PutUint32(b, uint32(seq))
+		binary.BigEndian.PutUint32(b[4:], uint32(w))
+		binary.BigEndian.PutUint32(b[8:], uint32(h))
+		binary.BigEndian.PutUint32(b[12:], uint32(x))
+		binary.BigEndian.PutUint32(b[16:], uint32(y))
+		binary.BigEndian.PutUint16(b[20:], num)
+		binary.BigEndian.PutUint16(b[22:], den)
+		b[25] = 1
+		return b
+	}
+	buf := bytes.Buffer{}
+	buf.Write(pngSignature)
+	writePNGChunk(&buf, "IHDR", ihdr)
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl, 2)
+	writePNGChunk(&buf, "acTL", actl)
+	writePNGChunk(&buf, "fcTL", fctl(0, 4, 4, 0, 0, 1, 10))
+	writePNGChunk(&buf, "IDAT", d1)
+	writePNGChunk(&buf, "fcTL", fctl(1, 2, 2, 2, 2, 0, 0))
+	writePNGChunk(&buf, "fdAT", append([]byte{0, 0, 0, 2}, d2...))
+	writePNGChunk(&buf, "IEND", nil)
+	return buf.Bytes()
+}
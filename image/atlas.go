@@ -0,0 +1,154 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// An Atlas packs many small images into a few large pages at runtime. Images and NineSlices returned by an
+// Atlas are sub-images of its pages, so that drawing them does not require switching textures. This reduces
+// draw calls for UIs that use hundreds of small skin images.
+type Atlas struct {
+	pageWidth  int
+	pageHeight int
+
+	pages  []*atlasPage
+	colors map[color.Color]*NineSlice
+}
+
+// atlasPage is a page of an Atlas, packed using rows ("shelves") of images.
+type atlasPage struct {
+	image       *ebiten.Image
+	shelfY      int
+	shelfX      int
+	shelfHeight int
+}
+
+// atlasPadding is the number of transparent pixels between images, to avoid bleeding when drawing scaled.
+const atlasPadding = 1
+
+// NewAtlas constructs a new Atlas that creates pages of size pageWidth x pageHeight as needed.
+func NewAtlas(pageWidth int, pageHeight int) *Atlas {
+	return &Atlas{
+		pageWidth:  pageWidth,
+		pageHeight: pageHeight,
+
+		colors: map[color.Color]*NineSlice{},
+	}
+}
+
+// Add copies i into a and returns the copy. i may be disposed of afterwards. An error is returned if i
+// does not fit into a page.
+func (a *Atlas) Add(i *ebiten.Image) (*ebiten.Image, error) {
+	w, h := i.Size()
+
+	page, p, err := a.allocate(w, h)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(p.X), float64(p.Y))
+	opts.CompositeMode = ebiten.CompositeModeCopy
+	page.image.DrawImage(i, &opts)
+
+	return page.image.SubImage(image.Rectangle{p, p.Add(image.Point{w, h})}).(*ebiten.Image), nil
+}
+
+// AddImage copies i into a and returns the copy. An error is returned if i does not fit into a page.
+func (a *Atlas) AddImage(i image.Image) (*ebiten.Image, error) {
+	ei := ebiten.NewImageFromImage(i)
+	defer ei.Dispose()
+	return a.Add(ei)
+}
+
+// NineSlice copies i into a and returns a NineSlice using the copy, having column widths w and row heights h.
+// An error is returned if i does not fit into a page, or if w and h are invalid for i.
+func (a *Atlas) NineSlice(i *ebiten.Image, w [3]int, h [3]int) (*NineSlice, error) {
+	if err := ValidateNineSlice(i, w, h); err != nil {
+		return nil, err
+	}
+
+	ai, err := a.Add(i)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNineSlice(ai, w, h), nil
+}
+
+// NineSliceColor returns a NineSlice that when drawn fills with color c, like NewNineSliceColor, but whose
+// image is stored in a.
+func (a *Atlas) NineSliceColor(c color.Color) (*NineSlice, error) {
+	if n, ok := a.colors[c]; ok {
+		return n, nil
+	}
+
+	if _, _, _, ca := c.RGBA(); ca == 0 {
+		return NewNineSliceColor(c), nil
+	}
+
+	// use a larger image than necessary so that linear filtering at the edges does not pick up neighbors
+	page, p, err := a.allocate(3, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	rect := image.Rectangle{p, p.Add(image.Point{3, 3})}
+	page.image.SubImage(rect).(*ebiten.Image).Fill(c)
+
+	center := rect.Inset(1)
+	n := NewNineSlice(page.image.SubImage(center).(*ebiten.Image), [3]int{0, 1, 0}, [3]int{0, 1, 0})
+	a.colors[c] = n
+	return n, nil
+}
+
+// Pages returns the number of pages currently used by a.
+func (a *Atlas) Pages() int {
+	return len(a.pages)
+}
+
+func (a *Atlas) allocate(w int, h int) (*atlasPage, image.Point, error) {
+	if w+atlasPadding*2 > a.pageWidth || h+atlasPadding*2 > a.pageHeight {
+		return nil, image.Point{}, fmt.Errorf("image of size %dx%d does not fit into atlas page of size %dx%d", w, h, a.pageWidth, a.pageHeight)
+	}
+
+	for _, page := range a.pages {
+		if p, ok := page.allocate(w, h, a.pageWidth, a.pageHeight); ok {
+			return page, p, nil
+		}
+	}
+
+	page := &atlasPage{
+		image: ebiten.NewImage(a.pageWidth, a.pageHeight),
+	}
+	a.pages = append(a.pages, page)
+
+	p, _ := page.allocate(w, h, a.pageWidth, a.pageHeight)
+	return page, p, nil
+}
+
+func (p *atlasPage) allocate(w int, h int, pageWidth int, pageHeight int) (image.Point, bool) {
+	pw, ph := w+atlasPadding*2, h+atlasPadding*2
+
+	x, y, sh := p.shelfX, p.shelfY, p.shelfHeight
+	if x+pw > pageWidth {
+		// start a new shelf
+		x, y, sh = 0, y+sh, 0
+	}
+
+	if y+ph > pageHeight {
+		return image.Point{}, false
+	}
+
+	if ph > sh {
+		sh = ph
+	}
+
+	p.shelfX, p.shelfY, p.shelfHeight = x+pw, y, sh
+
+	return image.Point{x + atlasPadding, y + atlasPadding}, true
+}
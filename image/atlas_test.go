@@ -0,0 +1,52 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAtlas_Add(t *testing.T) {
+	is := is.New(t)
+
+	a := NewAtlas(32, 32)
+
+	i1, err := a.Add(newImageEmptySize(10, 10, t))
+	is.NoErr(err)
+	is.Equal(i1.Bounds(), image.Rect(1, 1, 11, 11))
+
+	i2, err := a.Add(newImageEmptySize(10, 5, t))
+	is.NoErr(err)
+	is.Equal(i2.Bounds(), image.Rect(13, 1, 23, 6))
+
+	// does not fit into first shelf anymore
+	i3, err := a.Add(newImageEmptySize(10, 10, t))
+	is.NoErr(err)
+	is.Equal(i3.Bounds(), image.Rect(1, 13, 11, 23))
+	is.Equal(a.Pages(), 1)
+
+	// does not fit into first page anymore
+	i4, err := a.Add(newImageEmptySize(20, 20, t))
+	is.NoErr(err)
+	is.Equal(i4.Bounds(), image.Rect(1, 1, 21, 21))
+	is.Equal(a.Pages(), 2)
+
+	_, err = a.Add(newImageEmptySize(40, 10, t))
+	is.True(err != nil)
+}
+
+func TestAtlas_NineSliceColor(t *testing.T) {
+	is := is.New(t)
+
+	a := NewAtlas(32, 32)
+
+	n, err := a.NineSliceColor(color.White)
+	is.NoErr(err)
+
+	n2, err := a.NineSliceColor(color.White)
+	is.NoErr(err)
+	is.Equal(n, n2)
+	is.Equal(a.Pages(), 1)
+}
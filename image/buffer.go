@@ -1,6 +1,10 @@
 package image
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 // BufferedImage is a wrapper for an Ebiten Image that helps with caching the Image.
 // As long as Width and Height stay the same, no new Image will be created.
@@ -17,6 +21,22 @@ type MaskedRenderBuffer struct {
 	maskedBuf *BufferedImage
 }
 
+// ClipRenderBuffer is a helper to draw images clipped to a rectangle, acting as a scissor.
+type ClipRenderBuffer struct {
+}
+
+// RoundedClipRenderBuffer is a helper to draw images clipped to a rectangle with rounded corners. Clipping is
+// done using a shader, and does not require a mask image.
+type RoundedClipRenderBuffer struct {
+	renderBuf *BufferedImage
+}
+
+// CachedRenderBuffer is a helper to draw images that are only redrawn after they have been invalidated.
+type CachedRenderBuffer struct {
+	renderBuf *BufferedImage
+	valid     bool
+}
+
 // DrawFunc is a function that draws something into buf.
 type DrawFunc func(buf *ebiten.Image)
 
@@ -35,6 +55,16 @@ func (b *BufferedImage) Image() *ebiten.Image {
 	return b.image
 }
 
+// Dispose disposes the internal Ebiten Image. A new Image will be created the next time Image is called.
+func (b *BufferedImage) Dispose() {
+	if b.image == nil {
+		return
+	}
+
+	b.image.Dispose()
+	b.image = nil
+}
+
 // NewMaskedRenderBuffer returns a new MaskedRenderBuffer.
 func NewMaskedRenderBuffer() *MaskedRenderBuffer {
 	return &MaskedRenderBuffer{
@@ -44,9 +74,9 @@ func NewMaskedRenderBuffer() *MaskedRenderBuffer {
 }
 
 // Draw calls d to draw onto screen, using the mask drawn by dm. The buffer images passed
-// to d and dm are of the same size as screen.
+// to d and dm use the same coordinates as screen.
 func (m *MaskedRenderBuffer) Draw(screen *ebiten.Image, d DrawFunc, dm DrawFunc) {
-	w, h := screen.Size()
+	w, h := bufferSize(screen)
 
 	m.renderBuf.Width, m.renderBuf.Height = w, h
 	renderBuf := m.renderBuf.Image()
@@ -65,3 +95,131 @@ func (m *MaskedRenderBuffer) Draw(screen *ebiten.Image, d DrawFunc, dm DrawFunc)
 
 	screen.DrawImage(maskedBuf, nil)
 }
+
+// Dispose disposes the buffer images. They are created again the next time m is drawn.
+func (m *MaskedRenderBuffer) Dispose() {
+	m.renderBuf.Dispose()
+	m.maskedBuf.Dispose()
+}
+
+// DrawNineSliceMask calls d to draw onto screen, using mask drawn into rect as the mask. Only those parts
+// of what d draws are visible where mask is not fully transparent.
+func (m *MaskedRenderBuffer) DrawNineSliceMask(screen *ebiten.Image, rect image.Rectangle, mask *NineSlice, d DrawFunc) {
+	m.Draw(screen, d, func(buf *ebiten.Image) {
+		mask.Draw(buf, rect.Dx(), rect.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+			opts.CompositeMode = ebiten.CompositeModeCopy
+		})
+	})
+}
+
+// NewClipRenderBuffer returns a new ClipRenderBuffer.
+func NewClipRenderBuffer() *ClipRenderBuffer {
+	return &ClipRenderBuffer{}
+}
+
+// Draw calls d to draw onto screen, clipped to rect. The image passed to d is the sub-image of screen
+// for rect, which uses the same coordinates as screen, so that no offscreen buffer is needed.
+func (c *ClipRenderBuffer) Draw(screen *ebiten.Image, rect image.Rectangle, d DrawFunc) {
+	d(screen.SubImage(rect).(*ebiten.Image))
+}
+
+// NewRoundedClipRenderBuffer returns a new RoundedClipRenderBuffer.
+func NewRoundedClipRenderBuffer() *RoundedClipRenderBuffer {
+	return &RoundedClipRenderBuffer{
+		renderBuf: &BufferedImage{},
+	}
+}
+
+// Dispose disposes the buffer image. It is created again the next time c is drawn.
+func (c *RoundedClipRenderBuffer) Dispose() {
+	c.renderBuf.Dispose()
+}
+
+// Draw calls d to draw onto screen, clipped to rect with corners rounded by radius. The buffer image passed
+// to d uses the same coordinates as screen.
+func (c *RoundedClipRenderBuffer) Draw(screen *ebiten.Image, rect image.Rectangle, radius int, d DrawFunc) {
+	c.renderBuf.Width, c.renderBuf.Height = bufferSize(screen)
+	renderBuf := c.renderBuf.Image()
+	renderBuf.Clear()
+
+	d(renderBuf)
+
+	clipped := rect.Intersect(screen.Bounds())
+	if clipped.Empty() {
+		return
+	}
+
+	if r := minInt(rect.Dx(), rect.Dy()) / 2; radius > r {
+		radius = r
+	}
+
+	opts := ebiten.DrawRectShaderOptions{}
+	opts.GeoM.Translate(float64(clipped.Min.X), float64(clipped.Min.Y))
+	opts.Images[0] = renderBuf.SubImage(clipped).(*ebiten.Image)
+	opts.Uniforms = map[string]interface{}{
+		"RectMin":  []float32{float32(rect.Min.X), float32(rect.Min.Y)},
+		"RectSize": []float32{float32(rect.Dx()), float32(rect.Dy())},
+		"Radius":   float32(radius),
+	}
+	screen.DrawRectShader(clipped.Dx(), clipped.Dy(), roundedClipShader(), &opts)
+}
+
+// NewCachedRenderBuffer returns a new CachedRenderBuffer.
+func NewCachedRenderBuffer() *CachedRenderBuffer {
+	return &CachedRenderBuffer{
+		renderBuf: &BufferedImage{},
+	}
+}
+
+// Invalidate invalidates the cached image, so that it is redrawn the next time Draw is called.
+func (c *CachedRenderBuffer) Invalidate() {
+	c.valid = false
+}
+
+// Dispose disposes the cached image. It is created and redrawn the next time c is drawn.
+func (c *CachedRenderBuffer) Dispose() {
+	c.renderBuf.Dispose()
+	c.valid = false
+}
+
+// Valid returns whether the cached image is still valid.
+func (c *CachedRenderBuffer) Valid() bool {
+	return c.valid
+}
+
+// Draw draws the cached image onto screen, clipped to rect. If the cached image has been invalidated, or if
+// the size of screen has changed, d is called to redraw it first. The buffer image passed to d uses
+// the same coordinates as screen. Draw returns whether d has been called.
+func (c *CachedRenderBuffer) Draw(screen *ebiten.Image, rect image.Rectangle, d DrawFunc) bool {
+	w, h := bufferSize(screen)
+
+	redraw := !c.valid || c.renderBuf.Width != w || c.renderBuf.Height != h
+
+	c.renderBuf.Width, c.renderBuf.Height = w, h
+	renderBuf := c.renderBuf.Image()
+
+	if redraw {
+		renderBuf.Clear()
+		d(renderBuf)
+		c.valid = true
+	}
+
+	rect = rect.Intersect(screen.Bounds())
+	if rect.Empty() {
+		return redraw
+	}
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	screen.DrawImage(renderBuf.SubImage(rect).(*ebiten.Image), &opts)
+
+	return redraw
+}
+
+// bufferSize returns the size of a buffer image that can be drawn into using the same coordinates as screen,
+// which may be a sub-image.
+func bufferSize(screen *ebiten.Image) (int, int) {
+	b := screen.Bounds()
+	return b.Max.X, b.Max.Y
+}
@@ -1,6 +1,8 @@
 package image
 
 import (
+	"image"
+	"image/color"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -42,3 +44,77 @@ func TestMaskedRenderBuffer_Draw(t *testing.T) {
 	is.True(draw)
 	is.True(drawMask)
 }
+
+func TestClipRenderBuffer_Draw(t *testing.T) {
+	is := is.New(t)
+
+	b := NewClipRenderBuffer()
+	screen := newImageEmptySize(100, 100, t)
+
+	draw := false
+
+	b.Draw(screen, image.Rect(10, 10, 50, 50), func(buf *ebiten.Image) {
+		is.Equal(buf.Bounds(), image.Rect(10, 10, 50, 50))
+		draw = true
+	})
+
+	is.True(draw)
+}
+
+func TestMaskedRenderBuffer_DrawNineSliceMask(t *testing.T) {
+	is := is.New(t)
+
+	b := NewMaskedRenderBuffer()
+	screen := newImageEmptySize(100, 100, t)
+
+	draw := false
+
+	b.DrawNineSliceMask(screen, image.Rect(10, 10, 50, 50), NewNineSliceColor(color.White), func(buf *ebiten.Image) {
+		buf.Fill(color.White)
+		draw = true
+	})
+
+	is.True(draw)
+}
+
+func TestCachedRenderBuffer_Draw(t *testing.T) {
+	is := is.New(t)
+
+	b := NewCachedRenderBuffer()
+	screen := newImageEmptySize(100, 100, t)
+
+	draws := 0
+	d := func(buf *ebiten.Image) {
+		draws++
+	}
+
+	is.True(b.Draw(screen, image.Rect(10, 10, 50, 50), d))
+	is.True(!b.Draw(screen, image.Rect(10, 10, 50, 50), d))
+	is.Equal(draws, 1)
+
+	b.Invalidate()
+	is.True(!b.Valid())
+	is.True(b.Draw(screen, image.Rect(10, 10, 50, 50), d))
+	is.Equal(draws, 2)
+
+	b.Draw(newImageEmptySize(120, 100, t), image.Rect(10, 10, 50, 50), d)
+	is.Equal(draws, 3)
+}
+
+func TestRoundedClipRenderBuffer_Draw(t *testing.T) {
+	is := is.New(t)
+
+	b := NewRoundedClipRenderBuffer()
+	screen := newImageEmptySize(100, 100, t)
+
+	draw := false
+
+	b.Draw(screen, image.Rect(10, 10, 50, 50), 8, func(buf *ebiten.Image) {
+		w, h := buf.Size()
+		is.Equal(w, 100)
+		is.Equal(h, 100)
+		draw = true
+	})
+
+	is.True(draw)
+}
@@ -0,0 +1,129 @@
+package image
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ColorBlindness is a type of color vision deficiency.
+type ColorBlindness int
+
+type colorMatrix [3][3]float64
+
+const (
+	// Protanopia is the absence of red cones.
+	Protanopia = ColorBlindness(iota)
+
+	// Deuteranopia is the absence of green cones.
+	Deuteranopia
+
+	// Tritanopia is the absence of blue cones.
+	Tritanopia
+)
+
+// simulationMatrices are the matrices for full severity by Machado, Oliveira, and Fernandes (2009).
+var simulationMatrices = map[ColorBlindness]colorMatrix{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// errorShiftMatrices shift the color information lost by a deficiency into channels that can still be seen.
+var errorShiftMatrices = map[ColorBlindness]colorMatrix{
+	Protanopia: {
+		{0, 0, 0},
+		{0.7, 1, 0},
+		{0.7, 0, 1},
+	},
+	Deuteranopia: {
+		{0, 0, 0},
+		{0.7, 1, 0},
+		{0.7, 0, 1},
+	},
+	Tritanopia: {
+		{1, 0, 0.7},
+		{0, 1, 0.7},
+		{0, 0, 0},
+	},
+}
+
+var identityMatrix = colorMatrix{
+	{1, 0, 0},
+	{0, 1, 0},
+	{0, 0, 1},
+}
+
+// ColorBlindnessSimulation returns a color matrix that simulates how colors are perceived with deficiency b.
+// It can be used to validate that a UI is still usable, for example by setting it as UI.ColorFilter.
+func ColorBlindnessSimulation(b ColorBlindness) ebiten.ColorM {
+	return simulationMatrices[b].colorM()
+}
+
+// ColorBlindnessCompensation returns a color matrix that shifts colors so that differences that are lost with
+// deficiency b become visible in other channels ("daltonization").
+func ColorBlindnessCompensation(b ColorBlindness) ebiten.ColorM {
+	s := simulationMatrices[b]
+	e := errorShiftMatrices[b]
+
+	// c + E(c - Sc) = (I + E(I - S))c
+	return identityMatrix.add(e.mul(identityMatrix.sub(s))).colorM()
+}
+
+// TransformColor returns c transformed by m. It can be used to apply a color matrix to colors directly,
+// for example to colors of a theme.
+func TransformColor(c color.Color, m ebiten.ColorM) color.Color {
+	return m.Apply(c)
+}
+
+func (m colorMatrix) add(o colorMatrix) colorMatrix {
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] += o[i][j]
+		}
+	}
+	return m
+}
+
+func (m colorMatrix) sub(o colorMatrix) colorMatrix {
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] -= o[i][j]
+		}
+	}
+	return m
+}
+
+func (m colorMatrix) mul(o colorMatrix) colorMatrix {
+	r := colorMatrix{}
+	for i := range m {
+		for j := range o[0] {
+			for k := range o {
+				r[i][j] += m[i][k] * o[k][j]
+			}
+		}
+	}
+	return r
+}
+
+func (m colorMatrix) colorM() ebiten.ColorM {
+	c := ebiten.ColorM{}
+	for i := range m {
+		for j := range m[i] {
+			c.SetElement(i, j, m[i][j])
+		}
+	}
+	return c
+}
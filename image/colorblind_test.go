@@ -0,0 +1,34 @@
+package image
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestColorBlindnessSimulation(t *testing.T) {
+	is := is.New(t)
+
+	for _, b := range []ColorBlindness{Protanopia, Deuteranopia, Tritanopia} {
+		m := ColorBlindnessSimulation(b)
+		r, g, bl, _ := TransformColor(color.White, m).RGBA()
+		is.True(r >= 0xfe00 && g >= 0xfe00 && bl >= 0xfe00) // white stays white
+	}
+
+	r, g, _, _ := TransformColor(color.RGBA{255, 0, 0, 255}, ColorBlindnessSimulation(Protanopia)).RGBA()
+	is.True(r < 0x4000) // red is barely visible
+	is.True(g < 0x4000)
+}
+
+func TestColorBlindnessCompensation(t *testing.T) {
+	is := is.New(t)
+
+	gray := color.RGBA{128, 128, 128, 255}
+	for _, b := range []ColorBlindness{Protanopia, Deuteranopia, Tritanopia} {
+		r, g, bl, _ := TransformColor(gray, ColorBlindnessCompensation(b)).RGBA()
+		is.True(r>>8 >= 126 && r>>8 <= 130) // grays are unaffected
+		is.True(g>>8 >= 126 && g>>8 <= 130)
+		is.True(bl>>8 >= 126 && bl>>8 <= 130)
+	}
+}
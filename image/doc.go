@@ -1,3 +1,3 @@
 // Package image contains types to deal with nine-slice images, buffered (cached) images, as well as
-// drawing using masks.
+// drawing using masks, and decoding animated images.
 package image
@@ -1,30 +1,58 @@
 package image
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // A NineSlice is an image that can be drawn with any width and height. It is basically a 3x3 grid of image tiles:
 // The corner tiles are drawn as-is, while the center columns and rows of tiles will be stretched to fit the desired
-// width and height.
+// width and height. Alternatively, a NineSlice may be constructed so that the center columns and rows of tiles are
+// repeated instead of stretched.
 type NineSlice struct {
 	image       *ebiten.Image
 	widths      [3]int
 	heights     [3]int
 	transparent bool
+	tiled       bool
+
+	frames         []*NineSlice
+	frameDuration  time.Duration
+	frameDurations []time.Duration
 
 	init  sync.Once
 	tiles [9]*ebiten.Image
 }
 
+// drawCount is the total number of tiles drawn by all NineSlices.
+var drawCount int
+
 // A DrawImageOptionsFunc is responsible for setting DrawImageOptions when drawing an image.
 // This is usually used to translate the image.
 type DrawImageOptionsFunc func(opts *ebiten.DrawImageOptions)
 
+// AnimationClock returns the current time, which is used to determine the current frame of animated images.
+// It may be replaced, for example to pause animations.
+var AnimationClock = time.Now
+
+var animationEpoch = time.Now()
+
+// DebugNineSlices enables drawing translucent overlays over the nine regions of all NineSlices, using
+// colors from DebugNineSliceColors. This is useful to diagnose art mistakes, such as wrong slice widths.
+var DebugNineSlices = false
+
+// DebugNineSliceColors are the overlay colors used when DebugNineSlices is enabled, in row-major order.
+var DebugNineSliceColors = [9]color.Color{
+	color.NRGBA{255, 0, 0, 96}, color.NRGBA{0, 255, 0, 96}, color.NRGBA{0, 0, 255, 96},
+	color.NRGBA{255, 255, 0, 96}, color.NRGBA{255, 0, 255, 96}, color.NRGBA{0, 255, 255, 96},
+	color.NRGBA{255, 128, 0, 96}, color.NRGBA{128, 0, 255, 96}, color.NRGBA{0, 128, 255, 96},
+}
+
 var colorImages map[color.Color]*ebiten.Image = map[color.Color]*ebiten.Image{}
 
 var colorNineSlices map[color.Color]*NineSlice = map[color.Color]*NineSlice{}
@@ -38,6 +66,97 @@ func NewNineSlice(i *ebiten.Image, w [3]int, h [3]int) *NineSlice {
 	}
 }
 
+// NewNineSliceTiled constructs a new NineSlice from i, having columns widths w and row heights h. When drawn,
+// the center column and row of tiles are repeated to fit the desired width and height, instead of being stretched.
+func NewNineSliceTiled(i *ebiten.Image, w [3]int, h [3]int) *NineSlice {
+	return &NineSlice{
+		image:   i,
+		widths:  w,
+		heights: h,
+		tiled:   true,
+	}
+}
+
+// NewNineSliceChecked constructs a new NineSlice like NewNineSlice, but returns an error if the column widths w
+// or row heights h are invalid for i, instead of rendering a distorted image.
+func NewNineSliceChecked(i *ebiten.Image, w [3]int, h [3]int) (*NineSlice, error) {
+	if err := ValidateNineSlice(i, w, h); err != nil {
+		return nil, err
+	}
+	return NewNineSlice(i, w, h), nil
+}
+
+// NewNineSliceTiledChecked constructs a new NineSlice like NewNineSliceTiled, but returns an error if the
+// column widths w or row heights h are invalid for i, instead of rendering a distorted image.
+func NewNineSliceTiledChecked(i *ebiten.Image, w [3]int, h [3]int) (*NineSlice, error) {
+	if err := ValidateNineSlice(i, w, h); err != nil {
+		return nil, err
+	}
+	return NewNineSliceTiled(i, w, h), nil
+}
+
+// ValidateNineSlice returns an error if column widths w or row heights h cannot be used to slice i, that is,
+// if any of them is negative, or if they add up to more than the size of i.
+func ValidateNineSlice(i *ebiten.Image, w [3]int, h [3]int) error {
+	if i == nil {
+		return fmt.Errorf("nine-slice image is nil")
+	}
+
+	for c, cw := range w {
+		if cw < 0 {
+			return fmt.Errorf("nine-slice column %d has negative width %d", c, cw)
+		}
+	}
+
+	for r, rh := range h {
+		if rh < 0 {
+			return fmt.Errorf("nine-slice row %d has negative height %d", r, rh)
+		}
+	}
+
+	iw, ih := i.Size()
+
+	if sw := w[0] + w[1] + w[2]; sw > iw {
+		return fmt.Errorf("nine-slice column widths %v add up to %d, exceeding image width %d", w, sw, iw)
+	}
+
+	if sh := h[0] + h[1] + h[2]; sh > ih {
+		return fmt.Errorf("nine-slice row heights %v add up to %d, exceeding image height %d", h, sh, ih)
+	}
+
+	return nil
+}
+
+// NewNineSliceAnimated constructs a new NineSlice that when drawn cycles through frames, showing each frame
+// for frameDuration, according to AnimationClock.
+func NewNineSliceAnimated(frames []*NineSlice, frameDuration time.Duration) *NineSlice {
+	if len(frames) == 0 {
+		panic("no frames")
+	}
+
+	return &NineSlice{
+		frames:        frames,
+		frameDuration: frameDuration,
+	}
+}
+
+// NewNineSliceAnimatedDurations constructs a new NineSlice that when drawn cycles through frames, showing
+// each frame for the duration at the same index in durations, according to AnimationClock.
+func NewNineSliceAnimatedDurations(frames []*NineSlice, durations []time.Duration) *NineSlice {
+	if len(frames) == 0 {
+		panic("no frames")
+	}
+
+	if len(durations) != len(frames) {
+		panic("number of durations does not match number of frames")
+	}
+
+	return &NineSlice{
+		frames:         frames,
+		frameDurations: durations,
+	}
+}
+
 // NewNineSliceSimple constructs a new NineSlice from image. borderWidthHeight specifies the width of the
 // left and right column and the height of the top and bottom row. centerWidthHeight specifies the width
 // of the center column and row.
@@ -84,6 +203,11 @@ func NewImageColor(c color.Color) *ebiten.Image {
 	return i
 }
 
+// DrawCount returns the total number of draw calls made by all NineSlices so far.
+func DrawCount() int {
+	return drawCount
+}
+
 // Draw draws n onto screen, with the size specified by width and height. If optsFunc is not nil, it is used to set
 // DrawImageOptions for each tile drawn.
 func (n *NineSlice) Draw(screen *ebiten.Image, width int, height int, optsFunc DrawImageOptionsFunc) {
@@ -91,6 +215,11 @@ func (n *NineSlice) Draw(screen *ebiten.Image, width int, height int, optsFunc D
 		return
 	}
 
+	if n.frames != nil {
+		n.currentFrame().Draw(screen, width, height, optsFunc)
+		return
+	}
+
 	n.drawTiles(screen, width, height, optsFunc)
 }
 
@@ -120,6 +249,10 @@ func (n *NineSlice) drawTiles(screen *ebiten.Image, width int, height int, optsF
 
 			n.drawTile(screen, n.tiles[r*3+c], tx, ty, sw, sh, tw, th, optsFunc)
 
+			if DebugNineSlices {
+				drawDebugOverlay(screen, DebugNineSliceColors[r*3+c], tx, ty, tw, th, optsFunc)
+			}
+
 			sx += sw
 			tx += tw
 		}
@@ -134,6 +267,11 @@ func (n *NineSlice) drawTile(screen *ebiten.Image, tile *ebiten.Image, tx int, t
 		return
 	}
 
+	if n.tiled && (tw != sw || th != sh) {
+		n.drawTileRepeated(screen, tile, tx, ty, sw, sh, tw, th, optsFunc)
+		return
+	}
+
 	opts := ebiten.DrawImageOptions{
 		Filter: ebiten.FilterNearest,
 	}
@@ -149,6 +287,59 @@ func (n *NineSlice) drawTile(screen *ebiten.Image, tile *ebiten.Image, tx int, t
 	}
 
 	screen.DrawImage(tile, &opts)
+	drawCount++
+}
+
+func drawDebugOverlay(screen *ebiten.Image, c color.Color, tx int, ty int, tw int, th int, optsFunc DrawImageOptionsFunc) {
+	if c == nil || tw <= 0 || th <= 0 {
+		return
+	}
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(float64(tw), float64(th))
+	opts.GeoM.Translate(float64(tx), float64(ty))
+
+	if optsFunc != nil {
+		optsFunc(&opts)
+	}
+
+	screen.DrawImage(NewImageColor(c), &opts)
+}
+
+func (n *NineSlice) drawTileRepeated(screen *ebiten.Image, tile *ebiten.Image, tx int, ty int, sw int, sh int, tw int, th int, optsFunc DrawImageOptionsFunc) {
+	b := tile.Bounds()
+
+	for y := 0; y < th; y += sh {
+		h := sh
+		if y+h > th {
+			h = th - y
+		}
+
+		for x := 0; x < tw; x += sw {
+			w := sw
+			if x+w > tw {
+				w = tw - x
+			}
+
+			t := tile
+			if w != sw || h != sh {
+				t = tile.SubImage(image.Rect(b.Min.X, b.Min.Y, b.Min.X+w, b.Min.Y+h)).(*ebiten.Image)
+			}
+
+			opts := ebiten.DrawImageOptions{
+				Filter: ebiten.FilterNearest,
+			}
+
+			opts.GeoM.Translate(float64(tx+x), float64(ty+y))
+
+			if optsFunc != nil {
+				optsFunc(&opts)
+			}
+
+			screen.DrawImage(t, &opts)
+			drawCount++
+		}
+	}
 }
 
 func (n *NineSlice) createTiles() {
@@ -163,13 +354,15 @@ func (n *NineSlice) createTiles() {
 		return
 	}
 
+	min := n.image.Bounds().Min
+
 	sy := 0
 	for r, sh := range n.heights {
 		sx := 0
 		for c, sw := range n.widths {
 			if sh > 0 && sw > 0 {
 				rect := image.Rect(0, 0, sw, sh)
-				rect = rect.Add(image.Point{sx, sy})
+				rect = rect.Add(min.Add(image.Point{sx, sy}))
 				n.tiles[r*3+c] = n.image.SubImage(rect).(*ebiten.Image)
 			}
 			sx += sw
@@ -194,5 +387,61 @@ func (n *NineSlice) MinSize() (int, int) {
 		return 0, 0
 	}
 
+	if n.frames != nil {
+		w, h := 0, 0
+		for _, f := range n.frames {
+			fw, fh := f.MinSize()
+			if fw > w {
+				w = fw
+			}
+			if fh > h {
+				h = fh
+			}
+		}
+		return w, h
+	}
+
 	return n.widths[0] + n.widths[2], n.heights[0] + n.heights[2]
 }
+
+func (n *NineSlice) currentFrame() *NineSlice {
+	if n.frameDurations != nil {
+		return n.currentFrameDurations()
+	}
+
+	if n.frameDuration <= 0 {
+		return n.frames[0]
+	}
+
+	f := int(AnimationClock().Sub(animationEpoch) / n.frameDuration)
+	f %= len(n.frames)
+	if f < 0 {
+		f += len(n.frames)
+	}
+	return n.frames[f]
+}
+
+func (n *NineSlice) currentFrameDurations() *NineSlice {
+	var total time.Duration
+	for _, d := range n.frameDurations {
+		total += d
+	}
+
+	if total <= 0 {
+		return n.frames[0]
+	}
+
+	t := AnimationClock().Sub(animationEpoch) % total
+	if t < 0 {
+		t += total
+	}
+
+	for i, d := range n.frameDurations {
+		if t < d {
+			return n.frames[i]
+		}
+		t -= d
+	}
+
+	return n.frames[len(n.frames)-1]
+}
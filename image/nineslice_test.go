@@ -3,6 +3,7 @@ package image
 import (
 	"image/color"
 	"testing"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/matryer/is"
@@ -27,6 +28,124 @@ func TestNineSlice_MinSize(t *testing.T) {
 	is.Equal(h, 0)
 }
 
+func TestNineSlice_Draw_Tiled(t *testing.T) {
+	is := is.New(t)
+
+	screen := newImageEmptySize(100, 100, t)
+
+	draws := 0
+	optsFunc := func(opts *ebiten.DrawImageOptions) {
+		draws++
+	}
+
+	n := NewNineSlice(newImageEmptySize(20, 20, t), [3]int{3, 10, 7}, [3]int{2, 16, 2})
+	n.Draw(screen, 33, 20, optsFunc)
+	is.Equal(draws, 9)
+
+	draws = 0
+	n = NewNineSliceTiled(newImageEmptySize(20, 20, t), [3]int{3, 10, 7}, [3]int{2, 16, 2})
+	n.Draw(screen, 33, 20, optsFunc)
+	is.Equal(draws, 15)
+}
+
+func TestValidateNineSlice(t *testing.T) {
+	is := is.New(t)
+
+	i := newImageEmptySize(20, 20, t)
+
+	is.NoErr(ValidateNineSlice(i, [3]int{3, 10, 7}, [3]int{2, 16, 2}))
+	is.NoErr(ValidateNineSlice(i, [3]int{3, 10, 5}, [3]int{2, 10, 2}))
+	is.True(ValidateNineSlice(i, [3]int{3, 11, 7}, [3]int{2, 16, 2}) != nil)
+	is.True(ValidateNineSlice(i, [3]int{3, 10, 7}, [3]int{2, 16, 3}) != nil)
+	is.True(ValidateNineSlice(i, [3]int{-1, 10, 7}, [3]int{2, 16, 2}) != nil)
+	is.True(ValidateNineSlice(nil, [3]int{3, 10, 7}, [3]int{2, 16, 2}) != nil)
+
+	n, err := NewNineSliceChecked(i, [3]int{3, 11, 7}, [3]int{2, 16, 2})
+	is.True(err != nil)
+	is.True(n == nil)
+}
+
+func TestNineSlice_Draw_Debug(t *testing.T) {
+	is := is.New(t)
+
+	DebugNineSlices = true
+	defer func() {
+		DebugNineSlices = false
+	}()
+
+	screen := newImageEmptySize(100, 100, t)
+
+	draws := 0
+	optsFunc := func(opts *ebiten.DrawImageOptions) {
+		draws++
+	}
+
+	n := NewNineSlice(newImageEmptySize(20, 20, t), [3]int{3, 10, 7}, [3]int{2, 16, 2})
+	n.Draw(screen, 33, 20, optsFunc)
+	is.Equal(draws, 18)
+}
+
+func TestNineSlice_Animated(t *testing.T) {
+	is := is.New(t)
+
+	frames := []*NineSlice{
+		NewNineSlice(newImageEmptySize(20, 20, t), [3]int{3, 10, 7}, [3]int{2, 16, 2}),
+		NewNineSlice(newImageEmptySize(20, 20, t), [3]int{5, 10, 5}, [3]int{5, 10, 5}),
+	}
+
+	now := animationEpoch
+	AnimationClock = func() time.Time {
+		return now
+	}
+	defer func() {
+		AnimationClock = time.Now
+	}()
+
+	n := NewNineSliceAnimated(frames, 100*time.Millisecond)
+
+	is.Equal(n.currentFrame(), frames[0])
+
+	now = now.Add(150 * time.Millisecond)
+	is.Equal(n.currentFrame(), frames[1])
+
+	now = now.Add(100 * time.Millisecond)
+	is.Equal(n.currentFrame(), frames[0])
+
+	w, h := n.MinSize()
+	is.Equal(w, 10)
+	is.Equal(h, 10)
+}
+
+func TestNineSlice_AnimatedDurations(t *testing.T) {
+	is := is.New(t)
+
+	frames := []*NineSlice{
+		NewNineSliceColor(color.White),
+		NewNineSliceColor(color.Black),
+	}
+
+	now := animationEpoch
+	AnimationClock = func() time.Time {
+		return now
+	}
+	defer func() {
+		AnimationClock = time.Now
+	}()
+
+	n := NewNineSliceAnimatedDurations(frames, []time.Duration{100 * time.Millisecond, 300 * time.Millisecond})
+
+	is.Equal(n.currentFrame(), frames[0])
+
+	now = now.Add(150 * time.Millisecond)
+	is.Equal(n.currentFrame(), frames[1])
+
+	now = now.Add(200 * time.Millisecond)
+	is.Equal(n.currentFrame(), frames[1])
+
+	now = now.Add(100 * time.Millisecond)
+	is.Equal(n.currentFrame(), frames[0])
+}
+
 func newImageEmptySize(width int, height int, t *testing.T) *ebiten.Image {
 	t.Helper()
 	return ebiten.NewImage(width, height)
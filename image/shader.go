@@ -0,0 +1,39 @@
+package image
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// roundedClipShaderSrc is a Kage shader that draws its source image, fading out pixels outside of a rounded
+// rectangle using its signed distance field, which also anti-aliases the corners.
+const roundedClipShaderSrc = `package main
+
+var RectMin vec2
+var RectSize vec2
+var Radius float
+
+func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
+	halfSize := RectSize / 2
+	q := abs(position.xy-RectMin-halfSize) - halfSize + Radius
+	d := length(max(q, vec2(0))) + min(max(q.x, q.y), 0) - Radius
+	return imageSrc0At(texCoord) * clamp(0.5-d, 0, 1)
+}
+`
+
+var roundedClipShaderInstance *ebiten.Shader
+
+func roundedClipShader() *ebiten.Shader {
+	if roundedClipShaderInstance == nil {
+		s, err := ebiten.NewShader([]byte(roundedClipShaderSrc))
+		if err != nil {
+			panic(err)
+		}
+		roundedClipShaderInstance = s
+	}
+	return roundedClipShaderInstance
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
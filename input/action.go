@@ -0,0 +1,173 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is an abstract input, such as "accept" or "navigate up", that is bound to keys, gamepad buttons,
+// or gamepad axes using an ActionMap. Widgets should check actions instead of specific keys, so that
+// rebinding an action affects the UI as well.
+type Action string
+
+// ActionBinding binds an Action to a key combination, gamepad button, or gamepad axis direction.
+type ActionBinding struct {
+	Type          ActionBindingType
+	Key           ebiten.Key
+	Modifiers     []ebiten.Key
+	GamepadButton ebiten.GamepadButton
+	GamepadAxis   int
+	AxisPositive  bool
+}
+
+type ActionBindingType int
+
+// ActionMap maps actions to their bindings.
+type ActionMap struct {
+	// AxisThreshold is the absolute gamepad axis value from which on an axis binding is considered pressed.
+	AxisThreshold float64
+
+	bindings map[Action][]ActionBinding
+}
+
+const (
+	ActionAccept        = Action("accept")
+	ActionCancel        = Action("cancel")
+	ActionFocusNext     = Action("focusNext")
+	ActionFocusPrevious = Action("focusPrevious")
+	ActionNavigateUp    = Action("navigateUp")
+	ActionNavigateDown  = Action("navigateDown")
+	ActionNavigateLeft  = Action("navigateLeft")
+	ActionNavigateRight = Action("navigateRight")
+)
+
+const (
+	ActionBindingTypeKey = ActionBindingType(iota)
+	ActionBindingTypeGamepadButton
+	ActionBindingTypeGamepadAxis
+)
+
+// Actions is the ActionMap used by the UI and widgets.
+var Actions = DefaultActionMap()
+
+// NewActionMap constructs a new, empty ActionMap.
+func NewActionMap() *ActionMap {
+	return &ActionMap{
+		AxisThreshold: 0.5,
+
+		bindings: map[Action][]ActionBinding{},
+	}
+}
+
+// DefaultActionMap constructs a new ActionMap with default bindings for the predefined actions. Since button
+// layouts differ between gamepads, the gamepad bindings assume a common layout, where button 0 is the bottom
+// face button, button 1 is the right face button, buttons 12 to 15 are the D-pad (up, down, left, right), and
+// axes 0 and 1 are the left stick.
+func DefaultActionMap() *ActionMap {
+	m := NewActionMap()
+	m.Bind(ActionAccept, BindKey(ebiten.KeyEnter), BindKey(ebiten.KeyNumpadEnter), BindGamepadButton(0))
+	m.Bind(ActionCancel, BindKey(ebiten.KeyEscape), BindGamepadButton(1))
+	m.Bind(ActionFocusNext, BindKey(ebiten.KeyTab))
+	m.Bind(ActionFocusPrevious, BindKey(ebiten.KeyTab, ebiten.KeyShift))
+	m.Bind(ActionNavigateUp, BindKey(ebiten.KeyUp), BindGamepadButton(12), BindGamepadAxis(1, false))
+	m.Bind(ActionNavigateDown, BindKey(ebiten.KeyDown), BindGamepadButton(13), BindGamepadAxis(1, true))
+	m.Bind(ActionNavigateLeft, BindKey(ebiten.KeyLeft), BindGamepadButton(14), BindGamepadAxis(0, false))
+	m.Bind(ActionNavigateRight, BindKey(ebiten.KeyRight), BindGamepadButton(15), BindGamepadAxis(0, true))
+	return m
+}
+
+// BindKey returns a binding to key k, which is only considered pressed if all modifier keys mods are
+// pressed as well.
+func BindKey(k ebiten.Key, mods ...ebiten.Key) ActionBinding {
+	return ActionBinding{
+		Type:      ActionBindingTypeKey,
+		Key:       k,
+		Modifiers: mods,
+	}
+}
+
+// BindGamepadButton returns a binding to gamepad button b.
+func BindGamepadButton(b ebiten.GamepadButton) ActionBinding {
+	return ActionBinding{
+		Type:          ActionBindingTypeGamepadButton,
+		GamepadButton: b,
+	}
+}
+
+// BindGamepadAxis returns a binding to gamepad axis a, in positive or negative direction.
+func BindGamepadAxis(a int, positive bool) ActionBinding {
+	return ActionBinding{
+		Type:         ActionBindingTypeGamepadAxis,
+		GamepadAxis:  a,
+		AxisPositive: positive,
+	}
+}
+
+// Bind replaces the bindings of action a with b.
+func (m *ActionMap) Bind(a Action, b ...ActionBinding) {
+	m.bindings[a] = append([]ActionBinding(nil), b...)
+}
+
+// AddBinding adds binding b to action a.
+func (m *ActionMap) AddBinding(a Action, b ActionBinding) {
+	m.bindings[a] = append(m.bindings[a], b)
+}
+
+// Bindings returns the bindings of action a.
+func (m *ActionMap) Bindings(a Action) []ActionBinding {
+	return m.bindings[a]
+}
+
+// Pressed returns whether any of the bindings of action a is currently pressed.
+func (m *ActionMap) Pressed(a Action) bool {
+	for _, b := range m.bindings[a] {
+		if m.bindingPressed(b) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *ActionMap) bindingPressed(b ActionBinding) bool {
+	switch b.Type {
+	case ActionBindingTypeKey:
+		if !KeyPressed(b.Key) {
+			return false
+		}
+		for _, k := range b.Modifiers {
+			if !KeyPressed(k) {
+				return false
+			}
+		}
+		return true
+
+	case ActionBindingTypeGamepadButton:
+		return GamepadButtonPressed(b.GamepadButton)
+
+	case ActionBindingTypeGamepadAxis:
+		v := GamepadAxis(b.GamepadAxis)
+		if b.AxisPositive {
+			return v >= m.AxisThreshold
+		}
+		return v <= -m.AxisThreshold
+	}
+
+	return false
+}
+
+// String returns a human-readable representation of b.
+func (b ActionBinding) String() string {
+	switch b.Type {
+	case ActionBindingTypeKey:
+		return KeysName(append(append([]ebiten.Key(nil), b.Modifiers...), b.Key)...)
+	case ActionBindingTypeGamepadButton:
+		return GamepadButtonName(b.GamepadButton)
+	case ActionBindingTypeGamepadAxis:
+		return GamepadAxisName(b.GamepadAxis, b.AxisPositive)
+	}
+	return ""
+}
+
+// ActionPressed returns whether action a is currently pressed according to Actions.
+func ActionPressed(a Action) bool {
+	return Actions.Pressed(a)
+}
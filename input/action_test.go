@@ -0,0 +1,58 @@
+package input
+
+import (
+	"testing"
+
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestActionMap_Pressed(t *testing.T) {
+	is := is.New(t)
+
+	m := NewActionMap()
+	m.Bind(ActionFocusPrevious, BindKey(ebiten.KeyTab, ebiten.KeyShift))
+	m.Bind(ActionNavigateDown, BindGamepadAxis(1, true))
+	m.AddBinding(ActionNavigateDown, BindGamepadButton(13))
+
+	internalinput.KeyPressed[ebiten.KeyTab] = true
+	defer delete(internalinput.KeyPressed, ebiten.KeyTab)
+	is.True(!m.Pressed(ActionFocusPrevious))
+
+	internalinput.KeyPressed[ebiten.KeyShift] = true
+	defer delete(internalinput.KeyPressed, ebiten.KeyShift)
+	is.True(m.Pressed(ActionFocusPrevious))
+
+	internalinput.GamepadAxes[1] = 0.3
+	defer delete(internalinput.GamepadAxes, 1)
+	is.True(!m.Pressed(ActionNavigateDown))
+
+	internalinput.GamepadAxes[1] = 0.8
+	is.True(m.Pressed(ActionNavigateDown))
+
+	internalinput.GamepadAxes[1] = 0
+	internalinput.GamepadButtonPressed[13] = true
+	defer delete(internalinput.GamepadButtonPressed, 13)
+	is.True(m.Pressed(ActionNavigateDown))
+}
+
+func TestDefaultActionMap_Navigate(t *testing.T) {
+	is := is.New(t)
+
+	m := DefaultActionMap()
+
+	is.Equal(m.Bindings(ActionNavigateUp)[0].Key, ebiten.KeyUp)
+	is.Equal(m.Bindings(ActionNavigateDown)[1].GamepadButton, ebiten.GamepadButton(13))
+	is.Equal(len(m.Bindings(ActionNavigateLeft)), 3)
+}
+
+func TestActionMap_Bind(t *testing.T) {
+	is := is.New(t)
+
+	m := DefaultActionMap()
+	m.Bind(ActionCancel, BindKey(ebiten.KeyQ))
+
+	is.Equal(len(m.Bindings(ActionCancel)), 1)
+	is.Equal(m.Bindings(ActionCancel)[0].String(), "Q")
+}
@@ -97,3 +97,20 @@ func KeyPressed(k ebiten.Key) bool {
 func AnyKeyPressed() bool {
 	return internalinput.AnyKeyPressed
 }
+
+// GamepadButtonPressed returns whether gamepad button b is currently pressed on any gamepad.
+func GamepadButtonPressed(b ebiten.GamepadButton) bool {
+	p, ok := internalinput.GamepadButtonPressed[b]
+	return ok && p
+}
+
+// AnyGamepadButtonPressed returns whether any gamepad button is currently pressed on any gamepad.
+func AnyGamepadButtonPressed() bool {
+	return internalinput.AnyGamepadButtonPressed
+}
+
+// GamepadAxis returns the value of gamepad axis a, in the range [-1,1]. If multiple gamepads are connected,
+// the value with the largest magnitude is returned.
+func GamepadAxis(a int) float64 {
+	return internalinput.GamepadAxes[a]
+}
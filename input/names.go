@@ -0,0 +1,111 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NameLocalizeFunc is a function that returns the localized name of the key, mouse button, or gamepad button
+// identified by id, such as "key.KPAdd", "mouse.0", or "gamepad.7". def is the default English name, which
+// should be returned if no localized name is available.
+type NameLocalizeFunc func(id string, def string) string
+
+// NameLocalizer is used by KeyName, MouseButtonName, and GamepadButtonName to localize names. It may also be
+// used to provide names for the buttons of specific gamepads, for example "Right Trigger". If nil, the default
+// English names are used.
+var NameLocalizer NameLocalizeFunc
+
+var keyNames = map[ebiten.Key]string{
+	ebiten.KeyApostrophe:     "'",
+	ebiten.KeyBackslash:      "\\",
+	ebiten.KeyCapsLock:       "Caps Lock",
+	ebiten.KeyComma:          ",",
+	ebiten.KeyEqual:          "=",
+	ebiten.KeyEscape:         "Esc",
+	ebiten.KeyGraveAccent:    "`",
+	ebiten.KeyNumpadAdd:      "Num +",
+	ebiten.KeyNumpadDecimal:  "Num .",
+	ebiten.KeyNumpadDivide:   "Num /",
+	ebiten.KeyNumpadEnter:    "Num Enter",
+	ebiten.KeyNumpadEqual:    "Num =",
+	ebiten.KeyNumpadMultiply: "Num *",
+	ebiten.KeyNumpadSubtract: "Num -",
+	ebiten.KeyLeftBracket:    "[",
+	ebiten.KeyMinus:          "-",
+	ebiten.KeyNumLock:        "Num Lock",
+	ebiten.KeyPageDown:       "Page Down",
+	ebiten.KeyPageUp:         "Page Up",
+	ebiten.KeyPeriod:         ".",
+	ebiten.KeyPrintScreen:    "Print Screen",
+	ebiten.KeyRightBracket:   "]",
+	ebiten.KeyScrollLock:     "Scroll Lock",
+	ebiten.KeySemicolon:      ";",
+	ebiten.KeySlash:          "/",
+	ebiten.KeyControl:        "Ctrl",
+}
+
+// KeyName returns the human-readable name of k, for example "Num +" or "F5".
+func KeyName(k ebiten.Key) string {
+	name, ok := keyNames[k]
+	switch {
+	case ok:
+	case k >= ebiten.KeyNumpad0 && k <= ebiten.KeyNumpad9:
+		name = fmt.Sprintf("Num %d", k-ebiten.KeyNumpad0)
+	default:
+		name = k.String()
+	}
+
+	return localizeName("key."+k.String(), name)
+}
+
+// KeysName returns the human-readable name of the key combination keys, for example "Ctrl+Shift+S".
+func KeysName(keys ...ebiten.Key) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = KeyName(k)
+	}
+	return strings.Join(names, "+")
+}
+
+// MouseButtonName returns the human-readable name of b, for example "Right Mouse Button".
+func MouseButtonName(b ebiten.MouseButton) string {
+	var name string
+	switch b {
+	case ebiten.MouseButtonLeft:
+		name = "Left Mouse Button"
+	case ebiten.MouseButtonMiddle:
+		name = "Middle Mouse Button"
+	case ebiten.MouseButtonRight:
+		name = "Right Mouse Button"
+	default:
+		name = fmt.Sprintf("Mouse Button %d", b)
+	}
+
+	return localizeName(fmt.Sprintf("mouse.%d", b), name)
+}
+
+// GamepadButtonName returns the human-readable name of b. Since button layouts differ between gamepads, the
+// default name is only the button's number, for example "Gamepad Button 7". NameLocalizer may be used to
+// provide better names.
+func GamepadButtonName(b ebiten.GamepadButton) string {
+	return localizeName(fmt.Sprintf("gamepad.%d", b), fmt.Sprintf("Gamepad Button %d", b))
+}
+
+// GamepadAxisName returns the human-readable name of gamepad axis a in positive or negative direction, for
+// example "Gamepad Axis 1+".
+func GamepadAxisName(a int, positive bool) string {
+	dir := "-"
+	if positive {
+		dir = "+"
+	}
+	return localizeName(fmt.Sprintf("gamepadAxis.%d%s", a, dir), fmt.Sprintf("Gamepad Axis %d%s", a, dir))
+}
+
+func localizeName(id string, def string) string {
+	if NameLocalizer == nil {
+		return def
+	}
+	return NameLocalizer(id, def)
+}
@@ -0,0 +1,39 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestKeyName(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(KeyName(ebiten.KeyNumpadAdd), "Num +")
+	is.Equal(KeyName(ebiten.KeyNumpad7), "Num 7")
+	is.Equal(KeyName(ebiten.KeyF5), "F5")
+	is.Equal(KeyName(ebiten.KeyA), "A")
+	is.Equal(KeysName(ebiten.KeyControl, ebiten.KeyS), "Ctrl+S")
+}
+
+func TestNameLocalizer(t *testing.T) {
+	is := is.New(t)
+
+	NameLocalizer = func(id string, def string) string {
+		switch id {
+		case "key.KPAdd":
+			return "Ziffernblock +"
+		case "gamepad.7":
+			return "Right Trigger"
+		}
+		return def
+	}
+	defer func() {
+		NameLocalizer = nil
+	}()
+
+	is.Equal(KeyName(ebiten.KeyNumpadAdd), "Ziffernblock +")
+	is.Equal(GamepadButtonName(7), "Right Trigger")
+	is.Equal(MouseButtonName(ebiten.MouseButtonRight), "Right Mouse Button")
+}
@@ -1,6 +1,8 @@
 package input
 
 import (
+	"math"
+
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -24,14 +26,36 @@ var (
 	InputChars    []rune
 	KeyPressed    = map[ebiten.Key]bool{}
 	AnyKeyPressed bool
+
+	GamepadButtonPressed    = map[ebiten.GamepadButton]bool{}
+	AnyGamepadButtonPressed bool
+	GamepadAxes             = map[int]float64{}
+)
+
+// MouseButtonPressedFunc and CursorPositionFunc query the state of the mouse. They may be replaced in tests.
+var (
+	MouseButtonPressedFunc = ebiten.IsMouseButtonPressed
+	CursorPositionFunc     = ebiten.CursorPosition
 )
 
 // Update updates the input system. This is called by the UI.
+//
+// Mouse buttons that have been pressed since the last Draw are reported as just pressed until the end of the
+// next Draw, even if they have been released again in the meantime, so that clicks are not dropped when there
+// are several updates per frame.
 func Update() {
-	LeftMouseButtonPressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
-	MiddleMouseButtonPressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle)
-	RightMouseButtonPressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight)
-	CursorX, CursorY = ebiten.CursorPosition()
+	LeftMouseButtonPressed = MouseButtonPressedFunc(ebiten.MouseButtonLeft)
+	MiddleMouseButtonPressed = MouseButtonPressedFunc(ebiten.MouseButtonMiddle)
+	RightMouseButtonPressed = MouseButtonPressedFunc(ebiten.MouseButtonRight)
+	CursorX, CursorY = CursorPositionFunc()
+
+	LeftMouseButtonJustPressed = LeftMouseButtonJustPressed || (LeftMouseButtonPressed && !LastLeftMouseButtonPressed)
+	MiddleMouseButtonJustPressed = MiddleMouseButtonJustPressed || (MiddleMouseButtonPressed && !LastMiddleMouseButtonPressed)
+	RightMouseButtonJustPressed = RightMouseButtonJustPressed || (RightMouseButtonPressed && !LastRightMouseButtonPressed)
+
+	LastLeftMouseButtonPressed = LeftMouseButtonPressed
+	LastMiddleMouseButtonPressed = MiddleMouseButtonPressed
+	LastRightMouseButtonPressed = RightMouseButtonPressed
 
 	wx, wy := ebiten.Wheel()
 	WheelX += wx
@@ -48,21 +72,40 @@ func Update() {
 			AnyKeyPressed = true
 		}
 	}
-}
 
-// Draw updates the input system. This is called by the UI.
-func Draw() {
-	LeftMouseButtonJustPressed = LeftMouseButtonPressed && LeftMouseButtonPressed != LastLeftMouseButtonPressed
-	MiddleMouseButtonJustPressed = MiddleMouseButtonPressed && MiddleMouseButtonPressed != LastMiddleMouseButtonPressed
-	RightMouseButtonJustPressed = RightMouseButtonPressed && RightMouseButtonPressed != LastRightMouseButtonPressed
+	AnyGamepadButtonPressed = false
+	ids := ebiten.GamepadIDs()
+	for b := ebiten.GamepadButton(0); b <= ebiten.GamepadButtonMax; b++ {
+		p := false
+		for _, id := range ids {
+			if ebiten.IsGamepadButtonPressed(id, b) {
+				p = true
+				break
+			}
+		}
+		GamepadButtonPressed[b] = p
 
-	LastLeftMouseButtonPressed = LeftMouseButtonPressed
-	LastMiddleMouseButtonPressed = MiddleMouseButtonPressed
-	LastRightMouseButtonPressed = RightMouseButtonPressed
+		if p {
+			AnyGamepadButtonPressed = true
+		}
+	}
+
+	for a := range GamepadAxes {
+		GamepadAxes[a] = 0
+	}
+	for _, id := range ids {
+		for a := 0; a < ebiten.GamepadAxisNum(id); a++ {
+			v := ebiten.GamepadAxis(id, a)
+			if math.Abs(v) > math.Abs(GamepadAxes[a]) {
+				GamepadAxes[a] = v
+			}
+		}
+	}
 }
 
 // AfterDraw updates the input system after the Ebiten Draw function has been called. This is called by the UI.
 func AfterDraw() {
+	LeftMouseButtonJustPressed, MiddleMouseButtonJustPressed, RightMouseButtonJustPressed = false, false, false
 	InputChars = InputChars[:0]
 	WheelX, WheelY = 0, 0
 }
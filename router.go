@@ -0,0 +1,527 @@
+package ebitenui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/widget"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Router manages the screens of a game's UI, such as the main menu, settings, or pause screens. Screens are
+// registered by name and kept on a stack, the top screen's container is used as the UI's root container.
+//
+// Router.Draw should be called instead of UI.Draw, so that transitions between screens can be drawn. At least
+// one screen must be pushed before drawing. When the cancel action is pressed, for example Escape, and nothing
+// else handles it, the UI pops the top screen.
+type Router struct {
+	// ScreenChangedEvent fires an event with *RouterScreenChangedEventArgs when the top screen has changed.
+	ScreenChangedEvent *event.Event
+
+	// TransitionCompletedEvent fires an event with *RouterTransitionCompletedEventArgs when the transition
+	// between screens has finished. If a navigation is not animated, it fires immediately.
+	TransitionCompletedEvent *event.Event
+
+	ui         *UI
+	screens    map[string]ScreenFunc
+	stack      []*routerEntry
+	transition *RouterTransitionConfig
+
+	outgoing        *widget.Container
+	outgoingBack    bool
+	current         *RouterTransitionConfig
+	transitionStart time.Time
+	buffer          *ebiten.Image
+	incomingBuffer  *ebiten.Image
+}
+
+// Screen is a screen of a game's UI.
+type Screen struct {
+	// Container is the root container of the screen.
+	Container *widget.Container
+
+	// EnterFunc is called when the screen becomes the top screen. It may be nil.
+	EnterFunc func()
+
+	// ExitFunc is called when the screen stops being the top screen, because another screen has been pushed
+	// on top of it, or because it has been popped or replaced. It may be nil.
+	ExitFunc func()
+}
+
+// ScreenFunc constructs a new Screen. It is called each time the screen is pushed onto a Router's stack.
+type ScreenFunc func() *Screen
+
+type RouterOpt func(r *Router)
+
+type RouterOptions struct {
+}
+
+// RouterTransition specifies the animation used when switching between screens.
+type RouterTransition int
+
+// RouterTransitionConfig configures the transition used when switching between screens.
+type RouterTransitionConfig struct {
+	Transition RouterTransition
+
+	// Duration is the duration of the transition. It is scaled according to widget.MotionScale.
+	Duration time.Duration
+
+	// Easing maps the linear progress of the transition to the progress drawn. If nil, EaseIn is used for
+	// RouterTransitionSlide, and EaseLinear otherwise.
+	Easing EasingFunc
+}
+
+// EasingFunc maps linear progress t in the range [0,1] to eased progress, usually also in the range [0,1].
+type EasingFunc func(t float64) float64
+
+type RouterScreenChangedEventArgs struct {
+	Router *Router
+
+	// Previous is the name of the previous top screen, or "" if there was none.
+	Previous string
+
+	// Current is the name of the new top screen, or "" if there is none.
+	Current string
+}
+
+type RouterScreenChangedHandlerFunc func(args *RouterScreenChangedEventArgs)
+
+type RouterTransitionCompletedEventArgs struct {
+	Router *Router
+
+	// Current is the name of the top screen that has been transitioned to, or "" if there is none.
+	Current string
+}
+
+type RouterTransitionCompletedHandlerFunc func(args *RouterTransitionCompletedEventArgs)
+
+type routerEntry struct {
+	name   string
+	screen *Screen
+}
+
+const (
+	// RouterTransitionNone switches screens immediately.
+	RouterTransitionNone = RouterTransition(iota)
+
+	// RouterTransitionFade fades out the previous screen, revealing the new one.
+	RouterTransitionFade
+
+	// RouterTransitionSlide slides the previous screen out to the left when pushing, or to the right when
+	// popping, revealing the new one.
+	RouterTransitionSlide
+
+	// RouterTransitionRadialWipe reveals the new screen in a sweep around the center of the screen, clockwise
+	// when pushing, or counterclockwise when popping.
+	RouterTransitionRadialWipe
+
+	// RouterTransitionCrossfade fades out the previous screen while fading in the new one.
+	RouterTransitionCrossfade
+)
+
+var (
+	// EaseLinear does not ease progress.
+	EaseLinear EasingFunc = func(t float64) float64 {
+		return t
+	}
+
+	// EaseIn starts slowly and accelerates.
+	EaseIn EasingFunc = func(t float64) float64 {
+		return t * t
+	}
+
+	// EaseOut starts quickly and decelerates.
+	EaseOut EasingFunc = func(t float64) float64 {
+		return 1 - (1-t)*(1-t)
+	}
+
+	// EaseInOut starts slowly, accelerates, and decelerates again.
+	EaseInOut EasingFunc = func(t float64) float64 {
+		if t < 0.5 {
+			return 2 * t * t
+		}
+		return 1 - 2*(1-t)*(1-t)
+	}
+)
+
+// routerRadialWipeSegments is the number of triangles used to draw a full circle of a radial wipe.
+const routerRadialWipeSegments = 64
+
+var RouterOpts RouterOptions
+
+// NewRouter constructs a new Router that manages ui's root container.
+func NewRouter(ui *UI, opts ...RouterOpt) *Router {
+	r := &Router{
+		ScreenChangedEvent:       &event.Event{},
+		TransitionCompletedEvent: &event.Event{},
+
+		ui:         ui,
+		screens:    map[string]ScreenFunc{},
+		transition: &RouterTransitionConfig{},
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	ui.router = r
+
+	return r
+}
+
+// Transition configures a Router to use transition t, taking d, when switching between screens. The duration
+// is scaled according to widget.MotionScale.
+func (o RouterOptions) Transition(t RouterTransition, d time.Duration) RouterOpt {
+	return func(r *Router) {
+		r.transition.Transition = t
+		r.transition.Duration = d
+	}
+}
+
+// TransitionEasing configures a Router to ease the progress of transitions between screens using f.
+func (o RouterOptions) TransitionEasing(f EasingFunc) RouterOpt {
+	return func(r *Router) {
+		r.transition.Easing = f
+	}
+}
+
+func (o RouterOptions) TransitionCompletedHandler(f RouterTransitionCompletedHandlerFunc) RouterOpt {
+	return func(r *Router) {
+		r.TransitionCompletedEvent.AddHandler(func(args interface{}) {
+			f(args.(*RouterTransitionCompletedEventArgs))
+		})
+	}
+}
+
+func (o RouterOptions) ScreenChangedHandler(f RouterScreenChangedHandlerFunc) RouterOpt {
+	return func(r *Router) {
+		r.ScreenChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*RouterScreenChangedEventArgs))
+		})
+	}
+}
+
+// Register registers screen name, using f to construct it when it is pushed.
+func (r *Router) Register(name string, f ScreenFunc) {
+	r.screens[name] = f
+}
+
+// Push constructs screen name and pushes it onto the stack, making it the top screen.
+func (r *Router) Push(name string) {
+	r.PushWith(name, nil)
+}
+
+// PushWith is the same as Push, but uses transition t instead of the Router's transition. If t is nil, the
+// Router's transition is used.
+func (r *Router) PushWith(name string, t *RouterTransitionConfig) {
+	s := r.newScreen(name)
+	r.change(func() {
+		r.stack = append(r.stack, &routerEntry{name: name, screen: s})
+	}, false, t)
+}
+
+// Pop removes the top screen from the stack, making the screen below it the top screen. It returns false
+// if there is only one screen or none on the stack, in which case nothing is changed.
+func (r *Router) Pop() bool {
+	return r.PopWith(nil)
+}
+
+// PopWith is the same as Pop, but uses transition t instead of the Router's transition. If t is nil, the
+// Router's transition is used.
+func (r *Router) PopWith(t *RouterTransitionConfig) bool {
+	if len(r.stack) <= 1 {
+		return false
+	}
+
+	r.change(func() {
+		r.stack[len(r.stack)-1] = nil
+		r.stack = r.stack[:len(r.stack)-1]
+	}, true, t)
+
+	return true
+}
+
+// Replace replaces the top screen with a newly constructed screen name. If the stack is empty, it is the same
+// as Push.
+func (r *Router) Replace(name string) {
+	r.ReplaceWith(name, nil)
+}
+
+// ReplaceWith is the same as Replace, but uses transition t instead of the Router's transition. If t is nil,
+// the Router's transition is used.
+func (r *Router) ReplaceWith(name string, t *RouterTransitionConfig) {
+	s := r.newScreen(name)
+	r.change(func() {
+		e := &routerEntry{name: name, screen: s}
+		if len(r.stack) == 0 {
+			r.stack = append(r.stack, e)
+			return
+		}
+		r.stack[len(r.stack)-1] = e
+	}, false, t)
+}
+
+// Top returns the name of the top screen, or "" if the stack is empty.
+func (r *Router) Top() string {
+	if e := r.top(); e != nil {
+		return e.name
+	}
+	return ""
+}
+
+// Stack returns the names of the screens on the stack, from bottom to top.
+func (r *Router) Stack() []string {
+	names := make([]string, len(r.stack))
+	for i, e := range r.stack {
+		names[i] = e.name
+	}
+	return names
+}
+
+// Transitioning returns whether a transition between screens is currently being drawn.
+func (r *Router) Transitioning() bool {
+	return r.outgoing != nil
+}
+
+// Draw renders the UI onto screen, followed by the transition between screens if one is in progress. This
+// method should be called in the Ebiten Draw function instead of UI.Draw.
+func (r *Router) Draw(screen *ebiten.Image) {
+	if r.outgoing == nil {
+		r.ui.Draw(screen)
+		return
+	}
+
+	p := r.transitionProgress()
+	if p >= 1 {
+		r.ui.Draw(screen)
+		r.outgoing = nil
+		r.fireTransitionCompleted()
+		return
+	}
+
+	p = r.easing()(p)
+
+	if r.current.Transition == RouterTransitionCrossfade {
+		r.drawCrossfade(screen, p)
+		return
+	}
+
+	r.ui.Draw(screen)
+	r.drawOutgoing(screen, p)
+}
+
+func (r *Router) newScreen(name string) *Screen {
+	f, ok := r.screens[name]
+	if !ok {
+		panic(fmt.Sprintf("screen %q is not registered", name))
+	}
+	return f()
+}
+
+// change exits the current top screen, calls f to modify the stack, and enters the new top screen, using
+// transition t, or r.transition if t is nil.
+func (r *Router) change(f func(), back bool, t *RouterTransitionConfig) {
+	prev := r.top()
+	if prev != nil && prev.screen.ExitFunc != nil {
+		prev.screen.ExitFunc()
+	}
+
+	f()
+
+	cur := r.top()
+
+	r.ui.focus(nil)
+
+	if cur != nil {
+		r.ui.Container = cur.screen.Container
+		cur.screen.Container.RequestRelayout()
+	}
+
+	if t == nil {
+		t = r.transition
+	}
+	r.startTransition(prev, back, t)
+
+	if cur != nil && cur.screen.EnterFunc != nil {
+		cur.screen.EnterFunc()
+	}
+
+	args := &RouterScreenChangedEventArgs{
+		Router: r,
+	}
+	if prev != nil {
+		args.Previous = prev.name
+	}
+	if cur != nil {
+		args.Current = cur.name
+	}
+	r.ScreenChangedEvent.Fire(args)
+
+	if r.outgoing == nil {
+		r.fireTransitionCompleted()
+	}
+}
+
+func (r *Router) startTransition(prev *routerEntry, back bool, t *RouterTransitionConfig) {
+	r.outgoing = nil
+	r.current = t
+
+	if prev == nil || t.Transition == RouterTransitionNone || r.duration() <= 0 {
+		return
+	}
+
+	r.outgoing = prev.screen.Container
+	r.outgoingBack = back
+	r.transitionStart = r.ui.widgetClock().Now()
+}
+
+func (r *Router) fireTransitionCompleted() {
+	r.TransitionCompletedEvent.Fire(&RouterTransitionCompletedEventArgs{
+		Router:  r,
+		Current: r.Top(),
+	})
+}
+
+func (r *Router) duration() time.Duration {
+	return time.Duration(float64(r.current.Duration) * widget.MotionScale())
+}
+
+func (r *Router) easing() EasingFunc {
+	switch {
+	case r.current.Easing != nil:
+		return r.current.Easing
+	case r.current.Transition == RouterTransitionSlide:
+		return EaseIn
+	default:
+		return EaseLinear
+	}
+}
+
+func (r *Router) transitionProgress() float64 {
+	d := r.duration()
+	if d <= 0 {
+		return 1
+	}
+	return float64(r.ui.widgetClock().Now().Sub(r.transitionStart)) / float64(d)
+}
+
+// drawOutgoing draws the previous top screen onto screen, at eased transition progress p.
+func (r *Router) drawOutgoing(screen *ebiten.Image, p float64) {
+	r.buffer = sizedBuffer(r.buffer, screen)
+	r.buffer.Clear()
+	widget.RenderWithDeferred(r.buffer, []widget.Renderer{r.outgoing})
+
+	if r.current.Transition == RouterTransitionRadialWipe {
+		r.drawRadialWipe(screen, p)
+		return
+	}
+
+	opts := ebiten.DrawImageOptions{}
+
+	switch r.current.Transition {
+	case RouterTransitionFade:
+		opts.ColorM.Scale(1, 1, 1, 1-p)
+
+	case RouterTransitionSlide:
+		w, _ := screen.Size()
+		x := float64(w) * p
+		if !r.outgoingBack {
+			x = -x
+		}
+		opts.GeoM.Translate(x, 0)
+	}
+
+	screen.DrawImage(r.buffer, &opts)
+}
+
+// drawRadialWipe draws the part of r.buffer onto screen that has not yet been wiped away at progress p.
+func (r *Router) drawRadialWipe(screen *ebiten.Image, p float64) {
+	w, h := screen.Size()
+	cx, cy := float64(w)/2, float64(h)/2
+	radius := math.Hypot(cx, cy)
+
+	vertex := func(x float64, y float64) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   float32(x),
+			SrcY:   float32(y),
+			ColorR: 1,
+			ColorG: 1,
+			ColorB: 1,
+			ColorA: 1,
+		}
+	}
+
+	remaining := 1 - p
+	segments := int(math.Ceil(routerRadialWipeSegments * remaining))
+	if segments <= 0 {
+		return
+	}
+
+	// the remaining sector starts where the wipe currently is, and ends at 12 o'clock
+	dir := 1.0
+	if r.outgoingBack {
+		dir = -1
+	}
+	start := -math.Pi/2 + dir*2*math.Pi*p
+
+	vertices := make([]ebiten.Vertex, 0, segments+2)
+	indices := make([]uint16, 0, segments*3)
+
+	vertices = append(vertices, vertex(cx, cy))
+	for s := 0; s <= segments; s++ {
+		a := start + dir*2*math.Pi*remaining*float64(s)/float64(segments)
+		vertices = append(vertices, vertex(cx+math.Cos(a)*radius, cy+math.Sin(a)*radius))
+
+		if s > 0 {
+			indices = append(indices, 0, uint16(s), uint16(s+1))
+		}
+	}
+
+	screen.DrawTriangles(vertices, indices, r.buffer, &ebiten.DrawTrianglesOptions{
+		Address: ebiten.AddressClampToZero,
+	})
+}
+
+// drawCrossfade draws the previous and the current top screen onto screen, blended at eased transition
+// progress p.
+func (r *Router) drawCrossfade(screen *ebiten.Image, p float64) {
+	r.buffer = sizedBuffer(r.buffer, screen)
+	r.buffer.Clear()
+	widget.RenderWithDeferred(r.buffer, []widget.Renderer{r.outgoing})
+
+	r.incomingBuffer = sizedBuffer(r.incomingBuffer, screen)
+	r.incomingBuffer.Clear()
+	r.ui.Draw(r.incomingBuffer)
+
+	opts := ebiten.DrawImageOptions{}
+	opts.ColorM.Scale(1, 1, 1, 1-p)
+	screen.DrawImage(r.buffer, &opts)
+
+	opts = ebiten.DrawImageOptions{}
+	opts.ColorM.Scale(1, 1, 1, p)
+	screen.DrawImage(r.incomingBuffer, &opts)
+}
+
+// sizedBuffer returns b if it has the same size as screen, or a new image of that size otherwise.
+func sizedBuffer(b *ebiten.Image, screen *ebiten.Image) *ebiten.Image {
+	w, h := screen.Size()
+	if b != nil {
+		if bw, bh := b.Size(); bw == w && bh == h {
+			return b
+		}
+		b.Dispose()
+	}
+	return ebiten.NewImage(w, h)
+}
+
+func (r *Router) top() *routerEntry {
+	if len(r.stack) == 0 {
+		return nil
+	}
+	return r.stack[len(r.stack)-1]
+}
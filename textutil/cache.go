@@ -0,0 +1,86 @@
+package textutil
+
+import (
+	"math"
+
+	"golang.org/x/image/font"
+)
+
+// Cache measures text drawn using a single font face, and caches the widths of strings it has measured.
+// When the cache holds more than its maximum number of entries, it is cleared.
+//
+// Cache is useful for widgets that measure the same strings every frame, such as labels in world space.
+type Cache struct {
+	face       font.Face
+	maxEntries int
+	widths     map[string]float64
+}
+
+// DefaultCacheMaxEntries is the maximum number of entries of a Cache if none is specified.
+const DefaultCacheMaxEntries = 1000
+
+// NewCache returns a new Cache that measures text drawn using f, holding at most maxEntries widths.
+// If maxEntries is 0 or less, DefaultCacheMaxEntries is used.
+func NewCache(f font.Face, maxEntries int) *Cache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheMaxEntries
+	}
+
+	return &Cache{
+		face:       f,
+		maxEntries: maxEntries,
+		widths:     map[string]float64{},
+	}
+}
+
+// Face returns the font face used by c.
+func (c *Cache) Face() font.Face {
+	return c.face
+}
+
+// Len returns the number of widths currently cached.
+func (c *Cache) Len() int {
+	return len(c.widths)
+}
+
+// Clear removes all cached widths.
+func (c *Cache) Clear() {
+	c.widths = map[string]float64{}
+}
+
+// Width returns the width of s in pixels when drawn.
+func (c *Cache) Width(s string) float64 {
+	if w, ok := c.widths[s]; ok {
+		return w
+	}
+
+	if len(c.widths) >= c.maxEntries {
+		c.Clear()
+	}
+
+	w := Width(s, c.face)
+	c.widths[s] = w
+	return w
+}
+
+// Advance returns the width of s in pixels when drawn, rounded to the nearest pixel.
+func (c *Cache) Advance(s string) int {
+	return int(math.Round(c.Width(s)))
+}
+
+// IndexAt returns an index into r that corresponds closest to pixel position x when string(r) is drawn.
+// Pixel position x==0 corresponds to r[0].
+func (c *Cache) IndexAt(r []rune, x int) int {
+	return indexAt(r, x, c.Advance)
+}
+
+// Wrap splits s into lines at line terminators, and wraps each line at word boundaries so that it is
+// no wider than width when drawn. If width is 0 or less, lines are not wrapped.
+func (c *Cache) Wrap(s string, width int) []string {
+	return wrap(s, width, c.Width)
+}
+
+// Size returns the size of the bounding box of s when drawn, wrapped to width as in Wrap.
+func (c *Cache) Size(s string, width int) (float64, float64) {
+	return size(c.Wrap(s, width), c.face, c.Width)
+}
@@ -0,0 +1,9 @@
+// Package textutil provides text measurement functions, such as measuring the width of text, finding
+// the character index at a pixel position, and wrapping text at word boundaries.
+//
+// The widgets use these functions to measure their text. Custom widgets, tool tips, or labels drawn in
+// world space can use them to measure text in the same way.
+//
+// NewFallbackFace combines multiple font faces into one, for example to draw emoji or symbols that are
+// missing from a text font.
+package textutil
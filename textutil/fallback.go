@@ -0,0 +1,110 @@
+package textutil
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// GlyphChecker is implemented by font faces that can report whether they contain a glyph for a rune.
+type GlyphChecker interface {
+	HasGlyph(r rune) bool
+}
+
+// CoverageFunc returns whether a font face contains a glyph for r.
+type CoverageFunc func(r rune) bool
+
+type coverageFace struct {
+	font.Face
+	coverage CoverageFunc
+}
+
+type fallbackFace struct {
+	faces []font.Face
+}
+
+// WithCoverage returns a font face that draws using f, and that implements GlyphChecker using c. Many font
+// face implementations report glyphs as available even if they are not, and draw a replacement glyph instead.
+// WithCoverage can be used to make such faces work with NewFallbackFace, for example using truetype.Font.Index.
+func WithCoverage(f font.Face, c CoverageFunc) font.Face {
+	return &coverageFace{
+		Face:     f,
+		coverage: c,
+	}
+}
+
+// NewFallbackFace returns a font face that draws each rune using the first of faces that contains a glyph
+// for it, such as a text font followed by an emoji or symbol font. Faces that implement GlyphChecker are
+// asked whether they contain a glyph, other faces are asked using GlyphAdvance. If no face contains a glyph,
+// the last face is used. The metrics of the returned face are those of the first face.
+func NewFallbackFace(faces ...font.Face) font.Face {
+	if len(faces) == 0 {
+		panic("no font faces")
+	}
+
+	return &fallbackFace{
+		faces: faces,
+	}
+}
+
+func (c *coverageFace) HasGlyph(r rune) bool {
+	return c.coverage(r)
+}
+
+func (f *fallbackFace) face(r rune) font.Face {
+	for _, ff := range f.faces[:len(f.faces)-1] {
+		if hasGlyph(ff, r) {
+			return ff
+		}
+	}
+	return f.faces[len(f.faces)-1]
+}
+
+func hasGlyph(f font.Face, r rune) bool {
+	if c, ok := f.(GlyphChecker); ok {
+		return c.HasGlyph(r)
+	}
+
+	_, ok := f.GlyphAdvance(r)
+	return ok
+}
+
+// HasGlyph implements GlyphChecker.
+func (f *fallbackFace) HasGlyph(r rune) bool {
+	for _, ff := range f.faces {
+		if hasGlyph(ff, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fallbackFace) Close() error {
+	return nil
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return f.face(r).Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return f.face(r).GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return f.face(r).GlyphAdvance(r)
+}
+
+// Kern returns the kerning of r0 and r1 if both are drawn using the same face, and 0 otherwise.
+func (f *fallbackFace) Kern(r0 rune, r1 rune) fixed.Int26_6 {
+	f0 := f.face(r0)
+	if f0 != f.face(r1) {
+		return 0
+	}
+	return f0.Kern(r0, r1)
+}
+
+func (f *fallbackFace) Metrics() font.Metrics {
+	return f.faces[0].Metrics()
+}
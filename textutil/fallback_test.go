@@ -0,0 +1,27 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestNewFallbackFace(t *testing.T) {
+	is := is.New(t)
+
+	wide := *basicfont.Face7x13
+	wide.Advance = 12
+
+	f := NewFallbackFace(
+		WithCoverage(basicfont.Face7x13, func(r rune) bool {
+			return r < 128
+		}),
+		&wide)
+
+	is.Equal(Advance("a", f), 7)
+	is.Equal(Advance("☺", f), 12)
+	is.Equal(Advance("a☺b", f), 26)
+	is.Equal(f.Metrics(), basicfont.Face7x13.Metrics())
+	is.True(f.(GlyphChecker).HasGlyph('a'))
+}
@@ -0,0 +1,214 @@
+package textutil
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// MeasureFunc is a function that returns the width of line[start:end] when drawn, where line is the
+// line being measured.
+type MeasureFunc func(start int, end int) float64
+
+// Width returns the width of s in pixels when drawn using f.
+func Width(s string, f font.Face) float64 {
+	return Fixed26_6ToFloat64(font.MeasureString(f, s))
+}
+
+// Advance returns the advance of s in pixels when drawn using f, rounded to the nearest pixel.
+func Advance(s string, f font.Face) int {
+	_, a := font.BoundString(f, s)
+	return int(math.Round(Fixed26_6ToFloat64(a)))
+}
+
+// Ascent returns the ascent of f in pixels.
+func Ascent(f font.Face) float64 {
+	return Fixed26_6ToFloat64(f.Metrics().Ascent)
+}
+
+// LineHeight returns the recommended distance between the baselines of two lines of text drawn using f.
+func LineHeight(f font.Face) float64 {
+	return Fixed26_6ToFloat64(f.Metrics().Height)
+}
+
+// IndexAt returns an index into r that corresponds closest to pixel position x when string(r) is drawn
+// using f. Pixel position x==0 corresponds to r[0]. It can be used to find the caret position for a
+// mouse click.
+func IndexAt(r []rune, f font.Face, x int) int {
+	return indexAt(r, x, func(s string) int {
+		return Advance(s, f)
+	})
+}
+
+func indexAt(r []rune, x int, advance func(s string) int) int {
+	start := 0
+	end := len(r)
+	p := 0
+loop:
+	for {
+		p = start + (end-start)/2
+		sub := string(r[:p])
+		a := advance(sub)
+
+		switch {
+		// x is right of advance
+		case x > a:
+			if p == start {
+				break loop
+			}
+
+			start = p
+
+		// x is left of advance
+		case x < a:
+			if end == p {
+				break loop
+			}
+
+			end = p
+
+		// x matches advance exactly
+		default:
+			return p
+		}
+	}
+
+	if len(r) > 0 {
+		a1 := advance(string(r[:p]))
+		a2 := advance(string(r[:p+1]))
+		if math.Abs(float64(x-a2)) < math.Abs(float64(x-a1)) {
+			p++
+		}
+	}
+
+	return p
+}
+
+// SplitLines splits s into lines, and returns those lines and their byte offsets into s.
+// Lines may be terminated by "\n" or "\r\n". The line terminators are not included in the
+// returned lines.
+func SplitLines(s string) ([]string, []int) {
+	lines := []string{}
+	starts := []int{}
+
+	start := 0
+	for start < len(s) {
+		end := strings.IndexByte(s[start:], '\n')
+		next := 0
+		if end < 0 {
+			end = len(s)
+			next = len(s)
+		} else {
+			end += start
+			next = end + 1
+		}
+
+		lines = append(lines, strings.TrimSuffix(s[start:end], "\r"))
+		starts = append(starts, start)
+
+		start = next
+	}
+
+	return lines, starts
+}
+
+// WrapLine splits line at word boundaries into lines that are no wider than width, where measure
+// returns the width of line[start:end] when drawn. Words that are wider than width on their own are
+// not split. If width is 0 or less, line is returned as-is. The returned lines are substrings of line,
+// with whitespace removed at the points where line has been split.
+func WrapLine(line string, width int, measure MeasureFunc) []string {
+	if width <= 0 {
+		return []string{line}
+	}
+
+	lines := []string{}
+	start, end := -1, -1
+
+	wordStart := -1
+	for i, r := range line + " " {
+		if !unicode.IsSpace(r) {
+			if wordStart < 0 {
+				wordStart = i
+			}
+			continue
+		}
+
+		if wordStart < 0 {
+			continue
+		}
+
+		wordEnd := i
+		switch {
+		case start < 0:
+			start = wordStart
+		case measure(start, wordEnd) > float64(width):
+			lines = append(lines, line[start:end])
+			start = wordStart
+		}
+		end = wordEnd
+
+		wordStart = -1
+	}
+
+	if start < 0 {
+		return []string{line}
+	}
+
+	return append(lines, line[start:end])
+}
+
+// Wrap splits s into lines at line terminators, and wraps each line at word boundaries so that it is
+// no wider than width when drawn using f. If width is 0 or less, lines are not wrapped.
+func Wrap(s string, f font.Face, width int) []string {
+	return wrap(s, width, func(s string) float64 {
+		return Width(s, f)
+	})
+}
+
+func wrap(s string, width int, measure func(s string) float64) []string {
+	lines := []string{}
+
+	hardLines, _ := SplitLines(s)
+	for _, hardLine := range hardLines {
+		hardLine := hardLine
+		lines = append(lines, WrapLine(hardLine, width, func(start int, end int) float64 {
+			return measure(hardLine[start:end])
+		})...)
+	}
+
+	return lines
+}
+
+// Size returns the size of the bounding box of s when drawn using f, wrapped to width as in Wrap.
+func Size(s string, f font.Face, width int) (float64, float64) {
+	return size(Wrap(s, f, width), f, func(s string) float64 {
+		return Width(s, f)
+	})
+}
+
+func size(lines []string, f font.Face, measure func(s string) float64) (float64, float64) {
+	w := 0.0
+	for _, l := range lines {
+		if lw := measure(l); lw > w {
+			w = lw
+		}
+	}
+
+	if len(lines) == 0 {
+		return w, 0
+	}
+
+	m := f.Metrics()
+	lh := Fixed26_6ToFloat64(m.Height)
+	ld := lh - Fixed26_6ToFloat64(m.Ascent+m.Descent)
+
+	return w, float64(len(lines))*lh - ld
+}
+
+// Fixed26_6ToFloat64 converts i to a float64.
+func Fixed26_6ToFloat64(i fixed.Int26_6) float64 {
+	return float64(i) / (1 << 6)
+}
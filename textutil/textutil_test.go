@@ -0,0 +1,95 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestAdvance(t *testing.T) {
+	is := is.New(t)
+
+	f := basicfont.Face7x13
+	is.Equal(Advance("foo", f), 21)
+	is.Equal(Width("foo", f), 21.0)
+	is.Equal(Advance("", f), 0)
+}
+
+func TestIndexAt(t *testing.T) {
+	is := is.New(t)
+
+	f := basicfont.Face7x13
+	r := []rune("foobar")
+
+	is.Equal(IndexAt(r, f, 0), 0)
+	is.Equal(IndexAt(r, f, 3), 0)
+	is.Equal(IndexAt(r, f, 4), 1)
+	is.Equal(IndexAt(r, f, 14), 2)
+	is.Equal(IndexAt(r, f, 100), 6)
+	is.Equal(IndexAt(nil, f, 10), 0)
+}
+
+func TestSplitLines(t *testing.T) {
+	is := is.New(t)
+
+	lines, starts := SplitLines("foo\r\nbar\n\nbaz\n")
+	is.Equal(lines, []string{"foo", "bar", "", "baz"})
+	is.Equal(starts, []int{0, 5, 9, 10})
+}
+
+func TestWrapLine(t *testing.T) {
+	is := is.New(t)
+
+	f := basicfont.Face7x13
+	w := Advance("foo bar", f)
+
+	wrap := func(line string, width int) []string {
+		return WrapLine(line, width, func(start int, end int) float64 {
+			return Width(line[start:end], f)
+		})
+	}
+
+	is.Equal(wrap("foo bar baz qux", w), []string{"foo bar", "baz qux"})
+	is.Equal(wrap("foo bar baz qux", 0), []string{"foo bar baz qux"})
+	is.Equal(wrap("foobarbazqux", 1), []string{"foobarbazqux"})
+}
+
+func TestWrap(t *testing.T) {
+	is := is.New(t)
+
+	f := basicfont.Face7x13
+
+	is.Equal(Wrap("foo bar baz\nqux", f, 50), []string{"foo bar", "baz", "qux"})
+	is.Equal(Wrap("foo bar baz", f, 0), []string{"foo bar baz"})
+}
+
+func TestSize(t *testing.T) {
+	is := is.New(t)
+
+	f := basicfont.Face7x13
+
+	w, h := Size("foo bar baz\nqux", f, 50)
+	is.Equal(w, 49.0)
+	is.Equal(h, 39.0)
+}
+
+func TestCache(t *testing.T) {
+	is := is.New(t)
+
+	c := NewCache(basicfont.Face7x13, 2)
+
+	is.Equal(c.Advance("foo"), 21)
+	is.Equal(c.Width("foobar"), 42.0)
+	is.Equal(c.Len(), 2)
+
+	c.Width("baz")
+	is.Equal(c.Len(), 1)
+
+	is.Equal(c.IndexAt([]rune("foobar"), 14), 2)
+	is.Equal(c.Wrap("foo bar baz", 50), []string{"foo bar", "baz"})
+
+	w, h := c.Size("foo bar baz", 50)
+	is.Equal(w, 49.0)
+	is.Equal(h, 26.0)
+}
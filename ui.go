@@ -2,6 +2,9 @@ package ebitenui
 
 import (
 	"image"
+	"image/color"
+	"math"
+	"time"
 
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/input"
@@ -11,6 +14,15 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+var (
+	// NavRepeatDelay is the time that focus navigation actions must be held before focus movement is repeated.
+	NavRepeatDelay = 400 * time.Millisecond
+
+	// NavRepeatInterval is the time between repeated focus movements while a focus navigation action is held.
+	// If it is 0, focus movement is not repeated.
+	NavRepeatInterval = 100 * time.Millisecond
+)
+
 // UI encapsulates a complete user interface that can be rendered onto the screen.
 // There should only be exactly one UI per application.
 type UI struct {
@@ -23,31 +35,240 @@ type UI struct {
 	// DragAndDrop is used to render drag widgets while dragging and dropping. It may be nil to disable rendering.
 	DragAndDrop *widget.DragAndDrop
 
+	// FloatingText is used to render floating texts such as damage numbers, above u.Container but below
+	// windows. It may be nil to disable rendering.
+	FloatingText *widget.FloatingTextLayer
+
+	// FocusIndicator is used to highlight the focused widget. It may be nil to disable highlighting.
+	FocusIndicator *widget.FocusIndicator
+
+	// CoachMarks is used to render onboarding coach marks while they are active. It may be nil to disable
+	// rendering.
+	CoachMarks *widget.CoachMarks
+
+	// PieMenu is used to render a pie menu while it is open. It may be nil to disable rendering.
+	PieMenu *widget.PieMenu
+
+	// LayoutInspector is used to measure widgets while debugging layouts. It may be nil to disable measuring.
+	LayoutInspector *widget.LayoutInspector
+
+	// Viewport specifies the area of the screen that u renders into and receives cursor input from, for example
+	// for split-screen games that use one UI per player. If it is empty, the whole screen is used.
+	Viewport image.Rectangle
+
+	// Scale specifies the scale to render u with inside its viewport. If it is 0, a scale of 1 is used. It is
+	// only used with ResizeReflow.
+	Scale float64
+
+	// ResizeStrategy specifies how u adapts to the size of its viewport, for example when the window is resized.
+	ResizeStrategy ResizeStrategy
+
+	// VirtualSize specifies the fixed virtual resolution that u is laid out at when using ResizeScaleToFit or
+	// ResizeIntegerScale.
+	VirtualSize image.Point
+
+	// LetterboxColor is used to fill the parts of the viewport that are not covered by u when using
+	// ResizeScaleToFit or ResizeIntegerScale. It may be nil to leave them untouched.
+	LetterboxColor color.Color
+
+	// ColorFilter is applied to u's render output, for example image.ColorBlindnessSimulation to validate
+	// accessibility. It may be nil to disable filtering.
+	ColorFilter *ebiten.ColorM
+
+	// SharesInput specifies that input is processed by another UI. When using multiple UIs, all but one should
+	// set SharesInput, and the UI that does not set it should be updated first and drawn last. Input layers
+	// only affect the widgets of the UI that sets them up.
+	SharesInput bool
+
+	// ProcessInputInUpdate specifies that buffered keyboard input, such as typed characters and text entry
+	// commands, is processed in Update instead of Draw. This avoids delayed or dropped input when rendering
+	// is skipped or throttled. When using multiple UIs, either all or none of them should set it.
+	ProcessInputInUpdate bool
+
+	// Budget specifies limits for the work done to render a frame. It may be nil to disable checking.
+	Budget *FrameBudget
+
 	lastRect      image.Rectangle
+	hidden        bool
+	hiddenLayers  Layer
+	hiddenPause   widget.ReleasePauseFunc
+	clock         *widget.Clock
 	focusedWidget widget.HasWidget
+	actionRepeats map[input.Action]*actionRepeat
+	backPressed   bool
+	backHandlers  []*backHandler
+	router        *Router
+	windowRemoves map[*widget.Window]RemoveWindowFunc
+	focusScopes   []*focusScope
 	inputLayerers []input.Layerer
 	renderers     []widget.Renderer
 	windows       []*widget.Window
+	frameStats    widget.Stats
+	safeArea      widget.Insets
+	buffer        *ebiten.Image
+}
+
+// actionRepeat is the repeat state of a held action.
+type actionRepeat struct {
+	pressed  bool
+	deadline time.Time
+}
+
+// FrameBudget specifies limits for the work done to render a single frame. Limits that are 0 are not checked.
+type FrameBudget struct {
+	Widgets   int
+	Relayouts int
+	Draws     int
+
+	// ExceededFunc is called with the frame's stats when any of the limits has been exceeded.
+	ExceededFunc func(stats widget.Stats)
 }
 
+// ResizeStrategy specifies how a UI adapts to the size of its viewport.
+type ResizeStrategy int
+
+const (
+	// ResizeReflow lays out the UI to fill its viewport at UI.Scale.
+	ResizeReflow = ResizeStrategy(iota)
+
+	// ResizeScaleToFit lays out the UI at UI.VirtualSize, and scales it uniformly to fit into its viewport,
+	// centered and letterboxed.
+	ResizeScaleToFit
+
+	// ResizeIntegerScale lays out the UI at UI.VirtualSize, and scales it by the largest integer factor that fits
+	// into its viewport, centered and letterboxed. This keeps pixel art crisp. If the viewport is smaller than
+	// UI.VirtualSize, the UI is scaled down to fit instead.
+	ResizeIntegerScale
+)
+
+// Layer is a bit mask of layers of a UI, which can be hidden using UI.SetLayersHidden.
+type Layer uint16
+
+const (
+	// LayerContainer is the layer of UI.Container.
+	LayerContainer = Layer(1 << iota)
+
+	// LayerFloatingText is the layer of UI.FloatingText.
+	LayerFloatingText
+
+	// LayerWindows is the layer of all windows added using UI.AddWindow.
+	LayerWindows
+
+	// LayerCoachMarks is the layer of UI.CoachMarks.
+	LayerCoachMarks
+
+	// LayerFocusIndicator is the layer of UI.FocusIndicator.
+	LayerFocusIndicator
+
+	// LayerPieMenu is the layer of UI.PieMenu.
+	LayerPieMenu
+
+	// LayerToolTip is the layer of UI.ToolTip.
+	LayerToolTip
+
+	// LayerDragAndDrop is the layer of UI.DragAndDrop.
+	LayerDragAndDrop
+
+	// LayerLayoutInspector is the layer of UI.LayoutInspector.
+	LayerLayoutInspector
+
+	// LayerAll includes all layers.
+	LayerAll = Layer(^uint16(0))
+)
+
 // RemoveWindowFunc is a function to remove a Window from rendering.
 type RemoveWindowFunc func()
 
+// RemoveFocusScopeFunc is a function to remove a focus scope.
+type RemoveFocusScopeFunc func()
+
+// BackHandlerFunc is a function that handles the cancel action and returns true, or returns false if the action
+// should be handled elsewhere.
+type BackHandlerFunc func() bool
+
+// RemoveBackHandlerFunc is a function to remove a back handler.
+type RemoveBackHandlerFunc func()
+
+type backHandler struct {
+	f BackHandlerFunc
+}
+
+type focusScope struct {
+	container widget.FocusContainer
+	opener    widget.HasWidget
+}
+
 // Update updates u. This method should be called in the Ebiten Update function.
 func (u *UI) Update() {
-	internalinput.Update()
+	widget.SetClock(u.widgetClock())
+
+	if !u.SharesInput {
+		if u.ProcessInputInUpdate {
+			// input characters have already been processed in the previous Update of all UIs
+			internalinput.InputChars = internalinput.InputChars[:0]
+		}
+
+		internalinput.Update()
+	}
+
+	if u.ProcessInputInUpdate && !u.hidden {
+		u.updateInput()
+	}
+}
+
+// updateInput processes buffered input of the visible widgets of u.
+func (u *UI) updateInput() {
+	roots := make([]widget.Locater, 0, len(u.windows)+1)
+	if u.layerVisible(LayerContainer) {
+		roots = append(roots, u.Container)
+	}
+	if u.layerVisible(LayerWindows) {
+		for _, w := range u.windows {
+			roots = append(roots, w)
+		}
+	}
+
+	widget.UpdateInput(roots...)
 }
 
 // Draw renders u onto screen. This function should be called in the Ebiten Draw function.
 //
 // If screen's size changes from one frame to the next, u.Container.RequestRelayout is called.
+//
+// If u is hidden, nothing is rendered. See SetHidden.
 func (u *UI) Draw(screen *ebiten.Image) {
+	start := widget.CurrentStats()
+	defer u.checkBudget(start)
+
+	widget.SetClock(u.widgetClock())
+
 	event.ExecuteDeferred()
 
-	internalinput.Draw()
-	defer internalinput.AfterDraw()
+	if !u.SharesInput {
+		defer internalinput.AfterDraw()
+	}
+
+	if u.hidden {
+		return
+	}
+
+	if u.ProcessInputInUpdate {
+		defer u.hideInputChars()()
+	}
+
+	target := screen
+	vp, scale, size := u.layoutViewport(screen)
+	if outer := u.viewport(screen); vp != outer && u.LetterboxColor != nil {
+		screen.SubImage(outer).(*ebiten.Image).Fill(u.LetterboxColor)
+	}
+	if vp != screen.Bounds() || scale != 1 || u.ColorFilter != nil {
+		target = u.viewportBuffer(size)
+		defer u.drawBuffer(screen, vp, scale)
+
+		defer u.transformCursor(vp, scale)()
+	}
 
-	w, h := screen.Size()
+	w, h := target.Size()
 	rect := image.Rect(0, 0, w, h)
 
 	defer func() {
@@ -58,35 +279,551 @@ func (u *UI) Draw(screen *ebiten.Image) {
 		u.Container.RequestRelayout()
 	}
 
+	widget.SetSafeArea(u.safeArea.Apply(rect))
+
 	u.handleFocus()
+	u.handleBack()
 	u.setupInputLayers()
 	u.Container.SetLocation(rect)
-	u.render(screen)
+	u.render(target)
 }
 
-func (u *UI) handleFocus() {
-	if input.MouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		if u.focusedWidget != nil {
-			u.focusedWidget.(widget.Focuser).Focus(false)
-			u.focusedWidget = nil
+func (u *UI) viewport(screen *ebiten.Image) image.Rectangle {
+	if u.Viewport.Empty() {
+		return screen.Bounds()
+	}
+	return u.Viewport
+}
+
+func (u *UI) scale() float64 {
+	if u.Scale <= 0 {
+		return 1
+	}
+	return u.Scale
+}
+
+// layoutViewport returns the area of screen that u renders into, the scale to render with, and the size to lay
+// out u at, according to u.ResizeStrategy.
+func (u *UI) layoutViewport(screen *ebiten.Image) (image.Rectangle, float64, image.Point) {
+	vp := u.viewport(screen)
+
+	if u.ResizeStrategy == ResizeReflow || u.VirtualSize.X <= 0 || u.VirtualSize.Y <= 0 {
+		scale := u.scale()
+		return vp, scale, image.Point{int(math.Ceil(float64(vp.Dx()) / scale)), int(math.Ceil(float64(vp.Dy()) / scale))}
+	}
+
+	scale := math.Min(float64(vp.Dx())/float64(u.VirtualSize.X), float64(vp.Dy())/float64(u.VirtualSize.Y))
+	if u.ResizeStrategy == ResizeIntegerScale && scale >= 1 {
+		scale = math.Floor(scale)
+	}
+
+	w := int(math.Round(float64(u.VirtualSize.X) * scale))
+	h := int(math.Round(float64(u.VirtualSize.Y) * scale))
+	x := vp.Min.X + (vp.Dx()-w)/2
+	y := vp.Min.Y + (vp.Dy()-h)/2
+
+	return image.Rect(x, y, x+w, y+h), scale, u.VirtualSize
+}
+
+// viewportBuffer returns a cleared buffer to render into, having size.
+func (u *UI) viewportBuffer(size image.Point) *ebiten.Image {
+	if u.buffer != nil {
+		if bw, bh := u.buffer.Size(); bw == size.X && bh == size.Y {
+			u.buffer.Clear()
+			return u.buffer
 		}
 
+		u.buffer.Dispose()
+	}
+
+	u.buffer = ebiten.NewImage(size.X, size.Y)
+	return u.buffer
+}
+
+// drawBuffer draws u's buffer onto screen into viewport vp at scale.
+func (u *UI) drawBuffer(screen *ebiten.Image, vp image.Rectangle, scale float64) {
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(scale, scale)
+	opts.GeoM.Translate(float64(vp.Min.X), float64(vp.Min.Y))
+	if u.ColorFilter != nil {
+		opts.ColorM = *u.ColorFilter
+	}
+	screen.SubImage(vp).(*ebiten.Image).DrawImage(u.buffer, &opts)
+}
+
+// transformCursor transforms the cursor position into u's coordinate system inside viewport vp at scale.
+// It returns a function to restore the original cursor position.
+func (u *UI) transformCursor(vp image.Rectangle, scale float64) func() {
+	x, y := internalinput.CursorX, internalinput.CursorY
+
+	internalinput.CursorX = int(math.Floor(float64(x-vp.Min.X) / scale))
+	internalinput.CursorY = int(math.Floor(float64(y-vp.Min.Y) / scale))
+
+	return func() {
+		internalinput.CursorX, internalinput.CursorY = x, y
+	}
+}
+
+// hideInputChars hides the input characters from u's widgets while u is drawn, since they have already been
+// processed in Update. It returns a function to restore them.
+func (u *UI) hideInputChars() func() {
+	c := internalinput.InputChars
+	internalinput.InputChars = nil
+
+	return func() {
+		internalinput.InputChars = c
+	}
+}
+
+// SetSafeAreaInsets sets the insets from the edges of the screen that content should not be placed in, for
+// example because of notches, TV overscan, or rounded corners. Anchor layouts configured using
+// AnchorLayoutOpts.SafeArea keep their widgets inside the remaining area. It may be called at runtime, for
+// example when the window size or screen orientation changes.
+func (u *UI) SetSafeAreaInsets(i widget.Insets) {
+	u.safeArea = i
+	u.Container.RequestRelayout()
+}
+
+// SafeAreaInsets returns the insets set using SetSafeAreaInsets.
+func (u *UI) SafeAreaInsets() widget.Insets {
+	return u.safeArea
+}
+
+// SetPaused pauses or resumes u's widget animations and timers, such as caret blinking, key repeat, or tool tip
+// delays, for example while the game's pause menu is shown in another UI. While paused, widgets keep their state.
+// Other UIs are not affected, see widget.SetPaused to pause all UIs at once.
+func (u *UI) SetPaused(p bool) {
+	u.widgetClock().SetPaused(p)
+}
+
+// Paused returns whether u's widget animations and timers are paused using SetPaused or SetHidden.
+func (u *UI) Paused() bool {
+	return u.widgetClock().Paused()
+}
+
+// widgetClock returns the clock that u's widgets use for animations and timers. It is set using widget.SetClock
+// whenever u is updated or drawn, and stays in effect afterwards, so that widget methods called by the game
+// between frames use it as well.
+func (u *UI) widgetClock() *widget.Clock {
+	if u.clock == nil {
+		u.clock = widget.NewClock()
+	}
+	return u.clock
+}
+
+// SetDisabled disables or enables u.Container, the contents of all windows, and all of their descendants,
+// for example while a cutscene plays. Windows added afterwards are not affected. See
+// widget.Container.SetDisabledRecursive.
+func (u *UI) SetDisabled(d bool) {
+	u.Container.SetDisabledRecursive(d)
+
+	for _, w := range u.windows {
+		w.SetDisabledRecursive(d)
+	}
+}
+
+// SetHidden hides or shows all of u, for example for a game's photo mode. While hidden, u neither renders
+// nor handles input, and u's widget animations and timers are paused, independently of SetPaused. Other UIs are
+// not affected. Widgets keep their state, so that showing u again restores it instantly.
+func (u *UI) SetHidden(h bool) {
+	if h == u.hidden {
+		return
+	}
+
+	u.hidden = h
+
+	if h {
+		u.hiddenPause = u.widgetClock().HoldPause()
+		return
+	}
+
+	u.hiddenPause()
+	u.hiddenPause = nil
+}
+
+// Hidden returns whether all of u is hidden.
+func (u *UI) Hidden() bool {
+	return u.hidden
+}
+
+// SetLayersHidden hides or shows the layers l of u. Hidden layers neither render nor handle input. Unlike
+// SetHidden, animations and timers keep running.
+func (u *UI) SetLayersHidden(l Layer, h bool) {
+	if h {
+		u.hiddenLayers |= l
+	} else {
+		u.hiddenLayers &^= l
+	}
+}
+
+// LayersHidden returns the layers of u that are hidden using SetLayersHidden.
+func (u *UI) LayersHidden() Layer {
+	return u.hiddenLayers
+}
+
+// layerVisible returns whether layer l is not hidden using SetLayersHidden.
+func (u *UI) layerVisible(l Layer) bool {
+	return u.hiddenLayers&l == 0
+}
+
+// FrameStats returns the work done to render the previous frame.
+func (u *UI) FrameStats() widget.Stats {
+	return u.frameStats
+}
+
+func (u *UI) checkBudget(start widget.Stats) {
+	u.frameStats = widget.CurrentStats().Sub(start)
+
+	b := u.Budget
+	if b == nil || b.ExceededFunc == nil {
+		return
+	}
+
+	if b.exceeded(u.frameStats) {
+		b.ExceededFunc(u.frameStats)
+	}
+}
+
+func (b *FrameBudget) exceeded(s widget.Stats) bool {
+	return (b.Widgets > 0 && s.Widgets > b.Widgets) ||
+		(b.Relayouts > 0 && s.Relayouts > b.Relayouts) ||
+		(b.Draws > 0 && s.Draws > b.Draws)
+}
+
+func (u *UI) handleFocus() {
+	if input.MouseButtonJustPressed(ebiten.MouseButtonLeft) && !u.popupAtCursor() {
 		x, y := input.CursorPosition()
-		w := u.Container.WidgetAt(x, y)
-		if w != nil {
-			if f, ok := w.(widget.Focuser); ok {
-				f.Focus(true)
-				u.focusedWidget = w
+		w := u.widgetAt(x, y)
+		_, focusable := w.(widget.Focuser)
+
+		switch {
+		case focusable && u.inFocusScope(w):
+			u.focus(w)
+		case !u.inActiveFocusScope(x, y):
+			u.focus(nil)
+		}
+	}
+
+	prev := u.actionTriggered(input.ActionFocusPrevious)
+	next := u.actionTriggered(input.ActionFocusNext)
+	switch {
+	case prev:
+		u.FocusPrevious()
+	case next:
+		u.FocusNext()
+	}
+
+	for a, d := range navActions {
+		if u.actionTriggered(a) && !u.navConsumed(d) {
+			u.FocusDirection(d)
+		}
+	}
+}
+
+// navConsumed returns whether the focused widget handles navigation in direction d itself.
+func (u *UI) navConsumed(d widget.NavDirection) bool {
+	c, ok := u.focusedWidget.(widget.NavConsumer)
+	return ok && c.ConsumesNav(d)
+}
+
+// widgetAt returns the widget at x,y in the topmost window that contains it, or in u.Container if there is no
+// such window. Hidden layers are skipped.
+func (u *UI) widgetAt(x int, y int) widget.HasWidget {
+	for _, r := range u.locaterRoots() {
+		if _, ok := r.(*widget.Window); ok && !u.layerVisible(LayerWindows) {
+			continue
+		}
+		if r == widget.Locater(u.Container) && !u.layerVisible(LayerContainer) {
+			continue
+		}
+
+		if w := r.WidgetAt(x, y); w != nil {
+			return w
+		}
+	}
+
+	return nil
+}
+
+// inActiveFocusScope returns whether x,y is inside the container of the innermost focus scope.
+func (u *UI) inActiveFocusScope(x int, y int) bool {
+	if len(u.focusScopes) == 0 {
+		return false
+	}
+
+	l, ok := u.focusScopes[len(u.focusScopes)-1].container.(widget.Locater)
+	return ok && l.WidgetAt(x, y) != nil
+}
+
+// popupAtCursor returns whether the cursor is inside a popup of the focused widget.
+func (u *UI) popupAtCursor() bool {
+	p, ok := u.focusedWidget.(widget.FocusPopup)
+	return ok && p.PopupAt(input.CursorPosition())
+}
+
+// handleBack calls Back when the cancel action has just been pressed.
+func (u *UI) handleBack() {
+	pressed := input.ActionPressed(input.ActionCancel)
+	justPressed := pressed && !u.backPressed
+	u.backPressed = pressed
+
+	if justPressed {
+		u.Back()
+	}
+}
+
+// Back handles the cancel action as if it had been pressed, for example Escape. See PushBackHandler for the
+// order in which the action is handled. It returns true if the action has been handled.
+func (u *UI) Back() bool {
+	if b, ok := u.focusedWidget.(widget.BackHandler); ok && b.HandleBack() {
+		return true
+	}
+
+	for i := len(u.backHandlers) - 1; i >= 0; i-- {
+		if u.backHandlers[i].f() {
+			return true
+		}
+	}
+
+	if len(u.windows) > 0 {
+		w := u.windows[len(u.windows)-1]
+		if widget.HandleBack(w) {
+			return true
+		}
+
+		if w.CloseOnBack {
+			if remove, ok := u.windowRemoves[w]; ok {
+				remove()
+				return true
+			}
+		}
+	}
+
+	if widget.HandleBack(u.Container) {
+		return true
+	}
+
+	return u.router != nil && u.router.Pop()
+}
+
+// PushBackHandler adds f to handle the cancel action, such as Escape, until the returned function is called. The
+// action is offered to the following, in order, until it is handled: the focused widget if it implements
+// widget.BackHandler, back handlers added using PushBackHandler (most recently added first), the topmost
+// window (its widgets first, then removing the window itself if it has CloseOnBack set), the widgets of
+// u.Container, and finally the Router using u, which pops its top screen.
+func (u *UI) PushBackHandler(f BackHandlerFunc) RemoveBackHandlerFunc {
+	h := &backHandler{
+		f: f,
+	}
+
+	u.backHandlers = append(u.backHandlers, h)
+
+	return func() {
+		for i, bh := range u.backHandlers {
+			if bh == h {
+				u.backHandlers = append(u.backHandlers[:i], u.backHandlers[i+1:]...)
+				return
 			}
 		}
 	}
 }
 
+// navActions maps actions to the directions they move focus in.
+var navActions = map[input.Action]widget.NavDirection{
+	input.ActionNavigateUp:    widget.NavDirectionUp,
+	input.ActionNavigateDown:  widget.NavDirectionDown,
+	input.ActionNavigateLeft:  widget.NavDirectionLeft,
+	input.ActionNavigateRight: widget.NavDirectionRight,
+}
+
+// actionTriggered returns whether action a has just been pressed, or has been held long enough to repeat,
+// according to NavRepeatDelay and NavRepeatInterval.
+func (u *UI) actionTriggered(a input.Action) bool {
+	if u.actionRepeats == nil {
+		u.actionRepeats = map[input.Action]*actionRepeat{}
+	}
+
+	r, ok := u.actionRepeats[a]
+	if !ok {
+		r = &actionRepeat{}
+		u.actionRepeats[a] = r
+	}
+
+	if !input.ActionPressed(a) {
+		r.pressed = false
+		return false
+	}
+
+	now := widget.Now()
+
+	if !r.pressed {
+		r.pressed = true
+		r.deadline = now.Add(NavRepeatDelay)
+		return true
+	}
+
+	if NavRepeatInterval <= 0 || now.Before(r.deadline) {
+		return false
+	}
+
+	r.deadline = now.Add(NavRepeatInterval)
+	return true
+}
+
+// FocusNext moves focus to the next widget in focus traversal order, wrapping around at the end.
+func (u *UI) FocusNext() {
+	u.moveFocus(1)
+}
+
+// FocusPrevious moves focus to the previous widget in focus traversal order, wrapping around at the start.
+func (u *UI) FocusPrevious() {
+	u.moveFocus(-1)
+}
+
+// FocusDirection moves focus to the widget in direction d of the focused widget, for example when navigating
+// using a gamepad. Widgets may override the target using Widget.NavUp etc. If no widget is focused, the first
+// widget in focus traversal order is focused.
+func (u *UI) FocusDirection(d widget.NavDirection) {
+	if w := widget.NavTarget(u.focusOrder(), u.focusedWidget, d); w != nil {
+		u.focus(w)
+	}
+}
+
+func (u *UI) moveFocus(d int) {
+	order := u.focusOrder()
+	if len(order) == 0 {
+		return
+	}
+
+	i := -1
+	for j, w := range order {
+		if w == u.focusedWidget {
+			i = j
+			break
+		}
+	}
+
+	switch {
+	case i >= 0:
+		i = (i + d + len(order)) % len(order)
+	case d > 0:
+		i = 0
+	default:
+		i = len(order) - 1
+	}
+
+	u.focus(order[i])
+}
+
+// AuditKeyboard returns issues for all widgets of u.Container and windows that cannot be operated using only
+// a keyboard or gamepad. See widget.AuditKeyboard for details.
+func (u *UI) AuditKeyboard() []widget.KeyboardAuditIssue {
+	roots := []widget.FocusContainer{u.Container}
+	for _, w := range u.windows {
+		roots = append(roots, w)
+	}
+	return widget.AuditKeyboard(roots...)
+}
+
+// focusOrder returns all focusable widgets in focus traversal order. If a focus scope is active, only the
+// widgets in that scope are returned. Otherwise, those of u.Container are returned first, then those of windows.
+func (u *UI) focusOrder() []widget.HasWidget {
+	if len(u.focusScopes) > 0 {
+		return u.focusScopes[len(u.focusScopes)-1].container.FocusOrder()
+	}
+
+	order := u.Container.FocusOrder()
+	for _, w := range u.windows {
+		order = append(order, w.FocusOrder()...)
+	}
+	return order
+}
+
+// inFocusScope returns whether w is inside the active focus scope, or true if there is no active focus scope.
+func (u *UI) inFocusScope(w widget.HasWidget) bool {
+	if len(u.focusScopes) == 0 {
+		return true
+	}
+
+	for _, fw := range u.focusOrder() {
+		if fw == w {
+			return true
+		}
+	}
+	return false
+}
+
+// PushFocusScope restricts focus traversal to the widgets in c until the returned function is called, for
+// example while a popup is open. The first focusable widget in c is focused. When the focus scope is removed,
+// focus is restored to the widget that was focused before.
+//
+// Focus scopes are pushed automatically for modal windows added using AddWindow.
+func (u *UI) PushFocusScope(c widget.FocusContainer) RemoveFocusScopeFunc {
+	s := &focusScope{
+		container: c,
+		opener:    u.focusedWidget,
+	}
+
+	u.focusScopes = append(u.focusScopes, s)
+
+	if order := c.FocusOrder(); len(order) > 0 {
+		u.focus(order[0])
+	} else {
+		u.focus(nil)
+	}
+
+	return func() {
+		u.removeFocusScope(s)
+	}
+}
+
+func (u *UI) removeFocusScope(s *focusScope) {
+	for i, fs := range u.focusScopes {
+		if fs != s {
+			continue
+		}
+
+		u.focusScopes = append(u.focusScopes[:i], u.focusScopes[i+1:]...)
+
+		// a scope pushed later inherits this scope's opener, since that one may have been inside this scope
+		if i < len(u.focusScopes) {
+			u.focusScopes[i].opener = s.opener
+			return
+		}
+
+		u.focus(s.opener)
+		return
+	}
+}
+
+// focus moves focus to w, which may be nil to remove focus.
+func (u *UI) focus(w widget.HasWidget) {
+	if w == u.focusedWidget {
+		return
+	}
+
+	if u.focusedWidget != nil {
+		u.focusedWidget.(widget.Focuser).Focus(false)
+	}
+
+	u.focusedWidget = w
+
+	if w != nil {
+		w.(widget.Focuser).Focus(true)
+	}
+}
+
 func (u *UI) setupInputLayers() {
 	num := 1 // u.Container
 	if len(u.windows) > 0 {
 		num += len(u.windows)
 	}
+	if u.CoachMarks != nil {
+		num++
+	}
+	if u.PieMenu != nil {
+		num++
+	}
 	if u.DragAndDrop != nil {
 		num++
 	}
@@ -96,11 +833,21 @@ func (u *UI) setupInputLayers() {
 	}
 
 	u.inputLayerers = u.inputLayerers[:0]
-	u.inputLayerers = append(u.inputLayerers, u.Container)
-	for _, w := range u.windows {
-		u.inputLayerers = append(u.inputLayerers, w)
+	if u.layerVisible(LayerContainer) {
+		u.inputLayerers = append(u.inputLayerers, u.Container)
 	}
-	if u.DragAndDrop != nil {
+	if u.layerVisible(LayerWindows) {
+		for _, w := range u.windows {
+			u.inputLayerers = append(u.inputLayerers, w)
+		}
+	}
+	if u.CoachMarks != nil && u.layerVisible(LayerCoachMarks) {
+		u.inputLayerers = append(u.inputLayerers, u.CoachMarks)
+	}
+	if u.PieMenu != nil && u.layerVisible(LayerPieMenu) {
+		u.inputLayerers = append(u.inputLayerers, u.PieMenu)
+	}
+	if u.DragAndDrop != nil && u.layerVisible(LayerDragAndDrop) {
 		u.inputLayerers = append(u.inputLayerers, u.DragAndDrop)
 	}
 
@@ -113,43 +860,119 @@ func (u *UI) render(screen *ebiten.Image) {
 	if len(u.windows) > 0 {
 		num += len(u.windows)
 	}
+	if u.FloatingText != nil {
+		num++
+	}
+	if u.CoachMarks != nil {
+		num++
+	}
+	if u.PieMenu != nil {
+		num++
+	}
+	if u.FocusIndicator != nil {
+		num++
+	}
 	if u.ToolTip != nil {
 		num++
 	}
 	if u.DragAndDrop != nil {
 		num++
 	}
+	if u.LayoutInspector != nil {
+		num++
+	}
 
 	if cap(u.renderers) < num {
 		u.renderers = make([]widget.Renderer, num)
 	}
 
 	u.renderers = u.renderers[:0]
-	u.renderers = append(u.renderers, u.Container)
-	for _, w := range u.windows {
-		u.renderers = append(u.renderers, w)
+	if u.layerVisible(LayerContainer) {
+		u.renderers = append(u.renderers, u.Container)
 	}
-	if u.ToolTip != nil {
+	if u.FloatingText != nil && u.layerVisible(LayerFloatingText) {
+		u.renderers = append(u.renderers, u.FloatingText)
+	}
+	if u.layerVisible(LayerWindows) {
+		for _, w := range u.windows {
+			u.renderers = append(u.renderers, w)
+		}
+	}
+	if u.CoachMarks != nil && u.layerVisible(LayerCoachMarks) {
+		u.CoachMarks.SetRoots(u.locaterRoots()...)
+		u.renderers = append(u.renderers, u.CoachMarks)
+	}
+	if u.FocusIndicator != nil && u.layerVisible(LayerFocusIndicator) {
+		u.FocusIndicator.SetTarget(u.focusedWidget)
+		u.renderers = append(u.renderers, u.FocusIndicator)
+	}
+	if u.PieMenu != nil && u.layerVisible(LayerPieMenu) {
+		u.renderers = append(u.renderers, u.PieMenu)
+	}
+	if u.ToolTip != nil && u.layerVisible(LayerToolTip) {
 		u.renderers = append(u.renderers, u.ToolTip)
 	}
-	if u.DragAndDrop != nil {
+	if u.DragAndDrop != nil && u.layerVisible(LayerDragAndDrop) {
 		u.renderers = append(u.renderers, u.DragAndDrop)
 	}
+	if u.LayoutInspector != nil && u.layerVisible(LayerLayoutInspector) {
+		u.LayoutInspector.SetRoots(u.locaterRoots()...)
+		u.renderers = append(u.renderers, u.LayoutInspector)
+	}
 
 	// TODO: RenderWithDeferred should reside in "internal" subpackage
 	widget.RenderWithDeferred(screen, u.renderers)
 }
 
+// locaterRoots returns the windows, topmost first, followed by u.Container.
+func (u *UI) locaterRoots() []widget.Locater {
+	roots := make([]widget.Locater, 0, len(u.windows)+1)
+	for i := len(u.windows) - 1; i >= 0; i-- {
+		roots = append(roots, u.windows[i])
+	}
+	return append(roots, u.Container)
+}
+
 // AddWindow adds window w to u for rendering. It returns a function to remove w from u.
+//
+// If w is modal, focus traversal is restricted to w until it is removed.
 func (u *UI) AddWindow(w *widget.Window) RemoveWindowFunc {
 	u.windows = append(u.windows, w)
 
-	return func() {
+	var removeFocusScope RemoveFocusScopeFunc
+	if w.Modal {
+		removeFocusScope = u.PushFocusScope(w)
+	}
+
+	w.SetSnapTargetsFunc(func() []image.Rectangle {
+		rects := make([]image.Rectangle, 0, len(u.windows)-1)
+		for _, uw := range u.windows {
+			if uw != w {
+				rects = append(rects, uw.GetWidget().Rect)
+			}
+		}
+		return rects
+	})
+
+	remove := func() {
 		u.removeWindow(w)
+
+		if removeFocusScope != nil {
+			removeFocusScope()
+		}
 	}
+
+	if u.windowRemoves == nil {
+		u.windowRemoves = map[*widget.Window]RemoveWindowFunc{}
+	}
+	u.windowRemoves[w] = remove
+
+	return remove
 }
 
 func (u *UI) removeWindow(w *widget.Window) {
+	delete(u.windowRemoves, w)
+
 	for i, uw := range u.windows {
 		if uw == w {
 			u.windows = append(u.windows[:i], u.windows[i+1:]...)
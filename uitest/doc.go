@@ -0,0 +1,13 @@
+// Package uitest provides helpers for testing user interfaces, such as comparing their rendering against
+// golden files.
+//
+// Rendering requires Ebiten's game loop to be running. Test packages that use Snapshot must therefore call
+// Main from their TestMain function:
+//
+//	func TestMain(m *testing.M) {
+//		uitest.Main(m)
+//	}
+//
+// Golden files that do not exist yet are created automatically. To update existing golden files, run the
+// tests with the -uitest.update flag.
+package uitest
@@ -0,0 +1,48 @@
+package uitest
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type game struct {
+	done chan struct{}
+}
+
+var errDone = errors.New("tests done")
+
+// Main runs the tests of m while Ebiten's game loop is running, then exits.
+func Main(m *testing.M) {
+	code := 0
+	done := make(chan struct{})
+
+	go func() {
+		code = m.Run()
+		close(done)
+	}()
+
+	if err := ebiten.RunGame(&game{done: done}); err != nil && err != errDone {
+		panic(err)
+	}
+
+	os.Exit(code)
+}
+
+func (g *game) Update() error {
+	select {
+	case <-g.done:
+		return errDone
+	default:
+		return nil
+	}
+}
+
+func (g *game) Draw(screen *ebiten.Image) {
+}
+
+func (g *game) Layout(outsideWidth int, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
@@ -0,0 +1,7 @@
+package uitest
+
+import "testing"
+
+func TestMain(m *testing.M) {
+	Main(m)
+}
@@ -0,0 +1,59 @@
+package uitest
+
+import (
+	"testing"
+
+	"github.com/blizzy78/ebitenui"
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+	"github.com/blizzy78/ebitenui/widget"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestUI_SharesInput(t *testing.T) {
+	is := is.New(t)
+
+	pressed := false
+
+	defer func(p func(ebiten.MouseButton) bool, c func() (int, int)) {
+		internalinput.MouseButtonPressedFunc, internalinput.CursorPositionFunc = p, c
+	}(internalinput.MouseButtonPressedFunc, internalinput.CursorPositionFunc)
+
+	internalinput.MouseButtonPressedFunc = func(b ebiten.MouseButton) bool {
+		return pressed && b == ebiten.MouseButtonLeft
+	}
+	internalinput.CursorPositionFunc = func() (int, int) {
+		return 10, 10
+	}
+
+	sharedPressed := false
+	shared := &ebitenui.UI{
+		Container: widget.NewContainer(
+			widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.MouseButtonPressedHandler(func(args *widget.WidgetMouseButtonPressedEventArgs) {
+				sharedPressed = true
+			}))),
+		SharesInput: true,
+	}
+
+	owner := &ebitenui.UI{
+		Container: widget.NewContainer(),
+	}
+
+	screen := ebiten.NewImage(100, 100)
+	frame := func() {
+		owner.Update()
+		shared.Update()
+		shared.Draw(screen)
+		owner.Draw(screen)
+		event.ExecuteDeferred()
+	}
+
+	frame()
+	is.True(!sharedPressed)
+
+	pressed = true
+	frame()
+	is.True(sharedPressed)
+}
@@ -0,0 +1,177 @@
+package uitest
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/blizzy78/ebitenui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type snapshotOptions struct {
+	tolerance     uint8
+	maxDiffPixels int
+}
+
+type SnapshotOpt func(o *snapshotOptions)
+
+type SnapshotOptions struct {
+}
+
+var SnapshotOpts SnapshotOptions
+
+// Update specifies whether Snapshot and SnapshotImage overwrite existing golden files instead of comparing
+// against them. It is set using the -uitest.update flag.
+var Update = flag.Bool("uitest.update", false, "update golden files")
+
+// Tolerance configures Snapshot to consider pixels to be equal if none of their color channels differ by
+// more than t.
+func (o SnapshotOptions) Tolerance(t uint8) SnapshotOpt {
+	return func(s *snapshotOptions) {
+		s.tolerance = t
+	}
+}
+
+// MaxDiffPixels configures Snapshot to only fail if more than n pixels differ.
+func (o SnapshotOptions) MaxDiffPixels(n int) SnapshotOpt {
+	return func(s *snapshotOptions) {
+		s.maxDiffPixels = n
+	}
+}
+
+// Snapshot renders u at a size of width x height and compares the result against the PNG golden file golden,
+// as per SnapshotImage.
+//
+// Widgets that animate based on the current time, such as blinking carets, should be configured so that
+// their rendering is deterministic.
+func Snapshot(t testing.TB, u *ebitenui.UI, width int, height int, golden string, opts ...SnapshotOpt) {
+	t.Helper()
+
+	screen := ebiten.NewImage(width, height)
+	u.Update()
+	u.Draw(screen)
+
+	SnapshotImage(t, screen, golden, opts...)
+}
+
+// SnapshotImage compares got against the PNG golden file golden. If they differ, the test fails, and got
+// as well as an image highlighting the differing pixels are written next to golden, with the suffixes
+// "_actual.png" and "_diff.png", respectively.
+//
+// If golden does not exist or Update is set, got is written to golden instead.
+func SnapshotImage(t testing.TB, got image.Image, golden string, opts ...SnapshotOpt) {
+	t.Helper()
+
+	o := snapshotOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	got = toNRGBA(got)
+
+	want, err := readPNG(golden)
+	if os.IsNotExist(err) || *Update {
+		if err := writePNG(golden, got); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+
+	base := strings.TrimSuffix(golden, ".png")
+
+	if want.Bounds().Size() != got.Bounds().Size() {
+		_ = writePNG(base+"_actual.png", got)
+		t.Fatalf("size %v differs from golden file %s size %v", got.Bounds().Size(), golden, want.Bounds().Size())
+	}
+
+	num, diff := compare(want, got, o.tolerance)
+	if num <= o.maxDiffPixels {
+		return
+	}
+
+	_ = writePNG(base+"_actual.png", got)
+	_ = writePNG(base+"_diff.png", diff)
+	t.Errorf("%d pixels differ from golden file %s, see %s_diff.png", num, golden, base)
+}
+
+// compare returns the number of pixels that differ between want and got by more than tolerance in any color
+// channel, and an image showing want faded, with differing pixels highlighted in red. want and got must have
+// the same size.
+func compare(want image.Image, got image.Image, tolerance uint8) (int, *image.NRGBA) {
+	wb := want.Bounds()
+	gb := got.Bounds()
+	diff := image.NewNRGBA(image.Rect(0, 0, wb.Dx(), wb.Dy()))
+
+	num := 0
+	for y := 0; y < wb.Dy(); y++ {
+		for x := 0; x < wb.Dx(); x++ {
+			wc := color.NRGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y)).(color.NRGBA)
+			gc := color.NRGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y)).(color.NRGBA)
+
+			if channelDiff(wc.R, gc.R) > tolerance || channelDiff(wc.G, gc.G) > tolerance ||
+				channelDiff(wc.B, gc.B) > tolerance || channelDiff(wc.A, gc.A) > tolerance {
+
+				num++
+				diff.SetNRGBA(x, y, color.NRGBA{255, 0, 0, 255})
+				continue
+			}
+
+			g := color.GrayModel.Convert(wc).(color.Gray)
+			diff.SetNRGBA(x, y, color.NRGBA{g.Y, g.Y, g.Y, 64})
+		}
+	}
+
+	return num, diff
+}
+
+func channelDiff(a uint8, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// toNRGBA returns a copy of i as an *image.NRGBA, so that pixels are only read from i once.
+func toNRGBA(i image.Image) *image.NRGBA {
+	b := i.Bounds()
+	n := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			n.Set(x, y, i.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return n
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	return png.Decode(f)
+}
+
+func writePNG(path string, i image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, i); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
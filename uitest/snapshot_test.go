@@ -0,0 +1,54 @@
+package uitest
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCompare(t *testing.T) {
+	is := is.New(t)
+
+	want := newImage(color.NRGBA{100, 100, 100, 255})
+	got := newImage(color.NRGBA{100, 100, 100, 255})
+	got.SetNRGBA(1, 0, color.NRGBA{104, 100, 100, 255})
+
+	num, _ := compare(want, got, 4)
+	is.Equal(num, 0)
+
+	num, diff := compare(want, got, 3)
+	is.Equal(num, 1)
+	is.Equal(diff.NRGBAAt(1, 0), color.NRGBA{255, 0, 0, 255})
+	is.Equal(diff.NRGBAAt(0, 0).A, uint8(64))
+}
+
+func TestSnapshotImage_CreatesGolden(t *testing.T) {
+	is := is.New(t)
+
+	golden := filepath.Join(t.TempDir(), "golden.png")
+	i := newImage(color.NRGBA{1, 2, 3, 255})
+
+	SnapshotImage(t, i, golden)
+
+	_, err := os.Stat(golden)
+	is.NoErr(err)
+
+	SnapshotImage(t, i, golden)
+
+	_, err = os.Stat(filepath.Join(filepath.Dir(golden), "golden_diff.png"))
+	is.True(os.IsNotExist(err))
+}
+
+func newImage(c color.NRGBA) *image.NRGBA {
+	i := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			i.SetNRGBA(x, y, c)
+		}
+	}
+	return i
+}
@@ -1,6 +1,10 @@
 package widget
 
-import "image"
+import (
+	"fmt"
+	"image"
+	"math"
+)
 
 // AnchorLayout layouts a single widget anchored to either a corner or edge of a rectangle,
 // optionally stretching it in one or both directions.
@@ -9,7 +13,8 @@ import "image"
 //
 // Widget.LayoutData of widgets being layouted by AnchorLayout need to be of type AnchorLayoutData.
 type AnchorLayout struct {
-	padding Insets
+	padding  Insets
+	safeArea bool
 }
 
 // AnchorLayoutOpt is a function that configures a.
@@ -34,6 +39,22 @@ type AnchorLayoutData struct {
 
 	// StretchVertical specifies whether to stretch in the vertical direction.
 	StretchVertical bool
+
+	// HorizontalFraction specifies the horizontal anchoring position as a fraction of the available width
+	// if HorizontalPosition is AnchorLayoutPositionFraction. A fraction of 0 corresponds to AnchorLayoutPositionStart,
+	// 0.5 to AnchorLayoutPositionCenter, and 1 to AnchorLayoutPositionEnd.
+	HorizontalFraction float64
+
+	// VerticalFraction specifies the vertical anchoring position as a fraction of the available height
+	// if VerticalPosition is AnchorLayoutPositionFraction. A fraction of 0 corresponds to AnchorLayoutPositionStart,
+	// 0.5 to AnchorLayoutPositionCenter, and 1 to AnchorLayoutPositionEnd.
+	VerticalFraction float64
+
+	// OffsetX specifies a horizontal offset in pixels that is added after anchoring.
+	OffsetX int
+
+	// OffsetY specifies a vertical offset in pixels that is added after anchoring.
+	OffsetY int
 }
 
 const (
@@ -45,6 +66,10 @@ const (
 
 	// AnchorLayoutPositionEnd is the anchoring position for "right" (in the horizontal direction) or "bottom" (in the vertical direction.)
 	AnchorLayoutPositionEnd
+
+	// AnchorLayoutPositionFraction is the anchoring position specified by AnchorLayoutData.HorizontalFraction
+	// (in the horizontal direction) or AnchorLayoutData.VerticalFraction (in the vertical direction.)
+	AnchorLayoutPositionFraction
 )
 
 // AnchorLayoutOpts contains functions that configure an AnchorLayout.
@@ -68,6 +93,14 @@ func (o AnchorLayoutOptions) Padding(i Insets) AnchorLayoutOpt {
 	}
 }
 
+// SafeArea configures an anchor layout to keep its widget inside the screen's safe area, as returned by
+// SafeArea, when anchoring it to an edge or corner.
+func (o AnchorLayoutOptions) SafeArea() AnchorLayoutOpt {
+	return func(a *AnchorLayout) {
+		a.safeArea = true
+	}
+}
+
 // PreferredSize implements Layouter.
 func (a *AnchorLayout) PreferredSize(widgets []PreferredSizeLocateableWidget) (int, int) {
 	px, py := a.padding.Dx(), a.padding.Dy()
@@ -76,7 +109,7 @@ func (a *AnchorLayout) PreferredSize(widgets []PreferredSizeLocateableWidget) (i
 		return px, py
 	}
 
-	w, h := widgets[0].PreferredSize()
+	w, h := preferredSizeWithMargin(widgets[0])
 	return w + px, h + py
 }
 
@@ -87,8 +120,13 @@ func (a *AnchorLayout) Layout(widgets []PreferredSizeLocateableWidget, rect imag
 	}
 
 	widget := widgets[0]
-	ww, wh := widget.PreferredSize()
+	ww, wh := preferredSizeWithMargin(widget)
 	rect = a.padding.Apply(rect)
+
+	if a.safeArea && !safeArea.Empty() {
+		rect = rect.Intersect(safeArea)
+	}
+
 	wx := 0
 	wy := 0
 
@@ -100,7 +138,7 @@ func (a *AnchorLayout) Layout(widgets []PreferredSizeLocateableWidget, rect imag
 	r = r.Add(image.Point{wx, wy})
 	r = r.Add(rect.Min)
 
-	widget.SetLocation(r)
+	setLocationWithMargin(widget, r)
 }
 
 func (a *AnchorLayout) applyLayoutData(ld AnchorLayoutData, wx int, wy int, ww int, wh int, rect image.Rectangle) (int, int, int, int) {
@@ -120,6 +158,8 @@ func (a *AnchorLayout) applyLayoutData(ld AnchorLayoutData, wx int, wy int, ww i
 		wx = (rect.Dx() - ww) / 2
 	case AnchorLayoutPositionEnd:
 		wx = rect.Dx() - ww
+	case AnchorLayoutPositionFraction:
+		wx = int(math.Round(float64(rect.Dx()-ww) * ld.HorizontalFraction))
 	}
 
 	switch vPos {
@@ -127,7 +167,25 @@ func (a *AnchorLayout) applyLayoutData(ld AnchorLayoutData, wx int, wy int, ww i
 		wy = (rect.Dy() - wh) / 2
 	case AnchorLayoutPositionEnd:
 		wy = rect.Dy() - wh
+	case AnchorLayoutPositionFraction:
+		wy = int(math.Round(float64(rect.Dy()-wh) * ld.VerticalFraction))
 	}
 
+	wx += ld.OffsetX
+	wy += ld.OffsetY
+
 	return wx, wy, ww, wh
 }
+
+// layoutPadding implements paddedLayouter.
+func (a *AnchorLayout) layoutPadding() Insets {
+	return a.padding
+}
+
+// ValidateLayoutData implements LayoutDataValidator.
+func (a *AnchorLayout) ValidateLayoutData(ld interface{}) error {
+	if _, ok := ld.(AnchorLayoutData); !ok {
+		return fmt.Errorf("AnchorLayout requires AnchorLayoutData, got %T", ld)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package widget
 
 import (
 	"image"
+	"math"
 	"strconv"
 	"testing"
 
@@ -87,6 +88,20 @@ func TestAnchorLayout_Layout(t *testing.T) {
 			},
 			image.Rect(prect.Min.X, prect.Min.Y+(prect.Dy()-wh)/2, prect.Max.X, prect.Min.Y+(prect.Dy()-wh)/2+wh),
 		},
+		{
+			AnchorLayoutData{
+				HorizontalPosition: AnchorLayoutPositionFraction,
+				HorizontalFraction: 0.25,
+				VerticalPosition:   AnchorLayoutPositionFraction,
+				VerticalFraction:   0.75,
+				OffsetX:            3,
+				OffsetY:            -4,
+			},
+			wrect.Add(prect.Min).Add(image.Point{
+				int(math.Round(float64(prect.Dx()-ww)*0.25)) + 3,
+				int(math.Round(float64(prect.Dy()-wh)*0.75)) - 4,
+			}),
+		},
 	}
 
 	for i, test := range tests {
@@ -108,3 +123,23 @@ func newAnchorLayout(t *testing.T, opts ...AnchorLayoutOpt) Layouter {
 	l := NewAnchorLayout(opts...)
 	return l
 }
+
+func TestAnchorLayout_SafeArea(t *testing.T) {
+	is := is.New(t)
+
+	SetSafeArea(image.Rect(10, 20, 90, 80))
+	t.Cleanup(func() {
+		SetSafeArea(image.Rectangle{})
+	})
+
+	l := newAnchorLayout(t, AnchorLayoutOpts.SafeArea())
+
+	wi := newSimpleWidget(10, 10, AnchorLayoutData{
+		HorizontalPosition: AnchorLayoutPositionEnd,
+		VerticalPosition:   AnchorLayoutPositionEnd,
+	})
+
+	l.Layout([]PreferredSizeLocateableWidget{wi}, image.Rect(0, 0, 100, 100))
+
+	is.Equal(wi.widget.Rect, image.Rect(80, 70, 90, 80))
+}
@@ -0,0 +1,77 @@
+package widget
+
+// BackHandler may be implemented by widgets that handle the cancel ("back") action themselves, for example
+// to cancel an operation in progress. The UI offers the action to BackHandlers before closing popups or
+// screens.
+type BackHandler interface {
+	// HandleBack handles the cancel action and returns true, or returns false if the action should be handled
+	// elsewhere.
+	HandleBack() bool
+}
+
+// HandleBack offers the cancel action to all visible BackHandler widgets in the widget trees of roots, including
+// roots themselves. Widgets in later roots and later children are offered the action first, since they are drawn
+// on top. It returns true if any widget has handled the action.
+func HandleBack(roots ...HasWidget) bool {
+	for i := len(roots) - 1; i >= 0; i-- {
+		var handlers []BackHandler
+		collect := func(w HasWidget) {
+			if !w.GetWidget().Visible() {
+				return
+			}
+			if b, ok := w.(BackHandler); ok {
+				handlers = append(handlers, b)
+			}
+		}
+
+		collect(roots[i])
+		walkDescendants(roots[i], collect)
+
+		for j := len(handlers) - 1; j >= 0; j-- {
+			if handlers[j].HandleBack() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// walkWidgetTree calls f for all descendants of w, in drawing order. Unlike walkDescendants, it also reaches
+// the widgets that composite widgets implementing Containerer are made of.
+func walkWidgetTree(w interface{}, f func(w HasWidget)) {
+	for _, ch := range widgetTreeChildren(w) {
+		f(ch)
+		walkWidgetTree(ch, f)
+	}
+}
+
+// widgetTreeChildren returns the direct descendants of w as seen by walkWidgetTree.
+func widgetTreeChildren(w interface{}) []HasWidget {
+	switch c := w.(type) {
+	case *Container:
+		return containedWidgets(c)
+	case *Window:
+		return []HasWidget{c.contents}
+	case Containerer:
+		return c.ContainedWidgets()
+	}
+
+	return nil
+}
+
+// walkDescendants calls f for all descendants of w that are children of Containers or Windows, in drawing order.
+func walkDescendants(w interface{}, f func(w HasWidget)) {
+	var children []PreferredSizeLocateableWidget
+	switch c := w.(type) {
+	case *Container:
+		children = c.Children()
+	case *Window:
+		children = []PreferredSizeLocateableWidget{c.contents}
+	}
+
+	for _, ch := range children {
+		f(ch)
+		walkDescendants(ch, f)
+	}
+}
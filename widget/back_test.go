@@ -0,0 +1,26 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/matryer/is"
+)
+
+func TestHandleBack(t *testing.T) {
+	is := is.New(t)
+
+	k := newKeyBindInput(t)
+
+	c := newContainer(t)
+	c.AddChild(k)
+	event.ExecuteDeferred()
+
+	is.True(!HandleBack(c))
+
+	k.StartCapture()
+	is.True(HandleBack(c))
+	is.True(!k.Capturing())
+
+	is.True(!HandleBack(c))
+}
@@ -0,0 +1,255 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Badge is a decoration that displays a small bubble with a count, a label, or just a dot at a corner of
+// a widget, for example for unread counts or "new!" markers. A Badge is attached to a widget using
+// WidgetOpts.Badge. Showing and hiding a Badge is animated.
+type Badge struct {
+	face         font.Face
+	color        color.Color
+	image        *image.NineSlice
+	padding      Insets
+	corner       BadgeCorner
+	maxCount     int
+	dot          bool
+	dotSize      int
+	animDuration time.Duration
+
+	count      int
+	label      string
+	hidden     bool
+	scale      float64
+	lastUpdate time.Time
+}
+
+type BadgeOpt func(b *Badge)
+
+// BadgeCorner specifies the corner of a widget that a Badge is centered on.
+type BadgeCorner int
+
+type BadgeOptions struct {
+}
+
+const (
+	BadgeCornerTopRight = BadgeCorner(iota)
+	BadgeCornerTopLeft
+	BadgeCornerBottomRight
+	BadgeCornerBottomLeft
+)
+
+var BadgeOpts BadgeOptions
+
+func NewBadge(opts ...BadgeOpt) *Badge {
+	b := &Badge{
+		padding: Insets{
+			Left:  4,
+			Right: 4,
+		},
+		maxCount:     99,
+		dotSize:      8,
+		animDuration: 150 * time.Millisecond,
+	}
+
+	for _, o := range opts {
+		o(b)
+	}
+
+	return b
+}
+
+// Text configures a Badge to draw its count or label using face and color c.
+func (o BadgeOptions) Text(face font.Face, c color.Color) BadgeOpt {
+	return func(b *Badge) {
+		b.face = face
+		b.color = c
+	}
+}
+
+// Image configures a Badge to draw i as its bubble.
+func (o BadgeOptions) Image(i *image.NineSlice) BadgeOpt {
+	return func(b *Badge) {
+		b.image = i
+	}
+}
+
+// Padding configures a Badge to leave padding i around its text inside the bubble.
+func (o BadgeOptions) Padding(i Insets) BadgeOpt {
+	return func(b *Badge) {
+		b.padding = i
+	}
+}
+
+func (o BadgeOptions) Corner(c BadgeCorner) BadgeOpt {
+	return func(b *Badge) {
+		b.corner = c
+	}
+}
+
+// MaxCount configures a Badge to display counts greater than m as m followed by "+", for example "99+".
+func (o BadgeOptions) MaxCount(m int) BadgeOpt {
+	return func(b *Badge) {
+		b.maxCount = m
+	}
+}
+
+// Dot configures a Badge to display a dot of size s instead of text.
+func (o BadgeOptions) Dot(s int) BadgeOpt {
+	return func(b *Badge) {
+		b.dot = true
+		b.dotSize = s
+	}
+}
+
+// Label configures a Badge to display l instead of a count.
+func (o BadgeOptions) Label(l string) BadgeOpt {
+	return func(b *Badge) {
+		b.label = l
+	}
+}
+
+func (o BadgeOptions) Count(c int) BadgeOpt {
+	return func(b *Badge) {
+		b.count = c
+	}
+}
+
+// AnimationDuration configures a Badge to animate showing and hiding for d. If d is 0, it is not animated.
+func (o BadgeOptions) AnimationDuration(d time.Duration) BadgeOpt {
+	return func(b *Badge) {
+		b.animDuration = d
+	}
+}
+
+// Badge configures a Widget to display b.
+func (o WidgetOptions) Badge(b *Badge) WidgetOpt {
+	return o.PostRenderHandler(func(w *Widget, screen *ebiten.Image) {
+		if w.Visible() {
+			b.render(screen, w.Rect)
+		}
+	})
+}
+
+// SetCount sets the count to display. A count of 0 hides b, unless it has a label or displays a dot.
+func (b *Badge) SetCount(c int) {
+	b.count = c
+}
+
+func (b *Badge) Count() int {
+	return b.count
+}
+
+// SetLabel sets the label to display instead of a count. If l is empty, the count is displayed.
+func (b *Badge) SetLabel(l string) {
+	b.label = l
+}
+
+// SetHidden hides or shows b.
+func (b *Badge) SetHidden(h bool) {
+	b.hidden = h
+}
+
+// Shown returns whether b is currently shown, not taking the animation into account.
+func (b *Badge) Shown() bool {
+	return !b.hidden && (b.dot || b.label != "" || b.count > 0)
+}
+
+// text returns the text to display.
+func (b *Badge) text() string {
+	switch {
+	case b.dot:
+		return ""
+	case b.label != "":
+		return b.label
+	case b.count > b.maxCount:
+		return locale.FormatInt(b.maxCount) + "+"
+	default:
+		return locale.FormatInt(b.count)
+	}
+}
+
+// size returns the size of b's bubble.
+func (b *Badge) size() (int, int) {
+	if b.dot {
+		return b.dotSize, b.dotSize
+	}
+
+	m := b.face.Metrics()
+	h := int(math.Round(textutil.Fixed26_6ToFloat64(m.Ascent+m.Descent))) + b.padding.Dy()
+	w := textutil.Advance(b.text(), b.face) + b.padding.Dx()
+
+	// bubbles are at least round
+	if w < h {
+		w = h
+	}
+
+	return w, h
+}
+
+func (b *Badge) render(screen *ebiten.Image, rect img.Rectangle) {
+	now := Now()
+	if !b.lastUpdate.IsZero() {
+		b.advance(now.Sub(b.lastUpdate).Seconds())
+	}
+	b.lastUpdate = now
+
+	if b.scale <= 0 {
+		return
+	}
+
+	c := rect.Min
+	switch b.corner {
+	case BadgeCornerTopRight:
+		c.X = rect.Max.X
+	case BadgeCornerBottomRight:
+		c = rect.Max
+	case BadgeCornerBottomLeft:
+		c.Y = rect.Max.Y
+	}
+
+	w, h := b.size()
+
+	if b.image != nil {
+		b.image.Draw(screen, w, h, func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(-float64(w)/2, -float64(h)/2)
+			opts.GeoM.Scale(b.scale, b.scale)
+			opts.GeoM.Translate(float64(c.X), float64(c.Y))
+		})
+	}
+
+	t := b.text()
+	if t == "" {
+		return
+	}
+
+	ascent := textutil.Fixed26_6ToFloat64(b.face.Metrics().Ascent)
+	x := c.X - w/2 + (w-textutil.Advance(t, b.face))/2
+	y := c.Y - h/2 + b.padding.Top + int(math.Round(ascent))
+	text.Draw(screen, t, b.face, x, y, colorWithAlpha(b.color, b.scale))
+}
+
+// advance advances the show/hide animation according to dt seconds having passed.
+func (b *Badge) advance(dt float64) {
+	step := math.Inf(1)
+	if d := animationDuration(b.animDuration); d > 0 {
+		step = dt / d.Seconds()
+	}
+
+	if b.Shown() {
+		b.scale = math.Min(1, b.scale+step)
+	} else {
+		b.scale = math.Max(0, b.scale-step)
+	}
+}
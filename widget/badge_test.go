@@ -0,0 +1,58 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestBadge_Text(t *testing.T) {
+	is := is.New(t)
+
+	b := NewBadge(BadgeOpts.Text(loadFont(t), color.White), BadgeOpts.MaxCount(9))
+	is.True(!b.Shown())
+
+	b.SetCount(5)
+	is.True(b.Shown())
+	is.Equal(b.text(), "5")
+
+	b.SetCount(10)
+	is.Equal(b.text(), "9+")
+
+	b.SetLabel("new!")
+	is.Equal(b.text(), "new!")
+
+	b.SetHidden(true)
+	is.True(!b.Shown())
+}
+
+func TestBadge_Size_Round(t *testing.T) {
+	is := is.New(t)
+
+	b := NewBadge(BadgeOpts.Text(loadFont(t), color.White), BadgeOpts.Count(1))
+
+	w, h := b.size()
+	is.Equal(w, h)
+
+	b = NewBadge(BadgeOpts.Dot(6))
+	w, h = b.size()
+	is.Equal(w, 6)
+	is.Equal(h, 6)
+}
+
+func TestBadge_Advance(t *testing.T) {
+	is := is.New(t)
+
+	b := NewBadge(BadgeOpts.Dot(6))
+
+	b.advance(0.075)
+	is.Equal(b.scale, 0.5)
+
+	b.advance(1)
+	is.Equal(b.scale, 1.0)
+
+	b.SetHidden(true)
+	b.advance(1)
+	is.Equal(b.scale, 0.0)
+}
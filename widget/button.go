@@ -3,6 +3,7 @@ package widget
 import (
 	img "image"
 	"image/color"
+	"time"
 
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/image"
@@ -18,22 +19,33 @@ type Button struct {
 	GraphicImage      *ButtonImageImage
 	TextColor         *ButtonTextColor
 
-	PressedEvent  *event.Event
-	ReleasedEvent *event.Event
-	ClickedEvent  *event.Event
-
-	widgetOpts               []WidgetOpt
-	autoUpdateTextAndGraphic bool
-	textPadding              Insets
-	graphicPadding           Insets
-
-	init      *MultiOnce
-	widget    *Widget
-	container *Container
-	graphic   *Graphic
-	text      *Text
-	hovering  bool
-	pressing  bool
+	PressedEvent      *event.Event
+	ReleasedEvent     *event.Event
+	ClickedEvent      *event.Event
+	PressAndHoldEvent *event.Event
+
+	widgetOpts                 []WidgetOpt
+	autoUpdateTextAndGraphic   bool
+	textPadding                Insets
+	graphicPadding             Insets
+	pressAndHoldDuration       time.Duration
+	pressAndHoldIndicatorColor color.Color
+
+	init              *MultiOnce
+	widget            *Widget
+	container         *Container
+	graphic           *Graphic
+	text              *Text
+	hovering          bool
+	pressing          bool
+	pressedTime       time.Time
+	holdInterrupted   bool
+	pressAndHoldFired bool
+
+	// externalHovering and externalPressing are set by widgets that use a Button
+	// as part of a larger hit target.
+	externalHovering bool
+	externalPressing bool
 }
 
 type ButtonOpt func(b *Button)
@@ -72,12 +84,18 @@ type ButtonClickedEventArgs struct {
 	Button *Button
 }
 
+type ButtonPressAndHoldEventArgs struct {
+	Button *Button
+}
+
 type ButtonPressedHandlerFunc func(args *ButtonPressedEventArgs)
 
 type ButtonReleasedHandlerFunc func(args *ButtonReleasedEventArgs)
 
 type ButtonClickedHandlerFunc func(args *ButtonClickedEventArgs)
 
+type ButtonPressAndHoldHandlerFunc func(args *ButtonPressAndHoldEventArgs)
+
 type ButtonOptions struct {
 }
 
@@ -85,9 +103,10 @@ var ButtonOpts ButtonOptions
 
 func NewButton(opts ...ButtonOpt) *Button {
 	b := &Button{
-		PressedEvent:  &event.Event{},
-		ReleasedEvent: &event.Event{},
-		ClickedEvent:  &event.Event{},
+		PressedEvent:      &event.Event{},
+		ReleasedEvent:     &event.Event{},
+		ClickedEvent:      &event.Event{},
+		PressAndHoldEvent: &event.Event{},
 
 		init: &MultiOnce{},
 	}
@@ -272,11 +291,42 @@ func (o ButtonOptions) ClickedHandler(f ButtonClickedHandlerFunc) ButtonOpt {
 	}
 }
 
+// PressAndHoldHandler configures a Button to fire a press-and-hold event after it has been held
+// pressed for duration d, and registers f to handle it. A button that has fired a press-and-hold
+// event will not fire a clicked event when it is released.
+func (o ButtonOptions) PressAndHoldHandler(d time.Duration, f ButtonPressAndHoldHandlerFunc) ButtonOpt {
+	return func(b *Button) {
+		b.pressAndHoldDuration = d
+
+		b.PressAndHoldEvent.AddHandler(func(args interface{}) {
+			f(args.(*ButtonPressAndHoldEventArgs))
+		})
+	}
+}
+
+// PressAndHoldIndicator configures a Button to render a radial progress indicator in color c over
+// itself while it is being held pressed.
+func (o ButtonOptions) PressAndHoldIndicator(c color.Color) ButtonOpt {
+	return func(b *Button) {
+		b.pressAndHoldIndicatorColor = c
+	}
+}
+
 func (b *Button) GetWidget() *Widget {
 	b.init.Do()
 	return b.widget
 }
 
+// ContainedWidgets implements Containerer.
+func (b *Button) ContainedWidgets() []HasWidget {
+	b.init.Do()
+
+	if b.container == nil {
+		return nil
+	}
+	return []HasWidget{b.container}
+}
+
 func (b *Button) PreferredSize() (int, int) {
 	b.init.Do()
 
@@ -340,6 +390,8 @@ func (b *Button) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 
 	b.widget.Render(screen, def)
 
+	b.updatePressAndHold()
+
 	b.draw(screen)
 
 	if b.autoUpdateTextAndGraphic {
@@ -363,6 +415,36 @@ func (b *Button) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	if b.container != nil {
 		b.container.Render(screen, def)
 	}
+
+	if b.pressAndHoldIndicatorColor != nil && !b.pressAndHoldFired {
+		drawRadialProgress(screen, b.widget.Rect, b.PressAndHoldProgress(), b.pressAndHoldIndicatorColor)
+	}
+}
+
+// PressAndHoldProgress returns how far b has progressed towards firing a press-and-hold event,
+// in the range [0,1]. It returns 0 if b is not currently being held pressed.
+func (b *Button) PressAndHoldProgress() float64 {
+	if !b.pressing || b.holdInterrupted || b.pressAndHoldDuration <= 0 {
+		return 0
+	}
+
+	p := float64(Now().Sub(b.pressedTime)) / float64(b.pressAndHoldDuration)
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+func (b *Button) updatePressAndHold() {
+	if b.pressAndHoldFired || b.widget.Disabled || b.PressAndHoldProgress() < 1 {
+		return
+	}
+
+	b.pressAndHoldFired = true
+
+	b.PressAndHoldEvent.Fire(&ButtonPressAndHoldEventArgs{
+		Button: b,
+	})
 }
 
 func (b *Button) draw(screen *ebiten.Image) {
@@ -372,11 +454,11 @@ func (b *Button) draw(screen *ebiten.Image) {
 		if b.Image.Disabled != nil {
 			i = b.Image.Disabled
 		}
-	case b.pressing && (b.hovering || b.KeepPressedOnExit):
+	case b.displayPressed():
 		if b.Image.Pressed != nil {
 			i = b.Image.Pressed
 		}
-	case b.hovering:
+	case b.displayHovering():
 		if b.Image.Hover != nil {
 			i = b.Image.Hover
 		}
@@ -390,6 +472,16 @@ func (b *Button) draw(screen *ebiten.Image) {
 	}
 }
 
+// displayHovering returns whether b should be drawn as hovered.
+func (b *Button) displayHovering() bool {
+	return b.hovering || b.externalHovering
+}
+
+// displayPressed returns whether b should be drawn as pressed.
+func (b *Button) displayPressed() bool {
+	return (b.pressing && (b.displayHovering() || b.KeepPressedOnExit)) || b.externalPressing
+}
+
 func (b *Button) drawImageOptions(opts *ebiten.DrawImageOptions) {
 	if b.widget.Disabled && b.Image.Disabled == nil {
 		opts.ColorM.Scale(1, 1, 1, 0.35)
@@ -407,15 +499,27 @@ func (b *Button) createWidget() {
 			if !b.widget.Disabled {
 				b.hovering = true
 			}
+
+			if b.holdInterrupted {
+				b.holdInterrupted = false
+				b.pressedTime = Now()
+			}
 		}),
 
 		WidgetOpts.CursorExitHandler(func(args *WidgetCursorExitEventArgs) {
 			b.hovering = false
+
+			if b.pressing && !b.KeepPressedOnExit {
+				b.holdInterrupted = true
+			}
 		}),
 
 		WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
 			if !b.widget.Disabled {
 				b.pressing = true
+				b.pressedTime = Now()
+				b.holdInterrupted = false
+				b.pressAndHoldFired = false
 
 				b.PressedEvent.Fire(&ButtonPressedEventArgs{
 					Button:  b,
@@ -436,7 +540,7 @@ func (b *Button) createWidget() {
 					OffsetY: args.OffsetY,
 				})
 
-				if args.Inside {
+				if args.Inside && !b.pressAndHoldFired {
 					b.ClickedEvent.Fire(&ButtonClickedEventArgs{
 						Button: b,
 					})
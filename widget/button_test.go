@@ -2,6 +2,7 @@ package widget
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/matryer/is"
@@ -52,6 +53,56 @@ func TestButton_ClickedEvent_User(t *testing.T) {
 	is.True(eventArgs != nil)
 }
 
+func TestButton_PressAndHoldEvent_User(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *ButtonPressAndHoldEventArgs
+	clicked := false
+
+	b := newButton(t,
+		ButtonOpts.PressAndHoldHandler(time.Millisecond, func(args *ButtonPressAndHoldEventArgs) {
+			eventArgs = args
+		}),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			clicked = true
+		}))
+
+	advance := fakeTime(t)
+
+	leftMouseButtonPress(b, t)
+	advance(5 * time.Millisecond)
+	render(b, t)
+
+	is.True(eventArgs != nil)
+
+	leftMouseButtonRelease(b, t)
+
+	is.True(!clicked)
+}
+
+func TestButton_PressAndHoldEvent_ReleasedEarly(t *testing.T) {
+	is := is.New(t)
+
+	pressedAndHeld := false
+	clicked := false
+
+	b := newButton(t,
+		ButtonOpts.PressAndHoldHandler(time.Hour, func(args *ButtonPressAndHoldEventArgs) {
+			pressedAndHeld = true
+		}),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			clicked = true
+		}))
+
+	leftMouseButtonPress(b, t)
+	render(b, t)
+	leftMouseButtonRelease(b, t)
+
+	is.True(!pressedAndHeld)
+	is.True(clicked)
+	is.Equal(b.PressAndHoldProgress(), 0.0)
+}
+
 func newButton(t *testing.T, opts ...ButtonOpt) *Button {
 	t.Helper()
 
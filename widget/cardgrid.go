@@ -0,0 +1,542 @@
+package widget
+
+import (
+	img "image"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// CardGrid is a grid of uniformly sized cards, each showing a thumbnail, a title, and a subtitle, such as
+// save slots or a level selection. The cards wrap into as many columns as fit into the grid's width.
+//
+// Each card can be focused, so that the UI moves between cards when navigating using the keyboard or a
+// gamepad. Focusing or clicking a card selects it. Clicking a card, or pressing the accept action while it
+// is focused, activates it.
+type CardGrid struct {
+	// SelectedEvent fires an event with *CardGridSelectedEventArgs when the selected card has changed.
+	SelectedEvent *event.Event
+
+	// ActivatedEvent fires an event with *CardGridActivatedEventArgs when a card has been activated.
+	ActivatedEvent *event.Event
+
+	containerOpts []ContainerOpt
+	buttonOpts    []ButtonOpt
+	cards         []*Card
+	titleFace     font.Face
+	titleColor    *ButtonTextColor
+	subtitleFace  font.Face
+	subtitleColor *ButtonTextColor
+	selectedImage *image.NineSlice
+	cardPadding   Insets
+	cardSpacing   int
+	columns       int
+	cardWidth     int
+	cardHeight    int
+	columnSpacing int
+	rowSpacing    int
+	gridPadding   Insets
+
+	init           *MultiOnce
+	container      *Container
+	cardWidgets    []*cardGridCard
+	selected       *Card
+	lastAcceptDown bool
+}
+
+// Card is a card in a CardGrid.
+type Card struct {
+	// Thumbnail is the image shown at the top of the card. It may be nil.
+	Thumbnail *ebiten.Image
+
+	Title    string
+	Subtitle string
+
+	// Disabled is whether the card cannot be selected or activated, such as an empty save slot.
+	Disabled bool
+}
+
+type CardGridOpt func(g *CardGrid)
+
+type CardGridSelectedEventArgs struct {
+	CardGrid     *CardGrid
+	Card         *Card
+	PreviousCard *Card
+}
+
+type CardGridSelectedHandlerFunc func(args *CardGridSelectedEventArgs)
+
+type CardGridActivatedEventArgs struct {
+	CardGrid *CardGrid
+	Card     *Card
+}
+
+type CardGridActivatedHandlerFunc func(args *CardGridActivatedEventArgs)
+
+type CardGridOptions struct {
+}
+
+// cardGridCard is the widget of a card in a CardGrid.
+type cardGridCard struct {
+	grid    *CardGrid
+	card    *Card
+	button  *Button
+	focused bool
+}
+
+// cardGridLayout lays out uniformly sized cards in as many columns as fit.
+type cardGridLayout struct {
+	grid *CardGrid
+}
+
+var CardGridOpts CardGridOptions
+
+func NewCardGrid(opts ...CardGridOpt) *CardGrid {
+	g := &CardGrid{
+		SelectedEvent:  &event.Event{},
+		ActivatedEvent: &event.Event{},
+
+		columns: 3,
+
+		init: &MultiOnce{},
+	}
+
+	g.init.Append(g.createWidget)
+
+	for _, o := range opts {
+		o(g)
+	}
+
+	return g
+}
+
+func (o CardGridOptions) ContainerOpts(opts ...ContainerOpt) CardGridOpt {
+	return func(g *CardGrid) {
+		g.containerOpts = append(g.containerOpts, opts...)
+	}
+}
+
+// ButtonOpts configures a CardGrid to construct the buttons that make up the cards using opts.
+func (o CardGridOptions) ButtonOpts(opts ...ButtonOpt) CardGridOpt {
+	return func(g *CardGrid) {
+		g.buttonOpts = append(g.buttonOpts, opts...)
+	}
+}
+
+func (o CardGridOptions) Cards(c ...*Card) CardGridOpt {
+	return func(g *CardGrid) {
+		g.cards = c
+	}
+}
+
+// TitleText configures a CardGrid to draw card titles using face and color c.
+func (o CardGridOptions) TitleText(face font.Face, c *ButtonTextColor) CardGridOpt {
+	return func(g *CardGrid) {
+		g.titleFace = face
+		g.titleColor = c
+	}
+}
+
+// SubtitleText configures a CardGrid to draw card subtitles using face and color c.
+func (o CardGridOptions) SubtitleText(face font.Face, c *ButtonTextColor) CardGridOpt {
+	return func(g *CardGrid) {
+		g.subtitleFace = face
+		g.subtitleColor = c
+	}
+}
+
+// SelectedImage configures a CardGrid to draw i over the selected card, such as a frame.
+func (o CardGridOptions) SelectedImage(i *image.NineSlice) CardGridOpt {
+	return func(g *CardGrid) {
+		g.selectedImage = i
+	}
+}
+
+// CardPadding configures a CardGrid to separate the contents of cards from their edges by padding i, and
+// from each other by spacing s.
+func (o CardGridOptions) CardPadding(i Insets, s int) CardGridOpt {
+	return func(g *CardGrid) {
+		g.cardPadding = i
+		g.cardSpacing = s
+	}
+}
+
+// CardSize configures a CardGrid to make all cards w by h pixels large. If w or h are 0, the largest
+// preferred width or height of all cards is used.
+func (o CardGridOptions) CardSize(w int, h int) CardGridOpt {
+	return func(g *CardGrid) {
+		g.cardWidth = w
+		g.cardHeight = h
+	}
+}
+
+// Columns configures a CardGrid to prefer c columns when calculating its preferred size. The number of columns
+// actually shown depends on the grid's width. The default is 3.
+func (o CardGridOptions) Columns(c int) CardGridOpt {
+	return func(g *CardGrid) {
+		g.columns = c
+	}
+}
+
+func (o CardGridOptions) Padding(i Insets) CardGridOpt {
+	return func(g *CardGrid) {
+		g.gridPadding = i
+	}
+}
+
+func (o CardGridOptions) Spacing(c int, r int) CardGridOpt {
+	return func(g *CardGrid) {
+		g.columnSpacing = c
+		g.rowSpacing = r
+	}
+}
+
+func (o CardGridOptions) SelectedHandler(f CardGridSelectedHandlerFunc) CardGridOpt {
+	return func(g *CardGrid) {
+		g.SelectedEvent.AddHandler(func(args interface{}) {
+			f(args.(*CardGridSelectedEventArgs))
+		})
+	}
+}
+
+func (o CardGridOptions) ActivatedHandler(f CardGridActivatedHandlerFunc) CardGridOpt {
+	return func(g *CardGrid) {
+		g.ActivatedEvent.AddHandler(func(args interface{}) {
+			f(args.(*CardGridActivatedEventArgs))
+		})
+	}
+}
+
+func (g *CardGrid) GetWidget() *Widget {
+	g.init.Do()
+	return g.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (g *CardGrid) ContainedWidgets() []HasWidget {
+	g.init.Do()
+	return containedWidgets(g.container)
+}
+
+func (g *CardGrid) PreferredSize() (int, int) {
+	g.init.Do()
+	return g.container.PreferredSize()
+}
+
+func (g *CardGrid) SetLocation(rect img.Rectangle) {
+	g.init.Do()
+	g.container.SetLocation(rect)
+}
+
+func (g *CardGrid) RequestRelayout() {
+	g.init.Do()
+	g.container.RequestRelayout()
+}
+
+// FocusOrder implements FocusContainer.
+func (g *CardGrid) FocusOrder() []HasWidget {
+	g.init.Do()
+	return g.container.FocusOrder()
+}
+
+func (g *CardGrid) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	g.init.Do()
+	g.container.SetupInputLayer(def)
+}
+
+func (g *CardGrid) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	g.init.Do()
+
+	acceptDown := input.ActionPressed(input.ActionAccept)
+	if acceptDown && !g.lastAcceptDown {
+		for _, cw := range g.cardWidgets {
+			if cw.focused {
+				g.Activate(cw.card)
+				break
+			}
+		}
+	}
+	g.lastAcceptDown = acceptDown
+
+	g.container.Render(screen, def)
+}
+
+// Cards returns the cards of g.
+func (g *CardGrid) Cards() []*Card {
+	return g.cards
+}
+
+// SetCards replaces the cards of g with c. The selected card stays selected if it is contained in c.
+// Otherwise, no card is selected afterwards.
+func (g *CardGrid) SetCards(c ...*Card) {
+	g.init.Do()
+
+	g.cards = c
+	g.createCards()
+
+	if g.selected != nil && g.cardWidget(g.selected) == nil {
+		g.SetSelectedCard(nil)
+	}
+}
+
+// SelectedCard returns the selected card, or nil if no card is selected.
+func (g *CardGrid) SelectedCard() *Card {
+	return g.selected
+}
+
+// SetSelectedCard selects c. If c is nil, no card is selected.
+func (g *CardGrid) SetSelectedCard(c *Card) {
+	if c == g.selected || (c != nil && c.Disabled) {
+		return
+	}
+
+	prev := g.selected
+	g.selected = c
+
+	g.SelectedEvent.Fire(&CardGridSelectedEventArgs{
+		CardGrid:     g,
+		Card:         c,
+		PreviousCard: prev,
+	})
+}
+
+// Activate selects c and fires ActivatedEvent for it. It does nothing if c is disabled.
+func (g *CardGrid) Activate(c *Card) {
+	if c.Disabled {
+		return
+	}
+
+	g.SetSelectedCard(c)
+
+	g.ActivatedEvent.Fire(&CardGridActivatedEventArgs{
+		CardGrid: g,
+		Card:     c,
+	})
+}
+
+// CardWidget returns the widget of card c, for example to focus it. It returns nil if c is not one of
+// g's cards.
+func (g *CardGrid) CardWidget(c *Card) HasWidget {
+	g.init.Do()
+
+	if cw := g.cardWidget(c); cw != nil {
+		return cw
+	}
+	return nil
+}
+
+func (g *CardGrid) cardWidget(c *Card) *cardGridCard {
+	for _, cw := range g.cardWidgets {
+		if cw.card == c {
+			return cw
+		}
+	}
+	return nil
+}
+
+func (g *CardGrid) createWidget() {
+	g.container = NewContainer(append(g.containerOpts, ContainerOpts.Layout(&cardGridLayout{
+		grid: g,
+	}))...)
+	g.containerOpts = nil
+
+	g.createCards()
+}
+
+// createCards replaces the card widgets in g.container with new widgets for g.cards.
+func (g *CardGrid) createCards() {
+	g.container.RemoveAllChildren()
+
+	g.cardWidgets = make([]*cardGridCard, len(g.cards))
+	for i, c := range g.cards {
+		cw := g.newCard(c)
+		g.cardWidgets[i] = cw
+		g.container.AddChild(cw)
+	}
+}
+
+func (g *CardGrid) newCard(c *Card) *cardGridCard {
+	cw := &cardGridCard{
+		grid: g,
+		card: c,
+	}
+
+	opts := append([]ButtonOpt(nil), g.buttonOpts...)
+	cw.button = NewButton(append(opts, []ButtonOpt{
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			g.Activate(c)
+		}),
+		func(b *Button) {
+			b.init.Append(func() {
+				b.widget.Disabled = c.Disabled
+
+				b.container = NewContainer(
+					ContainerOpts.Layout(NewRowLayout(
+						RowLayoutOpts.Direction(DirectionVertical),
+						RowLayoutOpts.Padding(g.cardPadding),
+						RowLayoutOpts.Spacing(g.cardSpacing))),
+					ContainerOpts.AutoDisableChildren())
+
+				if c.Thumbnail != nil {
+					b.container.AddChild(NewGraphic(
+						GraphicOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+							Position: RowLayoutPositionCenter,
+						})),
+						GraphicOpts.Image(c.Thumbnail)))
+				}
+
+				if c.Title != "" {
+					b.container.AddChild(newCardText(c.Title, g.titleFace, g.titleColor, c.Disabled))
+				}
+
+				if c.Subtitle != "" {
+					b.container.AddChild(newCardText(c.Subtitle, g.subtitleFace, g.subtitleColor, c.Disabled))
+				}
+			})
+		},
+	}...)...)
+
+	return cw
+}
+
+func newCardText(label string, face font.Face, c *ButtonTextColor, disabled bool) *Text {
+	col := c.Idle
+	if disabled && c.Disabled != nil {
+		col = c.Disabled
+	}
+
+	return NewText(
+		TextOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+			Stretch: true,
+		})),
+		TextOpts.Text(label, face, col))
+}
+
+func (c *cardGridCard) GetWidget() *Widget {
+	return c.button.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (c *cardGridCard) ContainedWidgets() []HasWidget {
+	return c.button.ContainedWidgets()
+}
+
+func (c *cardGridCard) PreferredSize() (int, int) {
+	return c.button.PreferredSize()
+}
+
+func (c *cardGridCard) SetLocation(rect img.Rectangle) {
+	c.button.SetLocation(rect)
+}
+
+func (c *cardGridCard) RequestRelayout() {
+	c.button.RequestRelayout()
+}
+
+func (c *cardGridCard) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	c.button.SetupInputLayer(def)
+}
+
+func (c *cardGridCard) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	c.button.Render(screen, def)
+
+	if c.grid.selected == c.card && c.grid.selectedImage != nil {
+		w := c.button.GetWidget()
+		c.grid.selectedImage.Draw(screen, w.Rect.Dx(), w.Rect.Dy(), w.drawImageOptions)
+	}
+}
+
+// Focus implements Focuser. Focusing a card selects it.
+func (c *cardGridCard) Focus(focused bool) {
+	WidgetFireFocusEvent(c.button.GetWidget(), focused)
+	c.focused = focused
+
+	if focused {
+		c.grid.SetSelectedCard(c.card)
+	}
+}
+
+// PreferredSize implements Layouter.
+func (l *cardGridLayout) PreferredSize(widgets []PreferredSizeLocateableWidget) (int, int) {
+	if len(widgets) == 0 {
+		return l.grid.gridPadding.Dx(), l.grid.gridPadding.Dy()
+	}
+
+	cw, ch := l.cardSize(widgets)
+
+	cols := l.grid.columns
+	if cols < 1 {
+		cols = 1
+	}
+	if len(widgets) < cols {
+		cols = len(widgets)
+	}
+	rows := (len(widgets) + cols - 1) / cols
+
+	return l.grid.gridPadding.Dx() + cols*cw + l.grid.columnSpacing*(cols-1),
+		l.grid.gridPadding.Dy() + rows*ch + l.grid.rowSpacing*(rows-1)
+}
+
+// Layout implements Layouter.
+func (l *cardGridLayout) Layout(widgets []PreferredSizeLocateableWidget, rect img.Rectangle) {
+	if len(widgets) == 0 {
+		return
+	}
+
+	rect = l.grid.gridPadding.Apply(rect)
+	cw, ch := l.cardSize(widgets)
+
+	cols := l.columns(rect.Dx(), cw)
+
+	for i, w := range widgets {
+		c, r := i%cols, i/cols
+		x := rect.Min.X + c*(cw+l.grid.columnSpacing)
+		y := rect.Min.Y + r*(ch+l.grid.rowSpacing)
+		setLocationWithMargin(w, img.Rect(x, y, x+cw, y+ch))
+	}
+}
+
+// columns returns the number of cards of width cw that fit into width w, which is at least 1.
+func (l *cardGridLayout) columns(w int, cw int) int {
+	if cw+l.grid.columnSpacing <= 0 {
+		return 1
+	}
+
+	cols := (w + l.grid.columnSpacing) / (cw + l.grid.columnSpacing)
+	if cols < 1 {
+		cols = 1
+	}
+	return cols
+}
+
+// cardSize returns the uniform size of all cards.
+func (l *cardGridLayout) cardSize(widgets []PreferredSizeLocateableWidget) (int, int) {
+	cw, ch := l.grid.cardWidth, l.grid.cardHeight
+	if cw > 0 && ch > 0 {
+		return cw, ch
+	}
+
+	maxW, maxH := 0, 0
+	for _, w := range widgets {
+		pw, ph := w.PreferredSize()
+		if pw > maxW {
+			maxW = pw
+		}
+		if ph > maxH {
+			maxH = ph
+		}
+	}
+
+	if cw <= 0 {
+		cw = maxW
+	}
+	if ch <= 0 {
+		ch = maxH
+	}
+
+	return cw, ch
+}
@@ -0,0 +1,72 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestCardGrid_Layout(t *testing.T) {
+	is := is.New(t)
+
+	cards := []*Card{{Title: "1"}, {Title: "2"}, {Title: "3"}, {Title: "4"}}
+	g := newCardGrid(t,
+		CardGridOpts.Cards(cards...),
+		CardGridOpts.CardSize(40, 30),
+		CardGridOpts.Spacing(5, 5))
+
+	w, h := g.PreferredSize()
+	is.Equal(w, 3*40+2*5)
+	is.Equal(h, 2*30+5)
+
+	g.SetLocation(image.Rect(0, 0, 90, 200))
+	render(g, t)
+
+	is.Equal(g.CardWidget(cards[1]).GetWidget().Rect, image.Rect(45, 0, 85, 30))
+	is.Equal(g.CardWidget(cards[2]).GetWidget().Rect, image.Rect(0, 35, 40, 65))
+}
+
+func TestCardGrid_Activate(t *testing.T) {
+	is := is.New(t)
+
+	cards := []*Card{{Title: "1"}, {Title: "2"}, {Title: "empty", Disabled: true}}
+
+	var selected, activated *Card
+	g := newCardGrid(t,
+		CardGridOpts.Cards(cards...),
+		CardGridOpts.SelectedHandler(func(args *CardGridSelectedEventArgs) {
+			selected = args.Card
+		}),
+		CardGridOpts.ActivatedHandler(func(args *CardGridActivatedEventArgs) {
+			activated = args.Card
+		}))
+
+	g.CardWidget(cards[0]).(Focuser).Focus(true)
+	is.Equal(selected, cards[0])
+	is.Equal(activated, nil)
+
+	leftMouseButtonClick(g.CardWidget(cards[1]), t)
+	is.Equal(g.SelectedCard(), cards[1])
+	is.Equal(activated, cards[1])
+
+	g.Activate(cards[2])
+	is.Equal(g.SelectedCard(), cards[1])
+	is.Equal(activated, cards[1])
+}
+
+func newCardGrid(t *testing.T, opts ...CardGridOpt) *CardGrid {
+	t.Helper()
+
+	g := NewCardGrid(append(opts, []CardGridOpt{
+		CardGridOpts.ButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})),
+		CardGridOpts.TitleText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+		CardGridOpts.SubtitleText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+	}...)...)
+	event.ExecuteDeferred()
+	render(g, t)
+	return g
+}
@@ -4,10 +4,10 @@ import (
 	img "image"
 	"image/color"
 	"math"
-	"sync/atomic"
 	"time"
 
 	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/textutil"
 	"github.com/hajimehoshi/ebiten/v2"
 	"golang.org/x/image/font"
 )
@@ -106,27 +106,22 @@ func (c *Caret) ResetBlinking() {
 }
 
 func (c *Caret) resetBlinking() {
-	c.state = c.blinkState(true, nil, nil)
+	c.state = c.blinkState(true, time.Time{})
 }
 
-func (c *Caret) blinkState(visible bool, timer *time.Timer, expired *atomic.Value) caretBlinkState {
+func (c *Caret) blinkState(visible bool, deadline time.Time) caretBlinkState {
 	return func() caretBlinkState {
 		c.visible = visible
 
-		if timer != nil && expired.Load().(bool) {
-			return c.blinkState(!visible, nil, nil)
+		if !deadline.IsZero() && !Now().Before(deadline) {
+			return c.blinkState(!visible, time.Time{})
 		}
 
-		if timer == nil {
-			expired = &atomic.Value{}
-			expired.Store(false)
-
-			timer = time.AfterFunc(c.blinkInterval, func() {
-				expired.Store(true)
-			})
+		if deadline.IsZero() {
+			deadline = Now().Add(c.blinkInterval)
 		}
 
-		return c.blinkState(visible, timer, expired)
+		return c.blinkState(visible, deadline)
 	}
 }
 
@@ -134,6 +129,6 @@ func (c *Caret) createWidget() {
 	c.widget = NewWidget()
 
 	m := c.face.Metrics()
-	c.height = int(math.Round(fixedInt26_6ToFloat64(m.Ascent + m.Descent)))
+	c.height = int(math.Round(textutil.Fixed26_6ToFloat64(m.Ascent + m.Descent)))
 	c.face = nil
 }
@@ -2,6 +2,7 @@ package widget
 
 import (
 	"image"
+	"image/color"
 
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/input"
@@ -13,6 +14,7 @@ type Checkbox struct {
 
 	buttonOpts []ButtonOpt
 	image      *CheckboxGraphicImage
+	tint       *CheckboxGraphicTint
 	triState   bool
 
 	init   *MultiOnce
@@ -28,6 +30,15 @@ type CheckboxGraphicImage struct {
 	Greyed    *ButtonImageImage
 }
 
+// CheckboxGraphicTint specifies the colors to tint a checkbox's graphic image with, depending on the state of
+// its button. Nil colors fall back to Idle. If Idle is nil as well, the image is not tinted in that state.
+type CheckboxGraphicTint struct {
+	Idle     color.Color
+	Hover    color.Color
+	Pressed  color.Color
+	Disabled color.Color
+}
+
 type CheckboxState int
 
 type CheckboxChangedEventArgs struct {
@@ -76,6 +87,28 @@ func (o CheckboxOptions) Image(i *CheckboxGraphicImage) CheckboxOpt {
 	}
 }
 
+// ImageTint configures a Checkbox to tint its graphic image with colors from t. Images without a disabled
+// variant use the idle image while disabled.
+func (o CheckboxOptions) ImageTint(t *CheckboxGraphicTint) CheckboxOpt {
+	return func(c *Checkbox) {
+		c.tint = t
+	}
+}
+
+// TintedImage configures a Checkbox to use a single base image per state, tinted with colors from t. This
+// requires only one image for each of the unchecked, checked, and greyed states. greyed may be nil if the
+// Checkbox is not tri-state.
+func (o CheckboxOptions) TintedImage(unchecked *ebiten.Image, checked *ebiten.Image, greyed *ebiten.Image, t *CheckboxGraphicTint) CheckboxOpt {
+	return func(c *Checkbox) {
+		c.image = &CheckboxGraphicImage{
+			Unchecked: &ButtonImageImage{Idle: unchecked, Disabled: unchecked},
+			Checked:   &ButtonImageImage{Idle: checked, Disabled: checked},
+			Greyed:    &ButtonImageImage{Idle: greyed, Disabled: greyed},
+		}
+		c.tint = t
+	}
+}
+
 func (o CheckboxOptions) TriState() CheckboxOpt {
 	return func(c *Checkbox) {
 		c.triState = true
@@ -114,11 +147,18 @@ func (c *Checkbox) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	c.init.Do()
 
 	c.button.GraphicImage = c.state.graphicImage(c.image)
+	if c.tint != nil {
+		c.button.graphic.Tint = c.tint.color(c.button)
+	}
 
 	c.button.Render(screen, def)
 }
 
 func (c *Checkbox) createWidget() {
+	if c.tint != nil {
+		c.image = c.image.withDisabledFallback()
+	}
+
 	c.button = NewButton(append(c.buttonOpts, []ButtonOpt{
 		ButtonOpts.Graphic(c.image.Unchecked.Idle),
 
@@ -175,3 +215,37 @@ func (s CheckboxState) graphicImage(i *CheckboxGraphicImage) *ButtonImageImage {
 
 	return i.Unchecked
 }
+
+// withDisabledFallback returns a copy of i where missing disabled images are replaced by idle images.
+func (i *CheckboxGraphicImage) withDisabledFallback() *CheckboxGraphicImage {
+	fallback := func(b *ButtonImageImage) *ButtonImageImage {
+		if b == nil || b.Disabled != nil {
+			return b
+		}
+		return &ButtonImageImage{Idle: b.Idle, Disabled: b.Idle}
+	}
+
+	return &CheckboxGraphicImage{
+		Unchecked: fallback(i.Unchecked),
+		Checked:   fallback(i.Checked),
+		Greyed:    fallback(i.Greyed),
+	}
+}
+
+func (t *CheckboxGraphicTint) color(b *Button) color.Color {
+	var c color.Color
+	switch {
+	case b.widget.Disabled:
+		c = t.Disabled
+	case b.displayPressed():
+		c = t.Pressed
+	case b.displayHovering():
+		c = t.Hover
+	}
+
+	if c == nil {
+		c = t.Idle
+	}
+
+	return c
+}
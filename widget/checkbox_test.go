@@ -1,6 +1,7 @@
 package widget
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/blizzy78/ebitenui/event"
@@ -80,6 +81,24 @@ func TestCheckbox_State_Cycle_TriState(t *testing.T) {
 	is.Equal(c.State(), CheckboxUnchecked)
 }
 
+func TestCheckbox_ImageTint(t *testing.T) {
+	is := is.New(t)
+
+	idle := color.RGBA{255, 0, 0, 255}
+	disabled := color.RGBA{128, 128, 128, 255}
+
+	c := newCheckbox(t, CheckboxOpts.ImageTint(&CheckboxGraphicTint{
+		Idle:     idle,
+		Disabled: disabled,
+	}))
+	is.Equal(c.button.graphic.Tint, idle)
+
+	c.GetWidget().Disabled = true
+	render(c, t)
+	is.Equal(c.button.graphic.Tint, disabled)
+	is.True(c.button.graphic.Image != nil) // idle image used while disabled
+}
+
 func newCheckbox(t *testing.T, opts ...CheckboxOpt) *Checkbox {
 	t.Helper()
 
@@ -0,0 +1,179 @@
+package widget
+
+import (
+	"time"
+
+	"github.com/blizzy78/ebitenui/image"
+)
+
+// Clock is a clock for widget animations and timers that stands still while it is paused. Clocks created using
+// NewClock are based on the global clock, so that pausing it using SetPaused or HoldPause stops them as well.
+type Clock struct {
+	paused      bool
+	holds       int
+	pausedSince time.Time
+
+	// offset is the total duration the clock has been paused for.
+	offset time.Duration
+
+	source func() time.Time
+}
+
+// clock is the global clock used by widgets for animations and timers, so that these can be paused.
+var clock = &Clock{
+	source: func() time.Time {
+		return timeNow()
+	},
+}
+
+// currentClock is the clock returned by Now, or nil to use clock. See SetClock.
+var currentClock *Clock
+
+// timeNow returns the current wall clock time that clock is based on. It may be replaced in tests.
+var timeNow = time.Now
+
+// motionScale scales the durations of all widget animations.
+var motionScale = 1.0
+
+func init() {
+	image.AnimationClock = Now
+}
+
+// Now returns the current time according to the clock that widgets use for animations and timers, such as
+// caret blinking, key repeat, or tool tip delays. The clock stands still while paused. This is the clock set
+// using SetClock, or the global clock.
+func Now() time.Time {
+	if currentClock != nil {
+		return currentClock.now()
+	}
+	return clock.now()
+}
+
+// NewClock returns a new clock that is based on the global clock.
+func NewClock() *Clock {
+	return &Clock{
+		source: clock.now,
+	}
+}
+
+// SetClock makes Now return the time of c, or the time of the global clock if c is nil. UIs use this to pause
+// their widgets independently of other UIs, by setting their own clock while being updated and drawn.
+func SetClock(c *Clock) {
+	currentClock = c
+}
+
+// SetPaused pauses or resumes the global clock that widgets use for animations and timers. While paused, widgets
+// keep their state, but animations and timers do not progress. Pausing the global clock affects all UIs at once,
+// see Clock to pause the widgets of a single UI.
+func SetPaused(p bool) {
+	clock.SetPaused(p)
+}
+
+// ReleasePauseFunc is a function that releases a pause held using HoldPause.
+type ReleasePauseFunc func()
+
+// HoldPause pauses the global clock that widgets use for animations and timers until the returned function is
+// called. Holds are counted independently of SetPaused, so the clock only runs again once it is not paused using
+// SetPaused and all holds have been released.
+func HoldPause() ReleasePauseFunc {
+	return clock.HoldPause()
+}
+
+// Paused returns whether the global clock that widgets use for animations and timers is paused, either using
+// SetPaused or HoldPause.
+func Paused() bool {
+	return clock.Paused()
+}
+
+// SetMotionScale scales the durations of all widget animations, such as transitions, by s. If s is less than 1,
+// animations are shortened. If s is 0, animations are disabled completely, including scrolling marquees,
+// progressive text reveal, and pulsing or rising effects, and widgets show their end state immediately. This
+// may be used to implement a "reduced motion" accessibility setting. The default is 1.
+func SetMotionScale(s float64) {
+	if s < 0 {
+		s = 0
+	}
+	motionScale = s
+}
+
+// MotionScale returns the scale of the durations of all widget animations.
+func MotionScale() float64 {
+	return motionScale
+}
+
+// animationDuration returns the duration of an animation that normally takes d, according to the motion scale.
+func animationDuration(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * motionScale)
+}
+
+// motionDisabled returns whether animations are disabled completely.
+func motionDisabled() bool {
+	return motionScale <= 0
+}
+
+// Now returns the current time according to c.
+func (c *Clock) Now() time.Time {
+	return c.now()
+}
+
+// SetPaused pauses or resumes c. While paused, c stands still.
+func (c *Clock) SetPaused(p bool) {
+	c.update(func() {
+		c.paused = p
+	})
+}
+
+// Paused returns whether c is paused, either using SetPaused or HoldPause. It does not take into account whether
+// the global clock is paused.
+func (c *Clock) Paused() bool {
+	return c.stopped()
+}
+
+// HoldPause pauses c until the returned function is called. Holds are counted independently of SetPaused, so c
+// only runs again once it is not paused using SetPaused and all holds have been released.
+func (c *Clock) HoldPause() ReleasePauseFunc {
+	c.update(func() {
+		c.holds++
+	})
+
+	released := false
+	return func() {
+		if released {
+			return
+		}
+		released = true
+
+		c.update(func() {
+			c.holds--
+		})
+	}
+}
+
+func (c *Clock) now() time.Time {
+	if c.stopped() {
+		return c.pausedSince.Add(-c.offset)
+	}
+	return c.source().Add(-c.offset)
+}
+
+func (c *Clock) stopped() bool {
+	return c.paused || c.holds > 0
+}
+
+// update calls f to change c's paused state, then stops or starts c if necessary.
+func (c *Clock) update(f func()) {
+	wasStopped := c.stopped()
+	f()
+
+	stopped := c.stopped()
+	if stopped == wasStopped {
+		return
+	}
+
+	if stopped {
+		c.pausedSince = c.source()
+		return
+	}
+
+	c.offset += c.source().Sub(c.pausedSince)
+}
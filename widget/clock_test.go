@@ -0,0 +1,128 @@
+package widget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestSetPaused(t *testing.T) {
+	is := is.New(t)
+
+	SetPaused(true)
+	t.Cleanup(func() {
+		SetPaused(false)
+	})
+
+	is.True(Paused())
+
+	advance := fakeTime(t)
+
+	paused := Now()
+	advance(20 * time.Millisecond)
+	is.Equal(Now(), paused)
+
+	SetPaused(false)
+	is.True(!Paused())
+
+	// the clock continues where it stopped
+	is.Equal(Now(), paused)
+
+	advance(5 * time.Millisecond)
+	is.Equal(Now().Sub(paused), 5*time.Millisecond)
+}
+
+func TestHoldPause(t *testing.T) {
+	is := is.New(t)
+
+	SetPaused(true)
+	release := HoldPause()
+	t.Cleanup(func() {
+		release()
+		SetPaused(false)
+	})
+
+	SetPaused(false)
+	is.True(Paused())
+
+	release()
+	is.True(!Paused())
+
+	// releasing again does not release other holds
+	other := HoldPause()
+	release()
+	is.True(Paused())
+
+	other()
+	is.True(!Paused())
+}
+
+func TestSetClock(t *testing.T) {
+	is := is.New(t)
+
+	advance := fakeTime(t)
+
+	c := NewClock()
+	SetClock(c)
+	t.Cleanup(func() {
+		SetClock(nil)
+	})
+
+	c.SetPaused(true)
+	is.True(c.Paused())
+	is.True(!Paused())
+
+	paused := Now()
+	global := clock.Now()
+	advance(5 * time.Millisecond)
+	is.Equal(Now(), paused)
+	is.Equal(clock.Now().Sub(global), 5*time.Millisecond)
+
+	c.SetPaused(false)
+	SetPaused(true)
+	t.Cleanup(func() {
+		SetPaused(false)
+	})
+
+	// pausing the global clock also stops c
+	advance(5 * time.Millisecond)
+	is.Equal(Now(), paused)
+
+	SetClock(nil)
+	is.Equal(Now(), clock.Now())
+}
+
+func TestSetMotionScale(t *testing.T) {
+	is := is.New(t)
+
+	SetMotionScale(0.5)
+	t.Cleanup(func() {
+		SetMotionScale(1)
+	})
+
+	is.Equal(animationDuration(200*time.Millisecond), 100*time.Millisecond)
+	is.True(!motionDisabled())
+
+	SetMotionScale(0)
+	is.Equal(animationDuration(200*time.Millisecond), time.Duration(0))
+	is.True(motionDisabled())
+}
+
+// fakeTime replaces the wall clock time that the widget clock is based on with a fake time that only moves
+// when calling the returned function.
+func fakeTime(t *testing.T) func(d time.Duration) {
+	t.Helper()
+
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	t.Cleanup(func() {
+		timeNow = time.Now
+	})
+
+	return func(d time.Duration) {
+		now = now.Add(d)
+	}
+}
@@ -0,0 +1,484 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// CoachMarks is an onboarding overlay that walks the user through a sequence of steps. For each step, it
+// dims the screen except for a cutout around a target widget, and shows an explanation next to it with
+// buttons to go on to the next step or to skip the remaining steps. Input outside of the cutout and the
+// explanation is blocked while a step is shown, so that the user may only interact with the target widget.
+//
+// CoachMarks is usually set as UI.CoachMarks, which keeps its roots up to date.
+type CoachMarks struct {
+	// StepChangedEvent fires an event with *CoachMarksStepChangedEventArgs when a step is shown.
+	StepChangedEvent *event.Event
+
+	// FinishedEvent fires an event with *CoachMarksFinishedEventArgs when the last step has been finished,
+	// or when the steps have been skipped.
+	FinishedEvent *event.Event
+
+	containerOpts []ContainerOpt
+	buttonOpts    []ButtonOpt
+	textFace      font.Face
+	textColor     color.Color
+	textMaxWidth  int
+	buttonFace    font.Face
+	buttonColor   *ButtonTextColor
+	nextLabel     string
+	skipLabel     string
+	finishLabel   string
+	dimColor      color.Color
+	cutoutPadding int
+	padding       Insets
+	spacing       int
+
+	init        *MultiOnce
+	container   *Container
+	text        *Text
+	nextButton  *Button
+	skipButton  *Button
+	roots       []Locater
+	marks       []*CoachMark
+	step        int
+	screenRect  img.Rectangle
+	cutout      img.Rectangle
+	finishShown bool
+}
+
+// CoachMark is a step shown by CoachMarks.
+type CoachMark struct {
+	// TargetID is the ID of the widget to highlight. If no visible widget with that ID can be found, the
+	// whole screen is dimmed and the explanation is centered on the screen.
+	TargetID string
+
+	// Text is the explanation shown next to the target widget.
+	Text string
+}
+
+type CoachMarksOpt func(c *CoachMarks)
+
+type CoachMarksStepChangedEventArgs struct {
+	CoachMarks *CoachMarks
+	Mark       *CoachMark
+	Index      int
+}
+
+type CoachMarksStepChangedHandlerFunc func(args *CoachMarksStepChangedEventArgs)
+
+type CoachMarksFinishedEventArgs struct {
+	CoachMarks *CoachMarks
+
+	// Skipped is true if the steps have been skipped before the last step was finished.
+	Skipped bool
+}
+
+type CoachMarksFinishedHandlerFunc func(args *CoachMarksFinishedEventArgs)
+
+type CoachMarksOptions struct {
+}
+
+var CoachMarksOpts CoachMarksOptions
+
+func NewCoachMarks(opts ...CoachMarksOpt) *CoachMarks {
+	c := &CoachMarks{
+		StepChangedEvent: &event.Event{},
+		FinishedEvent:    &event.Event{},
+
+		textMaxWidth:  250,
+		nextLabel:     "Next",
+		skipLabel:     "Skip",
+		finishLabel:   "Done",
+		dimColor:      color.NRGBA{0, 0, 0, 160},
+		cutoutPadding: 4,
+
+		init: &MultiOnce{},
+	}
+
+	c.init.Append(c.createWidget)
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// ContainerOpts configures CoachMarks to construct the container of the explanation using opts.
+func (o CoachMarksOptions) ContainerOpts(opts ...ContainerOpt) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.containerOpts = append(c.containerOpts, opts...)
+	}
+}
+
+// Text configures CoachMarks to draw explanations using face and color col.
+func (o CoachMarksOptions) Text(face font.Face, col color.Color) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.textFace = face
+		c.textColor = col
+	}
+}
+
+// TextMaxWidth configures CoachMarks to wrap explanations so that they do not exceed width w. The default
+// is 250.
+func (o CoachMarksOptions) TextMaxWidth(w int) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.textMaxWidth = w
+	}
+}
+
+// ButtonOpts configures CoachMarks to construct its next and skip buttons using opts.
+func (o CoachMarksOptions) ButtonOpts(opts ...ButtonOpt) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.buttonOpts = append(c.buttonOpts, opts...)
+	}
+}
+
+// ButtonText configures CoachMarks to label its buttons using face and color col.
+func (o CoachMarksOptions) ButtonText(face font.Face, col *ButtonTextColor) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.buttonFace = face
+		c.buttonColor = col
+	}
+}
+
+// ButtonLabels configures CoachMarks to use the labels next, skip, and finish for its buttons. The finish
+// label replaces the next label on the last step. The defaults are "Next", "Skip", and "Done".
+func (o CoachMarksOptions) ButtonLabels(next string, skip string, finish string) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.nextLabel = next
+		c.skipLabel = skip
+		c.finishLabel = finish
+	}
+}
+
+// DimColor configures CoachMarks to dim the screen outside of the cutout using color col. The default is
+// a translucent black.
+func (o CoachMarksOptions) DimColor(col color.Color) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.dimColor = col
+	}
+}
+
+// CutoutPadding configures CoachMarks to extend the cutout by p pixels around the target widget. The
+// default is 4.
+func (o CoachMarksOptions) CutoutPadding(p int) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.cutoutPadding = p
+	}
+}
+
+// Padding configures CoachMarks to use padding i around the contents of the explanation.
+func (o CoachMarksOptions) Padding(i Insets) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.padding = i
+	}
+}
+
+// Spacing configures CoachMarks to use spacing s between the cutout and the explanation, as well as between
+// the elements of the explanation.
+func (o CoachMarksOptions) Spacing(s int) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.spacing = s
+	}
+}
+
+func (o CoachMarksOptions) StepChangedHandler(f CoachMarksStepChangedHandlerFunc) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.StepChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*CoachMarksStepChangedEventArgs))
+		})
+	}
+}
+
+func (o CoachMarksOptions) FinishedHandler(f CoachMarksFinishedHandlerFunc) CoachMarksOpt {
+	return func(c *CoachMarks) {
+		c.FinishedEvent.AddHandler(func(args interface{}) {
+			f(args.(*CoachMarksFinishedEventArgs))
+		})
+	}
+}
+
+// SetRoots sets the widget trees to search for target widgets, topmost first.
+func (c *CoachMarks) SetRoots(roots ...Locater) {
+	c.roots = roots
+}
+
+// Start starts showing marks, beginning with the first one. Any steps currently shown are replaced without
+// firing FinishedEvent.
+func (c *CoachMarks) Start(marks ...*CoachMark) {
+	c.init.Do()
+
+	c.marks = marks
+	c.finishShown = false
+	c.nextButton.Text().Label = c.nextLabel
+
+	if len(marks) == 0 {
+		return
+	}
+
+	c.setStep(0)
+}
+
+// Active returns whether a step is currently shown.
+func (c *CoachMarks) Active() bool {
+	return len(c.marks) > 0
+}
+
+// Mark returns the step currently shown, or nil if c is not active.
+func (c *CoachMarks) Mark() *CoachMark {
+	if !c.Active() {
+		return nil
+	}
+	return c.marks[c.step]
+}
+
+// StepIndex returns the index of the step currently shown.
+func (c *CoachMarks) StepIndex() int {
+	return c.step
+}
+
+// Next shows the next step, or fires FinishedEvent if the current step is the last step.
+func (c *CoachMarks) Next() {
+	if !c.Active() {
+		return
+	}
+
+	if c.step == len(c.marks)-1 {
+		c.finish(false)
+		return
+	}
+
+	c.setStep(c.step + 1)
+}
+
+// Skip hides c without showing the remaining steps, and fires FinishedEvent.
+func (c *CoachMarks) Skip() {
+	if !c.Active() {
+		return
+	}
+
+	c.finish(true)
+}
+
+func (c *CoachMarks) finish(skipped bool) {
+	c.marks = nil
+	c.step = 0
+	c.cutout = img.Rectangle{}
+
+	c.FinishedEvent.Fire(&CoachMarksFinishedEventArgs{
+		CoachMarks: c,
+		Skipped:    skipped,
+	})
+}
+
+func (c *CoachMarks) setStep(i int) {
+	c.step = i
+	c.text.Label = c.marks[i].Text
+
+	if finish := i == len(c.marks)-1; finish != c.finishShown {
+		c.finishShown = finish
+
+		label := c.nextLabel
+		if finish {
+			label = c.finishLabel
+		}
+		c.nextButton.Text().Label = label
+	}
+
+	c.container.RequestRelayout()
+
+	c.StepChangedEvent.Fire(&CoachMarksStepChangedEventArgs{
+		CoachMarks: c,
+		Mark:       c.marks[i],
+		Index:      i,
+	})
+}
+
+// Cutout returns the screen area around the target widget of the current step that is not dimmed. It
+// returns an empty rectangle if c is not active, or if the target widget could not be found.
+func (c *CoachMarks) Cutout() img.Rectangle {
+	return c.cutout
+}
+
+func (c *CoachMarks) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	c.init.Do()
+
+	if !c.Active() {
+		return
+	}
+
+	for _, r := range c.dimRects() {
+		r := r
+		input.AddLayer(&input.Layer{
+			DebugLabel: "coach marks dim",
+			EventTypes: input.LayerEventTypeAll,
+			BlockLower: true,
+			RectFunc: func() img.Rectangle {
+				return r
+			},
+		})
+	}
+
+	c.container.GetWidget().ElevateToNewInputLayer(&input.Layer{
+		DebugLabel: "coach mark",
+		EventTypes: input.LayerEventTypeAll,
+		BlockLower: true,
+		RectFunc: func() img.Rectangle {
+			return c.container.GetWidget().Rect
+		},
+	})
+
+	c.container.SetupInputLayer(def)
+}
+
+// Render implements Renderer.
+func (c *CoachMarks) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	c.init.Do()
+
+	if !c.Active() {
+		return
+	}
+
+	c.screenRect = screen.Bounds()
+	c.cutout = c.targetRect()
+
+	for _, r := range c.dimRects() {
+		fillRect(screen, r, c.dimColor)
+	}
+
+	c.layoutExplanation()
+	c.container.Render(screen, def)
+}
+
+// targetRect returns the cutout around the target widget of the current step, clipped to the screen.
+func (c *CoachMarks) targetRect() img.Rectangle {
+	w := FindWidget(c.marks[c.step].TargetID, c.roots...)
+	if w == nil || !w.GetWidget().Visible() {
+		return img.Rectangle{}
+	}
+
+	return w.GetWidget().Rect.Inset(-c.cutoutPadding).Intersect(c.screenRect)
+}
+
+// dimRects returns the areas of the screen outside of the cutout.
+func (c *CoachMarks) dimRects() []img.Rectangle {
+	s := c.screenRect
+	t := c.cutout
+	if t.Empty() {
+		return []img.Rectangle{s}
+	}
+
+	return []img.Rectangle{
+		img.Rect(s.Min.X, s.Min.Y, s.Max.X, t.Min.Y),
+		img.Rect(s.Min.X, t.Max.Y, s.Max.X, s.Max.Y),
+		img.Rect(s.Min.X, t.Min.Y, t.Min.X, t.Max.Y),
+		img.Rect(t.Max.X, t.Min.Y, s.Max.X, t.Max.Y),
+	}
+}
+
+// layoutExplanation positions the explanation below the cutout, or above it if there is not enough space
+// below. Without a cutout, the explanation is centered on the screen.
+func (c *CoachMarks) layoutExplanation() {
+	s := c.screenRect
+	t := c.cutout
+	w, h := c.container.PreferredSize()
+
+	var x, y int
+	if t.Empty() {
+		x = s.Min.X + (s.Dx()-w)/2
+		y = s.Min.Y + (s.Dy()-h)/2
+	} else {
+		x = clampInt(t.Min.X, s.Min.X, s.Max.X-w)
+		y = t.Max.Y + c.spacing
+		if y+h > s.Max.Y {
+			y = clampInt(t.Min.Y-c.spacing-h, s.Min.Y, s.Max.Y-h)
+		}
+	}
+
+	r := img.Rect(x, y, x+w, y+h)
+	if r != c.container.GetWidget().Rect {
+		c.container.SetLocation(r)
+		c.container.RequestRelayout()
+	}
+}
+
+func (c *CoachMarks) createWidget() {
+	c.container = NewContainer(append(c.containerOpts, ContainerOpts.Layout(NewGridLayout(
+		GridLayoutOpts.Columns(1),
+		GridLayoutOpts.Stretch([]bool{true}, []bool{true, false}),
+		GridLayoutOpts.Padding(c.padding),
+		GridLayoutOpts.Spacing(0, c.spacing))))...)
+	c.containerOpts = nil
+
+	c.text = NewText(
+		TextOpts.Text("", c.textFace, c.textColor),
+		TextOpts.WordWrap(c.textMaxWidth))
+	c.container.AddChild(c.text)
+
+	buttons := NewContainer(ContainerOpts.Layout(NewGridLayout(
+		GridLayoutOpts.Columns(2),
+		GridLayoutOpts.Stretch([]bool{true, true}, []bool{false}),
+		GridLayoutOpts.Spacing(c.spacing, 0))))
+
+	skipOpts := append([]ButtonOpt(nil), c.buttonOpts...)
+	c.skipButton = NewButton(append(skipOpts, []ButtonOpt{
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(GridLayoutData{
+			HorizontalPosition: GridLayoutPositionStart,
+		})),
+		ButtonOpts.Text(c.skipLabel, c.buttonFace, c.buttonColor),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			c.Skip()
+		}),
+	}...)...)
+	buttons.AddChild(c.skipButton)
+
+	nextOpts := append([]ButtonOpt(nil), c.buttonOpts...)
+	c.nextButton = NewButton(append(nextOpts, []ButtonOpt{
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(GridLayoutData{
+			HorizontalPosition: GridLayoutPositionEnd,
+		})),
+		ButtonOpts.Text(c.nextLabel, c.buttonFace, c.buttonColor),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			c.Next()
+		}),
+	}...)...)
+	buttons.AddChild(c.nextButton)
+	c.buttonOpts = nil
+
+	c.container.AddChild(buttons)
+}
+
+// FindWidget returns the first widget with ID id in the widget trees roots, searching the trees in order.
+// It returns nil if no widget with that ID can be found.
+func FindWidget(id string, roots ...Locater) HasWidget {
+	if id == "" {
+		return nil
+	}
+
+	var found HasWidget
+	for _, r := range roots {
+		if w, ok := r.(HasWidget); ok && w.GetWidget().ID == id {
+			return w
+		}
+
+		walkDescendants(r, func(w HasWidget) {
+			if found == nil && w.GetWidget().ID == id {
+				found = w
+			}
+		})
+
+		if found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
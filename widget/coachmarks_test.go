@@ -0,0 +1,121 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestFindWidget(t *testing.T) {
+	is := is.New(t)
+
+	w := newSimpleWidget(10, 10, nil)
+	w.GetWidget().ID = "target"
+
+	inner := NewContainer(ContainerOpts.Layout(NewRowLayout()))
+	inner.AddChild(w)
+
+	c := NewContainer(ContainerOpts.Layout(NewRowLayout()))
+	c.AddChild(inner)
+
+	is.Equal(FindWidget("target", c), w)
+	is.Equal(FindWidget("missing", c), nil)
+	is.Equal(FindWidget("", c), nil)
+}
+
+func TestCoachMarks_Cutout(t *testing.T) {
+	is := is.New(t)
+
+	w := newSimpleWidget(20, 10, nil)
+	w.GetWidget().ID = "target"
+	w.GetWidget().Rect = image.Rect(50, 20, 70, 30)
+
+	c := NewContainer(ContainerOpts.Layout(NewRowLayout()))
+	c.AddChild(w)
+
+	m := newCoachMarks(t, CoachMarksOpts.CutoutPadding(2))
+	m.SetRoots(c)
+	m.Start(&CoachMark{TargetID: "target", Text: "This is the target."})
+
+	renderCoachMarks(m, t)
+	is.Equal(m.Cutout(), image.Rect(48, 18, 72, 32))
+
+	m.Start(&CoachMark{TargetID: "missing", Text: "Somewhere else."})
+
+	renderCoachMarks(m, t)
+	is.Equal(m.Cutout(), image.Rectangle{})
+}
+
+func TestCoachMarks_Next(t *testing.T) {
+	is := is.New(t)
+
+	marks := []*CoachMark{{Text: "1"}, {Text: "2"}}
+
+	var changed []int
+	var finished *CoachMarksFinishedEventArgs
+	m := newCoachMarks(t,
+		CoachMarksOpts.StepChangedHandler(func(args *CoachMarksStepChangedEventArgs) {
+			changed = append(changed, args.Index)
+		}),
+		CoachMarksOpts.FinishedHandler(func(args *CoachMarksFinishedEventArgs) {
+			finished = args
+		}))
+
+	m.Start(marks...)
+	is.True(m.Active())
+	is.Equal(m.Mark(), marks[0])
+	is.Equal(m.nextButton.Text().Label, "Next")
+
+	m.Next()
+	is.Equal(m.Mark(), marks[1])
+	is.Equal(m.nextButton.Text().Label, "Done")
+
+	m.Next()
+	is.True(!m.Active())
+	is.Equal(changed, []int{0, 1})
+	is.True(finished != nil)
+	is.True(!finished.Skipped)
+}
+
+func TestCoachMarks_Skip(t *testing.T) {
+	is := is.New(t)
+
+	var finished *CoachMarksFinishedEventArgs
+	m := newCoachMarks(t,
+		CoachMarksOpts.FinishedHandler(func(args *CoachMarksFinishedEventArgs) {
+			finished = args
+		}))
+
+	m.Start(&CoachMark{Text: "1"}, &CoachMark{Text: "2"})
+	renderCoachMarks(m, t)
+
+	leftMouseButtonClick(m.skipButton, t)
+	is.True(!m.Active())
+	is.True(finished != nil)
+	is.True(finished.Skipped)
+}
+
+func newCoachMarks(t *testing.T, opts ...CoachMarksOpt) *CoachMarks {
+	t.Helper()
+
+	m := NewCoachMarks(append(opts, []CoachMarksOpt{
+		CoachMarksOpts.Text(loadFont(t), color.White),
+		CoachMarksOpts.ButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})),
+		CoachMarksOpts.ButtonText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+	}...)...)
+	event.ExecuteDeferred()
+	return m
+}
+
+func renderCoachMarks(m *CoachMarks, t *testing.T) {
+	t.Helper()
+
+	screen := ebiten.NewImage(200, 100)
+	RenderWithDeferred(screen, []Renderer{m})
+	event.ExecuteDeferred()
+}
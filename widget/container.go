@@ -3,6 +3,7 @@ package widget
 import (
 	img "image"
 
+	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/image"
 	"github.com/blizzy78/ebitenui/input"
 
@@ -13,22 +14,62 @@ type Container struct {
 	BackgroundImage     *image.NineSlice
 	AutoDisableChildren bool
 
-	widgetOpts  []WidgetOpt
-	layout      Layouter
-	layoutDirty bool
-
-	init     *MultiOnce
-	widget   *Widget
-	children []PreferredSizeLocateableWidget
+	// LayoutCompletedEvent fires an event with *ContainerLayoutCompletedEventArgs when an asynchronous layout
+	// has been completed.
+	LayoutCompletedEvent *event.Event
+
+	widgetOpts           []WidgetOpt
+	layout               Layouter
+	layoutDirty          bool
+	preChildrenDrawFunc  ContainerDrawFunc
+	postChildrenDrawFunc ContainerDrawFunc
+	clipChildren         bool
+	clipRadius           int
+	cacheRendering       bool
+	tabOrder             []HasWidget
+
+	asyncLayoutBudget      int
+	asyncLayoutPlaceholder ContainerDrawFunc
+	asyncLayoutChildren    []PreferredSizeLocateableWidget
+	asyncLayoutSizes       []img.Point
+	asyncLayoutPending     bool
+	asyncLayoutDone        bool
+	asyncPreferredSize     img.Point
+
+	init           *MultiOnce
+	widget         *Widget
+	children       []PreferredSizeLocateableWidget
+	clipBuf        *image.ClipRenderBuffer
+	roundedClipBuf *image.RoundedClipRenderBuffer
+	cacheBuf       *image.CachedRenderBuffer
+
+	cachedDeferred []RenderFunc
+
+	disabledBeforeRecursive map[*Widget]bool
 }
 
 type ContainerOpt func(c *Container)
 
 type RemoveChildFunc func()
 
+// ContainerDrawFunc is a function that draws custom decorations of a Container onto screen.
+type ContainerDrawFunc func(c *Container, screen *ebiten.Image)
+
 type ContainerOptions struct {
 }
 
+type ContainerLayoutCompletedEventArgs struct {
+	Container *Container
+}
+
+type ContainerLayoutCompletedHandlerFunc func(args *ContainerLayoutCompletedEventArgs)
+
+// fixedSizeWidget reports a previously computed preferred size instead of computing it again.
+type fixedSizeWidget struct {
+	PreferredSizeLocateableWidget
+	size img.Point
+}
+
 var ContainerOpts ContainerOptions
 
 type PreferredSizeLocateableWidget interface {
@@ -39,6 +80,8 @@ type PreferredSizeLocateableWidget interface {
 
 func NewContainer(opts ...ContainerOpt) *Container {
 	c := &Container{
+		LayoutCompletedEvent: &event.Event{},
+
 		init: &MultiOnce{},
 	}
 
@@ -63,60 +106,271 @@ func (o ContainerOptions) BackgroundImage(i *image.NineSlice) ContainerOpt {
 	}
 }
 
+// PreChildrenDrawFunc configures a Container to call f after drawing its background image, but before
+// rendering its children.
+func (o ContainerOptions) PreChildrenDrawFunc(f ContainerDrawFunc) ContainerOpt {
+	return func(c *Container) {
+		c.preChildrenDrawFunc = f
+	}
+}
+
+// PostChildrenDrawFunc configures a Container to call f after rendering its children. Deferred rendering
+// of children, such as open ComboButton contents, happens after f has been called.
+func (o ContainerOptions) PostChildrenDrawFunc(f ContainerDrawFunc) ContainerOpt {
+	return func(c *Container) {
+		c.postChildrenDrawFunc = f
+	}
+}
+
+// ClipChildren configures whether a Container clips the rendering of its children to its own Rect.
+// Deferred rendering of children, such as open ComboButton contents, is not clipped.
+func (o ContainerOptions) ClipChildren(clip bool) ContainerOpt {
+	return func(c *Container) {
+		c.clipChildren = clip
+	}
+}
+
+// CacheRendering configures whether a Container renders itself and its descendants into a texture that is
+// reused every frame, until the container is laid out again, or until Widget.InvalidateRender is called for
+// the container or any of its descendants. This reduces draw cost for mostly static panels. Rendering is
+// clipped to the container's Rect.
+//
+// Descendants keep receiving input events while the cache is valid. Firing an event, focus changes, and
+// changes to Disabled or Visibility invalidate the cache automatically, as does holding a mouse button
+// pressed on a descendant. Descendants that change their visual state in other ways, such as animations,
+// must call Widget.InvalidateRender.
+func (o ContainerOptions) CacheRendering(cache bool) ContainerOpt {
+	return func(c *Container) {
+		c.cacheRendering = cache
+	}
+}
+
+// ClipChildrenRounded configures a Container to clip the rendering of its children to its own Rect, with
+// corners rounded by radius, so that children do not poke past the corners of a rounded background image.
+// Deferred rendering of children is not clipped.
+func (o ContainerOptions) ClipChildrenRounded(radius int) ContainerOpt {
+	return func(c *Container) {
+		c.clipChildren = true
+		c.clipRadius = radius
+	}
+}
+
 func (o ContainerOptions) AutoDisableChildren() ContainerOpt {
 	return func(c *Container) {
 		c.AutoDisableChildren = true
 	}
 }
 
+// TabOrder configures a Container to traverse children in order when moving focus. Children not included in order
+// are traversed afterwards, according to their Widget.TabOrder.
+func (o ContainerOptions) TabOrder(order ...HasWidget) ContainerOpt {
+	return func(c *Container) {
+		c.tabOrder = order
+	}
+}
+
 func (o ContainerOptions) Layout(layout Layouter) ContainerOpt {
 	return func(c *Container) {
 		c.layout = layout
 	}
 }
 
+// AsyncLayout configures a Container to spread the computation of its children's preferred sizes across
+// multiple frames, computing at most n children's preferred sizes per frame. This prevents frame hitches
+// when a container with thousands of children is shown. Until the first layout has been completed, the
+// container does not render its children, and calls placeholder instead, if it is not nil. During later
+// layouts, children are rendered at their previous locations. The container's preferred size is only known
+// once a layout has been completed, at which point its parent containers layout their children again.
+//
+// Widgets are not safe for concurrent use, so layout happens on the UI's goroutine, not on a worker.
+func (o ContainerOptions) AsyncLayout(n int, placeholder ContainerDrawFunc) ContainerOpt {
+	return func(c *Container) {
+		c.asyncLayoutBudget = n
+		c.asyncLayoutPlaceholder = placeholder
+	}
+}
+
+func (o ContainerOptions) LayoutCompletedHandler(f ContainerLayoutCompletedHandlerFunc) ContainerOpt {
+	return func(c *Container) {
+		c.LayoutCompletedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ContainerLayoutCompletedEventArgs))
+		})
+	}
+}
+
 func (c *Container) AddChild(child PreferredSizeLocateableWidget) RemoveChildFunc {
 	c.init.Do()
+	return c.InsertChildAt(len(c.children), child)
+}
+
+// InsertChildAt adds child to c at position index, moving all children at that position and after
+// one position back. If index is out of range, child is added at the start or the end. It returns
+// a function to remove child from c. If child has already been added to a container, it is not added to c,
+// or a panic occurs if DevelopmentMode is enabled.
+func (c *Container) InsertChildAt(index int, child PreferredSizeLocateableWidget) RemoveChildFunc {
+	c.init.Do()
 
 	if child == nil {
 		panic("cannot add nil child")
 	}
 
-	c.children = append(c.children, child)
+	if !checkAddChild(child) {
+		return func() {}
+	}
+
+	if index < 0 {
+		index = 0
+	} else if index > len(c.children) {
+		index = len(c.children)
+	}
+
+	c.children = append(c.children, nil)
+	copy(c.children[index+1:], c.children[index:])
+	c.children[index] = child
 
 	child.GetWidget().parent = c.widget
 
 	c.RequestRelayout()
 
 	return func() {
-		c.removeChild(child)
+		c.RemoveChild(child)
 	}
 }
 
-func (c *Container) removeChild(child PreferredSizeLocateableWidget) {
-	index := -1
-	for i, ch := range c.children {
-		if ch == child {
-			index = i
-			break
-		}
-	}
+// RemoveChild removes child from c. It does nothing if child is not a child of c.
+func (c *Container) RemoveChild(child PreferredSizeLocateableWidget) {
+	c.init.Do()
 
+	index := c.childIndex(child)
 	if index < 0 {
 		return
 	}
 
 	c.children = append(c.children[:index], c.children[index+1:]...)
 
-	child.GetWidget().parent = nil
+	detach(child)
 
 	c.RequestRelayout()
 }
 
+// RemoveAllChildren removes all children from c.
+func (c *Container) RemoveAllChildren() {
+	c.init.Do()
+
+	for _, ch := range c.children {
+		detach(ch)
+	}
+
+	c.children = nil
+
+	c.RequestRelayout()
+}
+
+// ReplaceChild replaces child with replacement, keeping its position. If child is not a child of c,
+// it returns nil. Otherwise, it returns a function to remove replacement from c. If replacement has already
+// been added to a container, unless it is child itself, child is not replaced and nil is returned, or a panic
+// occurs if DevelopmentMode is enabled.
+func (c *Container) ReplaceChild(child PreferredSizeLocateableWidget, replacement PreferredSizeLocateableWidget) RemoveChildFunc {
+	c.init.Do()
+
+	if replacement == nil {
+		panic("cannot add nil child")
+	}
+
+	index := c.childIndex(child)
+	if index < 0 {
+		return nil
+	}
+
+	if replacement != child && !checkAddChild(replacement) {
+		return nil
+	}
+
+	detach(child)
+
+	c.children[index] = replacement
+	replacement.GetWidget().parent = c.widget
+
+	c.RequestRelayout()
+
+	return func() {
+		c.RemoveChild(replacement)
+	}
+}
+
+// Children returns the children of c.
+func (c *Container) Children() []PreferredSizeLocateableWidget {
+	c.init.Do()
+
+	ch := make([]PreferredSizeLocateableWidget, len(c.children))
+	copy(ch, c.children)
+	return ch
+}
+
+// SetDisabledRecursive disables or enables c and all of its descendants, including the widgets that composite
+// widgets implementing Containerer are made of. When disabling, the individual disabled states of c and its
+// descendants are remembered, and are restored when enabling again.
+func (c *Container) SetDisabledRecursive(disabled bool) {
+	c.init.Do()
+
+	if disabled {
+		if c.disabledBeforeRecursive != nil {
+			return
+		}
+
+		c.disabledBeforeRecursive = map[*Widget]bool{}
+		c.walkWidgets(func(w *Widget) {
+			if _, ok := c.disabledBeforeRecursive[w]; !ok {
+				c.disabledBeforeRecursive[w] = w.Disabled
+			}
+			w.Disabled = true
+		})
+
+		return
+	}
+
+	c.walkWidgets(func(w *Widget) {
+		w.Disabled = c.disabledBeforeRecursive[w]
+	})
+	c.disabledBeforeRecursive = nil
+}
+
+// walkWidgets calls f for c's widget and the widgets of all of c's descendants, including the widgets that
+// composite widgets are made of.
+func (c *Container) walkWidgets(f func(w *Widget)) {
+	f(c.widget)
+
+	walkWidgetTree(c, func(w HasWidget) {
+		f(w.GetWidget())
+	})
+}
+
+// containedWidgets returns the children of c, for use by composite widgets that implement Containerer using c.
+func containedWidgets(c *Container) []HasWidget {
+	c.init.Do()
+
+	ch := make([]HasWidget, len(c.children))
+	for i, w := range c.children {
+		ch[i] = w
+	}
+	return ch
+}
+
+func (c *Container) childIndex(child PreferredSizeLocateableWidget) int {
+	for i, ch := range c.children {
+		if ch == child {
+			return i
+		}
+	}
+	return -1
+}
+
 func (c *Container) RequestRelayout() {
 	c.init.Do()
 
 	c.layoutDirty = true
+	c.asyncLayoutPending = false
+	c.widget.InvalidateRender()
 
 	for _, ch := range c.children {
 		if r, ok := ch.(Relayoutable); ok {
@@ -137,11 +391,27 @@ func (c *Container) PreferredSize() (int, int) {
 		return 50, 50
 	}
 
-	return c.layout.PreferredSize(c.children)
+	if c.asyncLayoutBudget > 0 {
+		return c.asyncPreferredSize.X, c.asyncPreferredSize.Y
+	}
+
+	return c.layout.PreferredSize(c.layoutChildren())
+}
+
+// LayoutPending returns whether c uses asynchronous layout and its layout has not been completed yet.
+func (c *Container) LayoutPending() bool {
+	c.init.Do()
+	return c.asyncLayoutBudget > 0 && c.layout != nil && c.layoutDirty
 }
 
 func (c *Container) SetLocation(rect img.Rectangle) {
 	c.init.Do()
+
+	if rect != c.widget.Rect {
+		c.layoutDirty = true
+		c.widget.InvalidateRender()
+	}
+
 	c.widget.Rect = rect
 }
 
@@ -156,28 +426,241 @@ func (c *Container) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 
 	c.widget.Render(screen, def)
 
+	if c.widget.relayoutRequested {
+		c.widget.relayoutRequested = false
+		c.layoutDirty = true
+	}
+
+	if c.layoutDirty {
+		c.widget.InvalidateRender()
+	}
+
 	c.doLayout()
 
+	if c.cacheRendering {
+		c.renderCached(screen, def)
+		return
+	}
+
+	c.renderContents(screen, def)
+}
+
+func (c *Container) releaseResources() {
+	if c.cacheBuf != nil {
+		c.cacheBuf.Dispose()
+	}
+	if c.roundedClipBuf != nil {
+		c.roundedClipBuf.Dispose()
+	}
+}
+
+// renderCached renders c using its render cache, redrawing the cache only if it has been invalidated.
+// Deferred rendering requested while redrawing the cache is requested again every frame.
+func (c *Container) renderCached(screen *ebiten.Image, def DeferredRenderFunc) {
+	if c.cacheBuf == nil {
+		c.cacheBuf = image.NewCachedRenderBuffer()
+	}
+
+	redraw := c.widget.renderInvalidated
+	c.widget.renderInvalidated = false
+
+	if !redraw {
+		// events fired here invalidate the rendering again, so that it is redrawn after their handlers have run
+		redraw = fireCachedEvents(c)
+	}
+
+	if redraw {
+		c.cacheBuf.Invalidate()
+	}
+
+	c.cacheBuf.Draw(screen, c.widget.Rect, func(buf *ebiten.Image) {
+		c.cachedDeferred = c.cachedDeferred[:0]
+		c.renderContents(buf, func(r RenderFunc) {
+			c.cachedDeferred = append(c.cachedDeferred, r)
+		})
+	})
+
+	for _, r := range c.cachedDeferred {
+		def(r)
+	}
+}
+
+// renderChecker may be implemented by widgets whose visual state may change without firing events, to
+// report whether it has changed since they were last rendered.
+type renderChecker interface {
+	renderChanged() bool
+}
+
+// fireCachedEvents fires the input events of the visible descendants of w, which are not rendered because
+// they are drawn from a render cache. It returns whether the visual state of any descendant has changed.
+func fireCachedEvents(w interface{}) bool {
+	changed := false
+
+	for _, ch := range widgetTreeChildren(w) {
+		cw := ch.GetWidget()
+		if cw.Visible() == cw.cachedHidden || cw.Disabled != cw.cachedDisabled {
+			cw.cachedHidden = !cw.Visible()
+			cw.cachedDisabled = cw.Disabled
+			cw.InvalidateRender()
+			changed = true
+		}
+
+		if cw.cachedHidden {
+			continue
+		}
+
+		if _, ok := ch.(Renderer); ok {
+			cw.fireEvents()
+		}
+
+		if r, ok := ch.(renderChecker); ok && r.renderChanged() {
+			cw.InvalidateRender()
+			changed = true
+		}
+
+		// widgets being dragged usually change their visual state every frame
+		if cw.lastUpdateMouseLeftPressed {
+			cw.InvalidateRender()
+			changed = true
+		}
+
+		if fireCachedEvents(ch) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (c *Container) renderContents(screen *ebiten.Image, def DeferredRenderFunc) {
 	c.draw(screen)
 
+	if c.asyncLayoutBudget > 0 && !c.asyncLayoutDone {
+		if c.asyncLayoutPlaceholder != nil {
+			c.asyncLayoutPlaceholder(c, screen)
+		}
+		return
+	}
+
+	if c.clipChildren && c.clipRadius > 0 {
+		if c.roundedClipBuf == nil {
+			c.roundedClipBuf = image.NewRoundedClipRenderBuffer()
+		}
+
+		c.roundedClipBuf.Draw(screen, c.widget.Rect, c.clipRadius, func(buf *ebiten.Image) {
+			c.renderChildren(buf, def)
+		})
+	} else if c.clipChildren {
+		if c.clipBuf == nil {
+			c.clipBuf = image.NewClipRenderBuffer()
+		}
+
+		c.clipBuf.Draw(screen, c.widget.Rect, func(buf *ebiten.Image) {
+			c.renderChildren(buf, def)
+		})
+	} else {
+		c.renderChildren(screen, def)
+	}
+
+	if c.postChildrenDrawFunc != nil {
+		c.postChildrenDrawFunc(c, screen)
+	}
+}
+
+func (c *Container) renderChildren(screen *ebiten.Image, def DeferredRenderFunc) {
 	for _, ch := range c.children {
+		if !ch.GetWidget().Visible() {
+			continue
+		}
+
 		if cr, ok := ch.(Renderer); ok {
-			cr.Render(screen, def)
+			renderWithHandlers(cr, screen, def)
 		}
 	}
 }
 
 func (c *Container) doLayout() {
 	if c.layout != nil && c.layoutDirty {
-		c.layout.Layout(c.children, c.widget.Rect)
+		if c.asyncLayoutBudget > 0 {
+			c.doLayoutAsync()
+			return
+		}
+
+		children := c.layoutChildren()
+		validateLayoutData(c.layout, children)
+		c.layout.Layout(children, c.widget.Rect)
 		c.layoutDirty = false
+
+		stats.Relayouts++
 	}
 }
 
+// doLayoutAsync computes the preferred sizes of at most c.asyncLayoutBudget children, and lays out all
+// children once all preferred sizes are known.
+func (c *Container) doLayoutAsync() {
+	if !c.asyncLayoutPending {
+		c.asyncLayoutChildren = c.layoutChildren()
+		c.asyncLayoutSizes = c.asyncLayoutSizes[:0]
+		c.asyncLayoutPending = true
+	}
+
+	for n := 0; n < c.asyncLayoutBudget && len(c.asyncLayoutSizes) < len(c.asyncLayoutChildren); n++ {
+		w, h := c.asyncLayoutChildren[len(c.asyncLayoutSizes)].PreferredSize()
+		c.asyncLayoutSizes = append(c.asyncLayoutSizes, img.Point{w, h})
+	}
+
+	if len(c.asyncLayoutSizes) < len(c.asyncLayoutChildren) {
+		return
+	}
+
+	children := make([]PreferredSizeLocateableWidget, len(c.asyncLayoutChildren))
+	for i, ch := range c.asyncLayoutChildren {
+		children[i] = &fixedSizeWidget{
+			PreferredSizeLocateableWidget: ch,
+			size:                          c.asyncLayoutSizes[i],
+		}
+	}
+
+	validateLayoutData(c.layout, c.asyncLayoutChildren)
+	c.layout.Layout(children, c.widget.Rect)
+
+	w, h := c.layout.PreferredSize(children)
+	if size := (img.Point{w, h}); size != c.asyncPreferredSize {
+		c.asyncPreferredSize = size
+		c.widget.requestParentRelayout()
+	}
+
+	c.asyncLayoutChildren = nil
+	c.asyncLayoutPending = false
+	c.asyncLayoutDone = true
+	c.layoutDirty = false
+
+	stats.Relayouts++
+
+	c.LayoutCompletedEvent.Fire(&ContainerLayoutCompletedEventArgs{
+		Container: c,
+	})
+}
+
+// layoutChildren returns the children of c that are not collapsed.
+func (c *Container) layoutChildren() []PreferredSizeLocateableWidget {
+	children := make([]PreferredSizeLocateableWidget, 0, len(c.children))
+	for _, ch := range c.children {
+		if ch.GetWidget().Visibility != VisibilityCollapsed {
+			children = append(children, ch)
+		}
+	}
+	return children
+}
+
 func (c *Container) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 	c.init.Do()
 
 	for _, ch := range c.children {
+		if !ch.GetWidget().Visible() {
+			continue
+		}
+
 		if il, ok := ch.(input.Layerer); ok {
 			il.SetupInputLayer(def)
 		}
@@ -188,6 +671,10 @@ func (c *Container) draw(screen *ebiten.Image) {
 	if c.BackgroundImage != nil {
 		c.BackgroundImage.Draw(screen, c.widget.Rect.Dx(), c.widget.Rect.Dy(), c.widget.drawImageOptions)
 	}
+
+	if c.preChildrenDrawFunc != nil {
+		c.preChildrenDrawFunc(c, screen)
+	}
 }
 
 func (c *Container) createWidget() {
@@ -195,17 +682,23 @@ func (c *Container) createWidget() {
 	c.widgetOpts = nil
 }
 
+func (w *fixedSizeWidget) PreferredSize() (int, int) {
+	return w.size.X, w.size.Y
+}
+
 // WidgetAt implements WidgetLocator.
 func (c *Container) WidgetAt(x int, y int) HasWidget {
 	c.init.Do()
 
-	p := img.Point{x, y}
-
-	if !p.In(c.GetWidget().Rect) {
+	if !c.GetWidget().In(x, y) {
 		return nil
 	}
 
 	for _, ch := range c.children {
+		if !ch.GetWidget().Visible() {
+			continue
+		}
+
 		if wl, ok := ch.(Locater); ok {
 			w := wl.WidgetAt(x, y)
 			if w != nil {
@@ -215,7 +708,7 @@ func (c *Container) WidgetAt(x int, y int) HasWidget {
 			continue
 		}
 
-		if p.In(ch.GetWidget().Rect) {
+		if ch.GetWidget().In(x, y) {
 			return ch
 		}
 	}
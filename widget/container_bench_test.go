@@ -0,0 +1,51 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func BenchmarkContainer_Render(b *testing.B) {
+	c := newBenchmarkGrid(10, 100)
+	screen := ebiten.NewImage(800, 600)
+	c.SetLocation(screen.Bounds())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		RenderWithDeferred(screen, []Renderer{c})
+	}
+}
+
+func BenchmarkContainer_Relayout(b *testing.B) {
+	c := newBenchmarkGrid(10, 100)
+	screen := ebiten.NewImage(800, 600)
+	c.SetLocation(screen.Bounds())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.RequestRelayout()
+		RenderWithDeferred(screen, []Renderer{c})
+	}
+}
+
+// newBenchmarkGrid returns a container with a grid of columns x rows containers.
+func newBenchmarkGrid(columns int, rows int) *Container {
+	bg := image.NewNineSliceColor(color.White)
+
+	c := NewContainer(ContainerOpts.Layout(NewGridLayout(
+		GridLayoutOpts.Columns(columns),
+		GridLayoutOpts.Stretch([]bool{true}, nil),
+		GridLayoutOpts.Spacing(2, 2))))
+
+	for i := 0; i < columns*rows; i++ {
+		c.AddChild(NewContainer(ContainerOpts.BackgroundImage(bg)))
+	}
+
+	return c
+}
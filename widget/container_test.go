@@ -4,7 +4,9 @@ import (
 	"image"
 	"testing"
 
+	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/input"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/matryer/is"
@@ -72,6 +74,422 @@ func TestContainer_SetupInputLayer(t *testing.T) {
 	m.AssertExpectations(t)
 }
 
+func TestContainer_Render_ClipChildren(t *testing.T) {
+	w := NewWidget()
+	m := controlMock{}
+	m.On("GetWidget").Maybe().Return(w)
+	m.On("PreferredSize").Maybe().Return(50, 50)
+	m.On("SetLocation", mock.Anything).Maybe()
+	m.On("Render", mock.Anything, mock.Anything)
+
+	c := newContainer(t,
+		ContainerOpts.Layout(newRowLayout(t)),
+		ContainerOpts.ClipChildren(true))
+	c.AddChild(&m)
+	c.SetLocation(image.Rect(0, 0, 20, 20))
+
+	render(c, t)
+
+	m.AssertExpectations(t)
+}
+
+func TestContainer_Render_ClipChildrenRounded(t *testing.T) {
+	w := NewWidget()
+	m := controlMock{}
+	m.On("GetWidget").Maybe().Return(w)
+	m.On("PreferredSize").Maybe().Return(50, 50)
+	m.On("SetLocation", mock.Anything).Maybe()
+	m.On("Render", mock.Anything, mock.Anything)
+
+	c := newContainer(t,
+		ContainerOpts.Layout(newRowLayout(t)),
+		ContainerOpts.ClipChildrenRounded(5))
+	c.AddChild(&m)
+	c.SetLocation(image.Rect(0, 0, 20, 20))
+
+	render(c, t)
+
+	m.AssertExpectations(t)
+}
+
+func TestContainer_Render_Visibility(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t,
+		ContainerOpts.Layout(newRowLayout(t)))
+	c.SetLocation(image.Rect(0, 0, 100, 100))
+
+	w1 := newSimpleWidget(10, 10, nil)
+	w2 := newSimpleWidget(10, 10, nil)
+	c.AddChild(w1)
+	c.AddChild(w2)
+
+	render(c, t)
+	is.Equal(w2.GetWidget().Rect.Min.X, 10)
+
+	w1.GetWidget().SetVisibility(VisibilityHidden)
+	render(c, t)
+	is.Equal(w2.GetWidget().Rect.Min.X, 10)
+
+	w1.GetWidget().SetVisibility(VisibilityCollapsed)
+	render(c, t)
+	is.Equal(w2.GetWidget().Rect.Min.X, 0)
+
+	pw, _ := c.PreferredSize()
+	is.Equal(pw, 10)
+}
+
+func TestContainer_DrawFuncs(t *testing.T) {
+	is := is.New(t)
+
+	calls := []string{}
+
+	w := NewWidget()
+	m := controlMock{}
+	m.On("GetWidget").Maybe().Return(w)
+	m.On("PreferredSize").Maybe().Return(50, 50)
+	m.On("SetLocation", mock.Anything).Maybe()
+	m.On("Render", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		calls = append(calls, "child")
+	})
+
+	c := newContainer(t,
+		ContainerOpts.Layout(newRowLayout(t)),
+		ContainerOpts.PreChildrenDrawFunc(func(c *Container, screen *ebiten.Image) {
+			calls = append(calls, "pre")
+		}),
+		ContainerOpts.PostChildrenDrawFunc(func(c *Container, screen *ebiten.Image) {
+			calls = append(calls, "post")
+		}))
+	c.AddChild(&m)
+
+	render(c, t)
+
+	is.Equal(calls, []string{"pre", "child", "post"})
+}
+
+func TestContainer_SetDisabledRecursive(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	inner := newContainer(t)
+	w1 := newSimpleWidget(10, 10, nil)
+	w2 := newSimpleWidget(10, 10, nil)
+	w2.GetWidget().Disabled = true
+
+	c.AddChild(inner)
+	inner.AddChild(w1)
+	inner.AddChild(w2)
+
+	c.SetDisabledRecursive(true)
+
+	is.True(c.GetWidget().Disabled)
+	is.True(inner.GetWidget().Disabled)
+	is.True(w1.GetWidget().Disabled)
+	is.True(w2.GetWidget().Disabled)
+
+	c.SetDisabledRecursive(false)
+
+	is.True(!c.GetWidget().Disabled)
+	is.True(!inner.GetWidget().Disabled)
+	is.True(!w1.GetWidget().Disabled)
+	is.True(w2.GetWidget().Disabled)
+}
+
+func TestContainer_SetDisabledRecursive_Composite(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	l := newList(t, ListOpts.Entries([]interface{}{"first", "second"}))
+	c.AddChild(l)
+
+	c.SetDisabledRecursive(true)
+
+	for _, b := range listEntryButtons(l) {
+		is.True(b.GetWidget().Disabled)
+	}
+
+	c.SetDisabledRecursive(false)
+
+	for _, b := range listEntryButtons(l) {
+		is.True(!b.GetWidget().Disabled)
+	}
+}
+
+func TestContainer_InsertChildAt(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	w1 := newSimpleWidget(10, 10, nil)
+	w2 := newSimpleWidget(10, 10, nil)
+	w3 := newSimpleWidget(10, 10, nil)
+
+	c.AddChild(w1)
+	c.AddChild(w2)
+	c.InsertChildAt(1, w3)
+
+	is.Equal(c.Children(), []PreferredSizeLocateableWidget{w1, w3, w2})
+	is.Equal(w3.GetWidget().Parent(), c.GetWidget())
+}
+
+func TestContainer_RemoveChild(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *WidgetCursorExitEventArgs
+
+	c := newContainer(t)
+	w := newSimpleWidget(10, 10, nil)
+	w.GetWidget().CursorExitEvent.AddHandler(func(args interface{}) {
+		eventArgs = args.(*WidgetCursorExitEventArgs)
+	})
+	c.AddChild(w)
+
+	w.GetWidget().lastUpdateCursorEntered = true
+
+	c.RemoveChild(w)
+	event.ExecuteDeferred()
+
+	is.Equal(len(c.Children()), 0)
+	is.True(w.GetWidget().Parent() == nil)
+	is.True(eventArgs != nil)
+}
+
+func TestContainer_RemoveChild_Descendants(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *WidgetCursorExitEventArgs
+
+	c := newContainer(t)
+	inner := newContainer(t)
+	w := newSimpleWidget(10, 10, nil)
+	w.GetWidget().CursorExitEvent.AddHandler(func(args interface{}) {
+		eventArgs = args.(*WidgetCursorExitEventArgs)
+	})
+	inner.AddChild(w)
+	c.AddChild(inner)
+
+	w.GetWidget().lastUpdateCursorEntered = true
+	w.GetWidget().lastUpdateMouseLeftPressed = true
+
+	c.RemoveChild(inner)
+	event.ExecuteDeferred()
+
+	is.True(eventArgs != nil)
+	is.True(!w.GetWidget().lastUpdateMouseLeftPressed)
+	is.Equal(w.GetWidget().Parent(), inner.GetWidget())
+}
+
+func TestContainer_RemoveChild_ReleaseResources(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	inner := newContainer(t, ContainerOpts.CacheRendering(true))
+	c.AddChild(inner)
+	render(inner, t)
+
+	is.True(inner.cacheBuf.Valid())
+
+	c.RemoveChild(inner)
+	is.True(!inner.cacheBuf.Valid())
+}
+
+func TestDispose(t *testing.T) {
+	is := is.New(t)
+
+	w := newSimpleWidget(10, 10, nil)
+	w.GetWidget().CursorEnterEvent.AddHandler(func(args interface{}) {})
+
+	c := newContainer(t)
+	c.AddChild(w)
+	c.RemoveChild(w)
+	is.True(w.GetWidget().CursorEnterEvent.HasHandlers())
+
+	Dispose(w)
+	is.True(!w.GetWidget().CursorEnterEvent.HasHandlers())
+}
+
+func TestContainer_AddChild_MultipleParents(t *testing.T) {
+	is := is.New(t)
+
+	w := newSimpleWidget(10, 10, nil)
+
+	c1 := newContainer(t)
+	c1.AddChild(w)
+
+	c2 := newContainer(t)
+	c2.AddChild(w)
+	is.Equal(len(c2.Children()), 0)
+
+	c3 := newContainer(t)
+	other := newSimpleWidget(10, 10, nil)
+	c3.AddChild(other)
+	is.True(c3.ReplaceChild(other, w) == nil)
+	is.Equal(c3.Children()[0], PreferredSizeLocateableWidget(other))
+
+	is.Equal(w.GetWidget().Parent(), c1.GetWidget())
+}
+
+func TestContainer_RemoveAllChildren(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	w1 := newSimpleWidget(10, 10, nil)
+	w2 := newSimpleWidget(10, 10, nil)
+	c.AddChild(w1)
+	c.AddChild(w2)
+
+	c.RemoveAllChildren()
+
+	is.Equal(len(c.Children()), 0)
+	is.True(w1.GetWidget().Parent() == nil)
+	is.True(w2.GetWidget().Parent() == nil)
+}
+
+func TestContainer_ReplaceChild(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	w1 := newSimpleWidget(10, 10, nil)
+	w2 := newSimpleWidget(10, 10, nil)
+	w3 := newSimpleWidget(10, 10, nil)
+	c.AddChild(w1)
+	c.AddChild(w2)
+
+	is.True(c.ReplaceChild(w1, w3) != nil)
+	is.Equal(c.Children(), []PreferredSizeLocateableWidget{w3, w2})
+	is.True(w1.GetWidget().Parent() == nil)
+	is.Equal(w3.GetWidget().Parent(), c.GetWidget())
+
+	is.True(c.ReplaceChild(w1, w3) == nil)
+}
+
+func TestContainer_AsyncLayout(t *testing.T) {
+	is := is.New(t)
+
+	placeholderCalls := 0
+	completed := 0
+
+	c := newContainer(t,
+		ContainerOpts.Layout(newRowLayout(t)),
+		ContainerOpts.AsyncLayout(2, func(c *Container, screen *ebiten.Image) {
+			placeholderCalls++
+		}),
+		ContainerOpts.LayoutCompletedHandler(func(args *ContainerLayoutCompletedEventArgs) {
+			completed++
+		}))
+	c.SetLocation(image.Rect(0, 0, 100, 100))
+
+	widgets := []*simpleWidget{}
+	for i := 0; i < 5; i++ {
+		w := newSimpleWidget(10, 10, nil)
+		widgets = append(widgets, w)
+		c.AddChild(w)
+	}
+
+	render(c, t)
+	render(c, t)
+	is.True(c.LayoutPending())
+	is.Equal(placeholderCalls, 2)
+	is.Equal(completed, 0)
+
+	render(c, t)
+	is.True(!c.LayoutPending())
+	is.Equal(placeholderCalls, 2)
+	is.Equal(completed, 1)
+	is.Equal(widgets[4].GetWidget().Rect, image.Rect(40, 0, 50, 10))
+
+	w, h := c.PreferredSize()
+	is.Equal(w, 50)
+	is.Equal(h, 10)
+
+	c.RequestRelayout()
+	render(c, t)
+	is.True(c.LayoutPending())
+	is.Equal(placeholderCalls, 2)
+}
+
+func TestContainer_AsyncLayout_Nested(t *testing.T) {
+	is := is.New(t)
+
+	async := newContainer(t,
+		ContainerOpts.Layout(newRowLayout(t)),
+		ContainerOpts.AsyncLayout(2, nil))
+
+	for i := 0; i < 3; i++ {
+		async.AddChild(newSimpleWidget(10, 10, nil))
+	}
+
+	c := newContainer(t, ContainerOpts.Layout(newRowLayout(t)))
+	c.AddChild(newSimpleWidget(20, 10, nil))
+	c.AddChild(async)
+	c.SetLocation(image.Rect(0, 0, 100, 100))
+
+	render(c, t)
+	render(c, t)
+	is.True(!async.LayoutPending())
+	is.Equal(async.GetWidget().Rect.Dx(), 0)
+
+	render(c, t)
+	is.Equal(async.GetWidget().Rect, image.Rect(20, 0, 50, 10))
+
+	render(c, t)
+	is.Equal(async.Children()[2].GetWidget().Rect, image.Rect(40, 0, 50, 10))
+}
+
+func TestContainer_Render_CacheRendering(t *testing.T) {
+	is := is.New(t)
+
+	clicked := false
+	b := newButton(t,
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(AnchorLayoutData{
+			StretchHorizontal: true,
+			StretchVertical:   true,
+		})),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			clicked = true
+		}))
+
+	c := newContainer(t,
+		ContainerOpts.Layout(NewAnchorLayout()),
+		ContainerOpts.CacheRendering(true))
+	c.AddChild(b)
+	c.SetLocation(image.Rect(0, 0, 50, 50))
+
+	render(c, t)
+	render(c, t)
+
+	s := CurrentStats()
+	render(c, t)
+	is.Equal(CurrentStats().Sub(s).Widgets, 1)
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.LeftMouseButtonJustPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.CursorX, internalinput.CursorY = 10, 10
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.LeftMouseButtonJustPressed = true
+	render(c, t)
+	internalinput.LeftMouseButtonJustPressed = false
+
+	internalinput.LeftMouseButtonPressed = false
+	render(c, t)
+	is.True(clicked)
+
+	render(c, t)
+
+	s = CurrentStats()
+	render(c, t)
+	is.Equal(CurrentStats().Sub(s).Widgets, 1)
+
+	b.GetWidget().InvalidateRender()
+	s = CurrentStats()
+	render(c, t)
+	is.Equal(CurrentStats().Sub(s).Widgets, 2)
+}
+
 func (c *controlMock) GetWidget() *Widget {
 	args := c.Called()
 	return args.Get(0).(*Widget)
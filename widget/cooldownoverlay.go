@@ -0,0 +1,239 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CooldownOverlay decorates another widget, typically a button or hotbar slot, by darkening it while it is
+// on cooldown. The darkened area shrinks as the cooldown progresses, either as a radial sweep or vertically.
+// When the cooldown is over, the widget flashes briefly and ReadyEvent is fired.
+type CooldownOverlay struct {
+	ReadyEvent *event.Event
+
+	widget        PreferredSizeLocateableWidget
+	mode          CooldownOverlayMode
+	color         color.Color
+	flashColor    color.Color
+	flashDuration time.Duration
+
+	clipBuf    *image.ClipRenderBuffer
+	progress   float64
+	start      time.Time
+	duration   time.Duration
+	flash      float64
+	lastUpdate time.Time
+}
+
+type CooldownOverlayOpt func(c *CooldownOverlay)
+
+// CooldownOverlayMode specifies how a CooldownOverlay draws the darkened area.
+type CooldownOverlayMode int
+
+type CooldownOverlayReadyEventArgs struct {
+	CooldownOverlay *CooldownOverlay
+}
+
+type CooldownOverlayReadyHandlerFunc func(args *CooldownOverlayReadyEventArgs)
+
+type CooldownOverlayOptions struct {
+}
+
+const (
+	// CooldownOverlayModeRadial darkens a circle sector that starts at the top and shrinks counterclockwise.
+	CooldownOverlayModeRadial = CooldownOverlayMode(iota)
+
+	// CooldownOverlayModeVertical darkens the top part of the widget, shrinking towards the top.
+	CooldownOverlayModeVertical
+)
+
+var CooldownOverlayOpts CooldownOverlayOptions
+
+// NewCooldownOverlay constructs a new CooldownOverlay that decorates w.
+func NewCooldownOverlay(w PreferredSizeLocateableWidget, opts ...CooldownOverlayOpt) *CooldownOverlay {
+	c := &CooldownOverlay{
+		ReadyEvent: &event.Event{},
+
+		widget:        w,
+		color:         color.NRGBA{0, 0, 0, 160},
+		flashColor:    color.NRGBA{255, 255, 255, 160},
+		flashDuration: 250 * time.Millisecond,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+func (o CooldownOverlayOptions) Mode(m CooldownOverlayMode) CooldownOverlayOpt {
+	return func(c *CooldownOverlay) {
+		c.mode = m
+	}
+}
+
+// Color configures a CooldownOverlay to darken the widget using color col.
+func (o CooldownOverlayOptions) Color(col color.Color) CooldownOverlayOpt {
+	return func(c *CooldownOverlay) {
+		c.color = col
+	}
+}
+
+// Flash configures a CooldownOverlay to flash the widget in color col for d when the cooldown is over.
+// If col is nil or d is 0, the widget does not flash.
+func (o CooldownOverlayOptions) Flash(col color.Color, d time.Duration) CooldownOverlayOpt {
+	return func(c *CooldownOverlay) {
+		c.flashColor = col
+		c.flashDuration = d
+	}
+}
+
+func (o CooldownOverlayOptions) ReadyHandler(f CooldownOverlayReadyHandlerFunc) CooldownOverlayOpt {
+	return func(c *CooldownOverlay) {
+		c.ReadyEvent.AddHandler(func(args interface{}) {
+			f(args.(*CooldownOverlayReadyEventArgs))
+		})
+	}
+}
+
+func (c *CooldownOverlay) GetWidget() *Widget {
+	return c.widget.GetWidget()
+}
+
+func (c *CooldownOverlay) PreferredSize() (int, int) {
+	return c.widget.PreferredSize()
+}
+
+func (c *CooldownOverlay) SetLocation(rect img.Rectangle) {
+	c.widget.SetLocation(rect)
+}
+
+func (c *CooldownOverlay) RequestRelayout() {
+	if r, ok := c.widget.(Relayoutable); ok {
+		r.RequestRelayout()
+	}
+}
+
+func (c *CooldownOverlay) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	if l, ok := c.widget.(input.Layerer); ok {
+		l.SetupInputLayer(def)
+	}
+}
+
+func (c *CooldownOverlay) Focus(focused bool) {
+	if f, ok := c.widget.(Focuser); ok {
+		f.Focus(focused)
+	}
+}
+
+func (c *CooldownOverlay) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	if r, ok := c.widget.(Renderer); ok {
+		r.Render(screen, def)
+	}
+
+	now := Now()
+	if c.duration > 0 {
+		c.SetProgress(1 - float64(now.Sub(c.start))/float64(c.duration))
+	}
+	if !c.lastUpdate.IsZero() {
+		c.advanceFlash(now.Sub(c.lastUpdate).Seconds())
+	}
+	c.lastUpdate = now
+
+	c.draw(screen)
+}
+
+// Start starts a cooldown that lasts for d.
+func (c *CooldownOverlay) Start(d time.Duration) {
+	c.start = Now()
+	c.duration = d
+	c.SetProgress(1)
+}
+
+// SetProgress sets the remaining cooldown to p, in the range [0,1]. A value of 1 darkens the widget completely,
+// a value of 0 means the cooldown is over. When p reaches 0, the widget flashes and ReadyEvent is fired.
+func (c *CooldownOverlay) SetProgress(p float64) {
+	p = math.Max(0, math.Min(1, p))
+
+	prev := c.progress
+	c.progress = p
+
+	if p > 0 || prev <= 0 {
+		return
+	}
+
+	c.duration = 0
+
+	if c.flashColor != nil && animationDuration(c.flashDuration) > 0 {
+		c.flash = 1
+	}
+
+	c.ReadyEvent.Fire(&CooldownOverlayReadyEventArgs{
+		CooldownOverlay: c,
+	})
+}
+
+// Progress returns the remaining cooldown, in the range [0,1].
+func (c *CooldownOverlay) Progress() float64 {
+	return c.progress
+}
+
+// Ready returns whether the cooldown is over.
+func (c *CooldownOverlay) Ready() bool {
+	return c.progress <= 0
+}
+
+func (c *CooldownOverlay) advanceFlash(dt float64) {
+	if c.flash <= 0 {
+		return
+	}
+	d := animationDuration(c.flashDuration)
+	if d <= 0 {
+		c.flash = 0
+		return
+	}
+	c.flash = math.Max(0, c.flash-dt/d.Seconds())
+}
+
+func (c *CooldownOverlay) draw(screen *ebiten.Image) {
+	r := c.widget.GetWidget().Rect
+
+	if c.progress > 0 {
+		switch c.mode {
+		case CooldownOverlayModeVertical:
+			h := int(math.Round(float64(r.Dy()) * c.progress))
+			image.NewNineSliceColor(c.color).Draw(screen, r.Dx(), h, func(opts *ebiten.DrawImageOptions) {
+				opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+			})
+
+		default:
+			if c.clipBuf == nil {
+				c.clipBuf = image.NewClipRenderBuffer()
+			}
+
+			c.clipBuf.Draw(screen, r, func(buf *ebiten.Image) {
+				// draw a circle large enough to cover r's corners, then clip it to r
+				d := int(math.Ceil(math.Hypot(float64(r.Dx()), float64(r.Dy()))))
+				center := r.Min.Add(img.Point{r.Dx() / 2, r.Dy() / 2})
+				cr := img.Rect(center.X-d/2, center.Y-d/2, center.X-d/2+d, center.Y-d/2+d)
+				drawRadialProgress(buf, cr, c.progress, c.color)
+			})
+		}
+	}
+
+	if c.flash > 0 {
+		image.NewNineSliceColor(c.flashColor).Draw(screen, r.Dx(), r.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+			opts.ColorM.Scale(1, 1, 1, c.flash)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package widget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestCooldownOverlay_SetProgress_Ready(t *testing.T) {
+	is := is.New(t)
+
+	numEvents := 0
+
+	c := NewCooldownOverlay(newSimpleWidget(20, 20, nil),
+		CooldownOverlayOpts.ReadyHandler(func(args *CooldownOverlayReadyEventArgs) {
+			numEvents++
+		}))
+	event.ExecuteDeferred()
+
+	is.True(c.Ready())
+
+	c.SetProgress(2)
+	is.Equal(c.Progress(), 1.0)
+	is.True(!c.Ready())
+
+	c.SetProgress(0.5)
+	event.ExecuteDeferred()
+	is.Equal(numEvents, 0)
+
+	c.SetProgress(-1)
+	event.ExecuteDeferred()
+	is.True(c.Ready())
+	is.Equal(numEvents, 1)
+	is.Equal(c.flash, 1.0)
+
+	c.SetProgress(0)
+	event.ExecuteDeferred()
+	is.Equal(numEvents, 1)
+}
+
+func TestCooldownOverlay_AdvanceFlash(t *testing.T) {
+	is := is.New(t)
+
+	c := NewCooldownOverlay(newSimpleWidget(20, 20, nil),
+		CooldownOverlayOpts.Flash(nil, 0))
+
+	c.Start(time.Hour)
+	c.SetProgress(0)
+	is.Equal(c.flash, 0.0)
+
+	c = NewCooldownOverlay(newSimpleWidget(20, 20, nil))
+	c.SetProgress(1)
+	c.SetProgress(0)
+
+	c.advanceFlash(0.125)
+	is.Equal(c.flash, 0.5)
+
+	c.advanceFlash(1)
+	is.Equal(c.flash, 0.0)
+}
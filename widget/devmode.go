@@ -0,0 +1,70 @@
+package widget
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// DevelopmentMode enables additional checks that detect programming errors, such as a widget having layout
+// data that its container's Layouter does not support. Errors cause a panic that reports the offending widget
+// and where it was added to its container, or a widget being added to multiple containers. If DevelopmentMode
+// is disabled, adding a widget that has already been added to a container is ignored.
+//
+// The checks have a runtime cost, so DevelopmentMode should not be enabled in release builds.
+var DevelopmentMode = false
+
+// LayoutDataValidator may be implemented by Layouters to validate Widget.LayoutData of the widgets they layout.
+type LayoutDataValidator interface {
+	// ValidateLayoutData returns an error if ld is not supported. ld is never nil.
+	ValidateLayoutData(ld interface{}) error
+}
+
+// checkAddChild returns whether child may be added to a container, which it may not if it has already been
+// added to a container. If DevelopmentMode is enabled, it panics instead of returning false, reporting where
+// child has been added before, and records where child is being added.
+func checkAddChild(child HasWidget) bool {
+	w := child.GetWidget()
+
+	if !DevelopmentMode {
+		return w.parent == nil
+	}
+
+	if w.parent != nil {
+		panic(fmt.Sprintf("widget %s has already been added to a container at:\n%s", describeWidget(child), w.addedStack))
+	}
+
+	w.addedStack = debug.Stack()
+	return true
+}
+
+// validateLayoutData panics if DevelopmentMode is enabled and the layout data of any of widgets is not supported
+// by layout.
+func validateLayoutData(layout Layouter, widgets []PreferredSizeLocateableWidget) {
+	if !DevelopmentMode {
+		return
+	}
+
+	v, ok := layout.(LayoutDataValidator)
+	if !ok {
+		return
+	}
+
+	for _, wi := range widgets {
+		w := wi.GetWidget()
+		if w.LayoutData == nil {
+			continue
+		}
+
+		if err := v.ValidateLayoutData(w.LayoutData); err != nil {
+			panic(fmt.Sprintf("widget %s has invalid layout data: %v\nwidget was added to its container at:\n%s", describeWidget(wi), err, w.addedStack))
+		}
+	}
+}
+
+// describeWidget returns a description of w for error messages.
+func describeWidget(w HasWidget) string {
+	if id := w.GetWidget().ID; id != "" {
+		return fmt.Sprintf("%q (%T)", id, w)
+	}
+	return fmt.Sprintf("%T", w)
+}
@@ -0,0 +1,76 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDevelopmentMode_MultipleParents(t *testing.T) {
+	is := is.New(t)
+
+	enableDevelopmentMode(t)
+
+	w := newSimpleWidget(10, 10, nil)
+	w.widget.ID = "foo"
+
+	c1 := newContainer(t)
+	c1.AddChild(w)
+
+	c2 := newContainer(t)
+	msg := recoverPanic(func() {
+		c2.AddChild(w)
+	})
+
+	is.True(strings.Contains(msg, `"foo"`))
+	is.True(strings.Contains(msg, "already been added"))
+	is.Equal(len(c2.Children()), 0)
+
+	c1.RemoveChild(w)
+	is.Equal(recoverPanic(func() {
+		c2.AddChild(w)
+	}), "")
+}
+
+func TestDevelopmentMode_LayoutData(t *testing.T) {
+	is := is.New(t)
+
+	enableDevelopmentMode(t)
+
+	c := newContainer(t, ContainerOpts.Layout(NewAnchorLayout()))
+	c.AddChild(newSimpleWidget(10, 10, GridLayoutData{}))
+
+	msg := recoverPanic(func() {
+		render(c, t)
+	})
+
+	is.True(strings.Contains(msg, "AnchorLayout requires AnchorLayoutData"))
+
+	c = newContainer(t, ContainerOpts.Layout(NewAnchorLayout()))
+	c.AddChild(newSimpleWidget(10, 10, AnchorLayoutData{}))
+
+	is.Equal(recoverPanic(func() {
+		render(c, t)
+	}), "")
+}
+
+func enableDevelopmentMode(t *testing.T) {
+	t.Helper()
+
+	DevelopmentMode = true
+	t.Cleanup(func() {
+		DevelopmentMode = false
+	})
+}
+
+func recoverPanic(f func()) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = r.(string)
+		}
+	}()
+
+	f()
+	return
+}
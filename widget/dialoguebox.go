@@ -0,0 +1,380 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// DialogueBox displays a speaker's portrait and name together with multi-page body text, as is common in
+// narrative games. Body text may be revealed progressively, and a "continue" indicator is shown once a page
+// has been revealed completely. Clicking the box or calling Advance skips the reveal, or advances to the
+// next page.
+type DialogueBox struct {
+	PageChangedEvent *event.Event
+	CompletedEvent   *event.Event
+
+	containerOpts    []ContainerOpt
+	portrait         *ebiten.Image
+	name             string
+	nameFace         font.Face
+	nameColor        color.Color
+	namePlateImage   *image.NineSlice
+	namePlatePadding Insets
+	bodyFace         font.Face
+	bodyColor        color.Color
+	bodyMaxWidth     int
+	revealRate       float64
+	indicator        *ebiten.Image
+	spacing          int
+	pages            []string
+
+	init            *MultiOnce
+	container       *Container
+	layout          *GridLayout
+	portraitGraphic *Graphic
+	namePlate       *Container
+	nameText        *Text
+	bodyText        *Text
+	indicatorGraph  *Graphic
+	page            int
+}
+
+type DialogueBoxOpt func(d *DialogueBox)
+
+type DialogueBoxPageChangedEventArgs struct {
+	DialogueBox  *DialogueBox
+	Page         int
+	PreviousPage int
+}
+
+type DialogueBoxCompletedEventArgs struct {
+	DialogueBox *DialogueBox
+}
+
+type DialogueBoxPageChangedHandlerFunc func(args *DialogueBoxPageChangedEventArgs)
+
+type DialogueBoxCompletedHandlerFunc func(args *DialogueBoxCompletedEventArgs)
+
+type DialogueBoxOptions struct {
+}
+
+var DialogueBoxOpts DialogueBoxOptions
+
+func NewDialogueBox(opts ...DialogueBoxOpt) *DialogueBox {
+	d := &DialogueBox{
+		PageChangedEvent: &event.Event{},
+		CompletedEvent:   &event.Event{},
+
+		init: &MultiOnce{},
+	}
+
+	d.init.Append(d.createWidget)
+
+	for _, o := range opts {
+		o(d)
+	}
+
+	return d
+}
+
+func (o DialogueBoxOptions) ContainerOpts(opts ...ContainerOpt) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.containerOpts = append(d.containerOpts, opts...)
+	}
+}
+
+// Portrait configures a DialogueBox to display i next to the body text.
+func (o DialogueBoxOptions) Portrait(i *ebiten.Image) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.portrait = i
+	}
+}
+
+// Name configures a DialogueBox to display the speaker's name on the name plate, using face and color c.
+func (o DialogueBoxOptions) Name(name string, face font.Face, c color.Color) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.name = name
+		d.nameFace = face
+		d.nameColor = c
+	}
+}
+
+// NamePlate configures a DialogueBox to draw i behind the speaker's name, leaving padding around it.
+func (o DialogueBoxOptions) NamePlate(i *image.NineSlice, padding Insets) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.namePlateImage = i
+		d.namePlatePadding = padding
+	}
+}
+
+// Body configures a DialogueBox to draw body text using face and color c. Lines are wrapped so that they do
+// not exceed maxWidth.
+func (o DialogueBoxOptions) Body(face font.Face, c color.Color, maxWidth int) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.bodyFace = face
+		d.bodyColor = c
+		d.bodyMaxWidth = maxWidth
+	}
+}
+
+// Reveal configures a DialogueBox to reveal each page's body text progressively, at a rate of runesPerSecond.
+func (o DialogueBoxOptions) Reveal(runesPerSecond float64) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.revealRate = runesPerSecond
+	}
+}
+
+// ContinueIndicator configures a DialogueBox to display i below the body text once a page has been
+// revealed completely.
+func (o DialogueBoxOptions) ContinueIndicator(i *ebiten.Image) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.indicator = i
+	}
+}
+
+func (o DialogueBoxOptions) Spacing(s int) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.spacing = s
+	}
+}
+
+// Pages configures a DialogueBox to display pages, one after the other.
+func (o DialogueBoxOptions) Pages(pages ...string) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.pages = pages
+	}
+}
+
+func (o DialogueBoxOptions) PageChangedHandler(f DialogueBoxPageChangedHandlerFunc) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.PageChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*DialogueBoxPageChangedEventArgs))
+		})
+	}
+}
+
+// CompletedHandler configures a DialogueBox to call f when advancing past the last page.
+func (o DialogueBoxOptions) CompletedHandler(f DialogueBoxCompletedHandlerFunc) DialogueBoxOpt {
+	return func(d *DialogueBox) {
+		d.CompletedEvent.AddHandler(func(args interface{}) {
+			f(args.(*DialogueBoxCompletedEventArgs))
+		})
+	}
+}
+
+func (d *DialogueBox) GetWidget() *Widget {
+	d.init.Do()
+	return d.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (d *DialogueBox) ContainedWidgets() []HasWidget {
+	d.init.Do()
+	return containedWidgets(d.container)
+}
+
+func (d *DialogueBox) PreferredSize() (int, int) {
+	d.init.Do()
+	return d.container.PreferredSize()
+}
+
+func (d *DialogueBox) SetLocation(rect img.Rectangle) {
+	d.init.Do()
+	d.container.SetLocation(rect)
+}
+
+func (d *DialogueBox) RequestRelayout() {
+	d.init.Do()
+	d.container.RequestRelayout()
+}
+
+func (d *DialogueBox) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	d.init.Do()
+	d.container.SetupInputLayer(def)
+}
+
+func (d *DialogueBox) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	d.init.Do()
+
+	if d.indicatorGraph != nil {
+		if d.bodyText.Revealing() {
+			d.indicatorGraph.GetWidget().SetVisibility(VisibilityHidden)
+		} else {
+			d.indicatorGraph.GetWidget().SetVisibility(VisibilityVisible)
+		}
+	}
+
+	d.container.Render(screen, def)
+}
+
+// SetPortrait sets the portrait to display. If i is nil, no portrait is displayed.
+func (d *DialogueBox) SetPortrait(i *ebiten.Image) {
+	d.init.Do()
+	d.setPortrait(i)
+}
+
+func (d *DialogueBox) setPortrait(i *ebiten.Image) {
+	d.portraitGraphic.Image = i
+
+	// the body column must be stretched regardless of whether the portrait column is present
+	if i != nil {
+		d.portraitGraphic.GetWidget().SetVisibility(VisibilityVisible)
+		d.layout.columns = 2
+		d.layout.columnStretch = []bool{false, true}
+	} else {
+		d.portraitGraphic.GetWidget().SetVisibility(VisibilityCollapsed)
+		d.layout.columns = 1
+		d.layout.columnStretch = []bool{true}
+	}
+
+	d.container.RequestRelayout()
+}
+
+// SetName sets the speaker's name to display. If name is empty, the name plate is not displayed.
+func (d *DialogueBox) SetName(name string) {
+	d.init.Do()
+	d.setName(name)
+}
+
+func (d *DialogueBox) setName(name string) {
+	d.nameText.Label = name
+	if name != "" {
+		d.namePlate.GetWidget().SetVisibility(VisibilityVisible)
+	} else {
+		d.namePlate.GetWidget().SetVisibility(VisibilityCollapsed)
+	}
+
+	d.container.RequestRelayout()
+}
+
+// SetPages replaces the pages to display, and shows the first page.
+func (d *DialogueBox) SetPages(pages ...string) {
+	d.init.Do()
+
+	d.pages = pages
+	d.page = 0
+	d.showPage()
+}
+
+// Page returns the index of the page currently displayed.
+func (d *DialogueBox) Page() int {
+	return d.page
+}
+
+// PageCount returns the number of pages.
+func (d *DialogueBox) PageCount() int {
+	return len(d.pages)
+}
+
+// Advance reveals the current page completely if it is being revealed progressively. Otherwise, it advances
+// to the next page, or fires CompletedEvent if the current page is the last page.
+func (d *DialogueBox) Advance() {
+	d.init.Do()
+
+	if d.bodyText.Revealing() {
+		d.bodyText.SkipReveal()
+		return
+	}
+
+	if d.page >= len(d.pages)-1 {
+		d.CompletedEvent.Fire(&DialogueBoxCompletedEventArgs{
+			DialogueBox: d,
+		})
+		return
+	}
+
+	prev := d.page
+	d.page++
+	d.showPage()
+
+	d.PageChangedEvent.Fire(&DialogueBoxPageChangedEventArgs{
+		DialogueBox:  d,
+		Page:         d.page,
+		PreviousPage: prev,
+	})
+}
+
+func (d *DialogueBox) showPage() {
+	l := ""
+	if d.page < len(d.pages) {
+		l = d.pages[d.page]
+	}
+
+	d.bodyText.Label = l
+	if d.revealRate > 0 {
+		d.bodyText.RestartReveal()
+	}
+
+	d.container.RequestRelayout()
+}
+
+func (d *DialogueBox) createWidget() {
+	d.layout = NewGridLayout(
+		GridLayoutOpts.Columns(2),
+		GridLayoutOpts.Stretch([]bool{false, true}, []bool{true}),
+		GridLayoutOpts.Spacing(d.spacing, 0))
+
+	d.container = NewContainer(append(d.containerOpts, []ContainerOpt{
+		ContainerOpts.WidgetOpts(WidgetOpts.MouseButtonReleasedHandler(func(args *WidgetMouseButtonReleasedEventArgs) {
+			if !args.Widget.Disabled && args.Inside && args.Button == ebiten.MouseButtonLeft {
+				d.Advance()
+			}
+		})),
+		ContainerOpts.Layout(d.layout),
+	}...)...)
+	d.containerOpts = nil
+
+	d.portraitGraphic = NewGraphic(
+		GraphicOpts.WidgetOpts(WidgetOpts.LayoutData(GridLayoutData{
+			VerticalPosition: GridLayoutPositionStart,
+		})))
+	d.container.AddChild(d.portraitGraphic)
+	d.setPortrait(d.portrait)
+	d.portrait = nil
+
+	content := NewContainer(
+		ContainerOpts.Layout(NewRowLayout(
+			RowLayoutOpts.Direction(DirectionVertical),
+			RowLayoutOpts.Spacing(d.spacing))))
+	d.container.AddChild(content)
+
+	d.namePlate = NewContainer(
+		ContainerOpts.BackgroundImage(d.namePlateImage),
+		ContainerOpts.Layout(NewAnchorLayout(AnchorLayoutOpts.Padding(d.namePlatePadding))))
+	content.AddChild(d.namePlate)
+
+	d.nameText = NewText(TextOpts.Text("", d.nameFace, d.nameColor))
+	d.namePlate.AddChild(d.nameText)
+	d.setName(d.name)
+
+	bodyOpts := []TextOpt{
+		TextOpts.Text("", d.bodyFace, d.bodyColor),
+		TextOpts.WordWrap(d.bodyMaxWidth),
+	}
+	if d.revealRate > 0 {
+		bodyOpts = append(bodyOpts, TextOpts.Reveal(d.revealRate))
+	}
+	d.bodyText = NewText(append(bodyOpts, TextOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+		Stretch: true,
+	})))...)
+	content.AddChild(d.bodyText)
+
+	if d.indicator != nil {
+		d.indicatorGraph = NewGraphic(
+			GraphicOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+				Position: RowLayoutPositionEnd,
+			})),
+			GraphicOpts.Image(d.indicator))
+		content.AddChild(d.indicatorGraph)
+		d.indicator = nil
+	}
+
+	d.showPage()
+}
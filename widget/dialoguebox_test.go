@@ -0,0 +1,104 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestDialogueBox_Advance(t *testing.T) {
+	is := is.New(t)
+
+	var pageArgs *DialogueBoxPageChangedEventArgs
+	completed := false
+
+	d := newDialogueBox(t,
+		DialogueBoxOpts.Pages("foo", "bar"),
+		DialogueBoxOpts.PageChangedHandler(func(args *DialogueBoxPageChangedEventArgs) {
+			pageArgs = args
+		}),
+		DialogueBoxOpts.CompletedHandler(func(args *DialogueBoxCompletedEventArgs) {
+			completed = true
+		}))
+
+	is.Equal(d.bodyText.Label, "foo")
+
+	d.Advance()
+	event.ExecuteDeferred()
+
+	is.Equal(d.Page(), 1)
+	is.Equal(d.bodyText.Label, "bar")
+	is.True(pageArgs != nil)
+	is.Equal(pageArgs.PreviousPage, 0)
+	is.True(!completed)
+
+	d.Advance()
+	event.ExecuteDeferred()
+
+	is.Equal(d.Page(), 1)
+	is.True(completed)
+}
+
+func TestDialogueBox_Advance_SkipsReveal(t *testing.T) {
+	is := is.New(t)
+
+	d := newDialogueBox(t,
+		DialogueBoxOpts.Pages("foo bar baz", "qux"),
+		DialogueBoxOpts.Reveal(0.001))
+
+	is.True(d.bodyText.Revealing())
+
+	d.Advance()
+	render(d, t)
+
+	is.Equal(d.Page(), 0)
+	is.True(!d.bodyText.Revealing())
+
+	d.Advance()
+	event.ExecuteDeferred()
+
+	is.Equal(d.Page(), 1)
+	is.True(d.bodyText.Revealing())
+}
+
+func TestDialogueBox_SetPages(t *testing.T) {
+	is := is.New(t)
+
+	d := newDialogueBox(t,
+		DialogueBoxOpts.Pages("foo", "bar"))
+
+	d.Advance()
+	event.ExecuteDeferred()
+
+	d.SetPages("baz")
+
+	is.Equal(d.Page(), 0)
+	is.Equal(d.PageCount(), 1)
+	is.Equal(d.bodyText.Label, "baz")
+}
+
+func TestDialogueBox_SetName_Empty(t *testing.T) {
+	is := is.New(t)
+
+	d := newDialogueBox(t,
+		DialogueBoxOpts.Name("foo", loadFont(t), color.White))
+
+	is.Equal(d.namePlate.GetWidget().Visibility, VisibilityVisible)
+
+	d.SetName("")
+	is.Equal(d.namePlate.GetWidget().Visibility, VisibilityCollapsed)
+}
+
+func newDialogueBox(t *testing.T, opts ...DialogueBoxOpt) *DialogueBox {
+	t.Helper()
+
+	d := NewDialogueBox(append(opts, []DialogueBoxOpt{
+		DialogueBoxOpts.Body(loadFont(t), color.White, 200),
+	}...)...)
+	event.ExecuteDeferred()
+	render(d, t)
+	return d
+}
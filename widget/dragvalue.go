@@ -0,0 +1,236 @@
+package widget
+
+import (
+	"image"
+	"math"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// DragValue is a numeric label whose value can be changed by pressing the mouse button on it and dragging
+// horizontally. Holding Shift while dragging changes the value in smaller steps, holding Control changes it
+// in larger steps.
+type DragValue struct {
+	Value float64
+	Min   float64
+	Max   float64
+
+	ChangedEvent *event.Event
+
+	textOpts      []TextOpt
+	face          font.Face
+	color         *LabelColor
+	step          float64
+	pixelsPerStep float64
+	formatFunc    DragValueFormatFunc
+
+	init         *MultiOnce
+	text         *Text
+	dragging     bool
+	dragLastX    int
+	dragRaw      float64
+	pressedValue float64
+}
+
+type DragValueOpt func(d *DragValue)
+
+// DragValueFormatFunc is a function that returns the label to display for value v.
+type DragValueFormatFunc func(v float64) string
+
+type DragValueChangedEventArgs struct {
+	DragValue *DragValue
+	Value     float64
+
+	// Dragging is true while the value is being dragged, and false when the drag has ended.
+	Dragging bool
+}
+
+type DragValueChangedHandlerFunc func(args *DragValueChangedEventArgs)
+
+type DragValueOptions struct {
+}
+
+// dragValueFineFactor and dragValueCoarseFactor are the factors the step is multiplied with while Shift
+// or Control are held, respectively.
+const (
+	dragValueFineFactor   = 0.1
+	dragValueCoarseFactor = 10
+)
+
+var DragValueOpts DragValueOptions
+
+func NewDragValue(opts ...DragValueOpt) *DragValue {
+	d := &DragValue{
+		Min: math.Inf(-1),
+		Max: math.Inf(1),
+
+		ChangedEvent: &event.Event{},
+
+		step:          1,
+		pixelsPerStep: 4,
+		formatFunc: func(v float64) string {
+			return locale.FormatFloat(v, -1)
+		},
+
+		init: &MultiOnce{},
+	}
+
+	d.init.Append(d.createWidget)
+
+	for _, o := range opts {
+		o(d)
+	}
+
+	return d
+}
+
+func (o DragValueOptions) TextOpts(opts ...TextOpt) DragValueOpt {
+	return func(d *DragValue) {
+		d.textOpts = append(d.textOpts, opts...)
+	}
+}
+
+func (o DragValueOptions) Text(face font.Face, color *LabelColor) DragValueOpt {
+	return func(d *DragValue) {
+		d.face = face
+		d.color = color
+	}
+}
+
+func (o DragValueOptions) Value(v float64) DragValueOpt {
+	return func(d *DragValue) {
+		d.Value = v
+	}
+}
+
+func (o DragValueOptions) MinMax(min float64, max float64) DragValueOpt {
+	return func(d *DragValue) {
+		d.Min = min
+		d.Max = max
+	}
+}
+
+// Step configures a DragValue to change its value by s for every pixelsPerStep pixels the cursor is dragged.
+func (o DragValueOptions) Step(s float64, pixelsPerStep float64) DragValueOpt {
+	return func(d *DragValue) {
+		d.step = s
+		d.pixelsPerStep = pixelsPerStep
+	}
+}
+
+func (o DragValueOptions) FormatFunc(f DragValueFormatFunc) DragValueOpt {
+	return func(d *DragValue) {
+		d.formatFunc = f
+	}
+}
+
+func (o DragValueOptions) ChangedHandler(f DragValueChangedHandlerFunc) DragValueOpt {
+	return func(d *DragValue) {
+		d.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*DragValueChangedEventArgs))
+		})
+	}
+}
+
+func (d *DragValue) GetWidget() *Widget {
+	d.init.Do()
+	return d.text.GetWidget()
+}
+
+func (d *DragValue) SetLocation(rect image.Rectangle) {
+	d.init.Do()
+	d.text.SetLocation(rect)
+}
+
+func (d *DragValue) PreferredSize() (int, int) {
+	d.init.Do()
+	d.text.Label = d.formatFunc(d.Value)
+	return d.text.PreferredSize()
+}
+
+func (d *DragValue) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	d.init.Do()
+
+	if d.dragging {
+		d.updateDrag()
+	}
+
+	d.text.Label = d.formatFunc(d.Value)
+
+	if d.text.GetWidget().Disabled {
+		d.text.Color = d.color.Disabled
+	} else {
+		d.text.Color = d.color.Idle
+	}
+
+	d.text.Render(screen, def)
+}
+
+func (d *DragValue) updateDrag() {
+	if !input.MouseButtonPressed(ebiten.MouseButtonLeft) {
+		d.dragging = false
+
+		if d.Value != d.pressedValue {
+			d.fireChanged(false)
+		}
+		return
+	}
+
+	x, _ := input.CursorPosition()
+	dx := x - d.dragLastX
+	d.dragLastX = x
+	if dx == 0 {
+		return
+	}
+
+	step := d.step
+	switch {
+	case input.KeyPressed(ebiten.KeyShift):
+		step *= dragValueFineFactor
+	case input.KeyPressed(ebiten.KeyControl):
+		step *= dragValueCoarseFactor
+	}
+
+	d.dragRaw += float64(dx) / d.pixelsPerStep * step
+	d.dragRaw = math.Max(d.Min, math.Min(d.Max, d.dragRaw))
+
+	v := math.Round(d.dragRaw/step) * step
+	v = math.Max(d.Min, math.Min(d.Max, v))
+	if v == d.Value {
+		return
+	}
+
+	d.Value = v
+	d.fireChanged(true)
+}
+
+func (d *DragValue) fireChanged(dragging bool) {
+	d.ChangedEvent.Fire(&DragValueChangedEventArgs{
+		DragValue: d,
+		Value:     d.Value,
+		Dragging:  dragging,
+	})
+}
+
+func (d *DragValue) createWidget() {
+	d.text = NewText(append(d.textOpts, []TextOpt{
+		TextOpts.WidgetOpts(
+			WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+				if args.Widget.Disabled || args.Button != ebiten.MouseButtonLeft {
+					return
+				}
+
+				d.dragging = true
+				d.dragLastX = args.Widget.Rect.Min.X + args.OffsetX
+				d.dragRaw = d.Value
+				d.pressedValue = d.Value
+			}),
+		),
+		TextOpts.Text(d.formatFunc(d.Value), d.face, d.color.Idle),
+	}...)...)
+	d.textOpts = nil
+}
@@ -0,0 +1,72 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestDragValue_Drag(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs []DragValueChangedEventArgs
+
+	d := newDragValue(t,
+		DragValueOpts.Value(10),
+		DragValueOpts.MinMax(0, 100),
+		DragValueOpts.ChangedHandler(func(args *DragValueChangedEventArgs) {
+			eventArgs = append(eventArgs, *args)
+		}))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX = 0
+		internalinput.KeyPressed[ebiten.KeyControl] = false
+	}()
+
+	leftMouseButtonPress(d, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX = 20
+	render(d, t)
+
+	is.Equal(d.Value, 15.0)
+	is.Equal(d.text.Label, "15")
+
+	internalinput.KeyPressed[ebiten.KeyControl] = true
+	internalinput.CursorX = 26
+	render(d, t)
+
+	is.Equal(d.Value, 30.0)
+
+	internalinput.CursorX = 1000
+	render(d, t)
+
+	is.Equal(d.Value, 100.0)
+
+	internalinput.LeftMouseButtonPressed = false
+	render(d, t)
+
+	is.Equal(len(eventArgs), 4)
+	is.True(eventArgs[0].Dragging)
+	is.Equal(eventArgs[3].Value, 100.0)
+	is.True(!eventArgs[3].Dragging)
+}
+
+func newDragValue(t *testing.T, opts ...DragValueOpt) *DragValue {
+	t.Helper()
+
+	d := NewDragValue(append(opts, DragValueOpts.Text(loadFont(t), &LabelColor{
+		Idle: color.White,
+	}))...)
+	event.ExecuteDeferred()
+	d.SetLocation(image.Rect(0, 0, 50, 20))
+	render(d, t)
+	return d
+}
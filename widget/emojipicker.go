@@ -0,0 +1,381 @@
+package widget
+
+import (
+	"image"
+	"strings"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// EmojiPicker is a grid of emoji or other glyphs, organized in categories, that can be searched by name.
+// Clicking an emoji inserts it into a bound TextInput at the caret. EmojiPicker is usually shown as the
+// contents of a Window.
+//
+// Emoji are drawn using the face passed to EmojiPickerOptions.EmojiText, which should usually be created using
+// textutil.NewFallbackFace, so that glyphs missing from the text font are drawn using a symbol or emoji font.
+type EmojiPicker struct {
+	// SelectedEvent fires an event with *EmojiPickerSelectedEventArgs when an emoji has been selected.
+	SelectedEvent *event.Event
+
+	containerOpts      []ContainerOpt
+	searchOpts         []TextInputOpt
+	categoryButtonOpts []ButtonOpt
+	emojiButtonOpts    []ButtonOpt
+	categories         []*EmojiCategory
+	categoryFace       font.Face
+	categoryColor      *ButtonTextColor
+	emojiFace          font.Face
+	emojiColor         *ButtonTextColor
+	columns            int
+	spacing            int
+	target             *TextInput
+
+	init      *MultiOnce
+	container *Container
+	search    *TextInput
+	grid      *Container
+	buttons   [][]*Button
+	category  int
+	query     string
+}
+
+// EmojiCategory is a named group of emoji in an EmojiPicker.
+type EmojiCategory struct {
+	Name   string
+	Emojis []*Emoji
+}
+
+// Emoji is an emoji or other glyph in an EmojiPicker.
+type Emoji struct {
+	// Text is the text inserted when the emoji is selected. It may consist of multiple runes.
+	Text string
+
+	// Name is the name of the emoji, used for searching. It may contain additional keywords.
+	Name string
+}
+
+type EmojiPickerOpt func(p *EmojiPicker)
+
+type EmojiPickerSelectedEventArgs struct {
+	EmojiPicker *EmojiPicker
+	Emoji       *Emoji
+}
+
+type EmojiPickerSelectedHandlerFunc func(args *EmojiPickerSelectedEventArgs)
+
+type EmojiPickerOptions struct {
+}
+
+var EmojiPickerOpts EmojiPickerOptions
+
+func NewEmojiPicker(opts ...EmojiPickerOpt) *EmojiPicker {
+	p := &EmojiPicker{
+		SelectedEvent: &event.Event{},
+
+		columns: 8,
+
+		init: &MultiOnce{},
+	}
+
+	p.init.Append(p.createWidget)
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+func (o EmojiPickerOptions) ContainerOpts(opts ...ContainerOpt) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.containerOpts = append(p.containerOpts, opts...)
+	}
+}
+
+// SearchInputOpts configures an EmojiPicker to show a search field, constructed using opts.
+func (o EmojiPickerOptions) SearchInputOpts(opts ...TextInputOpt) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.searchOpts = append(p.searchOpts, opts...)
+	}
+}
+
+// CategoryButtonOpts configures an EmojiPicker to construct the buttons to switch between categories using
+// opts. The buttons are only shown if there is more than one category.
+func (o EmojiPickerOptions) CategoryButtonOpts(opts ...ButtonOpt) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.categoryButtonOpts = append(p.categoryButtonOpts, opts...)
+	}
+}
+
+// CategoryText configures an EmojiPicker to label the category buttons using face and color c.
+func (o EmojiPickerOptions) CategoryText(face font.Face, c *ButtonTextColor) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.categoryFace = face
+		p.categoryColor = c
+	}
+}
+
+// EmojiButtonOpts configures an EmojiPicker to construct the emoji buttons using opts.
+func (o EmojiPickerOptions) EmojiButtonOpts(opts ...ButtonOpt) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.emojiButtonOpts = append(p.emojiButtonOpts, opts...)
+	}
+}
+
+// EmojiText configures an EmojiPicker to draw emoji using face and color c.
+func (o EmojiPickerOptions) EmojiText(face font.Face, c *ButtonTextColor) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.emojiFace = face
+		p.emojiColor = c
+	}
+}
+
+func (o EmojiPickerOptions) Categories(c ...*EmojiCategory) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.categories = c
+	}
+}
+
+// Columns configures an EmojiPicker to show emoji in c columns. The default is 8.
+func (o EmojiPickerOptions) Columns(c int) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.columns = c
+	}
+}
+
+func (o EmojiPickerOptions) Spacing(s int) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.spacing = s
+	}
+}
+
+// Target configures an EmojiPicker to insert selected emoji into t.
+func (o EmojiPickerOptions) Target(t *TextInput) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.target = t
+	}
+}
+
+func (o EmojiPickerOptions) SelectedHandler(f EmojiPickerSelectedHandlerFunc) EmojiPickerOpt {
+	return func(p *EmojiPicker) {
+		p.SelectedEvent.AddHandler(func(args interface{}) {
+			f(args.(*EmojiPickerSelectedEventArgs))
+		})
+	}
+}
+
+func (p *EmojiPicker) GetWidget() *Widget {
+	p.init.Do()
+	return p.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (p *EmojiPicker) ContainedWidgets() []HasWidget {
+	p.init.Do()
+	return containedWidgets(p.container)
+}
+
+func (p *EmojiPicker) PreferredSize() (int, int) {
+	p.init.Do()
+	return p.container.PreferredSize()
+}
+
+func (p *EmojiPicker) SetLocation(rect image.Rectangle) {
+	p.init.Do()
+	p.container.SetLocation(rect)
+}
+
+func (p *EmojiPicker) RequestRelayout() {
+	p.init.Do()
+	p.container.RequestRelayout()
+}
+
+func (p *EmojiPicker) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	p.init.Do()
+	p.container.SetupInputLayer(def)
+}
+
+func (p *EmojiPicker) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	p.init.Do()
+	p.container.Render(screen, def)
+}
+
+// SetTarget sets the TextInput that selected emoji are inserted into. t may be nil.
+func (p *EmojiPicker) SetTarget(t *TextInput) {
+	p.target = t
+}
+
+// Target returns the TextInput that selected emoji are inserted into.
+func (p *EmojiPicker) Target() *TextInput {
+	return p.target
+}
+
+// SetCategory shows the emoji of category i.
+func (p *EmojiPicker) SetCategory(i int) {
+	p.init.Do()
+
+	if i < 0 || i >= len(p.categories) || i == p.category {
+		return
+	}
+
+	p.category = i
+	p.refresh()
+}
+
+// Category returns the index of the category currently shown.
+func (p *EmojiPicker) Category() int {
+	return p.category
+}
+
+// SetQuery shows the emoji of all categories whose names contain q, ignoring case. If q is empty, the emoji
+// of the current category are shown.
+func (p *EmojiPicker) SetQuery(q string) {
+	p.init.Do()
+
+	if q == p.query {
+		return
+	}
+
+	p.query = q
+	p.refresh()
+}
+
+// Query returns the current search query.
+func (p *EmojiPicker) Query() string {
+	return p.query
+}
+
+// VisibleEmojis returns the emoji currently shown.
+func (p *EmojiPicker) VisibleEmojis() []*Emoji {
+	p.init.Do()
+
+	emojis := []*Emoji{}
+	p.visible(func(e *Emoji, b *Button) {
+		emojis = append(emojis, e)
+	})
+	return emojis
+}
+
+// Select inserts e into the target TextInput, and fires SelectedEvent.
+func (p *EmojiPicker) Select(e *Emoji) {
+	if p.target != nil {
+		p.target.Insert(e.Text)
+	}
+
+	p.SelectedEvent.Fire(&EmojiPickerSelectedEventArgs{
+		EmojiPicker: p,
+		Emoji:       e,
+	})
+}
+
+// visible calls f for each emoji currently shown, and its button.
+func (p *EmojiPicker) visible(f func(e *Emoji, b *Button)) {
+	if p.query == "" {
+		if len(p.categories) == 0 {
+			return
+		}
+
+		for i, e := range p.categories[p.category].Emojis {
+			f(e, p.buttons[p.category][i])
+		}
+
+		return
+	}
+
+	q := strings.ToLower(p.query)
+	seen := map[string]bool{}
+
+	for ci, c := range p.categories {
+		for i, e := range c.Emojis {
+			if seen[e.Text] || !strings.Contains(strings.ToLower(e.Name), q) {
+				continue
+			}
+
+			seen[e.Text] = true
+			f(e, p.buttons[ci][i])
+		}
+	}
+}
+
+// refresh replaces the buttons in the grid with the buttons of the emoji currently shown.
+func (p *EmojiPicker) refresh() {
+	p.grid.RemoveAllChildren()
+
+	p.visible(func(e *Emoji, b *Button) {
+		p.grid.AddChild(b)
+	})
+}
+
+func (p *EmojiPicker) createWidget() {
+	p.container = NewContainer(append(p.containerOpts, ContainerOpts.Layout(NewRowLayout(
+		RowLayoutOpts.Direction(DirectionVertical),
+		RowLayoutOpts.Spacing(p.spacing))))...)
+	p.containerOpts = nil
+
+	if len(p.searchOpts) > 0 {
+		p.search = NewTextInput(append(p.searchOpts, TextInputOpts.ChangedHandler(func(args *TextInputChangedEventArgs) {
+			p.SetQuery(args.InputText)
+		}))...)
+		p.searchOpts = nil
+
+		p.search.GetWidget().LayoutData = RowLayoutData{
+			Stretch: true,
+		}
+
+		p.container.AddChild(p.search)
+	}
+
+	if len(p.categories) > 1 {
+		row := NewContainer(ContainerOpts.Layout(NewRowLayout(
+			RowLayoutOpts.Spacing(p.spacing))))
+
+		for i, c := range p.categories {
+			i := i
+
+			opts := append([]ButtonOpt(nil), p.categoryButtonOpts...)
+			row.AddChild(NewButton(append(opts, []ButtonOpt{
+				ButtonOpts.Text(c.Name, p.categoryFace, p.categoryColor),
+				ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+					if p.search != nil {
+						p.search.Clear()
+					}
+					p.query = ""
+					p.category = i
+					p.refresh()
+				}),
+			}...)...))
+		}
+
+		p.container.AddChild(row)
+	}
+	p.categoryButtonOpts = nil
+
+	p.grid = NewContainer(ContainerOpts.Layout(NewGridLayout(
+		GridLayoutOpts.Columns(p.columns),
+		GridLayoutOpts.Spacing(p.spacing, p.spacing))))
+	p.container.AddChild(p.grid)
+
+	p.buttons = make([][]*Button, len(p.categories))
+	for ci, c := range p.categories {
+		p.buttons[ci] = make([]*Button, len(c.Emojis))
+
+		for i, e := range c.Emojis {
+			e := e
+
+			opts := append([]ButtonOpt(nil), p.emojiButtonOpts...)
+			p.buttons[ci][i] = NewButton(append(opts, []ButtonOpt{
+				ButtonOpts.Text(e.Text, p.emojiFace, p.emojiColor),
+				ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+					p.Select(e)
+				}),
+			}...)...)
+		}
+	}
+	p.emojiButtonOpts = nil
+
+	p.refresh()
+}
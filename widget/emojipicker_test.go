@@ -0,0 +1,71 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestEmojiPicker_Categories(t *testing.T) {
+	is := is.New(t)
+
+	smile := &Emoji{Text: "☺", Name: "smiling face"}
+	frown := &Emoji{Text: "☹", Name: "frowning face"}
+	star := &Emoji{Text: "★", Name: "black star"}
+
+	p := newEmojiPicker(t,
+		EmojiPickerOpts.Categories(
+			&EmojiCategory{Name: "Faces", Emojis: []*Emoji{smile, frown}},
+			&EmojiCategory{Name: "Symbols", Emojis: []*Emoji{star}}))
+
+	is.Equal(p.VisibleEmojis(), []*Emoji{smile, frown})
+
+	p.SetCategory(1)
+	is.Equal(p.VisibleEmojis(), []*Emoji{star})
+
+	p.SetQuery("FACE")
+	is.Equal(p.VisibleEmojis(), []*Emoji{smile, frown})
+
+	p.SetQuery("")
+	is.Equal(p.VisibleEmojis(), []*Emoji{star})
+}
+
+func TestEmojiPicker_Select(t *testing.T) {
+	is := is.New(t)
+
+	smile := &Emoji{Text: "☺", Name: "smiling face"}
+
+	ti := newTextInput(t)
+	ti.InputText = "ab"
+	ti.SetSelection(1, 1)
+
+	var selected *Emoji
+	p := newEmojiPicker(t,
+		EmojiPickerOpts.Categories(&EmojiCategory{Name: "Faces", Emojis: []*Emoji{smile}}),
+		EmojiPickerOpts.Target(ti),
+		EmojiPickerOpts.SelectedHandler(func(args *EmojiPickerSelectedEventArgs) {
+			selected = args.Emoji
+		}))
+
+	leftMouseButtonClick(p.grid.Children()[0], t)
+
+	is.Equal(ti.InputText, "a☺b")
+	is.Equal(selected, smile)
+}
+
+func newEmojiPicker(t *testing.T, opts ...EmojiPickerOpt) *EmojiPicker {
+	t.Helper()
+
+	p := NewEmojiPicker(append(opts, []EmojiPickerOpt{
+		EmojiPickerOpts.CategoryText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+		EmojiPickerOpts.CategoryButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})),
+		EmojiPickerOpts.EmojiText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+		EmojiPickerOpts.EmojiButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})),
+	}...)...)
+	event.ExecuteDeferred()
+	render(p, t)
+	return p
+}
@@ -62,6 +62,12 @@ func (f *FlipBook) GetWidget() *Widget {
 	return f.container.GetWidget()
 }
 
+// ContainedWidgets implements Containerer.
+func (f *FlipBook) ContainedWidgets() []HasWidget {
+	f.init.Do()
+	return containedWidgets(f.container)
+}
+
 // PreferredSize implements PreferredSizer.
 func (f *FlipBook) PreferredSize() (int, int) {
 	f.init.Do()
@@ -80,6 +86,12 @@ func (f *FlipBook) RequestRelayout() {
 	f.container.RequestRelayout()
 }
 
+// FocusOrder implements FocusContainer.
+func (f *FlipBook) FocusOrder() []HasWidget {
+	f.init.Do()
+	return f.container.FocusOrder()
+}
+
 // SetupInputLayer implements InputLayerer.
 func (f *FlipBook) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 	f.init.Do()
@@ -117,6 +129,7 @@ func (f *FlipBook) createWidget() {
 }
 
 // SetPage sets the current page to be rendered to page. The previous page will no longer be rendered.
+// If page is nil, no page will be rendered.
 //
 // Note that when switching to a new page, it may be necessary to re-layout parent containers if the pages
 // are of different sizes.
@@ -125,7 +138,10 @@ func (f *FlipBook) SetPage(page PreferredSizeLocateableWidget) {
 
 	if f.removeCurrent != nil {
 		f.removeCurrent()
+		f.removeCurrent = nil
 	}
 
-	f.removeCurrent = f.container.AddChild(page)
+	if page != nil {
+		f.removeCurrent = f.container.AddChild(page)
+	}
 }
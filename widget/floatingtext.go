@@ -0,0 +1,238 @@
+package widget
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// FloatingTextLayer renders short-lived text and icons at screen positions, such as damage numbers. Each
+// floating text rises and fades out over its lifetime, and is then removed. Floating texts are pooled and
+// reused to avoid allocations during gameplay.
+type FloatingTextLayer struct {
+	face     font.Face
+	color    color.Color
+	duration time.Duration
+	rise     float64
+	spacing  int
+
+	active     []*floatingTextInstance
+	pool       []*floatingTextInstance
+	lastUpdate time.Time
+}
+
+type FloatingTextLayerOpt func(f *FloatingTextLayer)
+
+// FloatingText describes a floating text to spawn using FloatingTextLayer.Spawn.
+type FloatingText struct {
+	// Label is the text to draw. It may be empty if Icon is set.
+	Label string
+
+	// Icon is drawn to the left of Label, centered vertically. It may be nil.
+	Icon *ebiten.Image
+
+	// X is the horizontal center of the floating text, Y is its baseline when spawned.
+	X int
+	Y int
+
+	// Face and Color override the layer's default font face and color if set.
+	Face  font.Face
+	Color color.Color
+
+	// Duration and Rise override the layer's default lifetime and rise distance if set.
+	Duration time.Duration
+	Rise     float64
+}
+
+type FloatingTextLayerOptions struct {
+}
+
+type floatingTextInstance struct {
+	text FloatingText
+	age  float64
+}
+
+var FloatingTextLayerOpts FloatingTextLayerOptions
+
+func NewFloatingTextLayer(opts ...FloatingTextLayerOpt) *FloatingTextLayer {
+	f := &FloatingTextLayer{
+		color:    color.White,
+		duration: time.Second,
+		rise:     30,
+		spacing:  4,
+	}
+
+	for _, o := range opts {
+		o(f)
+	}
+
+	return f
+}
+
+// Text configures a FloatingTextLayer to draw text using face and color c by default.
+func (o FloatingTextLayerOptions) Text(face font.Face, c color.Color) FloatingTextLayerOpt {
+	return func(f *FloatingTextLayer) {
+		f.face = face
+		f.color = c
+	}
+}
+
+// Duration configures a FloatingTextLayer to remove floating texts after d by default.
+func (o FloatingTextLayerOptions) Duration(d time.Duration) FloatingTextLayerOpt {
+	return func(f *FloatingTextLayer) {
+		f.duration = d
+	}
+}
+
+// Rise configures a FloatingTextLayer to move floating texts up by r pixels over their lifetime by default.
+func (o FloatingTextLayerOptions) Rise(r float64) FloatingTextLayerOpt {
+	return func(f *FloatingTextLayer) {
+		f.rise = r
+	}
+}
+
+// IconSpacing configures a FloatingTextLayer to separate icons from text by s pixels.
+func (o FloatingTextLayerOptions) IconSpacing(s int) FloatingTextLayerOpt {
+	return func(f *FloatingTextLayer) {
+		f.spacing = s
+	}
+}
+
+// Spawn spawns a new floating text as described by t.
+func (f *FloatingTextLayer) Spawn(t FloatingText) {
+	if t.Face == nil {
+		t.Face = f.face
+	}
+	if t.Color == nil {
+		t.Color = f.color
+	}
+	if t.Duration <= 0 {
+		t.Duration = f.duration
+	}
+	if t.Rise == 0 {
+		t.Rise = f.rise
+	}
+
+	var i *floatingTextInstance
+	if l := len(f.pool); l > 0 {
+		i = f.pool[l-1]
+		f.pool = f.pool[:l-1]
+	} else {
+		i = &floatingTextInstance{}
+	}
+
+	i.text = t
+	i.age = 0
+
+	f.active = append(f.active, i)
+}
+
+// Clear removes all floating texts.
+func (f *FloatingTextLayer) Clear() {
+	f.pool = append(f.pool, f.active...)
+	f.active = f.active[:0]
+}
+
+// Len returns the number of floating texts currently displayed.
+func (f *FloatingTextLayer) Len() int {
+	return len(f.active)
+}
+
+func (f *FloatingTextLayer) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	now := Now()
+	if !f.lastUpdate.IsZero() {
+		f.advance(now.Sub(f.lastUpdate).Seconds())
+	}
+	f.lastUpdate = now
+
+	for _, i := range f.active {
+		f.draw(screen, i)
+	}
+}
+
+// advance ages all floating texts according to dt seconds having passed, and returns expired ones to the pool.
+func (f *FloatingTextLayer) advance(dt float64) {
+	active := f.active[:0]
+	for _, i := range f.active {
+		i.age += dt
+
+		if i.age >= i.text.Duration.Seconds() {
+			f.pool = append(f.pool, i)
+			continue
+		}
+
+		active = append(active, i)
+	}
+
+	// clear references to pooled instances
+	for j := len(active); j < len(f.active); j++ {
+		f.active[j] = nil
+	}
+
+	f.active = active
+}
+
+// offsetAndAlpha returns the vertical offset and alpha of i according to its age. Floating texts rise
+// decelerating, and fade out during the second half of their lifetime.
+func (i *floatingTextInstance) offsetAndAlpha() (float64, float64) {
+	p := math.Min(1, i.age/i.text.Duration.Seconds())
+
+	offset := 0.0
+	if !motionDisabled() {
+		offset = -i.text.Rise * (1 - (1-p)*(1-p))
+	}
+
+	alpha := 1.0
+	if p > 0.5 {
+		alpha = 2 * (1 - p)
+	}
+
+	return offset, alpha
+}
+
+func (f *FloatingTextLayer) draw(screen *ebiten.Image, i *floatingTextInstance) {
+	t := &i.text
+	offset, alpha := i.offsetAndAlpha()
+
+	w := 0
+	if t.Label != "" {
+		w = textutil.Advance(t.Label, t.Face)
+	}
+
+	iw, ih := 0, 0
+	if t.Icon != nil {
+		iw, ih = t.Icon.Size()
+		w += iw
+		if t.Label != "" {
+			w += f.spacing
+		}
+	}
+
+	x := float64(t.X) - float64(w)/2
+	y := float64(t.Y) + offset
+
+	if t.Icon != nil {
+		iy := y - float64(ih)/2
+		if t.Label != "" {
+			m := t.Face.Metrics()
+			iy = y - textutil.Fixed26_6ToFloat64(m.Ascent)/2 - float64(ih)/2
+		}
+
+		opts := ebiten.DrawImageOptions{}
+		opts.GeoM.Translate(math.Round(x), math.Round(iy))
+		opts.ColorM.Scale(1, 1, 1, alpha)
+		screen.DrawImage(t.Icon, &opts)
+
+		x += float64(iw + f.spacing)
+	}
+
+	if t.Label != "" {
+		text.Draw(screen, t.Label, t.Face, int(math.Round(x)), int(math.Round(y)), colorWithAlpha(t.Color, alpha))
+	}
+}
@@ -0,0 +1,51 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFloatingTextLayer_Advance(t *testing.T) {
+	is := is.New(t)
+
+	f := NewFloatingTextLayer(
+		FloatingTextLayerOpts.Text(loadFont(t), color.White),
+		FloatingTextLayerOpts.Duration(time.Second),
+		FloatingTextLayerOpts.Rise(40))
+
+	f.Spawn(FloatingText{Label: "123", X: 10, Y: 20})
+	f.Spawn(FloatingText{Label: "456", Duration: 2 * time.Second})
+	is.Equal(f.Len(), 2)
+
+	f.advance(0.5)
+	offset, alpha := f.active[0].offsetAndAlpha()
+	is.Equal(offset, -30.0)
+	is.Equal(alpha, 1.0)
+
+	f.advance(0.5)
+	is.Equal(f.Len(), 1)
+	is.Equal(f.active[0].text.Label, "456")
+	is.Equal(len(f.pool), 1)
+}
+
+func TestFloatingTextLayer_Spawn_ReusesPooled(t *testing.T) {
+	is := is.New(t)
+
+	f := NewFloatingTextLayer(
+		FloatingTextLayerOpts.Text(loadFont(t), color.White))
+
+	f.Spawn(FloatingText{Label: "foo"})
+	i := f.active[0]
+
+	f.Clear()
+	is.Equal(f.Len(), 0)
+
+	f.Spawn(FloatingText{Label: "bar"})
+	is.Equal(f.active[0], i)
+	is.Equal(i.text.Label, "bar")
+	is.Equal(i.age, 0.0)
+	is.Equal(len(f.pool), 0)
+}
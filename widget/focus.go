@@ -0,0 +1,180 @@
+package widget
+
+import (
+	img "image"
+	"math"
+	"sort"
+)
+
+// FocusContainer may be implemented by widgets that contain other widgets that can be focused.
+type FocusContainer interface {
+	// FocusOrder returns the focusable widgets contained in the widget, in focus traversal order. All
+	// returned widgets implement Focuser.
+	FocusOrder() []HasWidget
+}
+
+// NavDirection specifies a direction to move focus in when navigating, for example using a gamepad.
+type NavDirection int
+
+const (
+	NavDirectionUp = NavDirection(iota)
+	NavDirectionDown
+	NavDirectionLeft
+	NavDirectionRight
+)
+
+// NavConsumer may be implemented by focusable widgets that handle directional input themselves while focused,
+// for example to move a caret using the arrow keys. The UI does not move focus away from the widget in directions
+// that it consumes.
+type NavConsumer interface {
+	// ConsumesNav returns whether the widget handles navigation in direction d itself.
+	ConsumesNav(d NavDirection) bool
+}
+
+// navOrthogonalWeight is the factor that distances orthogonal to the navigation direction are weighted with,
+// so that widgets in line with the current widget are preferred over closer widgets that are off to the side.
+const navOrthogonalWeight = 2
+
+// FocusOrder implements FocusContainer.
+func (c *Container) FocusOrder() []HasWidget {
+	c.init.Do()
+	return appendFocusOrder(nil, c.tabOrderChildren())
+}
+
+// tabOrderChildren returns c's children in focus traversal order: children in c.tabOrder first, then the
+// remaining children sorted by Widget.TabOrder.
+func (c *Container) tabOrderChildren() []HasWidget {
+	children := make([]HasWidget, 0, len(c.children))
+
+	explicit := map[HasWidget]bool{}
+	for _, w := range c.tabOrder {
+		if c.childIndexOf(w) >= 0 {
+			children = append(children, w)
+			explicit[w] = true
+		}
+	}
+
+	rest := make([]HasWidget, 0, len(c.children))
+	for _, ch := range c.children {
+		if !explicit[ch] {
+			rest = append(rest, ch)
+		}
+	}
+
+	sort.SliceStable(rest, func(i int, j int) bool {
+		return rest[i].GetWidget().TabOrder < rest[j].GetWidget().TabOrder
+	})
+
+	return append(children, rest...)
+}
+
+// appendFocusOrder appends the focusable widgets of widgets and their descendants to order, and returns
+// the result.
+func appendFocusOrder(order []HasWidget, widgets []HasWidget) []HasWidget {
+	for _, w := range widgets {
+		if !focusTraversable(w.GetWidget()) {
+			continue
+		}
+
+		if _, ok := w.(Focuser); ok {
+			order = append(order, w)
+			continue
+		}
+
+		if fc, ok := w.(FocusContainer); ok {
+			order = append(order, fc.FocusOrder()...)
+		}
+	}
+
+	return order
+}
+
+func focusTraversable(w *Widget) bool {
+	return !w.SkipFocus && !w.Disabled && w.Visible()
+}
+
+// childIndexOf returns the index of child in c's children, or -1 if child is not a child of c.
+func (c *Container) childIndexOf(child HasWidget) int {
+	for i, ch := range c.children {
+		if ch == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// NavTarget returns the widget in order to move focus to when navigating from widget from in direction d, or nil
+// if there is none. If from's widget declares a neighbor for d using Widget.NavUp etc. and that neighbor is in order,
+// it is returned. Otherwise, the widget closest in direction d is returned. If from is nil, the first widget in
+// order is returned.
+func NavTarget(order []HasWidget, from HasWidget, d NavDirection) HasWidget {
+	if len(order) == 0 {
+		return nil
+	}
+
+	if from == nil {
+		return order[0]
+	}
+
+	if n := from.GetWidget().nav(d); n != nil {
+		for _, w := range order {
+			if w == n {
+				return w
+			}
+		}
+	}
+
+	fr := from.GetWidget().Rect
+	fc := img.Point{(fr.Min.X + fr.Max.X) / 2, (fr.Min.Y + fr.Max.Y) / 2}
+
+	var best HasWidget
+	bestScore := math.MaxFloat64
+
+	for _, w := range order {
+		if w == from {
+			continue
+		}
+
+		r := w.GetWidget().Rect
+		c := img.Point{(r.Min.X + r.Max.X) / 2, (r.Min.Y + r.Max.Y) / 2}
+
+		primary, orthogonal := navDistances(c.Sub(fc), d)
+		if primary <= 0 {
+			continue
+		}
+
+		score := float64(primary) + navOrthogonalWeight*math.Abs(float64(orthogonal))
+		if score < bestScore {
+			best, bestScore = w, score
+		}
+	}
+
+	return best
+}
+
+// navDistances returns the distance of p in direction d, and the distance of p orthogonal to d.
+func navDistances(p img.Point, d NavDirection) (int, int) {
+	switch d {
+	case NavDirectionUp:
+		return -p.Y, p.X
+	case NavDirectionDown:
+		return p.Y, p.X
+	case NavDirectionLeft:
+		return -p.X, p.Y
+	default:
+		return p.X, p.Y
+	}
+}
+
+func (w *Widget) nav(d NavDirection) HasWidget {
+	switch d {
+	case NavDirectionUp:
+		return w.NavUp
+	case NavDirectionDown:
+		return w.NavDown
+	case NavDirectionLeft:
+		return w.NavLeft
+	default:
+		return w.NavRight
+	}
+}
@@ -0,0 +1,119 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type focusableWidget struct {
+	*simpleWidget
+	focused bool
+}
+
+func TestContainer_FocusOrder(t *testing.T) {
+	is := is.New(t)
+
+	w1 := newFocusableWidget(WidgetOpts.TabOrder(1))
+	w2 := newFocusableWidget()
+	w3 := newFocusableWidget(WidgetOpts.SkipFocus())
+	w4 := newFocusableWidget()
+
+	inner := newContainer(t)
+	inner.AddChild(w4)
+
+	c := newContainer(t)
+	c.AddChild(w1)
+	c.AddChild(w2)
+	c.AddChild(newSimpleWidget(10, 10, nil))
+	c.AddChild(w3)
+	c.AddChild(inner)
+
+	is.Equal(c.FocusOrder(), []HasWidget{w2, w4, w1})
+}
+
+func TestContainer_FocusOrder_Explicit(t *testing.T) {
+	is := is.New(t)
+
+	w1 := newFocusableWidget()
+	w2 := newFocusableWidget()
+	w3 := newFocusableWidget()
+	w4 := newFocusableWidget()
+
+	c := newContainer(t, ContainerOpts.TabOrder(w3, w1, w4))
+	c.AddChild(w1)
+	c.AddChild(w2)
+	c.AddChild(w3)
+
+	is.Equal(c.FocusOrder(), []HasWidget{w3, w1, w2})
+}
+
+func TestContainer_FocusOrder_SkipsHiddenAndDisabled(t *testing.T) {
+	is := is.New(t)
+
+	w1 := newFocusableWidget(WidgetOpts.Visibility(VisibilityHidden))
+	w2 := newFocusableWidget()
+	w3 := newFocusableWidget()
+	w3.GetWidget().Disabled = true
+
+	c := newContainer(t)
+	c.AddChild(w1)
+	c.AddChild(w2)
+	c.AddChild(w3)
+
+	is.Equal(c.FocusOrder(), []HasWidget{w2})
+}
+
+func newFocusableWidget(opts ...WidgetOpt) *focusableWidget {
+	return &focusableWidget{
+		simpleWidget: &simpleWidget{
+			widget:          NewWidget(opts...),
+			preferredWidth:  10,
+			preferredHeight: 10,
+		},
+	}
+}
+
+func (f *focusableWidget) Focus(focused bool) {
+	f.focused = focused
+}
+
+func TestNavTarget(t *testing.T) {
+	is := is.New(t)
+
+	// a   b
+	//   c
+	a := newFocusableWidget()
+	a.GetWidget().Rect = image.Rect(0, 0, 10, 10)
+	b := newFocusableWidget()
+	b.GetWidget().Rect = image.Rect(50, 0, 60, 10)
+	c := newFocusableWidget()
+	c.GetWidget().Rect = image.Rect(20, 30, 30, 40)
+
+	order := []HasWidget{a, b, c}
+
+	is.Equal(NavTarget(order, nil, NavDirectionDown), a)
+	is.Equal(NavTarget(order, a, NavDirectionRight), b)
+	is.Equal(NavTarget(order, a, NavDirectionDown), c)
+	is.Equal(NavTarget(order, c, NavDirectionUp), a)
+	is.Equal(NavTarget(order, a, NavDirectionUp), nil)
+}
+
+func TestNavTarget_Override(t *testing.T) {
+	is := is.New(t)
+
+	a := newFocusableWidget()
+	a.GetWidget().Rect = image.Rect(0, 0, 10, 10)
+	b := newFocusableWidget()
+	b.GetWidget().Rect = image.Rect(20, 0, 30, 10)
+	c := newFocusableWidget()
+	c.GetWidget().Rect = image.Rect(100, 100, 110, 110)
+
+	a.GetWidget().NavRight = c
+
+	is.Equal(NavTarget([]HasWidget{a, b, c}, a, NavDirectionRight), c)
+
+	// override is ignored if the target is not focusable
+	is.Equal(NavTarget([]HasWidget{a, b}, a, NavDirectionRight), b)
+}
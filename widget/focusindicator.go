@@ -0,0 +1,213 @@
+package widget
+
+import (
+	img "image"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// FocusIndicator highlights the focused widget, for example when navigating using a keyboard or gamepad.
+// It is drawn as a nine-slice frame around the widget, an arrow sprite next to it, or both, and may pulse.
+// When focus moves to another widget, the FocusIndicator moves to the new widget smoothly.
+//
+// FocusIndicator is usually set as UI.FocusIndicator, which keeps its target up to date with the focused widget.
+type FocusIndicator struct {
+	image         *image.NineSlice
+	arrow         *ebiten.Image
+	arrowOffset   img.Point
+	outset        Insets
+	moveDuration  time.Duration
+	pulsePeriod   time.Duration
+	pulseMinAlpha float64
+	behind        bool
+
+	target    HasWidget
+	from      img.Rectangle
+	to        img.Rectangle
+	moveStart time.Time
+	pulsed    time.Time
+}
+
+type FocusIndicatorOpt func(f *FocusIndicator)
+
+type FocusIndicatorOptions struct {
+}
+
+var FocusIndicatorOpts FocusIndicatorOptions
+
+func NewFocusIndicator(opts ...FocusIndicatorOpt) *FocusIndicator {
+	f := &FocusIndicator{
+		moveDuration: 120 * time.Millisecond,
+	}
+
+	for _, o := range opts {
+		o(f)
+	}
+
+	return f
+}
+
+// Image configures a FocusIndicator to draw i around the focused widget.
+func (o FocusIndicatorOptions) Image(i *image.NineSlice) FocusIndicatorOpt {
+	return func(f *FocusIndicator) {
+		f.image = i
+	}
+}
+
+// Arrow configures a FocusIndicator to draw i left of the focused widget, vertically centered and moved by off.
+func (o FocusIndicatorOptions) Arrow(i *ebiten.Image, off img.Point) FocusIndicatorOpt {
+	return func(f *FocusIndicator) {
+		f.arrow = i
+		f.arrowOffset = off
+	}
+}
+
+// Outset configures a FocusIndicator to extend the focused widget's rectangle by i when drawing its image.
+func (o FocusIndicatorOptions) Outset(i Insets) FocusIndicatorOpt {
+	return func(f *FocusIndicator) {
+		f.outset = i
+	}
+}
+
+// MoveDuration configures a FocusIndicator to take d to move to a newly focused widget. If d is 0, it
+// moves immediately. The default is 120ms.
+func (o FocusIndicatorOptions) MoveDuration(d time.Duration) FocusIndicatorOpt {
+	return func(f *FocusIndicator) {
+		f.moveDuration = d
+	}
+}
+
+// Pulse configures a FocusIndicator to pulse its alpha between 1 and minAlpha, with a period of d.
+func (o FocusIndicatorOptions) Pulse(d time.Duration, minAlpha float64) FocusIndicatorOpt {
+	return func(f *FocusIndicator) {
+		f.pulsePeriod = d
+		f.pulseMinAlpha = minAlpha
+	}
+}
+
+// Behind configures a FocusIndicator to be drawn behind the focused widget instead of on top of it.
+func (o FocusIndicatorOptions) Behind() FocusIndicatorOpt {
+	return func(f *FocusIndicator) {
+		f.behind = true
+	}
+}
+
+// SetTarget sets the widget to highlight. If w is nil, nothing is highlighted.
+func (f *FocusIndicator) SetTarget(w HasWidget) {
+	if w == f.target {
+		return
+	}
+
+	now := Now()
+
+	moving := f.target != nil && w != nil && animationDuration(f.moveDuration) > 0
+	if moving {
+		f.from = f.rect(now)
+	}
+
+	if f.target != nil {
+		f.target.GetWidget().focusIndicator = nil
+	}
+
+	f.target = w
+	f.pulsed = now
+
+	if w == nil {
+		return
+	}
+
+	f.to = f.outsetRect(w.GetWidget().Rect)
+	if moving {
+		f.moveStart = now
+	} else {
+		f.from = f.to
+		f.moveStart = time.Time{}
+	}
+
+	if f.behind {
+		w.GetWidget().focusIndicator = f
+	}
+}
+
+// Target returns the widget that is currently highlighted.
+func (f *FocusIndicator) Target() HasWidget {
+	return f.target
+}
+
+// Render implements Renderer. If f is drawn behind the focused widget, it does nothing.
+func (f *FocusIndicator) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	if !f.behind {
+		f.draw(screen)
+	}
+}
+
+func (f *FocusIndicator) draw(screen *ebiten.Image) {
+	if f.target == nil || !f.target.GetWidget().Visible() {
+		return
+	}
+
+	now := Now()
+	f.to = f.outsetRect(f.target.GetWidget().Rect)
+	rect := f.rect(now)
+	alpha := f.alpha(now)
+
+	if f.image != nil {
+		f.image.Draw(screen, rect.Dx(), rect.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+			opts.ColorM.Scale(1, 1, 1, alpha)
+		})
+	}
+
+	if f.arrow != nil {
+		w, h := f.arrow.Size()
+		opts := ebiten.DrawImageOptions{}
+		opts.GeoM.Translate(float64(rect.Min.X-w+f.arrowOffset.X), float64(rect.Min.Y+(rect.Dy()-h)/2+f.arrowOffset.Y))
+		opts.ColorM.Scale(1, 1, 1, alpha)
+		screen.DrawImage(f.arrow, &opts)
+	}
+}
+
+// rect returns the rectangle to draw f in at time now, moving from f.from to f.to.
+func (f *FocusIndicator) rect(now time.Time) img.Rectangle {
+	d := animationDuration(f.moveDuration)
+	if d <= 0 || f.moveStart.IsZero() {
+		return f.to
+	}
+
+	p := float64(now.Sub(f.moveStart)) / float64(d)
+	if p >= 1 {
+		f.moveStart = time.Time{}
+		return f.to
+	}
+
+	// ease out
+	p = 1 - (1-p)*(1-p)
+
+	return img.Rect(
+		lerpInt(f.from.Min.X, f.to.Min.X, p),
+		lerpInt(f.from.Min.Y, f.to.Min.Y, p),
+		lerpInt(f.from.Max.X, f.to.Max.X, p),
+		lerpInt(f.from.Max.Y, f.to.Max.Y, p))
+}
+
+// alpha returns the alpha value to draw f with at time now.
+func (f *FocusIndicator) alpha(now time.Time) float64 {
+	if f.pulsePeriod <= 0 || motionDisabled() {
+		return 1
+	}
+
+	p := float64(now.Sub(f.pulsed)) / float64(f.pulsePeriod)
+	return f.pulseMinAlpha + (1-f.pulseMinAlpha)*(0.5+0.5*math.Cos(2*math.Pi*p))
+}
+
+func (f *FocusIndicator) outsetRect(r img.Rectangle) img.Rectangle {
+	return img.Rect(r.Min.X-f.outset.Left, r.Min.Y-f.outset.Top, r.Max.X+f.outset.Right, r.Max.Y+f.outset.Bottom)
+}
+
+func lerpInt(a int, b int, p float64) int {
+	return int(math.Round(float64(a) + float64(b-a)*p))
+}
@@ -0,0 +1,53 @@
+package widget
+
+import (
+	img "image"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFocusIndicator_Move(t *testing.T) {
+	is := is.New(t)
+
+	a := newSimpleWidget(10, 10, nil)
+	a.SetLocation(img.Rect(0, 0, 10, 10))
+	b := newSimpleWidget(10, 10, nil)
+	b.SetLocation(img.Rect(100, 0, 110, 10))
+
+	f := NewFocusIndicator(
+		FocusIndicatorOpts.Outset(Insets{Left: 2, Right: 2, Top: 2, Bottom: 2}),
+		FocusIndicatorOpts.MoveDuration(100*time.Millisecond))
+
+	f.SetTarget(a)
+	is.Equal(f.rect(Now()), img.Rect(-2, -2, 12, 12))
+
+	f.SetTarget(b)
+	start := f.moveStart
+	is.Equal(f.rect(start), img.Rect(-2, -2, 12, 12))
+
+	mid := f.rect(start.Add(50 * time.Millisecond))
+	is.True(mid.Min.X > -2 && mid.Min.X < 98)
+
+	is.Equal(f.rect(start.Add(100*time.Millisecond)), img.Rect(98, -2, 112, 12))
+}
+
+func TestFocusIndicator_Behind(t *testing.T) {
+	is := is.New(t)
+
+	a := newSimpleWidget(10, 10, nil)
+	b := newSimpleWidget(10, 10, nil)
+
+	f := NewFocusIndicator(FocusIndicatorOpts.Behind())
+
+	f.SetTarget(a)
+	is.Equal(a.GetWidget().focusIndicator, f)
+
+	f.SetTarget(b)
+	is.True(a.GetWidget().focusIndicator == nil)
+	is.Equal(b.GetWidget().focusIndicator, f)
+
+	f.SetTarget(nil)
+	is.True(b.GetWidget().focusIndicator == nil)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/blizzy78/ebitenui/image"
 
 	img "image"
+	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -12,7 +13,11 @@ type Graphic struct {
 	Image          *ebiten.Image
 	ImageNineSlice *image.NineSlice
 
+	// Tint is the color the image's colors are multiplied with. If nil, the image is drawn as is.
+	Tint color.Color
+
 	widgetOpts []WidgetOpt
+	skeleton   *Skeleton
 
 	init   *MultiOnce
 	widget *Widget
@@ -57,6 +62,20 @@ func (o GraphicOptions) ImageNineSlice(i *image.NineSlice) GraphicOpt {
 	}
 }
 
+func (o GraphicOptions) Tint(c color.Color) GraphicOpt {
+	return func(g *Graphic) {
+		g.Tint = c
+	}
+}
+
+// Skeleton configures a Graphic to draw a placeholder block according to s while it has no image, for example
+// while the image is being loaded.
+func (o GraphicOptions) Skeleton(s *Skeleton) GraphicOpt {
+	return func(g *Graphic) {
+		g.skeleton = s
+	}
+}
+
 func (g *Graphic) GetWidget() *Widget {
 	g.init.Do()
 	return g.widget
@@ -78,18 +97,38 @@ func (g *Graphic) PreferredSize() (int, int) {
 func (g *Graphic) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	g.init.Do()
 	g.widget.Render(screen, def)
+
+	if g.Loading() {
+		g.skeleton.draw(screen, g.skeleton.blockRect(g.widget.Rect, TextPositionCenter))
+		return
+	}
+
 	g.draw(screen)
 }
 
+// Loading returns whether g draws a placeholder block because it has no image.
+func (g *Graphic) Loading() bool {
+	return g.skeleton != nil && g.Image == nil && g.ImageNineSlice == nil
+}
+
 func (g *Graphic) draw(screen *ebiten.Image) {
 	if g.Image != nil {
 		opts := ebiten.DrawImageOptions{}
 		w, h := g.Image.Size()
 		opts.GeoM.Translate(float64((g.widget.Rect.Dx()-w)/2), float64((g.widget.Rect.Dy()-h)/2))
-		g.widget.drawImageOptions(&opts)
+		g.drawImageOptions(&opts)
 		screen.DrawImage(g.Image, &opts)
 	} else if g.ImageNineSlice != nil {
-		g.ImageNineSlice.Draw(screen, g.widget.Rect.Dx(), g.widget.Rect.Dy(), g.widget.drawImageOptions)
+		g.ImageNineSlice.Draw(screen, g.widget.Rect.Dx(), g.widget.Rect.Dy(), g.drawImageOptions)
+	}
+}
+
+func (g *Graphic) drawImageOptions(opts *ebiten.DrawImageOptions) {
+	g.widget.drawImageOptions(opts)
+
+	if g.Tint != nil {
+		nc := color.NRGBAModel.Convert(g.Tint).(color.NRGBA)
+		opts.ColorM.Scale(float64(nc.R)/255, float64(nc.G)/255, float64(nc.B)/255, float64(nc.A)/255)
 	}
 }
 
@@ -1,6 +1,7 @@
 package widget
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/blizzy78/ebitenui/event"
@@ -17,6 +18,16 @@ func TestGraphic_PreferredSize(t *testing.T) {
 	is.Equal(h, i.Bounds().Dy())
 }
 
+func TestGraphic_Skeleton(t *testing.T) {
+	is := is.New(t)
+
+	g := newGraphic(t, GraphicOpts.Skeleton(&Skeleton{Color: color.White}))
+	is.True(g.Loading())
+
+	g.Image = newImageEmptySize(10, 10, t)
+	is.True(!g.Loading())
+}
+
 func newGraphic(t *testing.T, opts ...GraphicOpt) *Graphic {
 	t.Helper()
 
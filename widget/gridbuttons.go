@@ -0,0 +1,219 @@
+package widget
+
+import (
+	"image"
+	"math"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// GridButtons is a grid of buttons of uniform size, such as a dial pad or a level selection grid. The buttons
+// are generated from a list of labels, and clicks on any of them are reported by a single ClickedEvent.
+type GridButtons struct {
+	ClickedEvent *event.Event
+
+	containerOpts []ContainerOpt
+	buttonOpts    []ButtonOpt
+	labels        []string
+	face          font.Face
+	color         *ButtonTextColor
+	columns       int
+	padding       Insets
+	columnSpacing int
+	rowSpacing    int
+
+	init      *MultiOnce
+	container *Container
+	buttons   []*Button
+}
+
+type GridButtonsOpt func(g *GridButtons)
+
+type GridButtonsClickedEventArgs struct {
+	GridButtons *GridButtons
+	Index       int
+	Label       string
+}
+
+type GridButtonsClickedHandlerFunc func(args *GridButtonsClickedEventArgs)
+
+type GridButtonsOptions struct {
+}
+
+var GridButtonsOpts GridButtonsOptions
+
+func NewGridButtons(opts ...GridButtonsOpt) *GridButtons {
+	g := &GridButtons{
+		ClickedEvent: &event.Event{},
+
+		columns: 3,
+
+		init: &MultiOnce{},
+	}
+
+	g.init.Append(g.createWidget)
+
+	for _, o := range opts {
+		o(g)
+	}
+
+	return g
+}
+
+func (o GridButtonsOptions) ContainerOpts(opts ...ContainerOpt) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.containerOpts = append(g.containerOpts, opts...)
+	}
+}
+
+// ButtonOpts configures a GridButtons to construct all buttons using opts.
+func (o GridButtonsOptions) ButtonOpts(opts ...ButtonOpt) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.buttonOpts = append(g.buttonOpts, opts...)
+	}
+}
+
+// Labels configures a GridButtons to create one button for each label, using face and color c.
+func (o GridButtonsOptions) Labels(labels []string, face font.Face, c *ButtonTextColor) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.labels = labels
+		g.face = face
+		g.color = c
+	}
+}
+
+func (o GridButtonsOptions) Columns(c int) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.columns = c
+	}
+}
+
+func (o GridButtonsOptions) Padding(i Insets) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.padding = i
+	}
+}
+
+func (o GridButtonsOptions) Spacing(c int, r int) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.columnSpacing = c
+		g.rowSpacing = r
+	}
+}
+
+func (o GridButtonsOptions) ClickedHandler(f GridButtonsClickedHandlerFunc) GridButtonsOpt {
+	return func(g *GridButtons) {
+		g.ClickedEvent.AddHandler(func(args interface{}) {
+			f(args.(*GridButtonsClickedEventArgs))
+		})
+	}
+}
+
+func (g *GridButtons) GetWidget() *Widget {
+	g.init.Do()
+	return g.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (g *GridButtons) ContainedWidgets() []HasWidget {
+	g.init.Do()
+	return containedWidgets(g.container)
+}
+
+// PreferredSize returns the preferred size of g, which makes room for all buttons to be as large as the
+// largest button.
+func (g *GridButtons) PreferredSize() (int, int) {
+	g.init.Do()
+
+	maxW, maxH := 0, 0
+	for _, b := range g.buttons {
+		w, h := b.PreferredSize()
+		if w > maxW {
+			maxW = w
+		}
+		if h > maxH {
+			maxH = h
+		}
+	}
+
+	cols := g.columns
+	if len(g.buttons) < cols {
+		cols = len(g.buttons)
+	}
+	rows := int(math.Ceil(float64(len(g.buttons)) / float64(g.columns)))
+
+	return g.padding.Dx() + cols*maxW + g.columnSpacing*(cols-1),
+		g.padding.Dy() + rows*maxH + g.rowSpacing*(rows-1)
+}
+
+func (g *GridButtons) SetLocation(rect image.Rectangle) {
+	g.init.Do()
+	g.container.SetLocation(rect)
+}
+
+func (g *GridButtons) RequestRelayout() {
+	g.init.Do()
+	g.container.RequestRelayout()
+}
+
+func (g *GridButtons) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	g.init.Do()
+	g.container.SetupInputLayer(def)
+}
+
+func (g *GridButtons) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	g.init.Do()
+	g.container.Render(screen, def)
+}
+
+// Button returns the button at index i.
+func (g *GridButtons) Button(i int) *Button {
+	g.init.Do()
+	return g.buttons[i]
+}
+
+func (g *GridButtons) createWidget() {
+	rows := int(math.Ceil(float64(len(g.labels)) / float64(g.columns)))
+
+	// stretching all columns and rows makes all cells the same size
+	colStretch := make([]bool, g.columns)
+	for i := range colStretch {
+		colStretch[i] = true
+	}
+	rowStretch := make([]bool, rows)
+	for i := range rowStretch {
+		rowStretch[i] = true
+	}
+
+	g.container = NewContainer(append(g.containerOpts, ContainerOpts.Layout(NewGridLayout(
+		GridLayoutOpts.Columns(g.columns),
+		GridLayoutOpts.Padding(g.padding),
+		GridLayoutOpts.Spacing(g.columnSpacing, g.rowSpacing),
+		GridLayoutOpts.Stretch(colStretch, rowStretch))))...)
+	g.containerOpts = nil
+
+	g.buttons = make([]*Button, len(g.labels))
+	for i, l := range g.labels {
+		i, l := i, l
+
+		opts := append([]ButtonOpt(nil), g.buttonOpts...)
+		b := NewButton(append(opts, []ButtonOpt{
+			ButtonOpts.Text(l, g.face, g.color),
+			ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+				g.ClickedEvent.Fire(&GridButtonsClickedEventArgs{
+					GridButtons: g,
+					Index:       i,
+					Label:       l,
+				})
+			}),
+		}...)...)
+
+		g.buttons[i] = b
+		g.container.AddChild(b)
+	}
+	g.buttonOpts = nil
+}
@@ -0,0 +1,71 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestGridButtons_ClickedEvent(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *GridButtonsClickedEventArgs
+
+	g := newGridButtons(t,
+		GridButtonsOpts.ClickedHandler(func(args *GridButtonsClickedEventArgs) {
+			eventArgs = args
+		}))
+
+	leftMouseButtonClick(g.Button(4), t)
+
+	is.Equal(eventArgs.Index, 4)
+	is.Equal(eventArgs.Label, "5")
+}
+
+func TestGridButtons_Layout(t *testing.T) {
+	is := is.New(t)
+
+	g := newGridButtons(t, GridButtonsOpts.Spacing(2, 2))
+
+	w, h := g.PreferredSize()
+	bw, bh := g.Button(0).PreferredSize()
+	for i := 1; i < 10; i++ {
+		w, h := g.Button(i).PreferredSize()
+		if w > bw {
+			bw = w
+		}
+		if h > bh {
+			bh = h
+		}
+	}
+
+	is.Equal(w, 3*bw+4)
+	is.Equal(h, 4*bh+6)
+
+	g.SetLocation(image.Rect(0, 0, w, h))
+	render(g, t)
+
+	for i := 0; i < 10; i++ {
+		is.Equal(g.Button(i).GetWidget().Rect.Size(), image.Point{bw, bh})
+	}
+	is.Equal(g.Button(4).GetWidget().Rect.Min, image.Point{bw + 2, bh + 2})
+}
+
+func newGridButtons(t *testing.T, opts ...GridButtonsOpt) *GridButtons {
+	t.Helper()
+
+	g := NewGridButtons(append(opts, []GridButtonsOpt{
+		GridButtonsOpts.ButtonOpts(ButtonOpts.Image(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		})),
+		GridButtonsOpts.Labels([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "0"}, loadFont(t), &ButtonTextColor{
+			Idle: color.White,
+		}),
+	}...)...)
+	event.ExecuteDeferred()
+	return g
+}
@@ -1,6 +1,7 @@
 package widget
 
 import (
+	"fmt"
 	"image"
 	"math"
 )
@@ -142,7 +143,7 @@ func (g *GridLayout) Layout(widgets []PreferredSizeLocateableWidget, rect image.
 			wx, wy, ww, wh = g.applyLayoutData(gld, wx, wy, ww, wh, x, y, cw, ch)
 		}
 
-		w.SetLocation(image.Rect(rect.Min.X+wx, rect.Min.Y+wy, rect.Min.X+wx+ww, rect.Min.Y+wy+wh))
+		setLocationWithMargin(w, image.Rect(rect.Min.X+wx, rect.Min.Y+wy, rect.Min.X+wx+ww, rect.Min.Y+wy+wh))
 
 		c++
 		x += cw + g.columnSpacing
@@ -208,7 +209,7 @@ func (g *GridLayout) preferredColumnWidthsAndRowHeights(widgets []PreferredSizeL
 	c := 0
 	r := 0
 	for _, w := range widgets {
-		ww, wh := w.PreferredSize()
+		ww, wh := preferredSizeWithMargin(w)
 
 		ld := w.GetWidget().LayoutData
 		if gld, ok := ld.(GridLayoutData); ok {
@@ -233,6 +234,11 @@ func (g *GridLayout) preferredColumnWidthsAndRowHeights(widgets []PreferredSizeL
 	return colWidths, rowHeights
 }
 
+// layoutPadding implements paddedLayouter.
+func (g *GridLayout) layoutPadding() Insets {
+	return g.padding
+}
+
 func (g *GridLayout) applyLayoutData(ld GridLayoutData, wx int, wy int, ww int, wh int, x int, y int, cw int, ch int) (int, int, int, int) {
 	if ld.MaxWidth > 0 && ww > ld.MaxWidth {
 		ww = ld.MaxWidth
@@ -278,3 +284,11 @@ func sumInts(ints []int) int {
 	}
 	return s
 }
+
+// ValidateLayoutData implements LayoutDataValidator.
+func (g *GridLayout) ValidateLayoutData(ld interface{}) error {
+	if _, ok := ld.(GridLayoutData); !ok {
+		return fmt.Errorf("GridLayout requires GridLayoutData, got %T", ld)
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// KeyBindInput is a button that captures a key, mouse button, or gamepad button when clicked. While capturing,
+// pressing an input bound to input.ActionCancel, such as Escape, cancels capturing, and pressing Backspace
+// clears the binding.
+//
+// KeyBindInput must be configured with a Button that has a text label, for example using ButtonOpts.Text.
+type KeyBindInput struct {
+	ChangedEvent  *event.Event
+	ConflictEvent *event.Event
+
+	buttonOpts    []ButtonOpt
+	labelFunc     KeyBindInputLabelFunc
+	capturePrompt string
+	conflictFunc  KeyBindInputConflictFunc
+
+	init        *MultiOnce
+	button      *Button
+	binding     KeyBinding
+	capturing   bool
+	waitRelease bool
+}
+
+type KeyBindInputOpt func(k *KeyBindInput)
+
+// KeyBinding is a key, mouse button, or gamepad button that has been bound by a KeyBindInput.
+type KeyBinding struct {
+	Type          KeyBindingType
+	Key           ebiten.Key
+	MouseButton   ebiten.MouseButton
+	GamepadButton ebiten.GamepadButton
+}
+
+type KeyBindingType int
+
+// KeyBindInputLabelFunc is a function that returns the label to display for binding b.
+type KeyBindInputLabelFunc func(b KeyBinding) string
+
+// KeyBindInputConflictFunc is a function that returns whether binding b conflicts with another binding.
+type KeyBindInputConflictFunc func(k *KeyBindInput, b KeyBinding) bool
+
+type KeyBindInputChangedEventArgs struct {
+	KeyBindInput    *KeyBindInput
+	Binding         KeyBinding
+	PreviousBinding KeyBinding
+}
+
+type KeyBindInputConflictEventArgs struct {
+	KeyBindInput *KeyBindInput
+	Binding      KeyBinding
+}
+
+type KeyBindInputChangedHandlerFunc func(args *KeyBindInputChangedEventArgs)
+
+type KeyBindInputConflictHandlerFunc func(args *KeyBindInputConflictEventArgs)
+
+type KeyBindInputOptions struct {
+}
+
+const (
+	KeyBindingTypeNone = KeyBindingType(iota)
+	KeyBindingTypeKey
+	KeyBindingTypeMouseButton
+	KeyBindingTypeGamepadButton
+)
+
+var KeyBindInputOpts KeyBindInputOptions
+
+func NewKeyBindInput(opts ...KeyBindInputOpt) *KeyBindInput {
+	k := &KeyBindInput{
+		ChangedEvent:  &event.Event{},
+		ConflictEvent: &event.Event{},
+
+		labelFunc: func(b KeyBinding) string {
+			return b.String()
+		},
+		capturePrompt: "Press any key",
+
+		init: &MultiOnce{},
+	}
+
+	k.init.Append(k.createWidget)
+
+	for _, o := range opts {
+		o(k)
+	}
+
+	return k
+}
+
+func (o KeyBindInputOptions) ButtonOpts(opts ...ButtonOpt) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.buttonOpts = append(k.buttonOpts, opts...)
+	}
+}
+
+func (o KeyBindInputOptions) LabelFunc(f KeyBindInputLabelFunc) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.labelFunc = f
+	}
+}
+
+// CapturePrompt configures a KeyBindInput to display p while capturing.
+func (o KeyBindInputOptions) CapturePrompt(p string) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.capturePrompt = p
+	}
+}
+
+// ConflictFunc configures a KeyBindInput to use f to check captured bindings for conflicts. If f returns
+// true, the binding is not changed, and ConflictEvent is fired instead.
+func (o KeyBindInputOptions) ConflictFunc(f KeyBindInputConflictFunc) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.conflictFunc = f
+	}
+}
+
+func (o KeyBindInputOptions) Binding(b KeyBinding) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.binding = b
+	}
+}
+
+func (o KeyBindInputOptions) ChangedHandler(f KeyBindInputChangedHandlerFunc) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*KeyBindInputChangedEventArgs))
+		})
+	}
+}
+
+func (o KeyBindInputOptions) ConflictHandler(f KeyBindInputConflictHandlerFunc) KeyBindInputOpt {
+	return func(k *KeyBindInput) {
+		k.ConflictEvent.AddHandler(func(args interface{}) {
+			f(args.(*KeyBindInputConflictEventArgs))
+		})
+	}
+}
+
+func (k *KeyBindInput) GetWidget() *Widget {
+	k.init.Do()
+	return k.button.GetWidget()
+}
+
+func (k *KeyBindInput) SetLocation(rect image.Rectangle) {
+	k.init.Do()
+	k.button.SetLocation(rect)
+}
+
+func (k *KeyBindInput) PreferredSize() (int, int) {
+	k.init.Do()
+	return k.button.PreferredSize()
+}
+
+func (k *KeyBindInput) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	k.init.Do()
+
+	// block all other widgets while capturing so that mouse buttons can be captured
+	if k.capturing {
+		k.button.GetWidget().ElevateToNewInputLayer(&input.Layer{
+			DebugLabel: "key bind input capturing",
+			EventTypes: input.LayerEventTypeAll,
+			BlockLower: true,
+			FullScreen: true,
+		})
+	}
+
+	k.button.SetupInputLayer(def)
+}
+
+func (k *KeyBindInput) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	k.init.Do()
+
+	if k.capturing {
+		k.capture()
+	}
+
+	k.updateLabel()
+
+	k.button.Render(screen, def)
+}
+
+// Binding returns the current binding.
+func (k *KeyBindInput) Binding() KeyBinding {
+	return k.binding
+}
+
+// SetBinding sets the current binding to b, without checking for conflicts.
+func (k *KeyBindInput) SetBinding(b KeyBinding) {
+	if b == k.binding {
+		return
+	}
+
+	prev := k.binding
+	k.binding = b
+
+	k.ChangedEvent.Fire(&KeyBindInputChangedEventArgs{
+		KeyBindInput:    k,
+		Binding:         b,
+		PreviousBinding: prev,
+	})
+}
+
+// Clear clears the current binding.
+func (k *KeyBindInput) Clear() {
+	k.SetBinding(KeyBinding{})
+}
+
+// StartCapture starts capturing a new binding.
+func (k *KeyBindInput) StartCapture() {
+	k.capturing = true
+	k.waitRelease = true
+}
+
+// Capturing returns whether k is currently capturing a new binding.
+func (k *KeyBindInput) Capturing() bool {
+	return k.capturing
+}
+
+// HandleBack implements BackHandler. While capturing, it cancels capturing.
+func (k *KeyBindInput) HandleBack() bool {
+	if !k.capturing {
+		return false
+	}
+
+	k.capturing = false
+	return true
+}
+
+func (k *KeyBindInput) capture() {
+	// wait for the button press that started capturing to be released
+	if k.waitRelease {
+		if !anyInputPressed() {
+			k.waitRelease = false
+		}
+		return
+	}
+
+	if input.ActionPressed(input.ActionCancel) {
+		k.capturing = false
+		return
+	}
+
+	if input.KeyPressed(ebiten.KeyBackspace) {
+		k.capturing = false
+		k.Clear()
+		return
+	}
+
+	b, ok := pressedKeyBinding()
+	if !ok {
+		return
+	}
+
+	k.capturing = false
+
+	if k.conflictFunc != nil && k.conflictFunc(k, b) {
+		k.ConflictEvent.Fire(&KeyBindInputConflictEventArgs{
+			KeyBindInput: k,
+			Binding:      b,
+		})
+		return
+	}
+
+	k.SetBinding(b)
+}
+
+func (k *KeyBindInput) updateLabel() {
+	l := k.capturePrompt
+	if !k.capturing {
+		l = k.labelFunc(k.binding)
+	}
+
+	t := k.button.Text()
+	if t.Label == l {
+		return
+	}
+
+	t.Label = l
+	k.button.RequestRelayout()
+}
+
+func (k *KeyBindInput) createWidget() {
+	k.button = NewButton(append(k.buttonOpts, ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+		if !k.capturing {
+			k.StartCapture()
+		}
+	}))...)
+	k.buttonOpts = nil
+}
+
+// pressedKeyBinding returns a binding for the first key, mouse button, or gamepad button that is currently
+// pressed, and whether any was pressed.
+func pressedKeyBinding() (KeyBinding, bool) {
+	if input.AnyKeyPressed() {
+		for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+			if input.KeyPressed(key) {
+				return KeyBinding{
+					Type: KeyBindingTypeKey,
+					Key:  key,
+				}, true
+			}
+		}
+	}
+
+	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
+		if input.MouseButtonPressed(b) {
+			return KeyBinding{
+				Type:        KeyBindingTypeMouseButton,
+				MouseButton: b,
+			}, true
+		}
+	}
+
+	if input.AnyGamepadButtonPressed() {
+		for b := ebiten.GamepadButton(0); b <= ebiten.GamepadButtonMax; b++ {
+			if input.GamepadButtonPressed(b) {
+				return KeyBinding{
+					Type:          KeyBindingTypeGamepadButton,
+					GamepadButton: b,
+				}, true
+			}
+		}
+	}
+
+	return KeyBinding{}, false
+}
+
+func anyInputPressed() bool {
+	return input.AnyKeyPressed() || input.AnyGamepadButtonPressed() ||
+		input.MouseButtonPressed(ebiten.MouseButtonLeft) ||
+		input.MouseButtonPressed(ebiten.MouseButtonMiddle) ||
+		input.MouseButtonPressed(ebiten.MouseButtonRight)
+}
+
+// String returns a human-readable representation of b.
+func (b KeyBinding) String() string {
+	switch b.Type {
+	case KeyBindingTypeKey:
+		return input.KeyName(b.Key)
+	case KeyBindingTypeMouseButton:
+		return input.MouseButtonName(b.MouseButton)
+	case KeyBindingTypeGamepadButton:
+		return input.GamepadButtonName(b.GamepadButton)
+	}
+
+	return ""
+}
@@ -0,0 +1,117 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestKeyBindInput_Capture(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *KeyBindInputChangedEventArgs
+
+	k := newKeyBindInput(t,
+		KeyBindInputOpts.ChangedHandler(func(args *KeyBindInputChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	leftMouseButtonClick(k, t)
+	render(k, t)
+
+	is.True(k.Capturing())
+	is.Equal(k.button.Text().Label, "Press any key")
+
+	internalinput.KeyPressed[ebiten.KeyA] = true
+	internalinput.AnyKeyPressed = true
+	defer func() {
+		internalinput.KeyPressed[ebiten.KeyA] = false
+		internalinput.AnyKeyPressed = false
+	}()
+
+	render(k, t)
+
+	is.True(!k.Capturing())
+	is.Equal(k.Binding(), KeyBinding{Type: KeyBindingTypeKey, Key: ebiten.KeyA})
+	is.Equal(eventArgs.Binding, k.Binding())
+	is.Equal(k.button.Text().Label, "A")
+}
+
+func TestKeyBindInput_Capture_Conflict(t *testing.T) {
+	is := is.New(t)
+
+	var changed bool
+	var eventArgs *KeyBindInputConflictEventArgs
+
+	k := newKeyBindInput(t,
+		KeyBindInputOpts.ConflictFunc(func(k *KeyBindInput, b KeyBinding) bool {
+			return b.Type == KeyBindingTypeGamepadButton
+		}),
+		KeyBindInputOpts.ChangedHandler(func(args *KeyBindInputChangedEventArgs) {
+			changed = true
+		}),
+		KeyBindInputOpts.ConflictHandler(func(args *KeyBindInputConflictEventArgs) {
+			eventArgs = args
+		}))
+
+	k.StartCapture()
+	render(k, t)
+
+	internalinput.GamepadButtonPressed[ebiten.GamepadButton2] = true
+	internalinput.AnyGamepadButtonPressed = true
+	defer func() {
+		internalinput.GamepadButtonPressed[ebiten.GamepadButton2] = false
+		internalinput.AnyGamepadButtonPressed = false
+	}()
+
+	render(k, t)
+
+	is.True(!k.Capturing())
+	is.True(!changed)
+	is.Equal(eventArgs.Binding, KeyBinding{Type: KeyBindingTypeGamepadButton, GamepadButton: ebiten.GamepadButton2})
+	is.Equal(k.Binding().Type, KeyBindingTypeNone)
+}
+
+func TestKeyBindInput_Capture_Clear(t *testing.T) {
+	is := is.New(t)
+
+	k := newKeyBindInput(t,
+		KeyBindInputOpts.Binding(KeyBinding{Type: KeyBindingTypeMouseButton, MouseButton: ebiten.MouseButtonRight}))
+
+	is.Equal(k.button.Text().Label, "Right Mouse Button")
+
+	k.StartCapture()
+	render(k, t)
+
+	internalinput.KeyPressed[ebiten.KeyBackspace] = true
+	internalinput.AnyKeyPressed = true
+	defer func() {
+		internalinput.KeyPressed[ebiten.KeyBackspace] = false
+		internalinput.AnyKeyPressed = false
+	}()
+
+	render(k, t)
+
+	is.True(!k.Capturing())
+	is.Equal(k.Binding().Type, KeyBindingTypeNone)
+}
+
+func newKeyBindInput(t *testing.T, opts ...KeyBindInputOpt) *KeyBindInput {
+	t.Helper()
+
+	k := NewKeyBindInput(append(opts, KeyBindInputOpts.ButtonOpts(
+		ButtonOpts.Image(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		}),
+		ButtonOpts.Text("", loadFont(t), &ButtonTextColor{
+			Idle: color.White,
+		})))...)
+	event.ExecuteDeferred()
+	render(k, t)
+	return k
+}
@@ -0,0 +1,72 @@
+package widget
+
+import "fmt"
+
+// KeyboardAuditIssue describes a widget that cannot be operated using only a keyboard or gamepad.
+type KeyboardAuditIssue struct {
+	Widget HasWidget
+	Kind   KeyboardAuditIssueKind
+}
+
+// KeyboardAuditIssueKind is the kind of a KeyboardAuditIssue.
+type KeyboardAuditIssueKind int
+
+const (
+	// KeyboardAuditUnreachable specifies that a widget can be focused, but is not reachable using focus
+	// traversal, for example because its Widget.SkipFocus is set.
+	KeyboardAuditUnreachable = KeyboardAuditIssueKind(iota)
+
+	// KeyboardAuditNoActivation specifies that a widget reacts to mouse buttons, but cannot be focused,
+	// and can therefore not be activated using a keyboard or gamepad.
+	KeyboardAuditNoActivation
+)
+
+// AuditKeyboard walks the widget trees of roots and returns issues for all visible and enabled widgets that
+// cannot be operated using only a keyboard or gamepad. A widget is considered interactive if it has handlers
+// for Widget.MouseButtonPressedEvent or Widget.MouseButtonReleasedEvent. Only descendants of Containers and
+// Windows are checked, the internal widgets of other widgets are not.
+//
+// AuditKeyboard is intended to be used during development, for example in a test or behind a debug key.
+func AuditKeyboard(roots ...FocusContainer) []KeyboardAuditIssue {
+	reachable := map[HasWidget]bool{}
+	for _, r := range roots {
+		for _, w := range r.FocusOrder() {
+			reachable[w] = true
+		}
+	}
+
+	issues := []KeyboardAuditIssue{}
+
+	for _, r := range roots {
+		walkDescendants(r, func(w HasWidget) {
+			wi := w.GetWidget()
+			if wi.Disabled || !wi.Visible() {
+				return
+			}
+
+			if _, ok := w.(Focuser); ok {
+				if !reachable[w] {
+					issues = append(issues, KeyboardAuditIssue{Widget: w, Kind: KeyboardAuditUnreachable})
+				}
+				return
+			}
+
+			if wi.MouseButtonPressedEvent.HasHandlers() || wi.MouseButtonReleasedEvent.HasHandlers() {
+				issues = append(issues, KeyboardAuditIssue{Widget: w, Kind: KeyboardAuditNoActivation})
+			}
+		})
+	}
+
+	return issues
+}
+
+// String returns a human-readable description of i.
+func (i KeyboardAuditIssue) String() string {
+	switch i.Kind {
+	case KeyboardAuditUnreachable:
+		return fmt.Sprintf("widget %s can be focused, but is not reachable using focus traversal", describeWidget(i.Widget))
+	case KeyboardAuditNoActivation:
+		return fmt.Sprintf("widget %s reacts to mouse buttons, but cannot be focused", describeWidget(i.Widget))
+	}
+	return ""
+}
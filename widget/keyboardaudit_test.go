@@ -0,0 +1,33 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/matryer/is"
+)
+
+func TestAuditKeyboard(t *testing.T) {
+	is := is.New(t)
+
+	reachable := newTextInput(t)
+	unreachable := newTextInput(t)
+	unreachable.GetWidget().SkipFocus = true
+	button := newButton(t)
+	disabledButton := newButton(t)
+	disabledButton.GetWidget().Disabled = true
+
+	c := newContainer(t)
+	c.AddChild(reachable)
+	c.AddChild(unreachable)
+	c.AddChild(button)
+	c.AddChild(disabledButton)
+	event.ExecuteDeferred()
+
+	issues := AuditKeyboard(c)
+	is.Equal(len(issues), 2)
+	is.Equal(issues[0].Widget, unreachable)
+	is.Equal(issues[0].Kind, KeyboardAuditUnreachable)
+	is.Equal(issues[1].Widget, button)
+	is.Equal(issues[1].Kind, KeyboardAuditNoActivation)
+}
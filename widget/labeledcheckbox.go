@@ -12,10 +12,11 @@ type LabeledCheckbox struct {
 	labelOpts    []LabelOpt
 	spacing      int
 
-	init      *MultiOnce
-	container *Container
-	checkbox  *Checkbox
-	label     *Label
+	init       *MultiOnce
+	container  *Container
+	checkbox   *Checkbox
+	label      *Label
+	gapPressed bool
 }
 
 type LabeledCheckboxOpt func(l *LabeledCheckbox)
@@ -64,6 +65,12 @@ func (l *LabeledCheckbox) GetWidget() *Widget {
 	return l.container.GetWidget()
 }
 
+// ContainedWidgets implements Containerer.
+func (l *LabeledCheckbox) ContainedWidgets() []HasWidget {
+	l.init.Do()
+	return containedWidgets(l.container)
+}
+
 func (l *LabeledCheckbox) PreferredSize() (int, int) {
 	l.init.Do()
 	return l.container.PreferredSize()
@@ -94,6 +101,43 @@ func (l *LabeledCheckbox) Label() *Label {
 
 func (l *LabeledCheckbox) createWidget() {
 	l.container = NewContainer(
+		ContainerOpts.WidgetOpts(
+			WidgetOpts.CursorEnterHandler(func(args *WidgetCursorEnterEventArgs) {
+				l.checkbox.button.externalHovering = !args.Widget.Disabled
+			}),
+
+			WidgetOpts.CursorExitHandler(func(args *WidgetCursorExitEventArgs) {
+				l.checkbox.button.externalHovering = false
+			}),
+
+			// the checkbox and the label handle clicks on themselves, so only handle clicks on the space in between
+			WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+				if args.Widget.Disabled || args.Button != ebiten.MouseButtonLeft {
+					return
+				}
+
+				p := args.Widget.Rect.Min.Add(image.Point{args.OffsetX, args.OffsetY})
+				if p.In(l.checkbox.GetWidget().Rect) || p.In(l.label.GetWidget().Rect) {
+					return
+				}
+
+				l.gapPressed = true
+				l.checkbox.button.externalPressing = true
+			}),
+
+			WidgetOpts.MouseButtonReleasedHandler(func(args *WidgetMouseButtonReleasedEventArgs) {
+				if !l.gapPressed {
+					return
+				}
+
+				l.gapPressed = false
+				l.checkbox.button.externalPressing = false
+
+				if !args.Widget.Disabled && args.Inside {
+					l.toggle()
+				}
+			}),
+		),
 		ContainerOpts.Layout(NewRowLayout(
 			RowLayoutOpts.Spacing(l.spacing))),
 		ContainerOpts.AutoDisableChildren(),
@@ -110,12 +154,24 @@ func (l *LabeledCheckbox) createWidget() {
 			Position: RowLayoutPositionCenter,
 		}),
 
+		WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+			if !args.Widget.Disabled && args.Button == ebiten.MouseButtonLeft {
+				l.checkbox.button.externalPressing = true
+			}
+		}),
+
 		WidgetOpts.MouseButtonReleasedHandler(func(args *WidgetMouseButtonReleasedEventArgs) {
+			l.checkbox.button.externalPressing = false
+
 			if !args.Widget.Disabled && args.Button == ebiten.MouseButtonLeft && args.Inside {
-				l.checkbox.SetState(l.checkbox.state.Advance(l.checkbox.triState))
+				l.toggle()
 			}
 		}),
 	)))...)
 	l.container.AddChild(l.label)
 	l.labelOpts = nil
 }
+
+func (l *LabeledCheckbox) toggle() {
+	l.checkbox.SetState(l.checkbox.state.Advance(l.checkbox.triState))
+}
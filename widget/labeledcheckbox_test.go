@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/blizzy78/ebitenui/event"
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/matryer/is"
 )
 
@@ -17,6 +18,55 @@ func TestLabeledCheckbox_SetState_User(t *testing.T) {
 	is.Equal(l.Checkbox().State(), CheckboxChecked)
 }
 
+func TestLabeledCheckbox_SetState_User_Gap(t *testing.T) {
+	is := is.New(t)
+
+	l := newLabeledCheckbox(t)
+
+	w := l.GetWidget()
+	w.MouseButtonPressedEvent.Fire(&WidgetMouseButtonPressedEventArgs{
+		Widget:  w,
+		Button:  ebiten.MouseButtonLeft,
+		OffsetX: 1000,
+		OffsetY: 1000,
+	})
+	event.ExecuteDeferred()
+
+	is.True(l.checkbox.button.externalPressing)
+
+	w.MouseButtonReleasedEvent.Fire(&WidgetMouseButtonReleasedEventArgs{
+		Widget:  w,
+		Button:  ebiten.MouseButtonLeft,
+		Inside:  true,
+		OffsetX: 1000,
+		OffsetY: 1000,
+	})
+	event.ExecuteDeferred()
+
+	is.Equal(l.Checkbox().State(), CheckboxChecked)
+	is.True(!l.checkbox.button.externalPressing)
+}
+
+func TestLabeledCheckbox_Hover(t *testing.T) {
+	is := is.New(t)
+
+	l := newLabeledCheckbox(t)
+
+	l.GetWidget().CursorEnterEvent.Fire(&WidgetCursorEnterEventArgs{
+		Widget: l.GetWidget(),
+	})
+	event.ExecuteDeferred()
+
+	is.True(l.checkbox.button.externalHovering)
+
+	l.GetWidget().CursorExitEvent.Fire(&WidgetCursorExitEventArgs{
+		Widget: l.GetWidget(),
+	})
+	event.ExecuteDeferred()
+
+	is.True(!l.checkbox.button.externalHovering)
+}
+
 func newLabeledCheckbox(t *testing.T, opts ...LabeledCheckboxOpt) *LabeledCheckbox {
 	t.Helper()
 
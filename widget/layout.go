@@ -35,6 +35,18 @@ const (
 	DirectionVertical
 )
 
+// preferredSizeWithMargin returns the preferred size of w, including w's margin.
+func preferredSizeWithMargin(w PreferredSizeLocateableWidget) (int, int) {
+	ww, wh := w.PreferredSize()
+	m := w.GetWidget().Margin
+	return ww + m.Dx(), wh + m.Dy()
+}
+
+// setLocationWithMargin sets the location of w to rect, excluding w's margin.
+func setLocationWithMargin(w PreferredSizeLocateableWidget, rect image.Rectangle) {
+	w.SetLocation(w.GetWidget().Margin.Apply(rect))
+}
+
 func NewInsetsSimple(widthHeight int) Insets {
 	return Insets{
 		Top:    widthHeight,
@@ -0,0 +1,255 @@
+package widget
+
+import (
+	"fmt"
+	img "image"
+	"image/color"
+
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// LayoutInspector is a debug tool that measures the widget under the cursor while a modifier key is held. It
+// shows the widget's size, its margin, its container's padding, and the distances to its neighbors or its
+// container's edges, drawn as rulers.
+//
+// LayoutInspector is usually set as UI.LayoutInspector, which keeps its roots up to date.
+type LayoutInspector struct {
+	modifier ebiten.Key
+	face     font.Face
+	color    *LayoutInspectorColor
+
+	roots []Locater
+}
+
+// LayoutInspectorColor specifies the colors used by a LayoutInspector.
+type LayoutInspectorColor struct {
+	Rect    color.Color
+	Margin  color.Color
+	Padding color.Color
+	Ruler   color.Color
+}
+
+type LayoutInspectorOpt func(l *LayoutInspector)
+
+type LayoutInspectorOptions struct {
+}
+
+// paddedLayouter is implemented by Layouters that use padding.
+type paddedLayouter interface {
+	layoutPadding() Insets
+}
+
+var LayoutInspectorOpts LayoutInspectorOptions
+
+func NewLayoutInspector(opts ...LayoutInspectorOpt) *LayoutInspector {
+	l := &LayoutInspector{
+		modifier: ebiten.KeyAlt,
+		color: &LayoutInspectorColor{
+			Rect:    color.NRGBA{255, 0, 255, 255},
+			Margin:  color.NRGBA{255, 160, 0, 96},
+			Padding: color.NRGBA{0, 200, 0, 96},
+			Ruler:   color.NRGBA{0, 255, 255, 255},
+		},
+	}
+
+	for _, o := range opts {
+		o(l)
+	}
+
+	return l
+}
+
+// Modifier configures a LayoutInspector to be active while k is held. The default is ebiten.KeyAlt.
+func (o LayoutInspectorOptions) Modifier(k ebiten.Key) LayoutInspectorOpt {
+	return func(l *LayoutInspector) {
+		l.modifier = k
+	}
+}
+
+// Face configures a LayoutInspector to draw measurements using face f. If no face is set, only rulers are drawn.
+func (o LayoutInspectorOptions) Face(f font.Face) LayoutInspectorOpt {
+	return func(l *LayoutInspector) {
+		l.face = f
+	}
+}
+
+func (o LayoutInspectorOptions) Color(c *LayoutInspectorColor) LayoutInspectorOpt {
+	return func(l *LayoutInspector) {
+		l.color = c
+	}
+}
+
+// SetRoots sets the widget trees to inspect, topmost first.
+func (l *LayoutInspector) SetRoots(roots ...Locater) {
+	l.roots = roots
+}
+
+// Render implements Renderer.
+func (l *LayoutInspector) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	if !input.KeyPressed(l.modifier) {
+		return
+	}
+
+	x, y := input.CursorPosition()
+
+	for _, r := range l.roots {
+		if w := r.WidgetAt(x, y); w != nil {
+			l.draw(screen, w, parentContainer(r, w))
+			return
+		}
+	}
+}
+
+func (l *LayoutInspector) draw(screen *ebiten.Image, w HasWidget, parent *Container) {
+	r := w.GetWidget().Rect
+	m := w.GetWidget().Margin
+
+	if parent != nil {
+		pr := parent.GetWidget().Rect
+		if pl, ok := parent.layout.(paddedLayouter); ok {
+			fillInsets(screen, pr, pl.layoutPadding(), l.color.Padding)
+		}
+
+		siblings := []img.Rectangle{}
+		for _, ch := range parent.children {
+			if ch != w && ch.GetWidget().Visible() {
+				siblings = append(siblings, ch.GetWidget().Rect)
+			}
+		}
+
+		l.drawRulers(screen, r, layoutInspectorDistances(r, siblings, pr))
+	}
+
+	fillInsets(screen, img.Rect(r.Min.X-m.Left, r.Min.Y-m.Top, r.Max.X+m.Right, r.Max.Y+m.Bottom), m, l.color.Margin)
+	strokeRect(screen, r, l.color.Rect)
+
+	l.drawLabel(screen, fmt.Sprintf("%dx%d", r.Dx(), r.Dy()), r.Min.X, r.Min.Y-2, l.color.Rect)
+}
+
+// drawRulers draws rulers from the edges of r, with lengths d, indexed by NavDirection.
+func (l *LayoutInspector) drawRulers(screen *ebiten.Image, r img.Rectangle, d [4]int) {
+	cx, cy := (r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2
+	c := l.color.Ruler
+
+	rulers := [4]img.Rectangle{
+		NavDirectionUp:    img.Rect(cx, r.Min.Y-d[NavDirectionUp], cx+1, r.Min.Y),
+		NavDirectionDown:  img.Rect(cx, r.Max.Y, cx+1, r.Max.Y+d[NavDirectionDown]),
+		NavDirectionLeft:  img.Rect(r.Min.X-d[NavDirectionLeft], cy, r.Min.X, cy+1),
+		NavDirectionRight: img.Rect(r.Max.X, cy, r.Max.X+d[NavDirectionRight], cy+1),
+	}
+
+	for dir, rr := range rulers {
+		if d[dir] <= 0 {
+			continue
+		}
+
+		fillRect(screen, rr, c)
+
+		// end ticks
+		if rr.Dx() == 1 {
+			fillRect(screen, img.Rect(rr.Min.X-2, rr.Min.Y, rr.Max.X+2, rr.Min.Y+1), c)
+			fillRect(screen, img.Rect(rr.Min.X-2, rr.Max.Y-1, rr.Max.X+2, rr.Max.Y), c)
+			l.drawLabel(screen, fmt.Sprint(d[dir]), rr.Max.X+3, (rr.Min.Y+rr.Max.Y)/2, c)
+		} else {
+			fillRect(screen, img.Rect(rr.Min.X, rr.Min.Y-2, rr.Min.X+1, rr.Max.Y+2), c)
+			fillRect(screen, img.Rect(rr.Max.X-1, rr.Min.Y-2, rr.Max.X, rr.Max.Y+2), c)
+			l.drawLabel(screen, fmt.Sprint(d[dir]), (rr.Min.X+rr.Max.X)/2, rr.Min.Y-2, c)
+		}
+	}
+}
+
+func (l *LayoutInspector) drawLabel(screen *ebiten.Image, s string, x int, y int, c color.Color) {
+	if l.face == nil {
+		return
+	}
+	text.Draw(screen, s, l.face, x, y, c)
+}
+
+// layoutInspectorDistances returns the distances from r to the closest of siblings in each direction, indexed
+// by NavDirection. Only siblings that overlap r on the orthogonal axis are considered. If there is no such
+// sibling, the distance to the respective edge of bounds is returned.
+func layoutInspectorDistances(r img.Rectangle, siblings []img.Rectangle, bounds img.Rectangle) [4]int {
+	d := [4]int{
+		NavDirectionUp:    r.Min.Y - bounds.Min.Y,
+		NavDirectionDown:  bounds.Max.Y - r.Max.Y,
+		NavDirectionLeft:  r.Min.X - bounds.Min.X,
+		NavDirectionRight: bounds.Max.X - r.Max.X,
+	}
+
+	for _, s := range siblings {
+		overlapX := s.Min.X < r.Max.X && s.Max.X > r.Min.X
+		overlapY := s.Min.Y < r.Max.Y && s.Max.Y > r.Min.Y
+
+		switch {
+		case overlapX && s.Max.Y <= r.Min.Y:
+			d[NavDirectionUp] = minInt(d[NavDirectionUp], r.Min.Y-s.Max.Y)
+		case overlapX && s.Min.Y >= r.Max.Y:
+			d[NavDirectionDown] = minInt(d[NavDirectionDown], s.Min.Y-r.Max.Y)
+		case overlapY && s.Max.X <= r.Min.X:
+			d[NavDirectionLeft] = minInt(d[NavDirectionLeft], r.Min.X-s.Max.X)
+		case overlapY && s.Min.X >= r.Max.X:
+			d[NavDirectionRight] = minInt(d[NavDirectionRight], s.Min.X-r.Max.X)
+		}
+	}
+
+	return d
+}
+
+// parentContainer returns the Container in the widget tree of root that has w as a child, or nil if there is none.
+func parentContainer(root interface{}, w HasWidget) *Container {
+	var children []PreferredSizeLocateableWidget
+	switch c := root.(type) {
+	case *Container:
+		children = c.children
+		for _, ch := range children {
+			if ch == w {
+				return c
+			}
+		}
+	case *Window:
+		children = []PreferredSizeLocateableWidget{c.contents}
+	}
+
+	for _, ch := range children {
+		if p := parentContainer(ch, w); p != nil {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// fillInsets fills the area between r and r shrunk by i with color c.
+func fillInsets(screen *ebiten.Image, r img.Rectangle, i Insets, c color.Color) {
+	fillRect(screen, img.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+i.Top), c)
+	fillRect(screen, img.Rect(r.Min.X, r.Max.Y-i.Bottom, r.Max.X, r.Max.Y), c)
+	fillRect(screen, img.Rect(r.Min.X, r.Min.Y+i.Top, r.Min.X+i.Left, r.Max.Y-i.Bottom), c)
+	fillRect(screen, img.Rect(r.Max.X-i.Right, r.Min.Y+i.Top, r.Max.X, r.Max.Y-i.Bottom), c)
+}
+
+func strokeRect(screen *ebiten.Image, r img.Rectangle, c color.Color) {
+	fillInsets(screen, r, Insets{Top: 1, Left: 1, Right: 1, Bottom: 1}, c)
+}
+
+func fillRect(screen *ebiten.Image, r img.Rectangle, c color.Color) {
+	if c == nil || r.Empty() {
+		return
+	}
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(float64(r.Dx()), float64(r.Dy()))
+	opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	screen.DrawImage(image.NewImageColor(c), &opts)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
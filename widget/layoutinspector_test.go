@@ -0,0 +1,39 @@
+package widget
+
+import (
+	img "image"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLayoutInspectorDistances(t *testing.T) {
+	is := is.New(t)
+
+	bounds := img.Rect(0, 0, 100, 100)
+	r := img.Rect(40, 40, 60, 60)
+
+	d := layoutInspectorDistances(r, nil, bounds)
+	is.Equal(d, [4]int{40, 40, 40, 40})
+
+	d = layoutInspectorDistances(r, []img.Rectangle{
+		img.Rect(45, 10, 55, 30), // above
+		img.Rect(70, 50, 80, 70), // right
+		img.Rect(0, 70, 30, 90),  // below left, not overlapping
+	}, bounds)
+	is.Equal(d, [4]int{10, 40, 40, 10})
+}
+
+func TestParentContainer(t *testing.T) {
+	is := is.New(t)
+
+	w := newSimpleWidget(10, 10, nil)
+	inner := newContainer(t)
+	inner.AddChild(w)
+	outer := newContainer(t)
+	outer.AddChild(inner)
+
+	is.Equal(parentContainer(outer, w), inner)
+	is.Equal(parentContainer(outer, inner), outer)
+	is.True(parentContainer(outer, newSimpleWidget(10, 10, nil)) == nil)
+}
@@ -4,7 +4,9 @@ import (
 	img "image"
 	"image/color"
 	"math"
+	"strings"
 
+	"github.com/blizzy78/ebitenui/clipboard"
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/image"
 	"github.com/blizzy78/ebitenui/input"
@@ -14,16 +16,19 @@ import (
 )
 
 type List struct {
-	EntrySelectedEvent *event.Event
+	EntrySelectedEvent  *event.Event
+	SectionChangedEvent *event.Event
 
 	containerOpts            []ContainerOpt
 	scrollContainerOpts      []ScrollContainerOpt
 	sliderOpts               []SliderOpt
 	entries                  []interface{}
 	entryLabelFunc           ListEntryLabelFunc
+	entryWidgetFunc          ListEntryWidgetFunc
 	entryFace                font.Face
 	entryUnselectedColor     *ButtonImage
 	entrySelectedColor       *ButtonImage
+	entryAlternateColor      *ButtonImage
 	entryUnselectedTextColor *ButtonTextColor
 	entryTextColor           *ButtonTextColor
 	entryTextPadding         Insets
@@ -31,20 +36,45 @@ type List struct {
 	hideHorizontalSlider     bool
 	hideVerticalSlider       bool
 	allowReselect            bool
+	headerFunc               ListEntryHeaderFunc
+	headerImage              *image.NineSlice
+	headerTextColor          color.Color
+	loadingFunc              ListEntryLoadingFunc
+	skeleton                 *Skeleton
+	copyFunc                 ListEntryCopyFunc
 
 	init            *MultiOnce
 	container       *Container
 	scrollContainer *ScrollContainer
-	vSlider         *Slider
-	hSlider         *Slider
+	content         *Container
+	vScrollbar      *Scrollbar
+	hScrollbar      *Scrollbar
 	buttons         []*Button
+	buttonPool      []*Button
 	selectedEntry   interface{}
+	section         interface{}
+	stickyHeader    *Button
+	copyKeyPressed  bool
 }
 
 type ListOpt func(l *List)
 
 type ListEntryLabelFunc func(e interface{}) string
 
+// ListEntryWidgetFunc is a function that returns a widget to display entry e. If recycled is not nil, it is a
+// widget previously returned for another entry that is no longer displayed. It may be updated and returned
+// to avoid constructing a new widget.
+type ListEntryWidgetFunc func(e interface{}, recycled PreferredSizeLocateableWidget) PreferredSizeLocateableWidget
+
+// ListEntryHeaderFunc is a function that returns whether entry e is a section header.
+type ListEntryHeaderFunc func(e interface{}) bool
+
+// ListEntryCopyFunc is a function that returns the fields of entry e, to be copied to the clipboard.
+type ListEntryCopyFunc func(e interface{}) []string
+
+// ListEntryLoadingFunc is a function that returns whether entry e is still being loaded.
+type ListEntryLoadingFunc func(e interface{}) bool
+
 type ListEntryColor struct {
 	Unselected                 color.Color
 	Selected                   color.Color
@@ -52,6 +82,17 @@ type ListEntryColor struct {
 	DisabledSelected           color.Color
 	SelectedBackground         color.Color
 	DisabledSelectedBackground color.Color
+
+	// HoverBackground is the background color of unselected entries while the cursor hovers over them.
+	// It may be nil.
+	HoverBackground color.Color
+
+	// PressedBackground is the background color of entries while they are pressed. It may be nil.
+	PressedBackground color.Color
+
+	// AlternateBackground is the background color of every other unselected entry, starting with the
+	// second one, to make long lists easier to read. It may be nil.
+	AlternateBackground color.Color
 }
 
 type ListEntrySelectedEventArgs struct {
@@ -62,6 +103,14 @@ type ListEntrySelectedEventArgs struct {
 
 type ListEntrySelectedHandlerFunc func(args *ListEntrySelectedEventArgs)
 
+type ListSectionChangedEventArgs struct {
+	List            *List
+	Section         interface{}
+	PreviousSection interface{}
+}
+
+type ListSectionChangedHandlerFunc func(args *ListSectionChangedEventArgs)
+
 type ListOptions struct {
 }
 
@@ -69,7 +118,8 @@ var ListOpts ListOptions
 
 func NewList(opts ...ListOpt) *List {
 	l := &List{
-		EntrySelectedEvent: &event.Event{},
+		EntrySelectedEvent:  &event.Event{},
+		SectionChangedEvent: &event.Event{},
 
 		init: &MultiOnce{},
 	}
@@ -131,6 +181,15 @@ func (o ListOptions) EntryLabelFunc(f ListEntryLabelFunc) ListOpt {
 	}
 }
 
+// EntryWidgetFunc configures a List to display entries using widgets returned by f, instead of using labels
+// returned by the function configured using EntryLabelFunc. The widgets are stretched to the entries' size,
+// replacing their layout data. Widgets of entries that are removed are recycled.
+func (o ListOptions) EntryWidgetFunc(f ListEntryWidgetFunc) ListOpt {
+	return func(l *List) {
+		l.entryWidgetFunc = f
+	}
+}
+
 func (o ListOptions) EntryFontFace(f font.Face) ListOpt {
 	return func(l *List) {
 		l.entryFace = f
@@ -141,14 +200,27 @@ func (o ListOptions) EntryColor(c *ListEntryColor) ListOpt {
 	return func(l *List) {
 		l.entryUnselectedColor = &ButtonImage{
 			Idle:     image.NewNineSliceColor(color.Transparent),
+			Hover:    nineSliceColorOrNil(c.HoverBackground),
+			Pressed:  nineSliceColorOrNil(c.PressedBackground),
 			Disabled: image.NewNineSliceColor(color.Transparent),
 		}
 
 		l.entrySelectedColor = &ButtonImage{
 			Idle:     image.NewNineSliceColor(c.SelectedBackground),
+			Pressed:  nineSliceColorOrNil(c.PressedBackground),
 			Disabled: image.NewNineSliceColor(c.DisabledSelectedBackground),
 		}
 
+		l.entryAlternateColor = nil
+		if c.AlternateBackground != nil {
+			l.entryAlternateColor = &ButtonImage{
+				Idle:     image.NewNineSliceColor(c.AlternateBackground),
+				Hover:    l.entryUnselectedColor.Hover,
+				Pressed:  l.entryUnselectedColor.Pressed,
+				Disabled: image.NewNineSliceColor(c.AlternateBackground),
+			}
+		}
+
 		l.entryUnselectedTextColor = &ButtonTextColor{
 			Idle:     c.Unselected,
 			Disabled: c.DisabledUnselected,
@@ -161,6 +233,18 @@ func (o ListOptions) EntryColor(c *ListEntryColor) ListOpt {
 	}
 }
 
+// EntryImages configures a List to draw entries using nine-slice images instead of the background colors
+// configured using EntryColor. Unselected entries are drawn using unselected, and selected entries are drawn
+// using selected. If alternate is not nil, every other unselected entry, starting with the second one, is
+// drawn using alternate.
+func (o ListOptions) EntryImages(unselected *ButtonImage, alternate *ButtonImage, selected *ButtonImage) ListOpt {
+	return func(l *List) {
+		l.entryUnselectedColor = unselected
+		l.entryAlternateColor = alternate
+		l.entrySelectedColor = selected
+	}
+}
+
 func (o ListOptions) EntryTextPadding(i Insets) ListOpt {
 	return func(l *List) {
 		l.entryTextPadding = i
@@ -175,6 +259,50 @@ func (o ListOptions) EntrySelectedHandler(f ListEntrySelectedHandlerFunc) ListOp
 	}
 }
 
+// SectionHeaders configures a List to display entries for which f returns true as section headers, using
+// labels returned by the function configured using EntryLabelFunc, drawn using image i and text color c.
+// Section headers cannot be selected. While a section is scrolled through, its header sticks to the top
+// of the List's viewport.
+func (o ListOptions) SectionHeaders(f ListEntryHeaderFunc, i *image.NineSlice, c color.Color) ListOpt {
+	return func(l *List) {
+		l.headerFunc = f
+		l.headerImage = i
+		l.headerTextColor = c
+	}
+}
+
+// Loading configures a List to display entries for which f returns true as placeholder blocks drawn according
+// to s, for example while the entries are being loaded. Such entries cannot be selected. Once they have been
+// loaded, the List's entries should be updated using SetEntries.
+func (o ListOptions) Loading(f ListEntryLoadingFunc, s *Skeleton) ListOpt {
+	return func(l *List) {
+		l.loadingFunc = f
+		l.skeleton = s
+	}
+}
+
+// Copyable configures a List to copy the selected entry to the clipboard when Ctrl+C is pressed while the
+// cursor is over the List. The fields returned by f are copied as tab-separated text. If f is nil, the label
+// returned by the function configured using EntryLabelFunc is copied.
+func (o ListOptions) Copyable(f ListEntryCopyFunc) ListOpt {
+	return func(l *List) {
+		if f == nil {
+			f = func(e interface{}) []string {
+				return []string{l.entryLabelFunc(e)}
+			}
+		}
+		l.copyFunc = f
+	}
+}
+
+func (o ListOptions) SectionChangedHandler(f ListSectionChangedHandlerFunc) ListOpt {
+	return func(l *List) {
+		l.SectionChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ListSectionChangedEventArgs))
+		})
+	}
+}
+
 func (o ListOptions) AllowReselect() ListOpt {
 	return func(l *List) {
 		l.allowReselect = true
@@ -186,6 +314,12 @@ func (l *List) GetWidget() *Widget {
 	return l.container.GetWidget()
 }
 
+// ContainedWidgets implements Containerer.
+func (l *List) ContainedWidgets() []HasWidget {
+	l.init.Do()
+	return containedWidgets(l.container)
+}
+
 func (l *List) PreferredSize() (int, int) {
 	l.init.Do()
 	return l.container.PreferredSize()
@@ -211,16 +345,120 @@ func (l *List) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 
 	d := l.container.GetWidget().Disabled
 
-	if l.vSlider != nil {
-		l.vSlider.DrawTrackDisabled = d
+	l.updateScrollbars()
+
+	if l.vScrollbar != nil {
+		l.vScrollbar.slider.DrawTrackDisabled = d
 	}
-	if l.hSlider != nil {
-		l.hSlider.DrawTrackDisabled = d
+	if l.hScrollbar != nil {
+		l.hScrollbar.slider.DrawTrackDisabled = d
 	}
 
 	l.scrollContainer.GetWidget().Disabled = d
 
+	if l.copyFunc != nil {
+		l.handleCopy()
+	}
+
 	l.container.Render(screen, def)
+
+	if l.headerFunc != nil {
+		l.renderStickyHeader(screen, def)
+	}
+}
+
+// renderStickyHeader updates the current section, and renders its header at the top of the viewport if the
+// header itself has been scrolled out of view. The header is pushed out by the next section's header.
+func (l *List) renderStickyHeader(screen *ebiten.Image, def DeferredRenderFunc) {
+	cr := l.scrollContainer.ContentRect()
+
+	current, next := -1, -1
+	for i, e := range l.entries {
+		if !l.headerFunc(e) {
+			continue
+		}
+
+		if l.buttons[i].GetWidget().Rect.Min.Y <= cr.Min.Y {
+			current = i
+			continue
+		}
+
+		next = i
+		break
+	}
+
+	var section interface{}
+	if current >= 0 {
+		section = l.entries[current]
+	}
+	l.setSection(section)
+
+	if current < 0 || l.buttons[current].GetWidget().Rect.Min.Y == cr.Min.Y {
+		return
+	}
+
+	h := l.buttons[current].GetWidget().Rect.Dy()
+	y := cr.Min.Y
+	if next >= 0 {
+		if ny := l.buttons[next].GetWidget().Rect.Min.Y; ny-h < y {
+			y = ny - h
+		}
+	}
+
+	t := l.stickyHeader.Text()
+	if label := l.entryLabelFunc(section); t.Label != label {
+		t.Label = label
+		l.stickyHeader.RequestRelayout()
+	}
+
+	rect := img.Rect(cr.Min.X, y, cr.Max.X, y+h)
+	if rect != l.stickyHeader.GetWidget().Rect {
+		l.stickyHeader.SetLocation(rect)
+		l.stickyHeader.RequestRelayout()
+	}
+
+	l.stickyHeader.GetWidget().Disabled = l.container.GetWidget().Disabled
+	l.stickyHeader.Render(screen.SubImage(cr).(*ebiten.Image), def)
+}
+
+func (l *List) setSection(s interface{}) {
+	if s == l.section {
+		return
+	}
+
+	prev := l.section
+	l.section = s
+
+	l.SectionChangedEvent.Fire(&ListSectionChangedEventArgs{
+		List:            l,
+		Section:         s,
+		PreviousSection: prev,
+	})
+}
+
+// Section returns the section header entry of the section currently displayed at the top of the viewport,
+// or nil if there is none.
+func (l *List) Section() interface{} {
+	l.init.Do()
+	return l.section
+}
+
+func (l *List) handleCopy() {
+	p := input.KeyPressed(ebiten.KeyControl) && input.KeyPressed(ebiten.KeyC)
+	if p && !l.copyKeyPressed && l.container.GetWidget().In(input.CursorPosition()) {
+		l.Copy()
+	}
+	l.copyKeyPressed = p
+}
+
+// Copy copies the selected entry to the clipboard as tab-separated text, as configured using
+// ListOptions.Copyable. It does nothing if no entry is selected.
+func (l *List) Copy() {
+	if l.selectedEntry == nil || l.copyFunc == nil {
+		return
+	}
+
+	_ = clipboard.WriteText(strings.Join(l.copyFunc(l.selectedEntry), "\t"))
 }
 
 func (l *List) createWidget() {
@@ -239,78 +477,278 @@ func (l *List) createWidget() {
 				GridLayoutOpts.Spacing(l.controlWidgetSpacing, l.controlWidgetSpacing))))...)
 	l.containerOpts = nil
 
-	content := NewContainer(
+	l.content = NewContainer(
 		ContainerOpts.Layout(NewRowLayout(
 			RowLayoutOpts.Direction(DirectionVertical))),
 		ContainerOpts.AutoDisableChildren())
 
+	if l.headerFunc != nil {
+		l.stickyHeader = l.newHeaderButton("")
+	}
+
 	l.buttons = make([]*Button, 0, len(l.entries))
 	for _, e := range l.entries {
-		e := e
-		but := NewButton(
-			ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
-				Stretch: true,
-			})),
-			ButtonOpts.Image(l.entryUnselectedColor),
-			ButtonOpts.TextSimpleLeft(l.entryLabelFunc(e), l.entryFace, l.entryUnselectedTextColor, l.entryTextPadding),
-			ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
-				l.setSelectedEntry(e, true)
-			}))
-
-		l.buttons = append(l.buttons, but)
-
-		content.AddChild(but)
+		l.addEntryButton(e)
 	}
 
 	l.scrollContainer = NewScrollContainer(append(l.scrollContainerOpts, []ScrollContainerOpt{
-		ScrollContainerOpts.Content(content),
+		ScrollContainerOpts.Content(l.content),
 		ScrollContainerOpts.StretchContentWidth(),
 	}...)...)
 	l.scrollContainerOpts = nil
 	l.container.AddChild(l.scrollContainer)
 
 	if !l.hideVerticalSlider {
-		pageSizeFunc := func() int {
-			return int(math.Round(float64(l.scrollContainer.ContentRect().Dy()) / float64(content.GetWidget().Rect.Dy()) * 1000))
-		}
-
-		l.vSlider = NewSlider(append(l.sliderOpts, []SliderOpt{
-			SliderOpts.Direction(DirectionVertical),
-			SliderOpts.MinMax(0, 1000),
-			SliderOpts.PageSizeFunc(pageSizeFunc),
-			SliderOpts.ChangedHandler(func(args *SliderChangedEventArgs) {
-				l.scrollContainer.ScrollTop = float64(args.Slider.Current) / 1000
-			}),
-		}...)...)
-		l.container.AddChild(l.vSlider)
+		l.vScrollbar = NewScrollbar(
+			ScrollbarOpts.SliderOpts(l.sliderOpts...),
+			ScrollbarOpts.Direction(DirectionVertical),
+			ScrollbarOpts.ChangedHandler(func(args *ScrollbarChangedEventArgs) {
+				x, _ := l.scrollContainer.ScrollOffset()
+				l.scrollContainer.SetScrollOffset(x, args.Offset)
+			}))
+		l.container.AddChild(l.vScrollbar)
 
 		l.scrollContainer.widget.ScrolledEvent.AddHandler(func(args interface{}) {
 			a := args.(*WidgetScrolledEventArgs)
-			p := pageSizeFunc() / 3
+			p := l.scrollContainer.ContentRect().Dy() / 3
 			if p < 1 {
 				p = 1
 			}
-			l.vSlider.Current -= int(math.Round(a.Y * float64(p)))
+			x, y := l.scrollContainer.ScrollOffset()
+			l.scrollContainer.SetScrollOffset(x, y-int(math.Round(a.Y*float64(p))))
 		})
 	}
 
 	if !l.hideHorizontalSlider {
-		l.hSlider = NewSlider(append(l.sliderOpts, []SliderOpt{
-			SliderOpts.Direction(DirectionHorizontal),
-			SliderOpts.MinMax(0, 1000),
-			SliderOpts.PageSizeFunc(func() int {
-				return int(math.Round(float64(l.scrollContainer.ContentRect().Dx()) / float64(content.GetWidget().Rect.Dx()) * 1000))
-			}),
-			SliderOpts.ChangedHandler(func(args *SliderChangedEventArgs) {
-				l.scrollContainer.ScrollLeft = float64(args.Slider.Current) / 1000
-			}),
-		}...)...)
-		l.container.AddChild(l.hSlider)
+		l.hScrollbar = NewScrollbar(
+			ScrollbarOpts.SliderOpts(l.sliderOpts...),
+			ScrollbarOpts.Direction(DirectionHorizontal),
+			ScrollbarOpts.ChangedHandler(func(args *ScrollbarChangedEventArgs) {
+				_, y := l.scrollContainer.ScrollOffset()
+				l.scrollContainer.SetScrollOffset(args.Offset, y)
+			}))
+		l.container.AddChild(l.hScrollbar)
 	}
 
 	l.sliderOpts = nil
 }
 
+// addEntryButton adds a button for entry e to l's content.
+func (l *List) addEntryButton(e interface{}) {
+	var but *Button
+	switch {
+	case l.isHeader(e):
+		but = l.newHeaderButton(l.entryLabelFunc(e))
+	case l.isLoading(e):
+		but = l.newSkeletonButton()
+	case l.entryWidgetFunc != nil:
+		but = l.templateEntryButton(e)
+	default:
+		but = NewButton(
+			ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+				Stretch: true,
+			})),
+			ButtonOpts.Image(l.entryUnselectedColor),
+			ButtonOpts.TextSimpleLeft(l.entryLabelFunc(e), l.entryFace, l.entryUnselectedTextColor, l.entryTextPadding),
+			ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+				l.setSelectedEntry(e, true)
+			}))
+	}
+
+	l.buttons = append(l.buttons, but)
+	l.updateEntryButton(len(l.buttons) - 1)
+
+	l.content.AddChild(but)
+}
+
+// newHeaderButton returns a button that displays a section header with label.
+func (l *List) newHeaderButton(label string) *Button {
+	return NewButton(
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+			Stretch: true,
+		})),
+		ButtonOpts.Image(&ButtonImage{
+			Idle:     l.headerImage,
+			Disabled: l.headerImage,
+		}),
+		ButtonOpts.TextSimpleLeft(label, l.entryFace, &ButtonTextColor{
+			Idle:     l.headerTextColor,
+			Disabled: l.headerTextColor,
+		}, l.entryTextPadding))
+}
+
+func (l *List) isHeader(e interface{}) bool {
+	return l.headerFunc != nil && l.headerFunc(e)
+}
+
+// newSkeletonButton returns a button that displays a placeholder block for an entry being loaded.
+func (l *List) newSkeletonButton() *Button {
+	return NewButton(
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+			Stretch: true,
+		})),
+		func(b *Button) {
+			b.init.Append(func() {
+				b.container = NewContainer(
+					ContainerOpts.Layout(NewAnchorLayout(AnchorLayoutOpts.Padding(l.entryTextPadding))))
+				b.container.AddChild(NewText(
+					TextOpts.WidgetOpts(WidgetOpts.LayoutData(AnchorLayoutData{
+						StretchHorizontal: true,
+					})),
+					TextOpts.Text("", l.entryFace, nil),
+					TextOpts.Skeleton(l.skeleton)))
+			})
+		})
+}
+
+func (l *List) isLoading(e interface{}) bool {
+	return l.loadingFunc != nil && l.loadingFunc(e)
+}
+
+// pooled returns whether the button of entry e may be recycled.
+func (l *List) pooled(e interface{}) bool {
+	return l.entryWidgetFunc != nil && !l.isHeader(e) && !l.isLoading(e)
+}
+
+// templateEntryButton returns a button that displays the widget returned by l.entryWidgetFunc for entry e.
+// A button from l.buttonPool is reused if possible.
+func (l *List) templateEntryButton(e interface{}) *Button {
+	var but *Button
+	var recycled PreferredSizeLocateableWidget
+
+	if n := len(l.buttonPool); n > 0 {
+		but = l.buttonPool[n-1]
+		l.buttonPool = l.buttonPool[:n-1]
+		recycled = but.container.children[0]
+	}
+
+	w := l.entryWidgetFunc(e, recycled)
+	w.GetWidget().LayoutData = AnchorLayoutData{
+		StretchHorizontal: true,
+		StretchVertical:   true,
+	}
+
+	if but != nil {
+		if w != recycled {
+			but.container.ReplaceChild(recycled, w)
+		}
+		return but
+	}
+
+	but = NewButton(
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+			Stretch: true,
+		})),
+		ButtonOpts.Image(l.entryUnselectedColor),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			if i := l.buttonIndex(args.Button); i >= 0 {
+				l.setSelectedEntry(l.entries[i], true)
+			}
+		}),
+		func(b *Button) {
+			b.init.Append(func() {
+				b.container = NewContainer(
+					ContainerOpts.Layout(NewAnchorLayout(AnchorLayoutOpts.Padding(l.entryTextPadding))),
+					ContainerOpts.AutoDisableChildren())
+				b.container.AddChild(w)
+			})
+		})
+
+	// make sure the content container exists before the button is recycled
+	but.GetWidget()
+
+	return but
+}
+
+func (l *List) buttonIndex(b *Button) int {
+	for i, but := range l.buttons {
+		if but == b {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetEntries replaces l's entries with e, without reconstructing l. The scroll position is preserved, and so
+// is the selected entry if it is contained in e. Otherwise, no entry is selected afterwards.
+func (l *List) SetEntries(e []interface{}) {
+	l.init.Do()
+
+	for i, b := range l.buttons {
+		if l.pooled(l.entries[i]) {
+			l.buttonPool = append(l.buttonPool, b)
+		}
+	}
+
+	l.entries = e
+
+	l.content.RemoveAllChildren()
+	l.buttons = make([]*Button, 0, len(e))
+	for _, en := range e {
+		l.addEntryButton(en)
+	}
+
+	if l.selectedEntry != nil && l.entryIndex(l.selectedEntry) < 0 {
+		l.setSelectedEntry(nil, false)
+	}
+}
+
+// AddEntry adds e to the end of l's entries.
+func (l *List) AddEntry(e interface{}) {
+	l.init.Do()
+
+	// don't append to a slice that may have been passed in
+	l.entries = append(l.entries[:len(l.entries):len(l.entries)], e)
+
+	l.addEntryButton(e)
+}
+
+// RemoveEntry removes e from l's entries. If e is the selected entry, no entry is selected afterwards.
+// It does nothing if e is not one of l's entries.
+func (l *List) RemoveEntry(e interface{}) {
+	l.init.Do()
+
+	i := l.entryIndex(e)
+	if i < 0 {
+		return
+	}
+
+	if l.pooled(e) {
+		l.buttonPool = append(l.buttonPool, l.buttons[i])
+	}
+
+	entries := make([]interface{}, 0, len(l.entries)-1)
+	entries = append(entries, l.entries[:i]...)
+	l.entries = append(entries, l.entries[i+1:]...)
+
+	l.content.RemoveChild(l.buttons[i])
+	l.buttons = append(l.buttons[:i], l.buttons[i+1:]...)
+
+	if e == l.selectedEntry {
+		l.setSelectedEntry(nil, false)
+		return
+	}
+
+	for ; i < len(l.buttons); i++ {
+		l.updateEntryButton(i)
+	}
+}
+
+// Entries returns l's entries.
+func (l *List) Entries() []interface{} {
+	l.init.Do()
+	return l.entries
+}
+
+func (l *List) entryIndex(e interface{}) int {
+	for i, en := range l.entries {
+		if en == e {
+			return i
+		}
+	}
+	return -1
+}
+
 func (l *List) SetSelectedEntry(e interface{}) {
 	l.setSelectedEntry(e, false)
 }
@@ -322,14 +760,8 @@ func (l *List) setSelectedEntry(e interface{}, user bool) {
 		prev := l.selectedEntry
 		l.selectedEntry = e
 
-		for i, b := range l.buttons {
-			if l.entries[i] == e {
-				b.Image = l.entrySelectedColor
-				b.TextColor = l.entryTextColor
-			} else {
-				b.Image = l.entryUnselectedColor
-				b.TextColor = l.entryUnselectedTextColor
-			}
+		for i := range l.buttons {
+			l.updateEntryButton(i)
 		}
 
 		l.EntrySelectedEvent.Fire(&ListEntrySelectedEventArgs{
@@ -339,6 +771,49 @@ func (l *List) setSelectedEntry(e interface{}, user bool) {
 	}
 }
 
+// updateEntryButton updates the images and text color of the button of the entry at index i.
+func (l *List) updateEntryButton(i int) {
+	if l.isHeader(l.entries[i]) {
+		return
+	}
+
+	b := l.buttons[i]
+
+	switch {
+	case l.isLoading(l.entries[i]):
+		bi := l.entryUnselectedColor
+		if l.sectionRow(i)%2 == 1 && l.entryAlternateColor != nil {
+			bi = l.entryAlternateColor
+		}
+		b.Image = &ButtonImage{
+			Idle:     bi.Idle,
+			Disabled: bi.Disabled,
+		}
+	case l.entries[i] == l.selectedEntry:
+		b.Image = l.entrySelectedColor
+		b.TextColor = l.entryTextColor
+	case l.sectionRow(i)%2 == 1 && l.entryAlternateColor != nil:
+		b.Image = l.entryAlternateColor
+		b.TextColor = l.entryUnselectedTextColor
+	default:
+		b.Image = l.entryUnselectedColor
+		b.TextColor = l.entryUnselectedTextColor
+	}
+}
+
+// sectionRow returns the index of the entry at index i, counted from the start of its section.
+func (l *List) sectionRow(i int) int {
+	if l.headerFunc == nil {
+		return i
+	}
+
+	row := 0
+	for j := i - 1; j >= 0 && !l.isHeader(l.entries[j]); j-- {
+		row++
+	}
+	return row
+}
+
 func (l *List) SelectedEntry() interface{} {
 	l.init.Do()
 	return l.selectedEntry
@@ -346,16 +821,34 @@ func (l *List) SelectedEntry() interface{} {
 
 func (l *List) SetScrollTop(t float64) {
 	l.init.Do()
-	if l.vSlider != nil {
-		l.vSlider.Current = int(math.Round(t * 1000))
-	}
 	l.scrollContainer.ScrollTop = t
 }
 
 func (l *List) SetScrollLeft(left float64) {
 	l.init.Do()
-	if l.hSlider != nil {
-		l.hSlider.Current = int(math.Round(left * 1000))
-	}
 	l.scrollContainer.ScrollLeft = left
 }
+
+// updateScrollbars updates the ranges and offsets of l's scrollbars to match the scroll container.
+func (l *List) updateScrollbars() {
+	cr := l.scrollContainer.ContentRect()
+	cw, ch := l.scrollContainer.contentSize()
+	x, y := l.scrollContainer.ScrollOffset()
+
+	if l.vScrollbar != nil {
+		l.vScrollbar.SetRange(ch, cr.Dy())
+		l.vScrollbar.SetOffset(y)
+	}
+
+	if l.hScrollbar != nil {
+		l.hScrollbar.SetRange(cw, cr.Dx())
+		l.hScrollbar.SetOffset(x)
+	}
+}
+
+func nineSliceColorOrNil(c color.Color) *image.NineSlice {
+	if c == nil {
+		return nil
+	}
+	return image.NewNineSliceColor(c)
+}
@@ -1,9 +1,12 @@
 package widget
 
 import (
+	img "image"
 	"image/color"
+	"strconv"
 	"testing"
 
+	"github.com/blizzy78/ebitenui/clipboard"
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/matryer/is"
 )
@@ -125,10 +128,222 @@ func TestList_EntrySelectedEvent_User_AllowReselect(t *testing.T) {
 	is.Equal(numEvents, 2)
 }
 
+func TestList_SetEntries(t *testing.T) {
+	is := is.New(t)
+
+	entries := []interface{}{"first", "second", "third"}
+
+	list := newList(t,
+		ListOpts.Entries(entries),
+
+		ListOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(string)
+		}))
+
+	list.SetSelectedEntry("second")
+	event.ExecuteDeferred()
+
+	list.SetEntries([]interface{}{"second", "fourth"})
+	is.Equal(list.Entries(), []interface{}{"second", "fourth"})
+	is.Equal(len(listEntryButtons(list)), 2)
+	is.Equal(list.SelectedEntry(), "second")
+	is.Equal(listEntryButtons(list)[0].Image, list.entrySelectedColor)
+
+	list.SetEntries([]interface{}{"fifth"})
+	is.Equal(list.SelectedEntry(), nil)
+}
+
+func TestList_AddEntry_RemoveEntry(t *testing.T) {
+	is := is.New(t)
+
+	entries := []interface{}{"first", "second"}
+
+	list := newList(t,
+		ListOpts.Entries(entries),
+
+		ListOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(string)
+		}))
+
+	list.AddEntry("third")
+	is.Equal(list.Entries(), []interface{}{"first", "second", "third"})
+	is.Equal(len(listEntryButtons(list)), 3)
+
+	list.SetSelectedEntry("second")
+	event.ExecuteDeferred()
+
+	list.RemoveEntry("second")
+	is.Equal(list.Entries(), []interface{}{"first", "third"})
+	is.Equal(len(listEntryButtons(list)), 2)
+	is.Equal(list.SelectedEntry(), nil)
+	is.Equal(entries, []interface{}{"first", "second"}) // original slice unchanged
+}
+
+func TestList_EntryWidgetFunc_Recycle(t *testing.T) {
+	is := is.New(t)
+
+	created := 0
+	recycled := 0
+
+	list := newList(t,
+		ListOpts.Entries([]interface{}{"first", "second"}),
+
+		ListOpts.EntryWidgetFunc(func(e interface{}, w PreferredSizeLocateableWidget) PreferredSizeLocateableWidget {
+			if w != nil {
+				recycled++
+				return w
+			}
+
+			created++
+			return newSimpleWidget(10, 10, nil)
+		}))
+
+	is.Equal(created, 2)
+
+	list.SetEntries([]interface{}{"third", "fourth", "fifth"})
+	is.Equal(created, 3)
+	is.Equal(recycled, 2)
+
+	list.RemoveEntry("fourth")
+	list.AddEntry("sixth")
+	is.Equal(created, 3)
+	is.Equal(recycled, 3)
+
+	listEntryButtons(list)[1].ClickedEvent.Fire(&ButtonClickedEventArgs{
+		Button: listEntryButtons(list)[1],
+	})
+	event.ExecuteDeferred()
+	is.Equal(list.SelectedEntry(), "fifth")
+}
+
+func TestList_EntryColor_Alternate(t *testing.T) {
+	is := is.New(t)
+
+	entries := []interface{}{"first", "second", "third", "fourth"}
+
+	l := newList(t,
+		ListOpts.Entries(entries),
+		ListOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(string)
+		}),
+		ListOpts.EntryColor(&ListEntryColor{
+			Unselected:                 color.Transparent,
+			Selected:                   color.Transparent,
+			DisabledUnselected:         color.Transparent,
+			DisabledSelected:           color.Transparent,
+			SelectedBackground:         color.Black,
+			DisabledSelectedBackground: color.Black,
+			HoverBackground:            color.White,
+			AlternateBackground:        color.Black,
+		}))
+
+	is.True(l.entryUnselectedColor.Hover != nil)
+	is.Equal(l.buttons[0].Image, l.entryUnselectedColor)
+	is.Equal(l.buttons[1].Image, l.entryAlternateColor)
+	is.Equal(l.buttons[2].Image, l.entryUnselectedColor)
+	is.Equal(l.buttons[3].Image, l.entryAlternateColor)
+
+	l.SetSelectedEntry("fourth")
+	is.Equal(l.buttons[3].Image, l.entrySelectedColor)
+
+	l.RemoveEntry("first")
+	is.Equal(l.buttons[0].Image, l.entryUnselectedColor)
+	is.Equal(l.buttons[1].Image, l.entryAlternateColor)
+	is.Equal(l.buttons[2].Image, l.entrySelectedColor)
+}
+
+func TestList_SectionHeaders(t *testing.T) {
+	is := is.New(t)
+
+	entries := []interface{}{"A", "a1", "a2", "B", "b1", "b2"}
+
+	var eventArgs *ListSectionChangedEventArgs
+	l := newList(t,
+		ListOpts.Entries(entries),
+		ListOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(string)
+		}),
+		ListOpts.SectionHeaders(func(e interface{}) bool {
+			return e == "A" || e == "B"
+		}, newNineSliceEmpty(t), color.White),
+		ListOpts.SectionChangedHandler(func(args *ListSectionChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	l.SetLocation(img.Rect(0, 0, 100, 10))
+	l.RequestRelayout()
+	render(l, t)
+
+	is.Equal(l.Section(), "A")
+	is.Equal(eventArgs.Section, "A")
+
+	l.SetScrollTop(1)
+	render(l, t)
+	render(l, t)
+
+	is.Equal(l.Section(), "B")
+	is.Equal(eventArgs.PreviousSection, "A")
+}
+
+func TestList_Loading(t *testing.T) {
+	is := is.New(t)
+
+	loading := &struct{}{}
+	entries := []interface{}{"first", loading}
+
+	l := newList(t,
+		ListOpts.Entries(entries),
+		ListOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(string)
+		}),
+		ListOpts.Loading(func(e interface{}) bool {
+			return e == loading
+		}, &Skeleton{Color: color.White}))
+
+	leftMouseButtonClick(listEntryButtons(l)[1], t)
+	is.Equal(l.SelectedEntry(), nil)
+
+	l.SetEntries([]interface{}{"first", "second"})
+	render(l, t)
+
+	leftMouseButtonClick(listEntryButtons(l)[1], t)
+	is.Equal(l.SelectedEntry(), "second")
+}
+
+func TestList_Copy(t *testing.T) {
+	is := is.New(t)
+
+	clipboard.SetClipboard(&clipboard.MemoryClipboard{})
+
+	type score struct {
+		name   string
+		points int
+	}
+
+	entries := []interface{}{&score{"foo", 10}, &score{"bar", 20}}
+
+	l := newList(t,
+		ListOpts.Entries(entries),
+		ListOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(*score).name
+		}),
+		ListOpts.Copyable(func(e interface{}) []string {
+			s := e.(*score)
+			return []string{s.name, strconv.Itoa(s.points)}
+		}))
+
+	l.SetSelectedEntry(entries[1])
+	l.Copy()
+
+	s, err := clipboard.ReadText()
+	is.NoErr(err)
+	is.Equal(s, "bar\t20")
+}
+
 func newList(t *testing.T, opts ...ListOpt) *List {
 	t.Helper()
 
-	l := NewList(append(opts, []ListOpt{
+	l := NewList(append([]ListOpt{
 		ListOpts.ScrollContainerOpts(ScrollContainerOpts.Image(&ScrollContainerImage{
 			Idle:     newNineSliceEmpty(t),
 			Disabled: newNineSliceEmpty(t),
@@ -149,7 +364,7 @@ func newList(t *testing.T, opts ...ListOpt) *List {
 			SelectedBackground:         color.Transparent,
 			DisabledSelectedBackground: color.Transparent,
 		}),
-	}...)...)
+	}, opts...)...)
 
 	event.ExecuteDeferred()
 	render(l, t)
@@ -157,6 +157,75 @@ func (l *ListComboButton) SetSelectedEntry(e interface{}) {
 	l.button.SetSelectedEntry(e)
 }
 
+// SetEntries replaces the entries of l's list with e, without reconstructing l. The selected entry is preserved
+// if it is contained in e. Otherwise, the first entry is selected, or nil if e is empty.
+func (l *ListComboButton) SetEntries(e []interface{}) {
+	l.init.Do()
+
+	sel := l.SelectedEntry()
+	if !containsEntry(e, sel) {
+		sel = nil
+		if len(e) > 0 {
+			sel = e[0]
+		}
+	}
+
+	// select first so that the list keeps the selection
+	l.selectEntry(sel)
+	l.list.SetEntries(e)
+}
+
+// AddEntry adds e to the end of the entries of l's list. If no entry was selected, e is selected.
+func (l *ListComboButton) AddEntry(e interface{}) {
+	l.init.Do()
+
+	l.list.AddEntry(e)
+
+	if l.SelectedEntry() == nil {
+		l.selectEntry(e)
+	}
+}
+
+// RemoveEntry removes e from the entries of l's list. If e is the selected entry, the first remaining entry
+// is selected, or nil if there is none.
+func (l *ListComboButton) RemoveEntry(e interface{}) {
+	l.init.Do()
+
+	if e == l.SelectedEntry() {
+		var sel interface{}
+		for _, en := range l.list.entries {
+			if en != e {
+				sel = en
+				break
+			}
+		}
+
+		l.selectEntry(sel)
+	}
+
+	l.list.RemoveEntry(e)
+}
+
+// Entries returns the entries of l's list.
+func (l *ListComboButton) Entries() []interface{} {
+	l.init.Do()
+	return l.list.Entries()
+}
+
+func (l *ListComboButton) selectEntry(e interface{}) {
+	l.list.SetSelectedEntry(e)
+	l.button.SetSelectedEntry(e)
+}
+
+func containsEntry(entries []interface{}, e interface{}) bool {
+	for _, en := range entries {
+		if en == e {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *ListComboButton) SelectedEntry() interface{} {
 	l.init.Do()
 	return l.button.SelectedEntry()
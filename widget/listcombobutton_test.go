@@ -152,6 +152,39 @@ func TestListComboButton_ContentVisible_Programmatic(t *testing.T) {
 	is.True(!l.ContentVisible())
 }
 
+func TestListComboButton_SetEntries(t *testing.T) {
+	is := is.New(t)
+
+	entries := []interface{}{"first", "second", "third"}
+
+	l := newListComboButton(t,
+		ListComboButtonOpts.ListOpts(ListOpts.Entries(entries)),
+		ListComboButtonOpts.EntryLabelFunc(
+			func(e interface{}) string {
+				return "label " + e.(string)
+			},
+			func(e interface{}) string {
+				return "label " + e.(string)
+			}))
+
+	l.SetSelectedEntry("second")
+	event.ExecuteDeferred()
+
+	l.SetEntries([]interface{}{"second", "fourth"})
+	event.ExecuteDeferred()
+	is.Equal(l.SelectedEntry(), "second")
+
+	l.SetEntries([]interface{}{"fifth", "sixth"})
+	event.ExecuteDeferred()
+	is.Equal(l.SelectedEntry(), "fifth")
+	is.Equal(l.Label(), "label fifth")
+
+	l.RemoveEntry("fifth")
+	event.ExecuteDeferred()
+	is.Equal(l.SelectedEntry(), "sixth")
+	is.Equal(l.Entries(), []interface{}{"sixth"})
+}
+
 func newListComboButton(t *testing.T, opts ...ListComboButtonOpt) *ListComboButton {
 	t.Helper()
 
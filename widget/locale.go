@@ -0,0 +1,185 @@
+package widget
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale formats numbers, percentages, and dates. Widgets use the active locale to format the values they
+// display by default, such as slider labels, badge counts, or progress text.
+type Locale struct {
+	// DecimalSeparator separates the integer part of a number from its fraction, such as "." or ",".
+	DecimalSeparator string
+
+	// GroupSeparator separates groups of digits in the integer part of a number, such as "," or ".".
+	// If empty, digits are not grouped.
+	GroupSeparator string
+
+	// GroupSize is the number of digits per group. If 0, 3 is used.
+	GroupSize int
+
+	// PercentPattern is the pattern used to format percentages. The first occurrence of "#" is replaced by
+	// the number. If empty, "#%" is used.
+	PercentPattern string
+
+	// DateLayout is the layout used to format dates, in the format of time.Time.Format. If empty,
+	// "2006-01-02" is used.
+	DateLayout string
+}
+
+// LocaleFormatter is implemented by types that can format numbers, percentages, and dates. *Locale implements
+// LocaleFormatter. Applications that already use an i18n library can implement it to format values in the
+// same way everywhere.
+type LocaleFormatter interface {
+	FormatInt(v int) string
+	FormatFloat(v float64, prec int) string
+	FormatPercent(p float64, prec int) string
+	FormatDate(t time.Time) string
+}
+
+var (
+	// DefaultLocale formats numbers without grouping, using "." as the decimal separator.
+	DefaultLocale = &Locale{
+		DecimalSeparator: ".",
+	}
+
+	// LocaleEnglish formats numbers like "1,234.5".
+	LocaleEnglish = &Locale{
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		DateLayout:       "01/02/2006",
+	}
+
+	// LocaleGerman formats numbers like "1.234,5", and percentages using a no-break space.
+	LocaleGerman = &Locale{
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		PercentPattern:   "#\u00a0%",
+		DateLayout:       "02.01.2006",
+	}
+
+	// LocaleFrench formats numbers like "1 234,5", using narrow no-break spaces to group digits.
+	LocaleFrench = &Locale{
+		DecimalSeparator: ",",
+		GroupSeparator:   "\u202f",
+		PercentPattern:   "#\u00a0%",
+		DateLayout:       "02/01/2006",
+	}
+)
+
+// locale is the active locale.
+var locale LocaleFormatter = DefaultLocale
+
+// SetLocale sets the locale that widgets use to format the values they display by default. Widgets that
+// have already been laid out must be relaid out, for example using RequestRelayout, for the change to take
+// effect. If l is nil, DefaultLocale is used.
+func SetLocale(l LocaleFormatter) {
+	if l == nil {
+		l = DefaultLocale
+	}
+	locale = l
+}
+
+// CurrentLocale returns the locale that widgets use to format the values they display by default.
+func CurrentLocale() LocaleFormatter {
+	return locale
+}
+
+// FormatInt formats v, grouping digits.
+func (l *Locale) FormatInt(v int) string {
+	s := strconv.Itoa(v)
+	if v < 0 {
+		return "-" + l.group(s[1:])
+	}
+	return l.group(s)
+}
+
+// FormatFloat formats v using prec digits after the decimal separator. If prec is -1, the smallest number
+// of digits necessary to represent v is used.
+func (l *Locale) FormatFloat(v float64, prec int) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return strconv.FormatFloat(v, 'f', prec, 64)
+	}
+
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteString("-")
+	}
+	b.WriteString(l.group(intPart))
+	if frac != "" {
+		b.WriteString(l.decimalSeparator())
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// FormatPercent formats p, where 1.0 is 100%, using prec digits after the decimal separator.
+func (l *Locale) FormatPercent(p float64, prec int) string {
+	pattern := l.PercentPattern
+	if pattern == "" {
+		pattern = "#%"
+	}
+
+	pow := math.Pow(10, float64(prec))
+	v := math.Round(p*100*pow) / pow
+	return strings.Replace(pattern, "#", l.FormatFloat(v, prec), 1)
+}
+
+// FormatDate formats the date of t.
+func (l *Locale) FormatDate(t time.Time) string {
+	layout := l.DateLayout
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+	return t.Format(layout)
+}
+
+func (l *Locale) decimalSeparator() string {
+	if l.DecimalSeparator == "" {
+		return "."
+	}
+	return l.DecimalSeparator
+}
+
+// group inserts the group separator into the digits s.
+func (l *Locale) group(s string) string {
+	if l.GroupSeparator == "" {
+		return s
+	}
+
+	size := l.GroupSize
+	if size <= 0 {
+		size = 3
+	}
+
+	if len(s) <= size {
+		return s
+	}
+
+	var b strings.Builder
+	first := len(s) % size
+	if first > 0 {
+		b.WriteString(s[:first])
+	}
+	for i := first; i < len(s); i += size {
+		if b.Len() > 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+		b.WriteString(s[i : i+size])
+	}
+	return b.String()
+}
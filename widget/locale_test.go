@@ -0,0 +1,56 @@
+package widget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestLocale_FormatInt(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(DefaultLocale.FormatInt(1234567), "1234567")
+	is.Equal(LocaleEnglish.FormatInt(1234567), "1,234,567")
+	is.Equal(LocaleEnglish.FormatInt(-123456), "-123,456")
+	is.Equal(LocaleEnglish.FormatInt(999), "999")
+	is.Equal(LocaleGerman.FormatInt(12345), "12.345")
+}
+
+func TestLocale_FormatFloat(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(DefaultLocale.FormatFloat(1234.5, -1), "1234.5")
+	is.Equal(LocaleEnglish.FormatFloat(1234.5, 2), "1,234.50")
+	is.Equal(LocaleGerman.FormatFloat(-1234.5, -1), "-1.234,5")
+	is.Equal(LocaleGerman.FormatFloat(3, 0), "3")
+}
+
+func TestLocale_FormatPercent(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(DefaultLocale.FormatPercent(0.426, 0), "43%")
+	is.Equal(LocaleGerman.FormatPercent(0.4265, 1), "42,7\u00a0%")
+}
+
+func TestLocale_FormatDate(t *testing.T) {
+	is := is.New(t)
+
+	d := time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)
+	is.Equal(DefaultLocale.FormatDate(d), "2021-03-14")
+	is.Equal(LocaleEnglish.FormatDate(d), "03/14/2021")
+	is.Equal(LocaleGerman.FormatDate(d), "14.03.2021")
+}
+
+func TestSetLocale(t *testing.T) {
+	is := is.New(t)
+
+	SetLocale(LocaleGerman)
+	defer SetLocale(nil)
+
+	s := newSlider(t)
+	is.Equal(s.label(1000), "1.000")
+
+	SetLocale(nil)
+	is.Equal(CurrentLocale(), DefaultLocale)
+}
@@ -0,0 +1,221 @@
+package widget
+
+import (
+	img "image"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Marquee displays a single line of text. If the text is too long to fit, it is scrolled horizontally,
+// either continuously or back and forth.
+type Marquee struct {
+	Label string
+
+	widgetOpts   []WidgetOpt
+	face         font.Face
+	color        *LabelColor
+	mode         MarqueeMode
+	speed        float64
+	gap          int
+	pauseOnHover bool
+
+	init       *MultiOnce
+	widget     *Widget
+	clipBuf    *image.ClipRenderBuffer
+	offset     float64
+	backwards  bool
+	hovering   bool
+	lastUpdate time.Time
+}
+
+type MarqueeOpt func(m *Marquee)
+
+// MarqueeMode specifies how a Marquee scrolls text that is too long.
+type MarqueeMode int
+
+type MarqueeOptions struct {
+}
+
+const (
+	// MarqueeModeLoop scrolls the text continuously, starting over after the end of the text.
+	MarqueeModeLoop = MarqueeMode(iota)
+
+	// MarqueeModeBounce scrolls the text back and forth between its start and its end.
+	MarqueeModeBounce
+)
+
+var MarqueeOpts MarqueeOptions
+
+func NewMarquee(opts ...MarqueeOpt) *Marquee {
+	m := &Marquee{
+		speed: 30,
+		gap:   40,
+
+		init: &MultiOnce{},
+	}
+
+	m.init.Append(m.createWidget)
+
+	for _, o := range opts {
+		o(m)
+	}
+
+	return m
+}
+
+func (o MarqueeOptions) WidgetOpts(opts ...WidgetOpt) MarqueeOpt {
+	return func(m *Marquee) {
+		m.widgetOpts = append(m.widgetOpts, opts...)
+	}
+}
+
+func (o MarqueeOptions) Text(label string, face font.Face, color *LabelColor) MarqueeOpt {
+	return func(m *Marquee) {
+		m.Label = label
+		m.face = face
+		m.color = color
+	}
+}
+
+func (o MarqueeOptions) Mode(mode MarqueeMode) MarqueeOpt {
+	return func(m *Marquee) {
+		m.mode = mode
+	}
+}
+
+// Speed configures a Marquee to scroll text by s pixels per second.
+func (o MarqueeOptions) Speed(s float64) MarqueeOpt {
+	return func(m *Marquee) {
+		m.speed = s
+	}
+}
+
+// Gap configures a Marquee to leave a gap of g pixels between the end of the text and its start when
+// scrolling continuously.
+func (o MarqueeOptions) Gap(g int) MarqueeOpt {
+	return func(m *Marquee) {
+		m.gap = g
+	}
+}
+
+// PauseOnHover configures a Marquee to stop scrolling while the mouse cursor is over it.
+func (o MarqueeOptions) PauseOnHover() MarqueeOpt {
+	return func(m *Marquee) {
+		m.pauseOnHover = true
+	}
+}
+
+func (m *Marquee) GetWidget() *Widget {
+	m.init.Do()
+	return m.widget
+}
+
+func (m *Marquee) SetLocation(rect img.Rectangle) {
+	m.init.Do()
+	m.widget.Rect = rect
+}
+
+func (m *Marquee) PreferredSize() (int, int) {
+	m.init.Do()
+	return textutil.Advance(m.Label, m.face), m.lineHeight()
+}
+
+// Reset scrolls back to the start of the text.
+func (m *Marquee) Reset() {
+	m.offset = 0
+	m.backwards = false
+}
+
+func (m *Marquee) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	m.init.Do()
+
+	m.widget.Render(screen, def)
+
+	now := Now()
+	if !m.lastUpdate.IsZero() && !(m.pauseOnHover && m.hovering) {
+		m.advance(now.Sub(m.lastUpdate).Seconds())
+	}
+	m.lastUpdate = now
+
+	if m.clipBuf == nil {
+		m.clipBuf = image.NewClipRenderBuffer()
+	}
+
+	m.clipBuf.Draw(screen, m.widget.Rect, m.draw)
+}
+
+// advance scrolls the text according to dt seconds having passed.
+func (m *Marquee) advance(dt float64) {
+	width := textutil.Advance(m.Label, m.face)
+	overflow := float64(width - m.widget.Rect.Dx())
+	if overflow <= 0 || motionDisabled() {
+		m.Reset()
+		return
+	}
+
+	d := m.speed * dt
+
+	if m.mode == MarqueeModeLoop {
+		m.offset = math.Mod(m.offset+d, float64(width+m.gap))
+		return
+	}
+
+	if m.backwards {
+		d = -d
+	}
+
+	m.offset += d
+
+	switch {
+	case m.offset >= overflow:
+		m.offset = overflow
+		m.backwards = true
+	case m.offset <= 0:
+		m.offset = 0
+		m.backwards = false
+	}
+}
+
+func (m *Marquee) draw(screen *ebiten.Image) {
+	c := m.color.Idle
+	if m.widget.Disabled && m.color.Disabled != nil {
+		c = m.color.Disabled
+	}
+
+	r := m.widget.Rect
+	ascent := int(math.Round(textutil.Fixed26_6ToFloat64(m.face.Metrics().Ascent)))
+	y := r.Min.Y + (r.Dy()-m.lineHeight())/2 + ascent
+	x := r.Min.X - int(math.Round(m.offset))
+
+	text.Draw(screen, m.Label, m.face, x, y, c)
+
+	// draw a second copy following the first one to make the loop seamless
+	if m.mode == MarqueeModeLoop && m.offset > 0 {
+		text.Draw(screen, m.Label, m.face, x+textutil.Advance(m.Label, m.face)+m.gap, y, c)
+	}
+}
+
+func (m *Marquee) lineHeight() int {
+	metrics := m.face.Metrics()
+	return int(math.Round(textutil.Fixed26_6ToFloat64(metrics.Ascent + metrics.Descent)))
+}
+
+func (m *Marquee) createWidget() {
+	m.widget = NewWidget(append(m.widgetOpts, []WidgetOpt{
+		WidgetOpts.CursorEnterHandler(func(args *WidgetCursorEnterEventArgs) {
+			m.hovering = true
+		}),
+
+		WidgetOpts.CursorExitHandler(func(args *WidgetCursorExitEventArgs) {
+			m.hovering = false
+		}),
+	}...)...)
+	m.widgetOpts = nil
+}
@@ -0,0 +1,64 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/matryer/is"
+)
+
+func TestMarquee_Advance_Loop(t *testing.T) {
+	is := is.New(t)
+
+	m := newMarquee(t, MarqueeOpts.Speed(1), MarqueeOpts.Gap(20))
+	w := textutil.Advance(m.Label, m.face)
+
+	m.advance(10)
+	is.Equal(m.offset, 10.0)
+
+	m.advance(float64(w + 20))
+	is.Equal(m.offset, 10.0)
+}
+
+func TestMarquee_Advance_Bounce(t *testing.T) {
+	is := is.New(t)
+
+	m := newMarquee(t, MarqueeOpts.Speed(10), MarqueeOpts.Mode(MarqueeModeBounce))
+	overflow := float64(textutil.Advance(m.Label, m.face) - 50)
+
+	m.advance(1000)
+	is.Equal(m.offset, overflow)
+	is.True(m.backwards)
+
+	m.advance(1)
+	is.Equal(m.offset, overflow-10)
+
+	m.advance(1000)
+	is.Equal(m.offset, 0.0)
+	is.True(!m.backwards)
+}
+
+func TestMarquee_Advance_Fits(t *testing.T) {
+	is := is.New(t)
+
+	m := newMarquee(t)
+	m.SetLocation(image.Rect(0, 0, 10000, 20))
+
+	m.advance(1)
+	is.Equal(m.offset, 0.0)
+}
+
+func newMarquee(t *testing.T, opts ...MarqueeOpt) *Marquee {
+	t.Helper()
+
+	m := NewMarquee(append(opts, MarqueeOpts.Text("the quick brown fox jumps over the lazy dog", loadFont(t), &LabelColor{
+		Idle: color.White,
+	}))...)
+	event.ExecuteDeferred()
+	m.SetLocation(image.Rect(0, 0, 50, 20))
+	return m
+}
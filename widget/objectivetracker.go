@@ -0,0 +1,537 @@
+package widget
+
+import (
+	"fmt"
+	img "image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// ObjectiveTracker displays a list of objectives, such as quest objectives, with their completion state and
+// progress. Adding, removing, and completing objectives is animated.
+//
+// In compact mode, only a limited number of objectives that are not completed are displayed. Clicking an
+// ObjectiveTracker that has a title toggles between compact and expanded mode.
+type ObjectiveTracker struct {
+	ObjectiveCompletedEvent *event.Event
+
+	widgetOpts     []WidgetOpt
+	title          string
+	face           font.Face
+	color          color.Color
+	completedColor color.Color
+	image          *ObjectiveTrackerImage
+	corner         ObjectiveTrackerCorner
+	compactCount   int
+	rowSpacing     int
+	iconSpacing    int
+	animDuration   time.Duration
+
+	init       *MultiOnce
+	widget     *Widget
+	entries    []*objectiveTrackerEntry
+	expanded   bool
+	lastUpdate time.Time
+}
+
+type ObjectiveTrackerOpt func(o *ObjectiveTracker)
+
+// Objective is an objective displayed by an ObjectiveTracker. An objective is completed when Progress
+// reaches Total. Progress is only displayed if Total is greater than 1.
+type Objective struct {
+	Label    string
+	Progress int
+	Total    int
+}
+
+// ObjectiveTrackerImage contains the checkmark images of an ObjectiveTracker.
+type ObjectiveTrackerImage struct {
+	Unchecked *ebiten.Image
+	Checked   *ebiten.Image
+}
+
+// ObjectiveTrackerCorner specifies the screen corner an ObjectiveTracker is anchored to.
+type ObjectiveTrackerCorner int
+
+type ObjectiveTrackerObjectiveCompletedEventArgs struct {
+	ObjectiveTracker *ObjectiveTracker
+	Objective        *Objective
+}
+
+type ObjectiveTrackerObjectiveCompletedHandlerFunc func(args *ObjectiveTrackerObjectiveCompletedEventArgs)
+
+type ObjectiveTrackerOptions struct {
+}
+
+type objectiveTrackerEntry struct {
+	objective *Objective
+	removing  bool
+
+	// appear is the progress of the add animation, from 0 to 1. When removing, it goes back to 0.
+	appear float64
+
+	// pop is the remaining progress of the complete animation, from 1 to 0.
+	pop float64
+}
+
+const (
+	ObjectiveTrackerCornerTopLeft = ObjectiveTrackerCorner(iota)
+	ObjectiveTrackerCornerTopRight
+	ObjectiveTrackerCornerBottomLeft
+	ObjectiveTrackerCornerBottomRight
+)
+
+// objectiveTrackerSlideDistance is the distance in pixels that objectives slide in from when added.
+const objectiveTrackerSlideDistance = 20
+
+var ObjectiveTrackerOpts ObjectiveTrackerOptions
+
+func NewObjectiveTracker(opts ...ObjectiveTrackerOpt) *ObjectiveTracker {
+	o := &ObjectiveTracker{
+		ObjectiveCompletedEvent: &event.Event{},
+
+		compactCount: 3,
+		rowSpacing:   4,
+		iconSpacing:  6,
+		animDuration: 300 * time.Millisecond,
+
+		init: &MultiOnce{},
+	}
+
+	o.init.Append(o.createWidget)
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func (o ObjectiveTrackerOptions) WidgetOpts(opts ...WidgetOpt) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.widgetOpts = append(t.widgetOpts, opts...)
+	}
+}
+
+// Title configures an ObjectiveTracker to display title above the objectives.
+func (o ObjectiveTrackerOptions) Title(title string) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.title = title
+	}
+}
+
+// Text configures an ObjectiveTracker to draw text using face and color c, and the labels of completed
+// objectives using completed. If completed is nil, c is used instead.
+func (o ObjectiveTrackerOptions) Text(face font.Face, c color.Color, completed color.Color) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.face = face
+		t.color = c
+		t.completedColor = completed
+	}
+}
+
+func (o ObjectiveTrackerOptions) Image(i *ObjectiveTrackerImage) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.image = i
+	}
+}
+
+// Corner configures an ObjectiveTracker to be anchored to corner c. When placed in a container using
+// AnchorLayout, the ObjectiveTracker is positioned in that corner, unless different layout data is configured.
+// Objectives are aligned towards corner c.
+func (o ObjectiveTrackerOptions) Corner(c ObjectiveTrackerCorner) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.corner = c
+	}
+}
+
+// CompactCount configures an ObjectiveTracker to display at most c objectives in compact mode.
+func (o ObjectiveTrackerOptions) CompactCount(c int) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.compactCount = c
+	}
+}
+
+// Expanded configures an ObjectiveTracker to start in expanded mode.
+func (o ObjectiveTrackerOptions) Expanded() ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.expanded = true
+	}
+}
+
+// Spacing configures an ObjectiveTracker to separate rows by row pixels, and checkmarks from labels by
+// icon pixels.
+func (o ObjectiveTrackerOptions) Spacing(row int, icon int) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.rowSpacing = row
+		t.iconSpacing = icon
+	}
+}
+
+// AnimationDuration configures an ObjectiveTracker to animate objectives for d. If d is 0, objectives are
+// not animated.
+func (o ObjectiveTrackerOptions) AnimationDuration(d time.Duration) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.animDuration = d
+	}
+}
+
+func (o ObjectiveTrackerOptions) ObjectiveCompletedHandler(f ObjectiveTrackerObjectiveCompletedHandlerFunc) ObjectiveTrackerOpt {
+	return func(t *ObjectiveTracker) {
+		t.ObjectiveCompletedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ObjectiveTrackerObjectiveCompletedEventArgs))
+		})
+	}
+}
+
+// Completed returns whether o has been completed.
+func (o *Objective) Completed() bool {
+	return o.Progress >= o.Total
+}
+
+func (o *ObjectiveTracker) GetWidget() *Widget {
+	o.init.Do()
+	return o.widget
+}
+
+func (o *ObjectiveTracker) SetLocation(rect img.Rectangle) {
+	o.init.Do()
+	o.widget.Rect = rect
+}
+
+func (o *ObjectiveTracker) PreferredSize() (int, int) {
+	o.init.Do()
+
+	w, h := 0, 0
+
+	if o.title != "" {
+		w = textutil.Advance(o.title, o.face)
+		h = o.lineHeight()
+	}
+
+	for _, e := range o.visibleEntries() {
+		if h > 0 {
+			h += o.rowSpacing
+		}
+
+		if rw := o.rowWidth(e); rw > w {
+			w = rw
+		}
+		h += o.rowHeight()
+	}
+
+	return w, h
+}
+
+func (o *ObjectiveTracker) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	o.init.Do()
+
+	o.widget.Render(screen, def)
+
+	now := Now()
+	if !o.lastUpdate.IsZero() {
+		o.advance(now.Sub(o.lastUpdate).Seconds())
+	}
+	o.lastUpdate = now
+
+	o.draw(screen)
+}
+
+// Add adds objective obj to the end of the list.
+func (o *ObjectiveTracker) Add(obj *Objective) {
+	o.init.Do()
+
+	e := &objectiveTrackerEntry{
+		objective: obj,
+	}
+	if animationDuration(o.animDuration) <= 0 {
+		e.appear = 1
+	}
+
+	o.entries = append(o.entries, e)
+}
+
+// Remove removes objective obj from the list.
+func (o *ObjectiveTracker) Remove(obj *Objective) {
+	o.init.Do()
+
+	e := o.entry(obj)
+	if e == nil {
+		return
+	}
+
+	e.removing = true
+
+	if animationDuration(o.animDuration) <= 0 {
+		o.removeFinished()
+	}
+}
+
+// SetProgress sets the progress of objective obj to p. If this completes obj, ObjectiveCompletedEvent is fired.
+func (o *ObjectiveTracker) SetProgress(obj *Objective, p int) {
+	o.init.Do()
+
+	if p < 0 {
+		p = 0
+	}
+	if p > obj.Total {
+		p = obj.Total
+	}
+
+	completed := obj.Completed()
+	obj.Progress = p
+
+	if completed || !obj.Completed() {
+		return
+	}
+
+	if e := o.entry(obj); e != nil && animationDuration(o.animDuration) > 0 {
+		e.pop = 1
+	}
+
+	o.ObjectiveCompletedEvent.Fire(&ObjectiveTrackerObjectiveCompletedEventArgs{
+		ObjectiveTracker: o,
+		Objective:        obj,
+	})
+}
+
+// Complete completes objective obj.
+func (o *ObjectiveTracker) Complete(obj *Objective) {
+	o.SetProgress(obj, obj.Total)
+}
+
+// Objectives returns the objectives in the list.
+func (o *ObjectiveTracker) Objectives() []*Objective {
+	objs := []*Objective{}
+	for _, e := range o.entries {
+		if !e.removing {
+			objs = append(objs, e.objective)
+		}
+	}
+	return objs
+}
+
+// SetExpanded switches to expanded mode if e is true, or to compact mode if e is false.
+func (o *ObjectiveTracker) SetExpanded(e bool) {
+	o.expanded = e
+}
+
+// Expanded returns whether o is in expanded mode.
+func (o *ObjectiveTracker) Expanded() bool {
+	return o.expanded
+}
+
+// advance advances all animations according to dt seconds having passed.
+func (o *ObjectiveTracker) advance(dt float64) {
+	step := math.Inf(1)
+	if d := animationDuration(o.animDuration); d > 0 {
+		step = dt / d.Seconds()
+	}
+
+	for _, e := range o.entries {
+		if e.removing {
+			e.appear = math.Max(0, e.appear-step)
+		} else {
+			e.appear = math.Min(1, e.appear+step)
+		}
+
+		if e.pop > 0 {
+			e.pop = math.Max(0, e.pop-step)
+		}
+	}
+
+	o.removeFinished()
+}
+
+func (o *ObjectiveTracker) removeFinished() {
+	entries := o.entries[:0]
+	for _, e := range o.entries {
+		if e.removing && (e.appear <= 0 || animationDuration(o.animDuration) <= 0) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	o.entries = entries
+}
+
+func (o *ObjectiveTracker) entry(obj *Objective) *objectiveTrackerEntry {
+	for _, e := range o.entries {
+		if e.objective == obj && !e.removing {
+			return e
+		}
+	}
+	return nil
+}
+
+// visibleEntries returns the entries to display in the current mode.
+func (o *ObjectiveTracker) visibleEntries() []*objectiveTrackerEntry {
+	if o.expanded {
+		return o.entries
+	}
+
+	entries := []*objectiveTrackerEntry{}
+	for _, e := range o.entries {
+		if len(entries) >= o.compactCount {
+			break
+		}
+
+		if e.objective.Completed() && e.pop <= 0 && !e.removing {
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+func (o *ObjectiveTracker) draw(screen *ebiten.Image) {
+	r := o.widget.Rect
+	right := o.corner == ObjectiveTrackerCornerTopRight || o.corner == ObjectiveTrackerCornerBottomRight
+	bottom := o.corner == ObjectiveTrackerCornerBottomLeft || o.corner == ObjectiveTrackerCornerBottomRight
+
+	y := r.Min.Y
+	if bottom {
+		_, h := o.PreferredSize()
+		y = r.Max.Y - h
+	}
+
+	ascent := int(math.Round(textutil.Fixed26_6ToFloat64(o.face.Metrics().Ascent)))
+
+	if o.title != "" {
+		x := r.Min.X
+		if right {
+			x = r.Max.X - textutil.Advance(o.title, o.face)
+		}
+		text.Draw(screen, o.title, o.face, x, y+ascent, o.color)
+		y += o.lineHeight() + o.rowSpacing
+	}
+
+	rh := o.rowHeight()
+
+	for _, e := range o.visibleEntries() {
+		slide := int(math.Round((1 - e.appear) * objectiveTrackerSlideDistance))
+
+		x := r.Min.X - slide
+		if right {
+			x = r.Max.X - o.rowWidth(e) + slide
+		}
+
+		o.drawEntry(screen, e, x, y, rh)
+
+		y += rh + o.rowSpacing
+	}
+}
+
+func (o *ObjectiveTracker) drawEntry(screen *ebiten.Image, e *objectiveTrackerEntry, x int, y int, rh int) {
+	completed := e.objective.Completed()
+
+	if i := o.checkmark(completed); i != nil {
+		w, h := i.Size()
+
+		// complete animation: scale checkmark up, then back down
+		scale := 1 + 0.5*math.Sin(math.Pi*e.pop)
+
+		opts := ebiten.DrawImageOptions{}
+		opts.GeoM.Translate(-float64(w)/2, -float64(h)/2)
+		opts.GeoM.Scale(scale, scale)
+		opts.GeoM.Translate(float64(x)+float64(w)/2, float64(y)+float64(rh)/2)
+		opts.ColorM.Scale(1, 1, 1, e.appear)
+		opts.Filter = ebiten.FilterLinear
+		screen.DrawImage(i, &opts)
+
+		x += w + o.iconSpacing
+	}
+
+	c := o.color
+	if completed && o.completedColor != nil {
+		c = o.completedColor
+	}
+
+	ascent := int(math.Round(textutil.Fixed26_6ToFloat64(o.face.Metrics().Ascent)))
+	ty := y + (rh-o.lineHeight())/2 + ascent
+	text.Draw(screen, o.label(e.objective), o.face, x, ty, colorWithAlpha(c, e.appear))
+}
+
+func (o *ObjectiveTracker) checkmark(completed bool) *ebiten.Image {
+	if o.image == nil {
+		return nil
+	}
+	if completed {
+		return o.image.Checked
+	}
+	return o.image.Unchecked
+}
+
+func (o *ObjectiveTracker) label(obj *Objective) string {
+	if obj.Total <= 1 {
+		return obj.Label
+	}
+	return fmt.Sprintf("%s (%d/%d)", obj.Label, obj.Progress, obj.Total)
+}
+
+func (o *ObjectiveTracker) rowWidth(e *objectiveTrackerEntry) int {
+	w := textutil.Advance(o.label(e.objective), o.face)
+	if i := o.checkmark(e.objective.Completed()); i != nil {
+		iw, _ := i.Size()
+		w += iw + o.iconSpacing
+	}
+	return w
+}
+
+func (o *ObjectiveTracker) rowHeight() int {
+	h := o.lineHeight()
+	if o.image != nil {
+		for _, i := range []*ebiten.Image{o.image.Unchecked, o.image.Checked} {
+			if i == nil {
+				continue
+			}
+			if _, ih := i.Size(); ih > h {
+				h = ih
+			}
+		}
+	}
+	return h
+}
+
+func (o *ObjectiveTracker) lineHeight() int {
+	metrics := o.face.Metrics()
+	return int(math.Round(textutil.Fixed26_6ToFloat64(metrics.Ascent + metrics.Descent)))
+}
+
+func (o *ObjectiveTracker) createWidget() {
+	var ld AnchorLayoutData
+	if o.corner == ObjectiveTrackerCornerTopRight || o.corner == ObjectiveTrackerCornerBottomRight {
+		ld.HorizontalPosition = AnchorLayoutPositionEnd
+	}
+	if o.corner == ObjectiveTrackerCornerBottomLeft || o.corner == ObjectiveTrackerCornerBottomRight {
+		ld.VerticalPosition = AnchorLayoutPositionEnd
+	}
+
+	o.widget = NewWidget(append([]WidgetOpt{
+		WidgetOpts.LayoutData(ld),
+
+		WidgetOpts.MouseButtonReleasedHandler(func(args *WidgetMouseButtonReleasedEventArgs) {
+			if o.title != "" && args.Inside && args.Button == ebiten.MouseButtonLeft {
+				o.SetExpanded(!o.expanded)
+			}
+		}),
+	}, o.widgetOpts...)...)
+	o.widgetOpts = nil
+}
+
+// colorWithAlpha returns c with its alpha multiplied by a.
+func colorWithAlpha(c color.Color, a float64) color.Color {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	nc.A = uint8(math.Round(float64(nc.A) * a))
+	return nc
+}
@@ -0,0 +1,105 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestObjectiveTracker_SetProgress_Completes(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *ObjectiveTrackerObjectiveCompletedEventArgs
+	numEvents := 0
+
+	o := newObjectiveTracker(t,
+		ObjectiveTrackerOpts.ObjectiveCompletedHandler(func(args *ObjectiveTrackerObjectiveCompletedEventArgs) {
+			eventArgs = args
+			numEvents++
+		}))
+
+	obj := &Objective{Label: "foo", Total: 5}
+	o.Add(obj)
+
+	o.SetProgress(obj, 3)
+	event.ExecuteDeferred()
+	is.Equal(obj.Progress, 3)
+	is.Equal(o.label(obj), "foo (3/5)")
+	is.Equal(numEvents, 0)
+
+	o.SetProgress(obj, 10)
+	event.ExecuteDeferred()
+	is.Equal(obj.Progress, 5)
+	is.True(obj.Completed())
+	is.Equal(numEvents, 1)
+	is.Equal(eventArgs.Objective, obj)
+	is.Equal(o.entries[0].pop, 1.0)
+
+	o.Complete(obj)
+	event.ExecuteDeferred()
+	is.Equal(numEvents, 1)
+}
+
+func TestObjectiveTracker_Advance(t *testing.T) {
+	is := is.New(t)
+
+	o := newObjectiveTracker(t)
+
+	obj := &Objective{Label: "foo", Total: 1}
+	o.Add(obj)
+	is.Equal(o.entries[0].appear, 0.0)
+
+	o.advance(0.15)
+	is.Equal(o.entries[0].appear, 0.5)
+
+	o.advance(1)
+	is.Equal(o.entries[0].appear, 1.0)
+
+	o.Remove(obj)
+	is.Equal(len(o.Objectives()), 0)
+	is.Equal(len(o.entries), 1)
+
+	o.advance(1)
+	is.Equal(len(o.entries), 0)
+}
+
+func TestObjectiveTracker_VisibleEntries_Compact(t *testing.T) {
+	is := is.New(t)
+
+	o := newObjectiveTracker(t,
+		ObjectiveTrackerOpts.CompactCount(2),
+		ObjectiveTrackerOpts.AnimationDuration(0))
+
+	objs := []*Objective{
+		{Label: "foo", Total: 1},
+		{Label: "bar", Total: 1},
+		{Label: "baz", Total: 1},
+	}
+	for _, obj := range objs {
+		o.Add(obj)
+	}
+
+	o.Complete(objs[0])
+	event.ExecuteDeferred()
+
+	v := o.visibleEntries()
+	is.Equal(len(v), 2)
+	is.Equal(v[0].objective, objs[1])
+	is.Equal(v[1].objective, objs[2])
+
+	o.SetExpanded(true)
+	is.Equal(len(o.visibleEntries()), 3)
+}
+
+func newObjectiveTracker(t *testing.T, opts ...ObjectiveTrackerOpt) *ObjectiveTracker {
+	t.Helper()
+
+	o := NewObjectiveTracker(append(opts, []ObjectiveTrackerOpt{
+		ObjectiveTrackerOpts.Text(loadFont(t), color.White, color.Black),
+	}...)...)
+	event.ExecuteDeferred()
+	return o
+}
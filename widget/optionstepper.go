@@ -0,0 +1,292 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// OptionStepper shows the currently selected option of a list of options, with buttons to step to the
+// previous and next option. While focused, the left and right arrow keys also step through the options.
+// Other input devices, such as gamepads, can use Previous and Next.
+type OptionStepper struct {
+	ChangedEvent *event.Event
+
+	containerOpts      []ContainerOpt
+	previousButtonOpts []ButtonOpt
+	nextButtonOpts     []ButtonOpt
+	options            []string
+	face               font.Face
+	color              color.Color
+	wrap               bool
+
+	init          *MultiOnce
+	container     *Container
+	previous      *Button
+	next          *Button
+	label         *Text
+	selected      int
+	focused       bool
+	lastLeftDown  bool
+	lastRightDown bool
+}
+
+type OptionStepperOpt func(o *OptionStepper)
+
+type OptionStepperChangedEventArgs struct {
+	OptionStepper    *OptionStepper
+	Selected         int
+	Option           string
+	PreviousSelected int
+}
+
+type OptionStepperChangedHandlerFunc func(args *OptionStepperChangedEventArgs)
+
+type OptionStepperOptions struct {
+}
+
+var OptionStepperOpts OptionStepperOptions
+
+func NewOptionStepper(opts ...OptionStepperOpt) *OptionStepper {
+	o := &OptionStepper{
+		ChangedEvent: &event.Event{},
+
+		init: &MultiOnce{},
+	}
+
+	o.init.Append(o.createWidget)
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func (o OptionStepperOptions) ContainerOpts(opts ...ContainerOpt) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.containerOpts = append(s.containerOpts, opts...)
+	}
+}
+
+func (o OptionStepperOptions) PreviousButtonOpts(opts ...ButtonOpt) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.previousButtonOpts = append(s.previousButtonOpts, opts...)
+	}
+}
+
+func (o OptionStepperOptions) NextButtonOpts(opts ...ButtonOpt) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.nextButtonOpts = append(s.nextButtonOpts, opts...)
+	}
+}
+
+func (o OptionStepperOptions) Options(options []string) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.options = options
+	}
+}
+
+func (o OptionStepperOptions) Label(face font.Face, c color.Color) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.face = face
+		s.color = c
+	}
+}
+
+// Wrap configures an OptionStepper to step from the last option to the first option and vice versa.
+func (o OptionStepperOptions) Wrap() OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.wrap = true
+	}
+}
+
+func (o OptionStepperOptions) Selected(i int) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.selected = i
+	}
+}
+
+func (o OptionStepperOptions) ChangedHandler(f OptionStepperChangedHandlerFunc) OptionStepperOpt {
+	return func(s *OptionStepper) {
+		s.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*OptionStepperChangedEventArgs))
+		})
+	}
+}
+
+func (o *OptionStepper) GetWidget() *Widget {
+	o.init.Do()
+	return o.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (o *OptionStepper) ContainedWidgets() []HasWidget {
+	o.init.Do()
+	return containedWidgets(o.container)
+}
+
+// PreferredSize returns the preferred size of o. The label is sized to fit the widest option, so that the
+// size of o does not change when stepping through the options.
+func (o *OptionStepper) PreferredSize() (int, int) {
+	o.init.Do()
+
+	w, h := o.container.PreferredSize()
+	lw, _ := o.label.PreferredSize()
+
+	maxW := 0
+	for _, opt := range o.options {
+		ow := int(textutil.Fixed26_6ToFloat64(font.MeasureString(o.face, opt)))
+		if ow > maxW {
+			maxW = ow
+		}
+	}
+
+	return w - lw + maxW, h
+}
+
+func (o *OptionStepper) SetLocation(rect img.Rectangle) {
+	o.init.Do()
+	o.container.SetLocation(rect)
+}
+
+func (o *OptionStepper) RequestRelayout() {
+	o.init.Do()
+	o.container.RequestRelayout()
+}
+
+func (o *OptionStepper) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	o.init.Do()
+	o.container.SetupInputLayer(def)
+}
+
+func (o *OptionStepper) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	o.init.Do()
+
+	o.handleKeys()
+
+	o.previous.GetWidget().Disabled = o.container.GetWidget().Disabled || (!o.wrap && o.selected <= 0)
+	o.next.GetWidget().Disabled = o.container.GetWidget().Disabled || (!o.wrap && o.selected >= len(o.options)-1)
+
+	o.container.Render(screen, def)
+}
+
+// ConsumesNav implements NavConsumer. The left and right arrow keys step through the options.
+func (o *OptionStepper) ConsumesNav(d NavDirection) bool {
+	return d == NavDirectionLeft || d == NavDirectionRight
+}
+
+func (o *OptionStepper) Focus(focused bool) {
+	o.init.Do()
+	WidgetFireFocusEvent(o.container.GetWidget(), focused)
+	o.focused = focused
+}
+
+// Selected returns the index of the currently selected option.
+func (o *OptionStepper) Selected() int {
+	return o.selected
+}
+
+// SelectedOption returns the currently selected option, or "" if there are no options.
+func (o *OptionStepper) SelectedOption() string {
+	if o.selected < 0 || o.selected >= len(o.options) {
+		return ""
+	}
+	return o.options[o.selected]
+}
+
+// SetSelected selects the option at index i.
+func (o *OptionStepper) SetSelected(i int) {
+	o.init.Do()
+
+	if i < 0 || i >= len(o.options) || i == o.selected {
+		return
+	}
+
+	prev := o.selected
+	o.selected = i
+	o.label.Label = o.options[i]
+
+	o.ChangedEvent.Fire(&OptionStepperChangedEventArgs{
+		OptionStepper:    o,
+		Selected:         i,
+		Option:           o.options[i],
+		PreviousSelected: prev,
+	})
+}
+
+// Previous selects the previous option.
+func (o *OptionStepper) Previous() {
+	o.step(-1)
+}
+
+// Next selects the next option.
+func (o *OptionStepper) Next() {
+	o.step(1)
+}
+
+func (o *OptionStepper) step(d int) {
+	if len(o.options) == 0 {
+		return
+	}
+
+	i := o.selected + d
+	if o.wrap {
+		i = (i + len(o.options)) % len(o.options)
+	}
+
+	o.SetSelected(i)
+}
+
+func (o *OptionStepper) handleKeys() {
+	leftDown := input.KeyPressed(ebiten.KeyLeft)
+	rightDown := input.KeyPressed(ebiten.KeyRight)
+
+	if o.focused && !o.container.GetWidget().Disabled {
+		if leftDown && !o.lastLeftDown {
+			o.Previous()
+		}
+		if rightDown && !o.lastRightDown {
+			o.Next()
+		}
+	}
+
+	o.lastLeftDown, o.lastRightDown = leftDown, rightDown
+}
+
+func (o *OptionStepper) createWidget() {
+	o.container = NewContainer(append(o.containerOpts, []ContainerOpt{
+		ContainerOpts.Layout(NewGridLayout(
+			GridLayoutOpts.Columns(3),
+			GridLayoutOpts.Stretch([]bool{false, true, false}, []bool{true}))),
+	}...)...)
+	o.containerOpts = nil
+
+	o.previous = NewButton(append(o.previousButtonOpts, ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+		o.Previous()
+	}))...)
+	o.previousButtonOpts = nil
+	o.container.AddChild(o.previous)
+
+	label := ""
+	if o.selected >= 0 && o.selected < len(o.options) {
+		label = o.options[o.selected]
+	}
+
+	o.label = NewText(
+		TextOpts.Text(label, o.face, o.color),
+		TextOpts.Position(TextPositionCenter, TextPositionCenter))
+	o.container.AddChild(o.label)
+
+	o.next = NewButton(append(o.nextButtonOpts, ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+		o.Next()
+	}))...)
+	o.nextButtonOpts = nil
+	o.container.AddChild(o.next)
+}
@@ -0,0 +1,92 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestOptionStepper_Next(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *OptionStepperChangedEventArgs
+
+	o := newOptionStepper(t,
+		OptionStepperOpts.ChangedHandler(func(args *OptionStepperChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	leftMouseButtonClick(o.next, t)
+
+	is.Equal(o.Selected(), 1)
+	is.Equal(o.SelectedOption(), "medium")
+	is.Equal(o.label.Label, "medium")
+	is.Equal(eventArgs.PreviousSelected, 0)
+	is.Equal(eventArgs.Option, "medium")
+
+	o.Next()
+	o.Next()
+	is.Equal(o.Selected(), 2)
+
+	render(o, t)
+	is.True(o.next.GetWidget().Disabled)
+}
+
+func TestOptionStepper_Wrap(t *testing.T) {
+	is := is.New(t)
+
+	o := newOptionStepper(t, OptionStepperOpts.Wrap())
+
+	o.Previous()
+	is.Equal(o.Selected(), 2)
+
+	o.Next()
+	is.Equal(o.Selected(), 0)
+}
+
+func TestOptionStepper_Keys(t *testing.T) {
+	is := is.New(t)
+
+	o := newOptionStepper(t)
+
+	defer func() {
+		internalinput.KeyPressed[ebiten.KeyRight] = false
+	}()
+
+	internalinput.KeyPressed[ebiten.KeyRight] = true
+	render(o, t)
+	is.Equal(o.Selected(), 0)
+
+	internalinput.KeyPressed[ebiten.KeyRight] = false
+	render(o, t)
+
+	o.Focus(true)
+
+	internalinput.KeyPressed[ebiten.KeyRight] = true
+	render(o, t)
+	render(o, t)
+	is.Equal(o.Selected(), 1)
+}
+
+func newOptionStepper(t *testing.T, opts ...OptionStepperOpt) *OptionStepper {
+	t.Helper()
+
+	buttonImage := &ButtonImage{
+		Idle: newNineSliceEmpty(t),
+	}
+
+	o := NewOptionStepper(append(opts, []OptionStepperOpt{
+		OptionStepperOpts.Options([]string{"low", "medium", "high"}),
+		OptionStepperOpts.Label(loadFont(t), color.White),
+		OptionStepperOpts.PreviousButtonOpts(ButtonOpts.Image(buttonImage)),
+		OptionStepperOpts.NextButtonOpts(ButtonOpts.Image(buttonImage)),
+	}...)...)
+	event.ExecuteDeferred()
+	render(o, t)
+	return o
+}
@@ -0,0 +1,450 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// PaletteRow is a horizontal row of color swatches to choose a color from, such as for character or vehicle
+// customization. The selected swatch is outlined. An optional slot at the end of the row lets the user add a
+// custom color, for example by opening a color picker in response to CustomEvent and calling AddColor.
+//
+// Each swatch can be focused, so that the UI moves between swatches when navigating using the keyboard or a
+// gamepad. Focusing or clicking a swatch selects it.
+type PaletteRow struct {
+	// ChangedEvent fires an event with *PaletteRowChangedEventArgs when the selected color has changed.
+	ChangedEvent *event.Event
+
+	// CustomEvent fires an event with *PaletteRowCustomEventArgs when the custom slot has been clicked, or
+	// when the accept action has been pressed while it is focused.
+	CustomEvent *event.Event
+
+	containerOpts []ContainerOpt
+	buttonOpts    []ButtonOpt
+	colors        []color.Color
+	swatchSize    int
+	swatchPadding Insets
+	selectedImage *image.NineSlice
+	spacing       int
+	customLabel   string
+	customFace    font.Face
+	customColor   *ButtonTextColor
+
+	init           *MultiOnce
+	container      *Container
+	swatches       []*paletteRowSlot
+	custom         *paletteRowSlot
+	selected       int
+	lastAcceptDown bool
+}
+
+type PaletteRowOpt func(p *PaletteRow)
+
+type PaletteRowChangedEventArgs struct {
+	PaletteRow    *PaletteRow
+	Color         color.Color
+	Index         int
+	PreviousIndex int
+}
+
+type PaletteRowChangedHandlerFunc func(args *PaletteRowChangedEventArgs)
+
+type PaletteRowCustomEventArgs struct {
+	PaletteRow *PaletteRow
+}
+
+type PaletteRowCustomHandlerFunc func(args *PaletteRowCustomEventArgs)
+
+type PaletteRowOptions struct {
+}
+
+// paletteRowSlot is the widget of a swatch or the custom slot in a PaletteRow.
+type paletteRowSlot struct {
+	row     *PaletteRow
+	index   int
+	button  *Button
+	focused bool
+}
+
+// paletteRowFill is the colored area inside a swatch.
+type paletteRowFill struct {
+	widget *Widget
+	color  color.Color
+	size   int
+}
+
+var PaletteRowOpts PaletteRowOptions
+
+func NewPaletteRow(opts ...PaletteRowOpt) *PaletteRow {
+	p := &PaletteRow{
+		ChangedEvent: &event.Event{},
+		CustomEvent:  &event.Event{},
+
+		swatchSize: 24,
+		selected:   -1,
+
+		init: &MultiOnce{},
+	}
+
+	p.init.Append(p.createWidget)
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+func (o PaletteRowOptions) ContainerOpts(opts ...ContainerOpt) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.containerOpts = append(p.containerOpts, opts...)
+	}
+}
+
+// ButtonOpts configures a PaletteRow to construct the buttons that make up the swatches and the custom slot
+// using opts.
+func (o PaletteRowOptions) ButtonOpts(opts ...ButtonOpt) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.buttonOpts = append(p.buttonOpts, opts...)
+	}
+}
+
+func (o PaletteRowOptions) Colors(c ...color.Color) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.colors = c
+	}
+}
+
+// SwatchSize configures a PaletteRow to fill a square of s by s pixels inside each swatch with its color,
+// separated from the edges of the swatch's button by padding i. The default size is 24.
+func (o PaletteRowOptions) SwatchSize(s int, i Insets) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.swatchSize = s
+		p.swatchPadding = i
+	}
+}
+
+// SelectedImage configures a PaletteRow to draw i over the selected swatch, such as an outline.
+func (o PaletteRowOptions) SelectedImage(i *image.NineSlice) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.selectedImage = i
+	}
+}
+
+func (o PaletteRowOptions) Spacing(s int) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.spacing = s
+	}
+}
+
+// CustomSlot configures a PaletteRow to show a slot labeled label at the end of the row, using face and color
+// c, that fires CustomEvent when clicked.
+func (o PaletteRowOptions) CustomSlot(label string, face font.Face, c *ButtonTextColor) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.customLabel = label
+		p.customFace = face
+		p.customColor = c
+	}
+}
+
+func (o PaletteRowOptions) ChangedHandler(f PaletteRowChangedHandlerFunc) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*PaletteRowChangedEventArgs))
+		})
+	}
+}
+
+func (o PaletteRowOptions) CustomHandler(f PaletteRowCustomHandlerFunc) PaletteRowOpt {
+	return func(p *PaletteRow) {
+		p.CustomEvent.AddHandler(func(args interface{}) {
+			f(args.(*PaletteRowCustomEventArgs))
+		})
+	}
+}
+
+func (p *PaletteRow) GetWidget() *Widget {
+	p.init.Do()
+	return p.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (p *PaletteRow) ContainedWidgets() []HasWidget {
+	p.init.Do()
+	return containedWidgets(p.container)
+}
+
+func (p *PaletteRow) PreferredSize() (int, int) {
+	p.init.Do()
+	return p.container.PreferredSize()
+}
+
+func (p *PaletteRow) SetLocation(rect img.Rectangle) {
+	p.init.Do()
+	p.container.SetLocation(rect)
+}
+
+func (p *PaletteRow) RequestRelayout() {
+	p.init.Do()
+	p.container.RequestRelayout()
+}
+
+// FocusOrder implements FocusContainer.
+func (p *PaletteRow) FocusOrder() []HasWidget {
+	p.init.Do()
+	return p.container.FocusOrder()
+}
+
+func (p *PaletteRow) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	p.init.Do()
+	p.container.SetupInputLayer(def)
+}
+
+func (p *PaletteRow) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	p.init.Do()
+
+	acceptDown := input.ActionPressed(input.ActionAccept)
+	if acceptDown && !p.lastAcceptDown && p.custom != nil && p.custom.focused {
+		p.fireCustom()
+	}
+	p.lastAcceptDown = acceptDown
+
+	p.container.Render(screen, def)
+}
+
+// Colors returns the colors of p.
+func (p *PaletteRow) Colors() []color.Color {
+	return p.colors
+}
+
+// SetColors replaces the colors of p with c. The selected swatch stays selected if its index is still
+// valid. Otherwise, no swatch is selected afterwards.
+func (p *PaletteRow) SetColors(c ...color.Color) {
+	p.init.Do()
+
+	p.colors = c
+	p.createSwatches()
+
+	if p.selected >= len(c) {
+		p.SetSelectedIndex(-1)
+	}
+}
+
+// AddColor adds c to the end of the row and selects it. If c is already contained in the row, it is selected
+// instead of being added again.
+func (p *PaletteRow) AddColor(c color.Color) {
+	p.init.Do()
+
+	for i, pc := range p.colors {
+		if pc == c {
+			p.SetSelectedIndex(i)
+			return
+		}
+	}
+
+	p.SetColors(append(p.colors[:len(p.colors):len(p.colors)], c)...)
+	p.SetSelectedIndex(len(p.colors) - 1)
+}
+
+// Selected returns the selected color, or nil if no swatch is selected.
+func (p *PaletteRow) Selected() color.Color {
+	if p.selected < 0 {
+		return nil
+	}
+	return p.colors[p.selected]
+}
+
+// SelectedIndex returns the index of the selected swatch, or -1 if no swatch is selected.
+func (p *PaletteRow) SelectedIndex() int {
+	return p.selected
+}
+
+// SetSelectedIndex selects the swatch at index i. If i is -1, no swatch is selected.
+func (p *PaletteRow) SetSelectedIndex(i int) {
+	if i < -1 || i >= len(p.colors) || i == p.selected {
+		return
+	}
+
+	prev := p.selected
+	p.selected = i
+
+	p.ChangedEvent.Fire(&PaletteRowChangedEventArgs{
+		PaletteRow:    p,
+		Color:         p.Selected(),
+		Index:         i,
+		PreviousIndex: prev,
+	})
+}
+
+// SwatchWidget returns the widget of the swatch at index i, for example to focus it. It returns nil if i
+// is out of range.
+func (p *PaletteRow) SwatchWidget(i int) HasWidget {
+	p.init.Do()
+
+	if i < 0 || i >= len(p.swatches) {
+		return nil
+	}
+	return p.swatches[i]
+}
+
+// CustomWidget returns the widget of the custom slot, or nil if p does not show a custom slot.
+func (p *PaletteRow) CustomWidget() HasWidget {
+	p.init.Do()
+
+	if p.custom == nil {
+		return nil
+	}
+	return p.custom
+}
+
+func (p *PaletteRow) fireCustom() {
+	p.CustomEvent.Fire(&PaletteRowCustomEventArgs{
+		PaletteRow: p,
+	})
+}
+
+func (p *PaletteRow) createWidget() {
+	p.container = NewContainer(append(p.containerOpts, []ContainerOpt{
+		ContainerOpts.Layout(NewRowLayout(
+			RowLayoutOpts.Spacing(p.spacing))),
+		ContainerOpts.AutoDisableChildren(),
+	}...)...)
+	p.containerOpts = nil
+
+	if p.customFace != nil {
+		p.custom = &paletteRowSlot{
+			row:   p,
+			index: -1,
+		}
+
+		opts := append([]ButtonOpt(nil), p.buttonOpts...)
+		p.custom.button = NewButton(append(opts, []ButtonOpt{
+			ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+				Stretch: true,
+			})),
+			ButtonOpts.Text(p.customLabel, p.customFace, p.customColor),
+			ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+				p.fireCustom()
+			}),
+		}...)...)
+	}
+
+	p.createSwatches()
+}
+
+// createSwatches replaces the widgets in p.container with new swatches for p.colors, followed by the custom
+// slot.
+func (p *PaletteRow) createSwatches() {
+	p.container.RemoveAllChildren()
+
+	p.swatches = make([]*paletteRowSlot, len(p.colors))
+	for i, c := range p.colors {
+		s := p.newSwatch(i, c)
+		p.swatches[i] = s
+		p.container.AddChild(s)
+	}
+
+	if p.custom != nil {
+		p.container.AddChild(p.custom)
+	}
+}
+
+func (p *PaletteRow) newSwatch(i int, c color.Color) *paletteRowSlot {
+	s := &paletteRowSlot{
+		row:   p,
+		index: i,
+	}
+
+	opts := append([]ButtonOpt(nil), p.buttonOpts...)
+	s.button = NewButton(append(opts, []ButtonOpt{
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			p.SetSelectedIndex(i)
+		}),
+		func(b *Button) {
+			b.init.Append(func() {
+				b.container = NewContainer(
+					ContainerOpts.Layout(NewAnchorLayout(AnchorLayoutOpts.Padding(p.swatchPadding))),
+					ContainerOpts.AutoDisableChildren())
+
+				b.container.AddChild(&paletteRowFill{
+					widget: NewWidget(WidgetOpts.LayoutData(AnchorLayoutData{
+						StretchHorizontal: true,
+						StretchVertical:   true,
+					})),
+					color: c,
+					size:  p.swatchSize,
+				})
+			})
+		},
+	}...)...)
+
+	return s
+}
+
+func (s *paletteRowSlot) GetWidget() *Widget {
+	return s.button.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (s *paletteRowSlot) ContainedWidgets() []HasWidget {
+	return s.button.ContainedWidgets()
+}
+
+func (s *paletteRowSlot) PreferredSize() (int, int) {
+	return s.button.PreferredSize()
+}
+
+func (s *paletteRowSlot) SetLocation(rect img.Rectangle) {
+	s.button.SetLocation(rect)
+}
+
+func (s *paletteRowSlot) RequestRelayout() {
+	s.button.RequestRelayout()
+}
+
+func (s *paletteRowSlot) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	s.button.SetupInputLayer(def)
+}
+
+func (s *paletteRowSlot) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	s.button.Render(screen, def)
+
+	if s.index >= 0 && s.row.selected == s.index && s.row.selectedImage != nil {
+		w := s.button.GetWidget()
+		s.row.selectedImage.Draw(screen, w.Rect.Dx(), w.Rect.Dy(), w.drawImageOptions)
+	}
+}
+
+// Focus implements Focuser. Focusing a swatch selects it.
+func (s *paletteRowSlot) Focus(focused bool) {
+	WidgetFireFocusEvent(s.button.GetWidget(), focused)
+	s.focused = focused
+
+	if focused && s.index >= 0 {
+		s.row.SetSelectedIndex(s.index)
+	}
+}
+
+func (f *paletteRowFill) GetWidget() *Widget {
+	return f.widget
+}
+
+func (f *paletteRowFill) PreferredSize() (int, int) {
+	return f.size, f.size
+}
+
+func (f *paletteRowFill) SetLocation(rect img.Rectangle) {
+	f.widget.Rect = rect
+}
+
+func (f *paletteRowFill) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	f.widget.Render(screen, def)
+	fillRect(screen, f.widget.Rect, f.color)
+}
@@ -0,0 +1,82 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestPaletteRow_Layout(t *testing.T) {
+	is := is.New(t)
+
+	p := newPaletteRow(t,
+		PaletteRowOpts.Colors(color.White, color.Black),
+		PaletteRowOpts.SwatchSize(20, Insets{Top: 2, Left: 2, Right: 2, Bottom: 2}),
+		PaletteRowOpts.Spacing(5))
+
+	w, h := p.PreferredSize()
+	is.Equal(w, 2*24+5)
+	is.Equal(h, 24)
+
+	p.SetLocation(image.Rect(0, 0, w, h))
+	render(p, t)
+
+	is.Equal(p.SwatchWidget(1).GetWidget().Rect, image.Rect(29, 0, 53, 24))
+}
+
+func TestPaletteRow_Select(t *testing.T) {
+	is := is.New(t)
+
+	var changed *PaletteRowChangedEventArgs
+	p := newPaletteRow(t,
+		PaletteRowOpts.Colors(color.White, color.Black),
+		PaletteRowOpts.ChangedHandler(func(args *PaletteRowChangedEventArgs) {
+			changed = args
+		}))
+
+	is.Equal(p.Selected(), nil)
+
+	leftMouseButtonClick(p.SwatchWidget(1), t)
+	is.Equal(p.SelectedIndex(), 1)
+	is.Equal(changed.Color, color.Black)
+	is.Equal(changed.PreviousIndex, -1)
+
+	p.SwatchWidget(0).(Focuser).Focus(true)
+	is.Equal(p.Selected(), color.White)
+}
+
+func TestPaletteRow_Custom(t *testing.T) {
+	is := is.New(t)
+
+	red := color.NRGBA{255, 0, 0, 255}
+
+	p := newPaletteRow(t,
+		PaletteRowOpts.Colors(color.White),
+		PaletteRowOpts.CustomSlot("+", loadFont(t), &ButtonTextColor{Idle: color.White}),
+		PaletteRowOpts.CustomHandler(func(args *PaletteRowCustomEventArgs) {
+			args.PaletteRow.AddColor(red)
+		}))
+
+	leftMouseButtonClick(p.CustomWidget(), t)
+	is.Equal(p.Colors(), []color.Color{color.White, red})
+	is.Equal(p.Selected(), red)
+	is.Equal(len(p.container.Children()), 3)
+
+	p.AddColor(color.White)
+	is.Equal(len(p.Colors()), 2)
+	is.Equal(p.SelectedIndex(), 0)
+}
+
+func newPaletteRow(t *testing.T, opts ...PaletteRowOpt) *PaletteRow {
+	t.Helper()
+
+	p := NewPaletteRow(append(opts,
+		PaletteRowOpts.ButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})))...)
+	event.ExecuteDeferred()
+	render(p, t)
+	return p
+}
@@ -0,0 +1,296 @@
+package widget
+
+import (
+	img "image"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// PieMenu is a menu that opens around a point and shows its entries on a ring. The entry in the direction
+// of the mouse cursor (or the direction set using HighlightDirection, for example from a gamepad stick) is
+// highlighted, and is selected when the mouse button is released, or when Select is called.
+//
+// PieMenu is not rendered as part of a Container, but should be set as the UI's PieMenu.
+type PieMenu struct {
+	SelectedEvent *event.Event
+
+	containerOpts    []ContainerOpt
+	entries          []interface{}
+	entryLabelFunc   PieMenuEntryLabelFunc
+	entryFace        font.Face
+	entryImage       *ButtonImage
+	entryTextColor   *ButtonTextColor
+	entryTextPadding Insets
+	radius           int
+	deadZone         int
+
+	init         *MultiOnce
+	container    *Container
+	layout       *RadialLayout
+	buttons      []*Button
+	open         bool
+	center       img.Point
+	highlighted  int
+	lastCursor   img.Point
+	releaseArmed bool
+}
+
+type PieMenuOpt func(p *PieMenu)
+
+type PieMenuEntryLabelFunc func(e interface{}) string
+
+type PieMenuSelectedEventArgs struct {
+	PieMenu *PieMenu
+	Entry   interface{}
+}
+
+type PieMenuSelectedHandlerFunc func(args *PieMenuSelectedEventArgs)
+
+type PieMenuOptions struct {
+}
+
+var PieMenuOpts PieMenuOptions
+
+func NewPieMenu(opts ...PieMenuOpt) *PieMenu {
+	p := &PieMenu{
+		SelectedEvent: &event.Event{},
+
+		radius:   80,
+		deadZone: 10,
+
+		init:        &MultiOnce{},
+		highlighted: -1,
+	}
+
+	p.init.Append(p.createWidget)
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+func (o PieMenuOptions) ContainerOpts(opts ...ContainerOpt) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.containerOpts = append(p.containerOpts, opts...)
+	}
+}
+
+func (o PieMenuOptions) Entries(e []interface{}) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.entries = e
+	}
+}
+
+func (o PieMenuOptions) EntryLabelFunc(f PieMenuEntryLabelFunc) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.entryLabelFunc = f
+	}
+}
+
+func (o PieMenuOptions) EntryFontFace(f font.Face) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.entryFace = f
+	}
+}
+
+// EntryImage configures a PieMenu to draw entries using i. The highlighted entry is drawn using the
+// Hover image.
+func (o PieMenuOptions) EntryImage(i *ButtonImage) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.entryImage = i
+	}
+}
+
+func (o PieMenuOptions) EntryTextColor(c *ButtonTextColor) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.entryTextColor = c
+	}
+}
+
+func (o PieMenuOptions) EntryTextPadding(i Insets) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.entryTextPadding = i
+	}
+}
+
+// Radius configures a PieMenu to place entries on a ring of radius r.
+func (o PieMenuOptions) Radius(r int) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.radius = r
+	}
+}
+
+// DeadZone configures a PieMenu to only highlight entries by mouse cursor if the cursor is at least d
+// pixels away from the center.
+func (o PieMenuOptions) DeadZone(d int) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.deadZone = d
+	}
+}
+
+func (o PieMenuOptions) SelectedHandler(f PieMenuSelectedHandlerFunc) PieMenuOpt {
+	return func(p *PieMenu) {
+		p.SelectedEvent.AddHandler(func(args interface{}) {
+			f(args.(*PieMenuSelectedEventArgs))
+		})
+	}
+}
+
+func (p *PieMenu) GetWidget() *Widget {
+	p.init.Do()
+	return p.container.GetWidget()
+}
+
+// Open opens p centered at screen position x,y.
+func (p *PieMenu) Open(x int, y int) {
+	p.init.Do()
+
+	p.open = true
+	p.center = img.Point{x, y}
+	p.lastCursor = img.Point{x, y}
+	p.releaseArmed = input.MouseButtonPressed(ebiten.MouseButtonLeft) || input.MouseButtonPressed(ebiten.MouseButtonRight)
+	p.setHighlighted(-1)
+
+	w, h := p.container.PreferredSize()
+	p.container.SetLocation(img.Rect(x-w/2, y-h/2, x-w/2+w, y-h/2+h))
+	p.container.RequestRelayout()
+}
+
+// Close closes p without selecting an entry.
+func (p *PieMenu) Close() {
+	p.open = false
+	p.releaseArmed = false
+	p.setHighlighted(-1)
+}
+
+// IsOpen returns whether p is open.
+func (p *PieMenu) IsOpen() bool {
+	return p.open
+}
+
+// HighlightDirection highlights the entry closest to direction x,y, as seen from the center of p. If x and
+// y are both 0, no entry will be highlighted.
+func (p *PieMenu) HighlightDirection(x float64, y float64) {
+	p.init.Do()
+
+	if x == 0 && y == 0 {
+		p.setHighlighted(-1)
+		return
+	}
+
+	p.setHighlighted(p.layout.index(x, y, len(p.entries)))
+}
+
+// Highlighted returns the currently highlighted entry, or nil if no entry is highlighted.
+func (p *PieMenu) Highlighted() interface{} {
+	if p.highlighted < 0 {
+		return nil
+	}
+	return p.entries[p.highlighted]
+}
+
+// Select selects the currently highlighted entry, if any, and closes p.
+func (p *PieMenu) Select() {
+	if !p.open {
+		return
+	}
+
+	e := p.Highlighted()
+	p.Close()
+
+	if e != nil {
+		p.SelectedEvent.Fire(&PieMenuSelectedEventArgs{
+			PieMenu: p,
+			Entry:   e,
+		})
+	}
+}
+
+func (p *PieMenu) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	p.init.Do()
+
+	if !p.open {
+		return
+	}
+
+	p.container.GetWidget().ElevateToNewInputLayer(&input.Layer{
+		DebugLabel: "pie menu",
+		EventTypes: input.LayerEventTypeAll,
+		BlockLower: true,
+		FullScreen: true,
+	})
+
+	p.container.SetupInputLayer(def)
+}
+
+func (p *PieMenu) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	p.init.Do()
+
+	if !p.open {
+		return
+	}
+
+	p.updateCursor()
+	if !p.open {
+		return
+	}
+
+	p.container.Render(screen, def)
+}
+
+func (p *PieMenu) updateCursor() {
+	x, y := input.CursorPosition()
+	c := img.Point{x, y}
+
+	// only follow the cursor if it has moved, so that HighlightDirection is not overridden
+	if c != p.lastCursor {
+		p.lastCursor = c
+
+		d := c.Sub(p.center)
+		if d.X*d.X+d.Y*d.Y >= p.deadZone*p.deadZone {
+			p.HighlightDirection(float64(d.X), float64(d.Y))
+		} else {
+			p.setHighlighted(-1)
+		}
+	}
+
+	pressed := input.MouseButtonPressed(ebiten.MouseButtonLeft) || input.MouseButtonPressed(ebiten.MouseButtonRight)
+	if pressed {
+		p.releaseArmed = true
+		return
+	}
+
+	if p.releaseArmed {
+		p.Select()
+	}
+}
+
+func (p *PieMenu) setHighlighted(i int) {
+	p.highlighted = i
+	for bi, b := range p.buttons {
+		b.externalHovering = bi == i
+	}
+}
+
+func (p *PieMenu) createWidget() {
+	p.layout = NewRadialLayout(RadialLayoutOpts.Radius(p.radius))
+
+	p.container = NewContainer(append(p.containerOpts, ContainerOpts.Layout(p.layout))...)
+	p.containerOpts = nil
+
+	p.buttons = make([]*Button, len(p.entries))
+	for i, e := range p.entries {
+		b := NewButton(
+			ButtonOpts.Image(p.entryImage),
+			ButtonOpts.TextPadding(p.entryTextPadding),
+			ButtonOpts.Text(p.entryLabelFunc(e), p.entryFace, p.entryTextColor))
+		p.buttons[i] = b
+		p.container.AddChild(b)
+	}
+}
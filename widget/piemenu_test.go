@@ -0,0 +1,86 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/matryer/is"
+)
+
+func TestPieMenu_HighlightDirection(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *PieMenuSelectedEventArgs
+
+	p := newPieMenu(t,
+		PieMenuOpts.SelectedHandler(func(args *PieMenuSelectedEventArgs) {
+			eventArgs = args
+		}))
+
+	p.Open(100, 100)
+	p.HighlightDirection(0.9, 0.1)
+
+	is.Equal(p.Highlighted(), "east")
+	is.True(p.buttons[1].externalHovering)
+
+	p.Select()
+	event.ExecuteDeferred()
+
+	is.True(!p.IsOpen())
+	is.Equal(eventArgs.Entry, "east")
+}
+
+func TestPieMenu_SelectOnRelease(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *PieMenuSelectedEventArgs
+
+	p := newPieMenu(t,
+		PieMenuOpts.SelectedHandler(func(args *PieMenuSelectedEventArgs) {
+			eventArgs = args
+		}))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX, internalinput.CursorY = 100, 100
+	p.Open(100, 100)
+
+	internalinput.CursorX, internalinput.CursorY = 100, 150
+	render(p, t)
+
+	is.True(p.IsOpen())
+	is.Equal(p.Highlighted(), "south")
+
+	internalinput.LeftMouseButtonPressed = false
+	render(p, t)
+
+	is.True(!p.IsOpen())
+	is.Equal(eventArgs.Entry, "south")
+}
+
+func newPieMenu(t *testing.T, opts ...PieMenuOpt) *PieMenu {
+	t.Helper()
+
+	p := NewPieMenu(append(opts, []PieMenuOpt{
+		PieMenuOpts.Entries([]interface{}{"north", "east", "south", "west"}),
+		PieMenuOpts.EntryLabelFunc(func(e interface{}) string {
+			return e.(string)
+		}),
+		PieMenuOpts.EntryFontFace(loadFont(t)),
+		PieMenuOpts.EntryImage(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		}),
+		PieMenuOpts.EntryTextColor(&ButtonTextColor{
+			Idle: color.White,
+		}),
+	}...)...)
+	event.ExecuteDeferred()
+	return p
+}
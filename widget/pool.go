@@ -0,0 +1,99 @@
+package widget
+
+// WidgetPool recycles widgets, so that UIs that are destroyed and rebuilt often, such as inventory popups or
+// shop pages, can reuse widgets and the images they have buffered instead of constructing new ones.
+//
+// Widgets are returned to the pool after they have been removed from their containers. Since recycled widgets
+// keep their state and event handlers, a reset function should be used to prepare them for reuse.
+type WidgetPool struct {
+	newFunc   WidgetPoolNewFunc
+	resetFunc WidgetPoolResetFunc
+	maxSize   int
+
+	free []PreferredSizeLocateableWidget
+}
+
+// WidgetPoolNewFunc constructs a new widget for a WidgetPool.
+type WidgetPoolNewFunc func() PreferredSizeLocateableWidget
+
+// WidgetPoolResetFunc resets the state of widget w before it is returned to a WidgetPool.
+type WidgetPoolResetFunc func(w PreferredSizeLocateableWidget)
+
+type WidgetPoolOpt func(p *WidgetPool)
+
+type WidgetPoolOptions struct {
+}
+
+var WidgetPoolOpts WidgetPoolOptions
+
+// NewWidgetPool constructs a new WidgetPool that uses f to construct new widgets when it is empty.
+func NewWidgetPool(f WidgetPoolNewFunc, opts ...WidgetPoolOpt) *WidgetPool {
+	p := &WidgetPool{
+		newFunc: f,
+	}
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+// Reset configures a WidgetPool to call f for each widget that is returned to the pool.
+func (o WidgetPoolOptions) Reset(f WidgetPoolResetFunc) WidgetPoolOpt {
+	return func(p *WidgetPool) {
+		p.resetFunc = f
+	}
+}
+
+// MaxSize configures a WidgetPool to keep at most n widgets. Widgets returned to a full pool are discarded.
+// If n is 0, the number of widgets is not limited.
+func (o WidgetPoolOptions) MaxSize(n int) WidgetPoolOpt {
+	return func(p *WidgetPool) {
+		p.maxSize = n
+	}
+}
+
+// Get returns a recycled widget, or a newly constructed one if p is empty.
+func (p *WidgetPool) Get() PreferredSizeLocateableWidget {
+	n := len(p.free)
+	if n == 0 {
+		return p.newFunc()
+	}
+
+	w := p.free[n-1]
+	p.free[n-1] = nil
+	p.free = p.free[:n-1]
+	return w
+}
+
+// Put returns widgets to p. The widgets must not be children of a container anymore.
+func (p *WidgetPool) Put(widgets ...PreferredSizeLocateableWidget) {
+	for _, w := range widgets {
+		if w.GetWidget().parent != nil {
+			panic("widget is still a child of a container")
+		}
+
+		if p.maxSize > 0 && len(p.free) >= p.maxSize {
+			return
+		}
+
+		if p.resetFunc != nil {
+			p.resetFunc(w)
+		}
+
+		p.free = append(p.free, w)
+	}
+}
+
+// PutChildren removes all children from c and returns them to p.
+func (p *WidgetPool) PutChildren(c *Container) {
+	children := c.Children()
+	c.RemoveAllChildren()
+	p.Put(children...)
+}
+
+// Len returns the number of widgets currently in p.
+func (p *WidgetPool) Len() int {
+	return len(p.free)
+}
@@ -0,0 +1,40 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWidgetPool(t *testing.T) {
+	is := is.New(t)
+
+	created := 0
+	resets := 0
+
+	p := NewWidgetPool(func() PreferredSizeLocateableWidget {
+		created++
+		return newSimpleWidget(10, 10, nil)
+	}, WidgetPoolOpts.Reset(func(w PreferredSizeLocateableWidget) {
+		resets++
+		w.GetWidget().Disabled = false
+	}), WidgetPoolOpts.MaxSize(2))
+
+	c := newContainer(t)
+	for i := 0; i < 3; i++ {
+		w := p.Get()
+		w.GetWidget().Disabled = true
+		c.AddChild(w)
+	}
+	is.Equal(created, 3)
+
+	p.PutChildren(c)
+	is.Equal(len(c.Children()), 0)
+	is.Equal(p.Len(), 2)
+	is.Equal(resets, 2)
+
+	w := p.Get()
+	is.True(!w.GetWidget().Disabled)
+	is.Equal(created, 3)
+	is.Equal(p.Len(), 1)
+}
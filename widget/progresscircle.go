@@ -0,0 +1,176 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"math"
+
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// ProgressCircle displays progress as a circular arc, such as a loading ring or capture-point progress.
+// The arc is drawn over a full-circle track, and text may be displayed in its center.
+type ProgressCircle struct {
+	// Progress is the fraction of the full circle to fill, in the range [0,1].
+	Progress float64
+
+	widgetOpts []WidgetOpt
+	size       int
+	thickness  float64
+	startAngle float64
+	image      *ProgressCircleImage
+	face       font.Face
+	textColor  color.Color
+	textFunc   ProgressCircleTextFunc
+
+	init   *MultiOnce
+	widget *Widget
+}
+
+type ProgressCircleOpt func(p *ProgressCircle)
+
+// ProgressCircleImage specifies how to draw the track and the filled arc of a ProgressCircle. Each part is
+// drawn using its image masked by the arc, tinted by its color, or filled with its color if the image is nil.
+type ProgressCircleImage struct {
+	TrackColor color.Color
+	TrackImage *ebiten.Image
+	FillColor  color.Color
+	FillImage  *ebiten.Image
+}
+
+// ProgressCircleTextFunc is a function that returns the text to display in the center for progress p.
+type ProgressCircleTextFunc func(p float64) string
+
+type ProgressCircleOptions struct {
+}
+
+var ProgressCircleOpts ProgressCircleOptions
+
+func NewProgressCircle(opts ...ProgressCircleOpt) *ProgressCircle {
+	p := &ProgressCircle{
+		size:      50,
+		thickness: 6,
+		textFunc: func(p float64) string {
+			return locale.FormatPercent(p, 0)
+		},
+
+		init: &MultiOnce{},
+	}
+
+	p.init.Append(p.createWidget)
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+func (o ProgressCircleOptions) WidgetOpts(opts ...WidgetOpt) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.widgetOpts = append(p.widgetOpts, opts...)
+	}
+}
+
+// Size configures a ProgressCircle to have a preferred diameter of s.
+func (o ProgressCircleOptions) Size(s int) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.size = s
+	}
+}
+
+// Thickness configures a ProgressCircle to draw an arc that is t pixels thick.
+func (o ProgressCircleOptions) Thickness(t float64) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.thickness = t
+	}
+}
+
+// StartAngle configures a ProgressCircle to start the arc at angle a (in radians, clockwise from the top.)
+func (o ProgressCircleOptions) StartAngle(a float64) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.startAngle = a
+	}
+}
+
+func (o ProgressCircleOptions) Image(i *ProgressCircleImage) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.image = i
+	}
+}
+
+// Text configures a ProgressCircle to display text in its center using face and color c.
+func (o ProgressCircleOptions) Text(face font.Face, c color.Color) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.face = face
+		p.textColor = c
+	}
+}
+
+// TextFunc configures a ProgressCircle to use f to get the text to display. The default displays the
+// progress in percent.
+func (o ProgressCircleOptions) TextFunc(f ProgressCircleTextFunc) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.textFunc = f
+	}
+}
+
+func (o ProgressCircleOptions) Progress(pr float64) ProgressCircleOpt {
+	return func(p *ProgressCircle) {
+		p.Progress = pr
+	}
+}
+
+func (p *ProgressCircle) GetWidget() *Widget {
+	p.init.Do()
+	return p.widget
+}
+
+func (p *ProgressCircle) SetLocation(rect img.Rectangle) {
+	p.init.Do()
+	p.widget.Rect = rect
+}
+
+func (p *ProgressCircle) PreferredSize() (int, int) {
+	p.init.Do()
+	return p.size, p.size
+}
+
+func (p *ProgressCircle) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	p.init.Do()
+	p.widget.Render(screen, def)
+	p.draw(screen)
+}
+
+func (p *ProgressCircle) draw(screen *ebiten.Image) {
+	r := p.widget.Rect
+
+	if p.image != nil {
+		drawRadialArc(screen, r, p.thickness, p.startAngle, 1, p.image.TrackImage, p.image.TrackColor)
+		drawRadialArc(screen, r, p.thickness, p.startAngle, p.Progress, p.image.FillImage, p.image.FillColor)
+	}
+
+	if p.face == nil {
+		return
+	}
+
+	t := p.textFunc(math.Max(0, math.Min(1, p.Progress)))
+	if t == "" {
+		return
+	}
+
+	m := p.face.Metrics()
+	h := textutil.Fixed26_6ToFloat64(m.Ascent + m.Descent)
+	x := r.Min.X + (r.Dx()-textutil.Advance(t, p.face))/2
+	y := int(math.Round(float64(r.Min.Y) + (float64(r.Dy())-h)/2 + textutil.Fixed26_6ToFloat64(m.Ascent)))
+	text.Draw(screen, t, p.face, x, y, p.textColor)
+}
+
+func (p *ProgressCircle) createWidget() {
+	p.widget = NewWidget(p.widgetOpts...)
+	p.widgetOpts = nil
+}
@@ -0,0 +1,46 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestProgressCircle_PreferredSize(t *testing.T) {
+	is := is.New(t)
+
+	p := NewProgressCircle(ProgressCircleOpts.Size(80))
+	event.ExecuteDeferred()
+
+	w, h := p.PreferredSize()
+	is.Equal(w, 80)
+	is.Equal(h, 80)
+}
+
+func TestProgressCircle_TextFunc_Default(t *testing.T) {
+	is := is.New(t)
+
+	p := NewProgressCircle()
+	event.ExecuteDeferred()
+
+	is.Equal(p.textFunc(0.426), "43%")
+	is.Equal(p.textFunc(1), "100%")
+}
+
+func TestProgressCircle_Render(t *testing.T) {
+	p := NewProgressCircle(
+		ProgressCircleOpts.Image(&ProgressCircleImage{
+			TrackColor: color.Black,
+			FillColor:  color.White,
+		}),
+		ProgressCircleOpts.Text(loadFont(t), color.White),
+		ProgressCircleOpts.Progress(0.5))
+	event.ExecuteDeferred()
+
+	p.SetLocation(image.Rect(0, 0, 50, 50))
+	render(p, t)
+}
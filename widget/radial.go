@@ -0,0 +1,144 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const radialSegments = 64
+
+var radialSourceImage *ebiten.Image
+
+// drawRadialProgress draws a filled circle sector centered in rect, starting at the top and going
+// clockwise. progress specifies the fraction of the full circle to draw and is clamped to [0,1].
+func drawRadialProgress(screen *ebiten.Image, rect img.Rectangle, progress float64, c color.Color) {
+	if progress <= 0 || c == nil {
+		return
+	}
+	if progress > 1 {
+		progress = 1
+	}
+
+	cr, cg, cb, ca := radialVertexColor(c)
+
+	cx := float64(rect.Min.X) + float64(rect.Dx())/2
+	cy := float64(rect.Min.Y) + float64(rect.Dy())/2
+	radius := math.Min(float64(rect.Dx()), float64(rect.Dy())) / 2
+
+	vertex := func(x float64, y float64) ebiten.Vertex {
+		return ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   1,
+			SrcY:   1,
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		}
+	}
+
+	segments := int(math.Ceil(radialSegments * progress))
+
+	vertices := make([]ebiten.Vertex, 0, segments+2)
+	indices := make([]uint16, 0, segments*3)
+
+	vertices = append(vertices, vertex(cx, cy))
+	for s := 0; s <= segments; s++ {
+		a := 2*math.Pi*progress*float64(s)/float64(segments) - math.Pi/2
+		vertices = append(vertices, vertex(cx+math.Cos(a)*radius, cy+math.Sin(a)*radius))
+
+		if s > 0 {
+			indices = append(indices, 0, uint16(s), uint16(s+1))
+		}
+	}
+
+	screen.DrawTriangles(vertices, indices, radialSource(), nil)
+}
+
+// drawRadialArc draws a ring segment of the given thickness centered in rect, starting at angle start (in radians,
+// clockwise from the top) and going clockwise. progress specifies the fraction of the full ring to draw and is
+// clamped to [0,1]. If src is not nil, it is drawn scaled to rect and masked by the ring segment, tinted by c.
+// Otherwise, the ring segment is filled with c.
+func drawRadialArc(screen *ebiten.Image, rect img.Rectangle, thickness float64, start float64, progress float64,
+	src *ebiten.Image, c color.Color) {
+
+	if progress <= 0 || (c == nil && src == nil) {
+		return
+	}
+	if progress > 1 {
+		progress = 1
+	}
+	if c == nil {
+		c = color.White
+	}
+
+	cr, cg, cb, ca := radialVertexColor(c)
+
+	cx := float64(rect.Min.X) + float64(rect.Dx())/2
+	cy := float64(rect.Min.Y) + float64(rect.Dy())/2
+	outer := math.Min(float64(rect.Dx()), float64(rect.Dy())) / 2
+	inner := math.Max(0, outer-thickness)
+
+	if src == nil {
+		src = radialSource()
+	}
+	sb := src.Bounds()
+
+	vertex := func(x float64, y float64) ebiten.Vertex {
+		sx, sy := float32(sb.Min.X), float32(sb.Min.Y)
+		if src != radialSourceImage {
+			sx += float32((x - float64(rect.Min.X)) / float64(rect.Dx()) * float64(sb.Dx()))
+			sy += float32((y - float64(rect.Min.Y)) / float64(rect.Dy()) * float64(sb.Dy()))
+		}
+
+		return ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   sx,
+			SrcY:   sy,
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		}
+	}
+
+	segments := int(math.Ceil(radialSegments * progress))
+
+	vertices := make([]ebiten.Vertex, 0, (segments+1)*2)
+	indices := make([]uint16, 0, segments*6)
+
+	for s := 0; s <= segments; s++ {
+		a := start + 2*math.Pi*progress*float64(s)/float64(segments) - math.Pi/2
+		cos, sin := math.Cos(a), math.Sin(a)
+		vertices = append(vertices, vertex(cx+cos*outer, cy+sin*outer), vertex(cx+cos*inner, cy+sin*inner))
+
+		if s > 0 {
+			o0, i0, o1, i1 := uint16(2*s-2), uint16(2*s-1), uint16(2*s), uint16(2*s+1)
+			indices = append(indices, o0, i0, o1, i0, i1, o1)
+		}
+	}
+
+	opts := ebiten.DrawTrianglesOptions{}
+	opts.Filter = ebiten.FilterLinear
+	screen.DrawTriangles(vertices, indices, src, &opts)
+}
+
+// radialSource returns a single white pixel to be used as the source image when drawing filled shapes.
+func radialSource() *ebiten.Image {
+	if radialSourceImage == nil {
+		i := ebiten.NewImage(3, 3)
+		i.Fill(color.White)
+		radialSourceImage = i.SubImage(img.Rect(1, 1, 2, 2)).(*ebiten.Image)
+	}
+	return radialSourceImage
+}
+
+func radialVertexColor(c color.Color) (float32, float32, float32, float32) {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return float32(nc.R) / 255, float32(nc.G) / 255, float32(nc.B) / 255, float32(nc.A) / 255
+}
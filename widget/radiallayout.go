@@ -0,0 +1,123 @@
+package widget
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// RadialLayout layouts widgets evenly spaced on a ring around the center of the container, starting at
+// the top and going clockwise. The widgets' centers are placed on the ring.
+type RadialLayout struct {
+	radius     int
+	padding    Insets
+	startAngle float64
+}
+
+type RadialLayoutOptions struct {
+}
+
+// RadialLayoutOpt is a function that configures r.
+type RadialLayoutOpt func(r *RadialLayout)
+
+// RadialLayoutOpts contains functions that configure a RadialLayout.
+var RadialLayoutOpts RadialLayoutOptions
+
+// NewRadialLayout constructs a new RadialLayout, configured by opts.
+func NewRadialLayout(opts ...RadialLayoutOpt) *RadialLayout {
+	r := &RadialLayout{
+		radius: 100,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// Radius configures a radial layout to place widgets on a ring of radius r.
+func (o RadialLayoutOptions) Radius(r int) RadialLayoutOpt {
+	return func(rl *RadialLayout) {
+		rl.radius = r
+	}
+}
+
+// Padding configures a radial layout to use padding i.
+func (o RadialLayoutOptions) Padding(i Insets) RadialLayoutOpt {
+	return func(r *RadialLayout) {
+		r.padding = i
+	}
+}
+
+// StartAngle configures a radial layout to place the first widget at angle a (in radians, clockwise
+// from the top.)
+func (o RadialLayoutOptions) StartAngle(a float64) RadialLayoutOpt {
+	return func(r *RadialLayout) {
+		r.startAngle = a
+	}
+}
+
+// PreferredSize implements Layouter.
+func (r *RadialLayout) PreferredSize(widgets []PreferredSizeLocateableWidget) (int, int) {
+	maxW, maxH := 0, 0
+	for _, w := range widgets {
+		ww, wh := preferredSizeWithMargin(w)
+		if ww > maxW {
+			maxW = ww
+		}
+		if wh > maxH {
+			maxH = wh
+		}
+	}
+
+	return 2*r.radius + maxW + r.padding.Dx(), 2*r.radius + maxH + r.padding.Dy()
+}
+
+// Layout implements Layouter.
+func (r *RadialLayout) Layout(widgets []PreferredSizeLocateableWidget, rect image.Rectangle) {
+	rect = r.padding.Apply(rect)
+	cx := float64(rect.Min.X) + float64(rect.Dx())/2
+	cy := float64(rect.Min.Y) + float64(rect.Dy())/2
+
+	for i, w := range widgets {
+		a := r.angle(i, len(widgets)) - math.Pi/2
+		x := cx + math.Cos(a)*float64(r.radius)
+		y := cy + math.Sin(a)*float64(r.radius)
+
+		ww, wh := preferredSizeWithMargin(w)
+		wx := int(math.Round(x - float64(ww)/2))
+		wy := int(math.Round(y - float64(wh)/2))
+		setLocationWithMargin(w, image.Rect(wx, wy, wx+ww, wy+wh))
+	}
+}
+
+// angle returns the angle of widget i of num widgets, in radians, clockwise from the top.
+func (r *RadialLayout) angle(i int, num int) float64 {
+	return r.startAngle + 2*math.Pi*float64(i)/float64(num)
+}
+
+// index returns the index of the widget of num widgets closest to direction x,y, or -1 if num is 0.
+func (r *RadialLayout) index(x float64, y float64, num int) int {
+	if num <= 0 {
+		return -1
+	}
+
+	a := math.Atan2(x, -y) - r.startAngle
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+
+	return int(math.Round(a/(2*math.Pi/float64(num)))) % num
+}
+
+// ValidateLayoutData implements LayoutDataValidator. RadialLayout does not support any layout data.
+func (r *RadialLayout) ValidateLayoutData(ld interface{}) error {
+	return fmt.Errorf("RadialLayout does not support layout data, got %T", ld)
+}
+
+// layoutPadding implements paddedLayouter.
+func (r *RadialLayout) layoutPadding() Insets {
+	return r.padding
+}
@@ -0,0 +1,60 @@
+package widget
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRadialLayout_PreferredSize(t *testing.T) {
+	is := is.New(t)
+
+	l := NewRadialLayout(
+		RadialLayoutOpts.Radius(50),
+		RadialLayoutOpts.Padding(NewInsetsSimple(5)))
+
+	w, h := l.PreferredSize([]PreferredSizeLocateableWidget{
+		newSimpleWidget(10, 20, nil),
+		newSimpleWidget(30, 10, nil),
+	})
+
+	is.Equal(w, 140)
+	is.Equal(h, 130)
+}
+
+func TestRadialLayout_Layout(t *testing.T) {
+	is := is.New(t)
+
+	l := NewRadialLayout(RadialLayoutOpts.Radius(50))
+
+	widgets := []PreferredSizeLocateableWidget{
+		newSimpleWidget(10, 10, nil),
+		newSimpleWidget(10, 10, nil),
+		newSimpleWidget(10, 10, nil),
+		newSimpleWidget(10, 10, nil),
+	}
+
+	l.Layout(widgets, image.Rect(0, 0, 100, 100))
+
+	is.Equal(widgets[0].GetWidget().Rect, image.Rect(45, -5, 55, 5))
+	is.Equal(widgets[1].GetWidget().Rect, image.Rect(95, 45, 105, 55))
+	is.Equal(widgets[2].GetWidget().Rect, image.Rect(45, 95, 55, 105))
+	is.Equal(widgets[3].GetWidget().Rect, image.Rect(-5, 45, 5, 55))
+}
+
+func TestRadialLayout_Index(t *testing.T) {
+	is := is.New(t)
+
+	l := NewRadialLayout()
+	is.Equal(l.index(0, -1, 4), 0)
+	is.Equal(l.index(1, 0, 4), 1)
+	is.Equal(l.index(0, 1, 4), 2)
+	is.Equal(l.index(-1, 0, 4), 3)
+	is.Equal(l.index(-1, -10, 4), 0)
+	is.Equal(l.index(1, 1, 0), -1)
+
+	l = NewRadialLayout(RadialLayoutOpts.StartAngle(math.Pi / 2))
+	is.Equal(l.index(1, 0, 4), 0)
+}
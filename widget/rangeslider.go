@@ -0,0 +1,436 @@
+package widget
+
+import (
+	img "image"
+	"math"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// RangeSlider is a slider with two handles that select a range of values between Min and Max.
+// The handles cannot cross each other, so that Low is always less than or equal to High.
+type RangeSlider struct {
+	Min  int
+	Max  int
+	Low  int
+	High int
+
+	ChangedEvent *event.Event
+
+	widgetOpts   []WidgetOpt
+	handleOpts   []ButtonOpt
+	direction    Direction
+	trackImage   *SliderTrackImage
+	fillImage    *image.NineSlice
+	trackPadding Insets
+	handleSize   int
+
+	init                *MultiOnce
+	widget              *Widget
+	lowHandle           *Button
+	highHandle          *Button
+	lastLow             int
+	lastHigh            int
+	hovering            bool
+	dragHandle          *Button
+	dragUndecided       bool
+	dragPressedCursor   int
+	dragPressedPosition float64
+}
+
+type RangeSliderOpt func(s *RangeSlider)
+
+type RangeSliderChangedEventArgs struct {
+	RangeSlider *RangeSlider
+	Low         int
+	High        int
+	Dragging    bool
+}
+
+type RangeSliderChangedHandlerFunc func(args *RangeSliderChangedEventArgs)
+
+type RangeSliderOptions struct {
+}
+
+var RangeSliderOpts RangeSliderOptions
+
+func NewRangeSlider(opts ...RangeSliderOpt) *RangeSlider {
+	s := &RangeSlider{
+		Min:  1,
+		Max:  100,
+		Low:  1,
+		High: 100,
+
+		ChangedEvent: &event.Event{},
+
+		trackImage: &SliderTrackImage{},
+		handleSize: 16,
+
+		lastLow:  1,
+		lastHigh: 100,
+
+		init: &MultiOnce{},
+	}
+
+	s.init.Append(s.createWidget)
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	return s
+}
+
+func (o RangeSliderOptions) WidgetOpts(opts ...WidgetOpt) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.widgetOpts = append(s.widgetOpts, opts...)
+	}
+}
+
+func (o RangeSliderOptions) Direction(d Direction) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.direction = d
+	}
+}
+
+func (o RangeSliderOptions) Images(track *SliderTrackImage, handle *ButtonImage) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.trackImage = track
+		s.handleOpts = append(s.handleOpts, ButtonOpts.Image(handle))
+	}
+}
+
+// FillImage configures a RangeSlider to draw the region of the track between the two handles using i.
+func (o RangeSliderOptions) FillImage(i *image.NineSlice) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.fillImage = i
+	}
+}
+
+func (o RangeSliderOptions) TrackPadding(i Insets) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.trackPadding = i
+	}
+}
+
+func (o RangeSliderOptions) HandleSize(s int) RangeSliderOpt {
+	return func(sl *RangeSlider) {
+		sl.handleSize = s
+	}
+}
+
+// MinMax configures a RangeSlider to allow values between min and max. The selected range is initially
+// set to the full range.
+func (o RangeSliderOptions) MinMax(min int, max int) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.Min = min
+		s.Max = max
+		s.Low = min
+		s.High = max
+	}
+}
+
+func (o RangeSliderOptions) ChangedHandler(f RangeSliderChangedHandlerFunc) RangeSliderOpt {
+	return func(s *RangeSlider) {
+		s.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*RangeSliderChangedEventArgs))
+		})
+	}
+}
+
+func (s *RangeSlider) GetWidget() *Widget {
+	s.init.Do()
+	return s.widget
+}
+
+func (s *RangeSlider) PreferredSize() (int, int) {
+	if s.direction == DirectionHorizontal {
+		return 200, s.handleSize + s.trackPadding.Dy()
+	}
+
+	return s.handleSize + s.trackPadding.Dx(), 200
+}
+
+func (s *RangeSlider) SetLocation(rect img.Rectangle) {
+	s.init.Do()
+	s.widget.Rect = rect
+}
+
+func (s *RangeSlider) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	s.init.Do()
+
+	for _, h := range []*Button{s.lowHandle, s.highHandle} {
+		h := h
+
+		h.GetWidget().ElevateToNewInputLayer(&input.Layer{
+			DebugLabel: "range slider handle",
+			EventTypes: input.LayerEventTypeAll,
+			BlockLower: true,
+			FullScreen: false,
+			RectFunc: func() img.Rectangle {
+				return h.GetWidget().Rect
+			},
+		})
+
+		h.SetupInputLayer(def)
+	}
+}
+
+func (s *RangeSlider) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	s.init.Do()
+
+	s.clampValues()
+	s.lowHandle.GetWidget().Disabled = s.widget.Disabled
+	s.highHandle.GetWidget().Disabled = s.widget.Disabled
+
+	s.widget.Render(screen, def)
+
+	s.draw(screen)
+
+	if s.dragHandle != nil {
+		s.updateDrag()
+	}
+
+	s.updateHandleLocations()
+
+	if s.fillImage != nil {
+		s.drawFill(screen)
+	}
+
+	s.lowHandle.Render(screen, def)
+	s.highHandle.Render(screen, def)
+
+	s.fireEvents()
+
+	s.lastLow, s.lastHigh = s.Low, s.High
+}
+
+func (s *RangeSlider) draw(screen *ebiten.Image) {
+	i := s.trackImage.Idle
+	if s.widget.Disabled {
+		if s.trackImage.Disabled != nil {
+			i = s.trackImage.Disabled
+		}
+	} else if s.hovering {
+		if s.trackImage.Hover != nil {
+			i = s.trackImage.Hover
+		}
+	}
+
+	if i != nil {
+		i.Draw(screen, s.widget.Rect.Dx(), s.widget.Rect.Dy(), s.widget.drawImageOptions)
+	}
+}
+
+func (s *RangeSlider) drawFill(screen *ebiten.Image) {
+	lr, hr := s.lowHandle.GetWidget().Rect, s.highHandle.GetWidget().Rect
+
+	var r img.Rectangle
+	if s.direction == DirectionHorizontal {
+		r = img.Rect(lr.Min.X+lr.Dx()/2, lr.Min.Y, hr.Min.X+hr.Dx()/2, lr.Max.Y)
+	} else {
+		r = img.Rect(lr.Min.X, lr.Min.Y+lr.Dy()/2, lr.Max.X, hr.Min.Y+hr.Dy()/2)
+	}
+
+	if r.Empty() {
+		return
+	}
+
+	s.fillImage.Draw(screen, r.Dx(), r.Dy(), func(opts *ebiten.DrawImageOptions) {
+		opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	})
+}
+
+func (s *RangeSlider) fireEvents() {
+	if s.Low != s.lastLow || s.High != s.lastHigh {
+		s.ChangedEvent.Fire(&RangeSliderChangedEventArgs{
+			RangeSlider: s,
+			Low:         s.Low,
+			High:        s.High,
+			Dragging:    s.dragHandle != nil,
+		})
+	}
+}
+
+func (s *RangeSlider) updateDrag() {
+	p := s.cursorPosition() - s.dragPressedCursor
+
+	// if both handles are at the same value, the direction of the first movement decides which one is dragged
+	if s.dragUndecided {
+		if p == 0 {
+			return
+		}
+
+		s.dragHandle = s.highHandle
+		if p < 0 {
+			s.dragHandle = s.lowHandle
+		}
+		s.dragUndecided = false
+	}
+
+	v := s.positionToValue(s.dragPressedPosition + float64(p))
+
+	if s.dragHandle == s.lowHandle {
+		if v > s.High {
+			v = s.High
+		}
+		s.Low = v
+	} else {
+		if v < s.Low {
+			v = s.Low
+		}
+		s.High = v
+	}
+
+	s.clampValues()
+}
+
+func (s *RangeSlider) updateHandleLocations() {
+	s.lowHandle.GetWidget().Rect = s.handleRect(s.Low)
+	s.highHandle.GetWidget().Rect = s.handleRect(s.High)
+}
+
+func (s *RangeSlider) handleRect(v int) img.Rectangle {
+	r := s.trackPadding.Apply(s.widget.Rect)
+	off := int(math.Round(s.valueToPosition(v) - float64(s.handleSize)/2))
+
+	if s.direction == DirectionHorizontal {
+		return img.Rect(r.Min.X+off, r.Min.Y, r.Min.X+off+s.handleSize, r.Max.Y)
+	}
+	return img.Rect(r.Min.X, r.Min.Y+off, r.Max.X, r.Min.Y+off+s.handleSize)
+}
+
+// trackLength returns the length of the track the handles' centers can move on.
+func (s *RangeSlider) trackLength() float64 {
+	r := s.trackPadding.Apply(s.widget.Rect)
+
+	l := r.Dx()
+	if s.direction == DirectionVertical {
+		l = r.Dy()
+	}
+
+	return math.Max(float64(l-s.handleSize), 0)
+}
+
+// valueToPosition returns the position of the center of the handle for value v, relative to the start
+// of the track after padding.
+func (s *RangeSlider) valueToPosition(v int) float64 {
+	if s.Max <= s.Min {
+		return float64(s.handleSize) / 2
+	}
+
+	return float64(s.handleSize)/2 + s.trackLength()*float64(v-s.Min)/float64(s.Max-s.Min)
+}
+
+// positionToValue returns the value for position p of the center of a handle, relative to the start of
+// the track after padding.
+func (s *RangeSlider) positionToValue(p float64) int {
+	l := s.trackLength()
+	if l <= 0 {
+		return s.Min
+	}
+
+	i := (p - float64(s.handleSize)/2) / l
+	if i < 0 {
+		i = 0
+	} else if i > 1 {
+		i = 1
+	}
+
+	return int(math.Round(float64(s.Min)*(1-i) + float64(s.Max)*i))
+}
+
+// cursorPosition returns the cursor position along the track, relative to the start of the track after padding.
+func (s *RangeSlider) cursorPosition() int {
+	x, y := input.CursorPosition()
+	r := s.trackPadding.Apply(s.widget.Rect)
+
+	if s.direction == DirectionHorizontal {
+		return x - r.Min.X
+	}
+	return y - r.Min.Y
+}
+
+func (s *RangeSlider) clampValues() {
+	if s.Low < s.Min {
+		s.Low = s.Min
+	} else if s.Low > s.Max {
+		s.Low = s.Max
+	}
+
+	if s.High < s.Min {
+		s.High = s.Min
+	} else if s.High > s.Max {
+		s.High = s.Max
+	}
+
+	if s.Low > s.High {
+		s.Low = s.High
+	}
+}
+
+func (s *RangeSlider) startDrag(h *Button) {
+	s.dragHandle = h
+	s.dragUndecided = s.Low == s.High
+	s.dragPressedCursor = s.cursorPosition()
+
+	v := s.Low
+	if h == s.highHandle {
+		v = s.High
+	}
+	s.dragPressedPosition = s.valueToPosition(v)
+}
+
+func (s *RangeSlider) createWidget() {
+	s.widget = NewWidget(append(s.widgetOpts, []WidgetOpt{
+		WidgetOpts.CursorEnterHandler(func(args *WidgetCursorEnterEventArgs) {
+			if !s.widget.Disabled {
+				s.hovering = true
+			}
+		}),
+
+		WidgetOpts.CursorExitHandler(func(args *WidgetCursorExitEventArgs) {
+			s.hovering = false
+		}),
+
+		// pressing on the track moves the nearest handle there
+		WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+			if s.widget.Disabled || args.Button != ebiten.MouseButtonLeft {
+				return
+			}
+
+			v := s.positionToValue(float64(s.cursorPosition()))
+			if math.Abs(float64(v-s.Low)) <= math.Abs(float64(v-s.High)) && v <= s.High {
+				s.Low = v
+			} else {
+				s.High = v
+			}
+
+			s.clampValues()
+		}),
+	}...)...)
+	s.widgetOpts = nil
+
+	newHandle := func() *Button {
+		var b *Button
+		b = NewButton(append(s.handleOpts, []ButtonOpt{
+			ButtonOpts.KeepPressedOnExit(),
+
+			ButtonOpts.PressedHandler(func(args *ButtonPressedEventArgs) {
+				s.startDrag(b)
+			}),
+
+			ButtonOpts.ReleasedHandler(func(args *ButtonReleasedEventArgs) {
+				s.dragHandle = nil
+			}),
+		}...)...)
+		return b
+	}
+
+	s.lowHandle = newHandle()
+	s.highHandle = newHandle()
+}
@@ -0,0 +1,122 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/matryer/is"
+)
+
+func TestRangeSlider_Initial(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *RangeSliderChangedEventArgs
+	s := newRangeSlider(t,
+		RangeSliderOpts.MinMax(10, 20),
+		RangeSliderOpts.ChangedHandler(func(args *RangeSliderChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	is.Equal(s.Low, 10)
+	is.Equal(s.High, 20)
+	is.Equal(eventArgs.Low, 10)
+	is.Equal(eventArgs.High, 20)
+}
+
+func TestRangeSlider_Clamp(t *testing.T) {
+	is := is.New(t)
+
+	s := newRangeSlider(t,
+		RangeSliderOpts.MinMax(10, 20))
+
+	s.Low = 18
+	s.High = 15
+	render(s, t)
+
+	is.Equal(s.Low, 15)
+	is.Equal(s.High, 15)
+}
+
+func TestRangeSlider_Drag(t *testing.T) {
+	is := is.New(t)
+
+	s := newRangeSlider(t,
+		RangeSliderOpts.MinMax(0, 100),
+		RangeSliderOpts.HandleSize(10))
+	s.SetLocation(image.Rect(0, 0, 110, 10))
+	render(s, t)
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.CursorX, internalinput.CursorY = 5, 5
+	leftMouseButtonPress(s.lowHandle, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX = 45
+	render(s, t)
+
+	is.Equal(s.Low, 40)
+	is.Equal(s.lowHandle.GetWidget().Rect, image.Rect(40, 0, 50, 10))
+
+	internalinput.CursorX = 200
+	render(s, t)
+
+	is.Equal(s.Low, 100)
+	is.Equal(s.High, 100)
+}
+
+func TestRangeSlider_Drag_SameValue(t *testing.T) {
+	is := is.New(t)
+
+	s := newRangeSlider(t,
+		RangeSliderOpts.MinMax(0, 100),
+		RangeSliderOpts.HandleSize(10))
+	s.SetLocation(image.Rect(0, 0, 110, 10))
+	s.Low, s.High = 100, 100
+	render(s, t)
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.CursorX, internalinput.CursorY = 105, 5
+	leftMouseButtonPress(s.highHandle, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	render(s, t)
+
+	is.Equal(s.Low, 100)
+	is.Equal(s.High, 100)
+
+	internalinput.CursorX = 65
+	render(s, t)
+
+	is.Equal(s.Low, 60)
+	is.Equal(s.High, 100)
+
+	internalinput.CursorX = 200
+	render(s, t)
+
+	is.Equal(s.Low, 100)
+	is.Equal(s.High, 100)
+}
+
+func newRangeSlider(t *testing.T, opts ...RangeSliderOpt) *RangeSlider {
+	t.Helper()
+
+	s := NewRangeSlider(append(opts, RangeSliderOpts.Images(&SliderTrackImage{
+		Idle: newNineSliceEmpty(t),
+	}, &ButtonImage{
+		Idle: newNineSliceEmpty(t),
+	}))...)
+	event.ExecuteDeferred()
+	render(s, t)
+	return s
+}
@@ -1,6 +1,9 @@
 package widget
 
-import "image"
+import (
+	"fmt"
+	"image"
+)
 
 // RowLayout layouts widgets in either a single row or a single column,
 // optionally stretching them in the other direction.
@@ -95,7 +98,7 @@ func (r *RowLayout) PreferredSize(widgets []PreferredSizeLocateableWidget) (int,
 // Layout implements Layouter.
 func (r *RowLayout) Layout(widgets []PreferredSizeLocateableWidget, rect image.Rectangle) {
 	r.layout(widgets, rect, true, func(w PreferredSizeLocateableWidget, wr image.Rectangle) {
-		w.SetLocation(wr)
+		setLocationWithMargin(w, wr)
 	})
 }
 
@@ -109,7 +112,7 @@ func (r *RowLayout) layout(widgets []PreferredSizeLocateableWidget, rect image.R
 
 	for _, widget := range widgets {
 		wx, wy := x, y
-		ww, wh := widget.PreferredSize()
+		ww, wh := preferredSizeWithMargin(widget)
 
 		ld := widget.GetWidget().LayoutData
 		if rld, ok := ld.(RowLayoutData); ok {
@@ -188,3 +191,16 @@ func (r *RowLayout) applyPosition(ld RowLayoutData, wx int, wy int, ww int, wh i
 
 	return wx, wy
 }
+
+// layoutPadding implements paddedLayouter.
+func (r *RowLayout) layoutPadding() Insets {
+	return r.padding
+}
+
+// ValidateLayoutData implements LayoutDataValidator.
+func (r *RowLayout) ValidateLayoutData(ld interface{}) error {
+	if _, ok := ld.(RowLayoutData); !ok {
+		return fmt.Errorf("RowLayout requires RowLayoutData, got %T", ld)
+	}
+	return nil
+}
@@ -130,6 +130,32 @@ func TestRowLayout_Layout(t *testing.T) {
 	}
 }
 
+func TestRowLayout_Layout_Margin(t *testing.T) {
+	is := is.New(t)
+
+	l := newRowLayout(t)
+
+	w1 := newSimpleWidget(10, 10, nil)
+	w1.GetWidget().Margin = Insets{
+		Top:    1,
+		Left:   2,
+		Right:  3,
+		Bottom: 4,
+	}
+	w2 := newSimpleWidget(10, 10, nil)
+
+	widgets := []PreferredSizeLocateableWidget{w1, w2}
+
+	w, h := l.PreferredSize(widgets)
+	is.Equal(w, 25)
+	is.Equal(h, 15)
+
+	l.Layout(widgets, image.Rect(0, 0, 100, 100))
+
+	is.Equal(w1.GetWidget().Rect, image.Rect(2, 1, 12, 11))
+	is.Equal(w2.GetWidget().Rect, image.Rect(15, 0, 25, 10))
+}
+
 func newRowLayout(t *testing.T, opts ...RowLayoutOpt) Layouter {
 	t.Helper()
 	l := NewRowLayout(opts...)
@@ -0,0 +1,17 @@
+package widget
+
+import "image"
+
+// safeArea is the area of the screen that is safe to place content in.
+var safeArea image.Rectangle
+
+// SafeArea returns the area of the screen that is safe to place content in, for example not covered by notches
+// or TV overscan. It is empty if no safe area has been set.
+func SafeArea() image.Rectangle {
+	return safeArea
+}
+
+// SetSafeArea sets the area of the screen that is safe to place content in. It is usually set by the UI.
+func SetSafeArea(r image.Rectangle) {
+	safeArea = r
+}
@@ -0,0 +1,180 @@
+package widget
+
+import (
+	img "image"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Scrollbar is a widget to scroll through content that is larger than the area it is shown in. The content's
+// length and the visible page's length are set as its range, and Offset specifies the position of the visible
+// page inside the content, from 0 to content length minus page length.
+type Scrollbar struct {
+	ChangedEvent *event.Event
+
+	sliderOpts    []SliderOpt
+	contentLength int
+	pageLength    int
+
+	init   *MultiOnce
+	slider *Slider
+}
+
+type ScrollbarOpt func(s *Scrollbar)
+
+type ScrollbarChangedEventArgs struct {
+	Scrollbar *Scrollbar
+	Offset    int
+	Dragging  bool
+}
+
+type ScrollbarChangedHandlerFunc func(args *ScrollbarChangedEventArgs)
+
+type ScrollbarOptions struct {
+}
+
+var ScrollbarOpts ScrollbarOptions
+
+func NewScrollbar(opts ...ScrollbarOpt) *Scrollbar {
+	s := &Scrollbar{
+		ChangedEvent: &event.Event{},
+
+		contentLength: 1,
+		pageLength:    1,
+
+		init: &MultiOnce{},
+	}
+
+	s.init.Append(s.createWidget)
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	return s
+}
+
+// SliderOpts configures the Slider that a Scrollbar uses, for example to set images.
+func (o ScrollbarOptions) SliderOpts(opts ...SliderOpt) ScrollbarOpt {
+	return func(s *Scrollbar) {
+		s.sliderOpts = append(s.sliderOpts, opts...)
+	}
+}
+
+func (o ScrollbarOptions) Direction(d Direction) ScrollbarOpt {
+	return func(s *Scrollbar) {
+		s.sliderOpts = append(s.sliderOpts, SliderOpts.Direction(d))
+	}
+}
+
+// Range configures a Scrollbar to scroll through content of contentLength, showing pageLength of it at once.
+func (o ScrollbarOptions) Range(contentLength int, pageLength int) ScrollbarOpt {
+	return func(s *Scrollbar) {
+		s.contentLength = contentLength
+		s.pageLength = pageLength
+	}
+}
+
+func (o ScrollbarOptions) ChangedHandler(f ScrollbarChangedHandlerFunc) ScrollbarOpt {
+	return func(s *Scrollbar) {
+		s.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ScrollbarChangedEventArgs))
+		})
+	}
+}
+
+func (s *Scrollbar) GetWidget() *Widget {
+	s.init.Do()
+	return s.slider.GetWidget()
+}
+
+func (s *Scrollbar) PreferredSize() (int, int) {
+	s.init.Do()
+	return s.slider.PreferredSize()
+}
+
+func (s *Scrollbar) SetLocation(rect img.Rectangle) {
+	s.init.Do()
+	s.slider.SetLocation(rect)
+}
+
+func (s *Scrollbar) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	s.init.Do()
+	s.slider.SetupInputLayer(def)
+}
+
+func (s *Scrollbar) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	s.init.Do()
+	s.slider.Render(screen, def)
+}
+
+// SetRange sets the length of the content to scroll through to contentLength, and the length of the visible
+// page to pageLength. The offset is clamped to the new range.
+func (s *Scrollbar) SetRange(contentLength int, pageLength int) {
+	s.init.Do()
+
+	s.contentLength = contentLength
+	s.pageLength = pageLength
+	s.updateSliderRange()
+}
+
+// Range returns the length of the content and the length of the visible page.
+func (s *Scrollbar) Range() (int, int) {
+	return s.contentLength, s.pageLength
+}
+
+// SetOffset sets the position of the visible page inside the content to o.
+func (s *Scrollbar) SetOffset(o int) {
+	s.init.Do()
+
+	s.slider.Current = o
+	s.slider.clampCurrentMinMax()
+}
+
+// Offset returns the position of the visible page inside the content.
+func (s *Scrollbar) Offset() int {
+	s.init.Do()
+	return s.slider.Current
+}
+
+func (s *Scrollbar) updateSliderRange() {
+	m := s.contentLength - s.pageLength
+	if m < 0 {
+		m = 0
+	}
+
+	s.slider.Min = 0
+	s.slider.Max = m
+	s.slider.clampCurrentMinMax()
+}
+
+func (s *Scrollbar) createWidget() {
+	s.slider = NewSlider(append(s.sliderOpts, []SliderOpt{
+		SliderOpts.PageSizeFunc(func() int {
+			return s.pageLength
+		}),
+
+		SliderOpts.ChangedHandler(func(args *SliderChangedEventArgs) {
+			s.ChangedEvent.Fire(&ScrollbarChangedEventArgs{
+				Scrollbar: s,
+				Offset:    args.Current,
+				Dragging:  s.slider.dragging,
+			})
+		}),
+	}...)...)
+	s.sliderOpts = nil
+
+	s.slider.handleLengthRatioFunc = func() float64 {
+		if s.contentLength <= 0 || s.pageLength >= s.contentLength {
+			return 1
+		}
+		return float64(s.pageLength) / float64(s.contentLength)
+	}
+
+	s.slider.Current = 0
+	s.slider.lastCurrent = 0
+	s.updateSliderRange()
+}
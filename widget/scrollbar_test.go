@@ -0,0 +1,56 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestScrollbar_SetOffset(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *ScrollbarChangedEventArgs
+	s := newScrollbar(t,
+		ScrollbarOpts.Range(1000, 100),
+		ScrollbarOpts.ChangedHandler(func(args *ScrollbarChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	s.SetOffset(2000)
+	render(s, t)
+
+	is.Equal(s.Offset(), 900)
+	is.Equal(eventArgs.Offset, 900)
+
+	s.SetRange(500, 100)
+	is.Equal(s.Offset(), 400)
+}
+
+func TestScrollbar_HandleLength(t *testing.T) {
+	is := is.New(t)
+
+	s := newScrollbar(t,
+		ScrollbarOpts.Direction(DirectionVertical),
+		ScrollbarOpts.Range(1000, 250),
+		ScrollbarOpts.SliderOpts(SliderOpts.HandleSize(1)))
+	s.SetLocation(image.Rect(0, 0, 10, 200))
+	render(s, t)
+
+	is.Equal(s.slider.handle.GetWidget().Rect.Dy(), 50)
+}
+
+func newScrollbar(t *testing.T, opts ...ScrollbarOpt) *Scrollbar {
+	t.Helper()
+
+	s := NewScrollbar(append(opts, ScrollbarOpts.SliderOpts(SliderOpts.Images(&SliderTrackImage{
+		Idle: newNineSliceEmpty(t),
+	}, &ButtonImage{
+		Idle: newNineSliceEmpty(t),
+	})))...)
+	event.ExecuteDeferred()
+	render(s, t)
+	return s
+}
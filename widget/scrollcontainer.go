@@ -4,6 +4,7 @@ import (
 	img "image"
 	"math"
 
+	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/image"
 	"github.com/blizzy78/ebitenui/input"
 
@@ -14,15 +15,30 @@ type ScrollContainer struct {
 	ScrollLeft float64
 	ScrollTop  float64
 
+	ScrollChangedEvent    *event.Event
+	EdgeReachedEvent      *event.Event
+	RefreshRequestedEvent *event.Event
+
 	widgetOpts          []WidgetOpt
 	image               *ScrollContainerImage
 	content             HasWidget
 	padding             Insets
 	stretchContentWidth bool
-
-	init      *MultiOnce
-	widget    *Widget
-	renderBuf *image.MaskedRenderBuffer
+	pullIndicator       PreferredSizeLocateableWidget
+	pullThreshold       int
+	clipRadius          int
+
+	init         *MultiOnce
+	widget       *Widget
+	renderBuf    *image.MaskedRenderBuffer
+	roundedBuf   *image.RoundedClipRenderBuffer
+	pulling      bool
+	pullStartY   int
+	pullDistance int
+	refreshing   bool
+	lastLeft     float64
+	lastTop      float64
+	edges        ScrollContainerEdge
 }
 
 type ScrollContainerOpt func(s *ScrollContainer)
@@ -36,10 +52,47 @@ type ScrollContainerImage struct {
 type ScrollContainerOptions struct {
 }
 
+// ScrollContainerEdge is a bit mask of edges of a ScrollContainer's content.
+type ScrollContainerEdge int
+
+type ScrollContainerScrollChangedEventArgs struct {
+	ScrollContainer    *ScrollContainer
+	ScrollLeft         float64
+	ScrollTop          float64
+	PreviousScrollLeft float64
+	PreviousScrollTop  float64
+}
+
+type ScrollContainerEdgeReachedEventArgs struct {
+	ScrollContainer *ScrollContainer
+	Edge            ScrollContainerEdge
+}
+
+type ScrollContainerScrollChangedHandlerFunc func(args *ScrollContainerScrollChangedEventArgs)
+
+type ScrollContainerEdgeReachedHandlerFunc func(args *ScrollContainerEdgeReachedEventArgs)
+
+type ScrollContainerRefreshRequestedEventArgs struct {
+	ScrollContainer *ScrollContainer
+}
+
+type ScrollContainerRefreshRequestedHandlerFunc func(args *ScrollContainerRefreshRequestedEventArgs)
+
+const (
+	ScrollContainerEdgeTop = ScrollContainerEdge(1 << iota)
+	ScrollContainerEdgeBottom
+	ScrollContainerEdgeLeft
+	ScrollContainerEdgeRight
+)
+
 var ScrollContainerOpts ScrollContainerOptions
 
 func NewScrollContainer(opts ...ScrollContainerOpt) *ScrollContainer {
 	s := &ScrollContainer{
+		ScrollChangedEvent:    &event.Event{},
+		EdgeReachedEvent:      &event.Event{},
+		RefreshRequestedEvent: &event.Event{},
+
 		init: &MultiOnce{},
 
 		renderBuf: image.NewMaskedRenderBuffer(),
@@ -78,17 +131,73 @@ func (o ScrollContainerOptions) Padding(p Insets) ScrollContainerOpt {
 	}
 }
 
+// ClipRadius configures a ScrollContainer to clip its content to a rectangle with corners rounded by radius,
+// instead of using ScrollContainerImage.Mask, which may then be nil.
+func (o ScrollContainerOptions) ClipRadius(radius int) ScrollContainerOpt {
+	return func(s *ScrollContainer) {
+		s.clipRadius = radius
+	}
+}
+
 func (o ScrollContainerOptions) StretchContentWidth() ScrollContainerOpt {
 	return func(s *ScrollContainer) {
 		s.stretchContentWidth = true
 	}
 }
 
+// PullToRefresh configures a ScrollContainer to allow pulling its content down using the mouse or touch
+// while it is scrolled to the top. While pulling, indicator is displayed above the content. If the content is
+// released after being pulled down by at least threshold pixels, RefreshRequestedEvent is fired, and the
+// ScrollContainer stays in refreshing state until SetRefreshing(false) is called.
+func (o ScrollContainerOptions) PullToRefresh(indicator PreferredSizeLocateableWidget, threshold int) ScrollContainerOpt {
+	return func(s *ScrollContainer) {
+		s.pullIndicator = indicator
+		s.pullThreshold = threshold
+	}
+}
+
+func (o ScrollContainerOptions) ScrollChangedHandler(f ScrollContainerScrollChangedHandlerFunc) ScrollContainerOpt {
+	return func(s *ScrollContainer) {
+		s.ScrollChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ScrollContainerScrollChangedEventArgs))
+		})
+	}
+}
+
+// EdgeReachedHandler configures a ScrollContainer to call f when the view reaches an edge of the content,
+// for example to load more content when reaching the bottom. Edges that the view is at initially are
+// reported as well.
+func (o ScrollContainerOptions) EdgeReachedHandler(f ScrollContainerEdgeReachedHandlerFunc) ScrollContainerOpt {
+	return func(s *ScrollContainer) {
+		s.EdgeReachedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ScrollContainerEdgeReachedEventArgs))
+		})
+	}
+}
+
+func (o ScrollContainerOptions) RefreshRequestedHandler(f ScrollContainerRefreshRequestedHandlerFunc) ScrollContainerOpt {
+	return func(s *ScrollContainer) {
+		s.RefreshRequestedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ScrollContainerRefreshRequestedEventArgs))
+		})
+	}
+}
+
 func (s *ScrollContainer) GetWidget() *Widget {
 	s.init.Do()
 	return s.widget
 }
 
+// ContainedWidgets implements Containerer.
+func (s *ScrollContainer) ContainedWidgets() []HasWidget {
+	s.init.Do()
+
+	if s.content == nil {
+		return nil
+	}
+	return []HasWidget{s.content}
+}
+
 func (s *ScrollContainer) SetLocation(rect img.Rectangle) {
 	s.init.Do()
 	s.widget.Rect = rect
@@ -126,6 +235,19 @@ func (s *ScrollContainer) SetupInputLayer(def input.DeferredSetupInputLayerFunc)
 	}
 }
 
+// FocusOrder implements FocusContainer.
+func (s *ScrollContainer) FocusOrder() []HasWidget {
+	s.init.Do()
+	return appendFocusOrder(nil, []HasWidget{s.content})
+}
+
+func (s *ScrollContainer) releaseResources() {
+	s.renderBuf.Dispose()
+	if s.roundedBuf != nil {
+		s.roundedBuf.Dispose()
+	}
+}
+
 func (s *ScrollContainer) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	s.init.Do()
 
@@ -134,9 +256,110 @@ func (s *ScrollContainer) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 
 	s.widget.Render(screen, def)
 
+	if s.pullIndicator != nil {
+		s.updatePull()
+	}
+
 	s.draw(screen)
 
 	s.renderContent(screen, def)
+
+	s.fireScrollEvents()
+
+	if s.pullIndicator != nil {
+		s.renderPullIndicator(screen, def)
+	}
+}
+
+// updatePull updates the pull-to-refresh state according to mouse input.
+func (s *ScrollContainer) updatePull() {
+	x, y := input.CursorPosition()
+
+	switch {
+	case !s.pulling:
+		if s.widget.Disabled || s.refreshing || s.ScrollTop > 0 {
+			return
+		}
+
+		p := img.Point{x, y}
+		if input.MouseButtonJustPressedLayer(ebiten.MouseButtonLeft, s.content.GetWidget().EffectiveInputLayer()) && p.In(s.ContentRect()) {
+			s.pulling = true
+			s.pullStartY = y
+		}
+
+	case input.MouseButtonPressed(ebiten.MouseButtonLeft):
+		// pulling has some resistance
+		d := (y - s.pullStartY) / 2
+		if d < 0 {
+			d = 0
+		}
+		if d > 2*s.pullThreshold {
+			d = 2 * s.pullThreshold
+		}
+		s.pullDistance = d
+
+	default:
+		s.pulling = false
+		if s.pullDistance >= s.pullThreshold && !s.widget.Disabled {
+			s.SetRefreshing(true)
+		}
+		s.pullDistance = 0
+	}
+}
+
+// pullOffset returns the distance to move the content down by.
+func (s *ScrollContainer) pullOffset() int {
+	if s.refreshing && s.pullDistance < s.pullThreshold {
+		return s.pullThreshold
+	}
+	return s.pullDistance
+}
+
+func (s *ScrollContainer) renderPullIndicator(screen *ebiten.Image, def DeferredRenderFunc) {
+	off := s.pullOffset()
+	if off <= 0 {
+		return
+	}
+
+	r, ok := s.pullIndicator.(Renderer)
+	if !ok {
+		return
+	}
+
+	cr := s.ContentRect()
+	w, h := s.pullIndicator.PreferredSize()
+	x := cr.Min.X + (cr.Dx()-w)/2
+	y := cr.Min.Y + (off-h)/2
+
+	rect := img.Rect(x, y, x+w, y+h)
+	if rect != s.pullIndicator.GetWidget().Rect {
+		s.pullIndicator.SetLocation(rect)
+		if rl, ok := s.pullIndicator.(Relayoutable); ok {
+			rl.RequestRelayout()
+		}
+	}
+
+	renderWithHandlers(r, screen.SubImage(img.Rect(cr.Min.X, cr.Min.Y, cr.Max.X, cr.Min.Y+off)).(*ebiten.Image), def)
+}
+
+// SetRefreshing sets whether s is in refreshing state. Setting it to true fires RefreshRequestedEvent.
+func (s *ScrollContainer) SetRefreshing(r bool) {
+	if r == s.refreshing {
+		return
+	}
+
+	s.refreshing = r
+
+	if r {
+		s.RefreshRequestedEvent.Fire(&ScrollContainerRefreshRequestedEventArgs{
+			ScrollContainer: s,
+		})
+	}
+}
+
+// Refreshing returns whether s is in refreshing state.
+func (s *ScrollContainer) Refreshing() bool {
+	return s.refreshing
 }
 
 func (s *ScrollContainer) draw(screen *ebiten.Image) {
@@ -172,15 +395,8 @@ func (s *ScrollContainer) renderContent(screen *ebiten.Image, def DeferredRender
 	}
 
 	if l, ok := s.content.(Locateable); ok {
-		cw, ch := 50, 50
-		if p, ok := s.content.(PreferredSizer); ok {
-			cw, ch = p.PreferredSize()
-		}
-
+		cw, ch := s.contentSize()
 		crect := s.ContentRect()
-		if s.stretchContentWidth && cw < crect.Dx() {
-			cw = crect.Dx()
-		}
 
 		rect := img.Rect(0, 0, cw, ch)
 		rect = rect.Add(s.widget.Rect.Min)
@@ -188,6 +404,10 @@ func (s *ScrollContainer) renderContent(screen *ebiten.Image, def DeferredRender
 
 		rect = rect.Sub(img.Point{int(math.Round(float64(cw-crect.Dx()) * s.ScrollLeft)), int(math.Round(float64(ch-crect.Dy()) * s.ScrollTop))})
 
+		if s.pullIndicator != nil {
+			rect = rect.Add(img.Point{0, s.pullOffset()})
+		}
+
 		if rect != s.content.GetWidget().Rect {
 			l.SetLocation(rect)
 
@@ -197,16 +417,125 @@ func (s *ScrollContainer) renderContent(screen *ebiten.Image, def DeferredRender
 		}
 	}
 
-	s.renderBuf.Draw(screen,
-		func(buf *ebiten.Image) {
-			r.Render(buf, def)
-		},
-		func(buf *ebiten.Image) {
-			s.image.Mask.Draw(buf, s.widget.Rect.Dx()-s.padding.Dx(), s.widget.Rect.Dy()-s.padding.Dy(), func(opts *ebiten.DrawImageOptions) {
-				opts.GeoM.Translate(float64(s.widget.Rect.Min.X+s.padding.Left), float64(s.widget.Rect.Min.Y+s.padding.Top))
-				opts.CompositeMode = ebiten.CompositeModeCopy
-			})
+	if s.clipRadius > 0 {
+		if s.roundedBuf == nil {
+			s.roundedBuf = image.NewRoundedClipRenderBuffer()
+		}
+
+		s.roundedBuf.Draw(screen, s.padding.Apply(s.widget.Rect), s.clipRadius, func(buf *ebiten.Image) {
+			renderWithHandlers(r, buf, def)
+		})
+
+		return
+	}
+
+	s.renderBuf.DrawNineSliceMask(screen, s.padding.Apply(s.widget.Rect), s.image.Mask, func(buf *ebiten.Image) {
+		renderWithHandlers(r, buf, def)
+	})
+}
+
+// contentSize returns the size of s's content.
+func (s *ScrollContainer) contentSize() (int, int) {
+	cw, ch := 50, 50
+	if p, ok := s.content.(PreferredSizer); ok {
+		cw, ch = p.PreferredSize()
+	}
+
+	if crect := s.ContentRect(); s.stretchContentWidth && cw < crect.Dx() {
+		cw = crect.Dx()
+	}
+
+	return cw, ch
+}
+
+// maxScrollOffset returns the maximum scroll offsets in pixels. They are 0 if the content fits into the view.
+func (s *ScrollContainer) maxScrollOffset() (int, int) {
+	cw, ch := s.contentSize()
+	crect := s.ContentRect()
+
+	mx, my := cw-crect.Dx(), ch-crect.Dy()
+	if mx < 0 {
+		mx = 0
+	}
+	if my < 0 {
+		my = 0
+	}
+
+	return mx, my
+}
+
+// ScrollOffset returns the current scroll offsets in pixels.
+func (s *ScrollContainer) ScrollOffset() (int, int) {
+	s.init.Do()
+	mx, my := s.maxScrollOffset()
+	return int(math.Round(float64(mx) * s.ScrollLeft)), int(math.Round(float64(my) * s.ScrollTop))
+}
+
+// SetScrollOffset scrolls to offsets x,y in pixels.
+func (s *ScrollContainer) SetScrollOffset(x int, y int) {
+	s.init.Do()
+
+	mx, my := s.maxScrollOffset()
+
+	s.ScrollLeft = 0
+	if mx > 0 {
+		s.ScrollLeft = float64(x) / float64(mx)
+	}
+
+	s.ScrollTop = 0
+	if my > 0 {
+		s.ScrollTop = float64(y) / float64(my)
+	}
+
+	s.clampScroll()
+}
+
+// fireScrollEvents fires ScrollChangedEvent if the scroll position has changed, and EdgeReachedEvent for
+// each edge that the view has reached.
+func (s *ScrollContainer) fireScrollEvents() {
+	if s.ScrollLeft != s.lastLeft || s.ScrollTop != s.lastTop {
+		s.ScrollChangedEvent.Fire(&ScrollContainerScrollChangedEventArgs{
+			ScrollContainer:    s,
+			ScrollLeft:         s.ScrollLeft,
+			ScrollTop:          s.ScrollTop,
+			PreviousScrollLeft: s.lastLeft,
+			PreviousScrollTop:  s.lastTop,
 		})
+
+		s.lastLeft, s.lastTop = s.ScrollLeft, s.ScrollTop
+	}
+
+	if s.content == nil {
+		return
+	}
+
+	mx, my := s.maxScrollOffset()
+
+	var edges ScrollContainerEdge
+	if my == 0 || s.ScrollTop <= 0 {
+		edges |= ScrollContainerEdgeTop
+	}
+	if my == 0 || s.ScrollTop >= 1 {
+		edges |= ScrollContainerEdgeBottom
+	}
+	if mx == 0 || s.ScrollLeft <= 0 {
+		edges |= ScrollContainerEdgeLeft
+	}
+	if mx == 0 || s.ScrollLeft >= 1 {
+		edges |= ScrollContainerEdgeRight
+	}
+
+	reached := edges &^ s.edges
+	s.edges = edges
+
+	for e := ScrollContainerEdgeTop; e <= ScrollContainerEdgeRight; e <<= 1 {
+		if reached&e != 0 {
+			s.EdgeReachedEvent.Fire(&ScrollContainerEdgeReachedEventArgs{
+				ScrollContainer: s,
+				Edge:            e,
+			})
+		}
+	}
 }
 
 func (s *ScrollContainer) ContentRect() img.Rectangle {
@@ -231,4 +560,8 @@ func (s *ScrollContainer) clampScroll() {
 func (s *ScrollContainer) createWidget() {
 	s.widget = NewWidget(s.widgetOpts...)
 	s.widgetOpts = nil
+
+	if s.pullIndicator != nil {
+		s.pullIndicator.GetWidget().parent = s.widget
+	}
 }
@@ -0,0 +1,115 @@
+package widget
+
+import (
+	img "image"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+	"github.com/matryer/is"
+)
+
+func TestScrollContainer_PullToRefresh(t *testing.T) {
+	is := is.New(t)
+
+	numEvents := 0
+	s := newScrollContainer(t,
+		ScrollContainerOpts.Content(newContainer(t)),
+		ScrollContainerOpts.PullToRefresh(newSimpleWidget(10, 10, nil), 20),
+		ScrollContainerOpts.RefreshRequestedHandler(func(args *ScrollContainerRefreshRequestedEventArgs) {
+			numEvents++
+		}))
+
+	s.SetLocation(img.Rect(0, 0, 100, 100))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.LeftMouseButtonJustPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.CursorX, internalinput.CursorY = 50, 10
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.LeftMouseButtonJustPressed = true
+	render(s, t)
+	internalinput.LeftMouseButtonJustPressed = false
+
+	internalinput.CursorY = 40
+	render(s, t)
+	is.Equal(s.pullOffset(), 15)
+	is.Equal(s.content.GetWidget().Rect.Min.Y, 15)
+
+	internalinput.CursorY = 70
+	render(s, t)
+	is.Equal(s.pullOffset(), 30)
+
+	internalinput.LeftMouseButtonPressed = false
+	render(s, t)
+
+	is.True(s.Refreshing())
+	is.Equal(s.pullOffset(), 20)
+	is.Equal(numEvents, 1)
+
+	s.SetRefreshing(false)
+	render(s, t)
+	is.Equal(s.pullOffset(), 0)
+}
+
+func TestScrollContainer_ScrollOffset(t *testing.T) {
+	is := is.New(t)
+
+	s := newScrollContainer(t, ScrollContainerOpts.Content(newSimpleWidget(100, 300, nil)))
+	s.SetLocation(img.Rect(0, 0, 100, 100))
+
+	s.SetScrollOffset(0, 100)
+	is.Equal(s.ScrollTop, 0.5)
+
+	x, y := s.ScrollOffset()
+	is.Equal(x, 0)
+	is.Equal(y, 100)
+
+	s.SetScrollOffset(0, 1000)
+	is.Equal(s.ScrollTop, 1.0)
+}
+
+func TestScrollContainer_Events(t *testing.T) {
+	is := is.New(t)
+
+	var changedArgs *ScrollContainerScrollChangedEventArgs
+	var edges []ScrollContainerEdge
+
+	s := newScrollContainer(t,
+		ScrollContainerOpts.Content(newSimpleWidget(100, 300, nil)),
+		ScrollContainerOpts.ScrollChangedHandler(func(args *ScrollContainerScrollChangedEventArgs) {
+			changedArgs = args
+		}),
+		ScrollContainerOpts.EdgeReachedHandler(func(args *ScrollContainerEdgeReachedEventArgs) {
+			edges = append(edges, args.Edge)
+		}))
+
+	s.SetLocation(img.Rect(0, 0, 100, 100))
+	render(s, t)
+
+	is.Equal(edges, []ScrollContainerEdge{ScrollContainerEdgeTop, ScrollContainerEdgeLeft, ScrollContainerEdgeRight})
+	is.True(changedArgs == nil)
+
+	edges = nil
+	s.ScrollTop = 1
+	render(s, t)
+
+	is.Equal(changedArgs.ScrollTop, 1.0)
+	is.Equal(changedArgs.PreviousScrollTop, 0.0)
+	is.Equal(edges, []ScrollContainerEdge{ScrollContainerEdgeBottom})
+}
+
+func newScrollContainer(t *testing.T, opts ...ScrollContainerOpt) *ScrollContainer {
+	t.Helper()
+
+	s := NewScrollContainer(append(opts, ScrollContainerOpts.Image(&ScrollContainerImage{
+		Idle: newNineSliceEmpty(t),
+		Mask: newNineSliceEmpty(t),
+	}))...)
+	event.ExecuteDeferred()
+	render(s, t)
+	return s
+}
@@ -0,0 +1,95 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Skeleton configures a placeholder block that widgets draw instead of their content while it is still
+// being loaded, such as a Text whose label has not been set yet.
+type Skeleton struct {
+	// Color is the color of the block.
+	Color color.Color
+
+	// ShimmerColor is the color of a highlight that repeatedly sweeps across the block. If nil, the block
+	// is drawn without a highlight.
+	ShimmerColor color.Color
+
+	// ShimmerPeriod is the duration of one sweep of the highlight. If 0, 1.5 seconds is used.
+	ShimmerPeriod time.Duration
+
+	// Width is the width of the block. If 0, the block fills the widget's width.
+	Width int
+}
+
+// blockRect returns the area of the block inside r, positioned horizontally according to pos.
+func (s *Skeleton) blockRect(r image.Rectangle, pos TextPosition) image.Rectangle {
+	if s.Width <= 0 || s.Width >= r.Dx() {
+		return r
+	}
+
+	x := r.Min.X
+	switch pos {
+	case TextPositionCenter:
+		x += (r.Dx() - s.Width) / 2
+	case TextPositionEnd:
+		x = r.Max.X - s.Width
+	}
+
+	return image.Rect(x, r.Min.Y, x+s.Width, r.Max.Y)
+}
+
+// skeletonShimmerStrips is the number of strips the highlight is made of, to approximate a gradient.
+const skeletonShimmerStrips = 8
+
+// draw draws s into rect r of screen.
+func (s *Skeleton) draw(screen *ebiten.Image, r image.Rectangle) {
+	if r.Empty() {
+		return
+	}
+
+	fillRect(screen, r, s.Color)
+
+	if s.ShimmerColor == nil {
+		return
+	}
+
+	period := s.ShimmerPeriod
+	if period <= 0 {
+		period = 1500 * time.Millisecond
+	}
+	period = animationDuration(period)
+	if period <= 0 {
+		return
+	}
+
+	phase := float64(Now().UnixNano()%int64(period)) / float64(period)
+
+	bw := r.Dx() / 2
+	if bw < skeletonShimmerStrips {
+		bw = skeletonShimmerStrips
+	}
+	x := r.Min.X - bw + int(phase*float64(r.Dx()+bw))
+
+	sub := screen.SubImage(r).(*ebiten.Image)
+	sw := bw / skeletonShimmerStrips
+	nc := color.NRGBAModel.Convert(s.ShimmerColor).(color.NRGBA)
+
+	for i := 0; i < skeletonShimmerStrips; i++ {
+		// alpha rises towards the center of the highlight and falls off again
+		d := float64(i) + 0.5 - skeletonShimmerStrips/2.0
+		if d < 0 {
+			d = -d
+		}
+		a := 1 - d/(skeletonShimmerStrips/2.0)
+
+		c := nc
+		c.A = uint8(float64(nc.A) * a)
+
+		sx := x + i*sw
+		fillRect(sub, image.Rect(sx, r.Min.Y, sx+sw, r.Max.Y), c)
+	}
+}
@@ -2,13 +2,17 @@ package widget
 
 import (
 	img "image"
+	"image/color"
 	"math"
 
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/image"
 	"github.com/blizzy78/ebitenui/input"
+	"github.com/blizzy78/ebitenui/textutil"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
 )
 
 type Slider struct {
@@ -26,6 +30,18 @@ type Slider struct {
 	trackPadding Insets
 	handleSize   int
 	pageSizeFunc SliderPageSizeFunc
+	tickInterval int
+	tickImage    *image.NineSlice
+	tickWidth    int
+	tickLength   int
+	labelFace    font.Face
+	labelColor   color.Color
+	labelFunc    SliderLabelFunc
+	snapStrength float64
+
+	// handleLengthRatioFunc returns the length of the handle as a fraction of the track length. If nil,
+	// the handle length is determined by the page size.
+	handleLengthRatioFunc func() float64
 
 	init                         *MultiOnce
 	widget                       *Widget
@@ -50,6 +66,9 @@ type SliderOpt func(s *Slider)
 
 type SliderPageSizeFunc func() int
 
+// SliderLabelFunc is a function that returns the label text for a slider value.
+type SliderLabelFunc func(value int) string
+
 type SliderChangedEventArgs struct {
 	Slider   *Slider
 	Current  int
@@ -61,6 +80,8 @@ type SliderChangedHandlerFunc func(args *SliderChangedEventArgs)
 type SliderOptions struct {
 }
 
+const sliderLabelSpacing = 2
+
 var SliderOpts SliderOptions
 
 func NewSlider(opts ...SliderOpt) *Slider {
@@ -135,6 +156,39 @@ func (o SliderOptions) PageSizeFunc(f SliderPageSizeFunc) SliderOpt {
 	}
 }
 
+// Ticks configures a Slider to draw tick marks every interval values, starting at the minimum value.
+// Tick marks are drawn using i, with width specifying their size along the track, and length specifying
+// their size across the track.
+func (o SliderOptions) Ticks(interval int, i *image.NineSlice, width int, length int) SliderOpt {
+	return func(s *Slider) {
+		s.tickInterval = interval
+		s.tickImage = i
+		s.tickWidth = width
+		s.tickLength = length
+	}
+}
+
+// Labels configures a Slider to draw labels for the minimum and maximum values at the ends of the track,
+// and for the current value next to the handle. Labels are drawn below a horizontal slider, and to the
+// right of a vertical slider. If f is nil, values are formatted as plain numbers.
+func (o SliderOptions) Labels(face font.Face, c color.Color, f SliderLabelFunc) SliderOpt {
+	return func(s *Slider) {
+		s.labelFace = face
+		s.labelColor = c
+		s.labelFunc = f
+	}
+}
+
+// SnapToTicks configures a Slider to snap to tick marks while dragging the handle. strength specifies
+// how close the handle must be to a tick mark to snap to it, as a fraction of half the tick interval:
+// A strength of 1 always snaps to the nearest tick mark, while smaller values allow values between
+// tick marks.
+func (o SliderOptions) SnapToTicks(strength float64) SliderOpt {
+	return func(s *Slider) {
+		s.snapStrength = strength
+	}
+}
+
 func (o SliderOptions) ChangedHandler(f SliderChangedHandlerFunc) SliderOpt {
 	return func(s *Slider) {
 		s.ChangedEvent.AddHandler(func(args interface{}) {
@@ -149,11 +203,46 @@ func (s *Slider) GetWidget() *Widget {
 }
 
 func (s *Slider) PreferredSize() (int, int) {
+	lw, lh := s.labelsSize()
+
+	if s.direction == DirectionHorizontal {
+		return 200, s.handleSize + s.trackPadding.Top + s.trackPadding.Bottom + lh
+	}
+
+	return s.handleSize + s.trackPadding.Left + s.trackPadding.Right + lw, 200
+}
+
+// labelsSize returns the width and height of the area needed to draw labels.
+func (s *Slider) labelsSize() (int, int) {
+	if s.labelFace == nil {
+		return 0, 0
+	}
+
 	if s.direction == DirectionHorizontal {
-		return 200, s.handleSize + s.trackPadding.Top + s.trackPadding.Bottom
+		m := s.labelFace.Metrics()
+		return 0, int(math.Ceil(textutil.Fixed26_6ToFloat64(m.Ascent+m.Descent))) + sliderLabelSpacing
 	}
 
-	return s.handleSize + s.trackPadding.Left + s.trackPadding.Right, 200
+	w := textutil.Advance(s.label(s.Min), s.labelFace)
+	if mw := textutil.Advance(s.label(s.Max), s.labelFace); mw > w {
+		w = mw
+	}
+	return w + sliderLabelSpacing, 0
+}
+
+// trackRect returns the area of the track, which is the widget's Rect without the area needed to draw labels.
+func (s *Slider) trackRect() img.Rectangle {
+	r := s.widget.Rect
+	lw, lh := s.labelsSize()
+	r.Max = r.Max.Sub(img.Point{lw, lh})
+	return r
+}
+
+func (s *Slider) label(v int) string {
+	if s.labelFunc != nil {
+		return s.labelFunc(v)
+	}
+	return locale.FormatInt(v)
 }
 
 func (s *Slider) SetLocation(rect img.Rectangle) {
@@ -189,8 +278,16 @@ func (s *Slider) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	s.updateHandleLocation(hl, tl)
 	s.updateHandleSize(hl)
 
+	if s.tickInterval > 0 && s.tickImage != nil {
+		s.drawTicks(screen, hl, tl)
+	}
+
 	s.handle.Render(screen, def)
 
+	if s.labelFace != nil {
+		s.drawLabels(screen)
+	}
+
 	s.fireEvents()
 
 	s.lastCurrent = s.Current
@@ -209,8 +306,67 @@ func (s *Slider) draw(screen *ebiten.Image) {
 	}
 
 	if i != nil {
-		i.Draw(screen, s.widget.Rect.Dx(), s.widget.Rect.Dy(), s.widget.drawImageOptions)
+		r := s.trackRect()
+		i.Draw(screen, r.Dx(), r.Dy(), s.widget.drawImageOptions)
+	}
+}
+
+func (s *Slider) drawTicks(screen *ebiten.Image, handleLength float64, trackLength float64) {
+	r := s.trackRect()
+
+	for v := s.Min; v <= s.Max; v += s.tickInterval {
+		p := int(math.Round(s.handleCenter(s.currentToInternal(v), handleLength, trackLength)))
+
+		var tr img.Rectangle
+		if s.direction == DirectionHorizontal {
+			tr = img.Rect(0, 0, s.tickWidth, s.tickLength)
+			tr = tr.Add(img.Point{r.Min.X + s.trackPadding.Left + p - s.tickWidth/2, r.Min.Y + (r.Dy()-s.tickLength)/2})
+		} else {
+			tr = img.Rect(0, 0, s.tickLength, s.tickWidth)
+			tr = tr.Add(img.Point{r.Min.X + (r.Dx()-s.tickLength)/2, r.Min.Y + s.trackPadding.Top + p - s.tickWidth/2})
+		}
+
+		s.tickImage.Draw(screen, tr.Dx(), tr.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(tr.Min.X), float64(tr.Min.Y))
+		})
+	}
+}
+
+func (s *Slider) drawLabels(screen *ebiten.Image) {
+	r := s.trackRect()
+	hr := s.handle.GetWidget().Rect
+	m := s.labelFace.Metrics()
+	ascent := int(math.Round(textutil.Fixed26_6ToFloat64(m.Ascent)))
+	lineHeight := int(math.Round(textutil.Fixed26_6ToFloat64(m.Ascent + m.Descent)))
+
+	minLabel, maxLabel, valueLabel := s.label(s.Min), s.label(s.Max), s.label(s.Current)
+	minWidth, maxWidth, valueWidth := textutil.Advance(minLabel, s.labelFace), textutil.Advance(maxLabel, s.labelFace), textutil.Advance(valueLabel, s.labelFace)
+
+	// labels are positioned by their top left corners
+	var minPos, maxPos, valuePos img.Point
+	if s.direction == DirectionHorizontal {
+		y := r.Max.Y + sliderLabelSpacing
+		minPos = img.Point{r.Min.X, y}
+		maxPos = img.Point{r.Max.X - maxWidth, y}
+		valuePos = img.Point{hr.Min.X + (hr.Dx()-valueWidth)/2, y}
+	} else {
+		x := r.Max.X + sliderLabelSpacing
+		minPos = img.Point{x, r.Min.Y}
+		maxPos = img.Point{x, r.Max.Y - lineHeight}
+		valuePos = img.Point{x, hr.Min.Y + (hr.Dy()-lineHeight)/2}
 	}
+
+	minRect := img.Rect(0, 0, minWidth, lineHeight).Add(minPos)
+	maxRect := img.Rect(0, 0, maxWidth, lineHeight).Add(maxPos)
+	valueRect := img.Rect(0, 0, valueWidth, lineHeight).Add(valuePos)
+
+	if !minRect.Overlaps(valueRect) {
+		text.Draw(screen, minLabel, s.labelFace, minPos.X, minPos.Y+ascent, s.labelColor)
+	}
+	if !maxRect.Overlaps(valueRect) {
+		text.Draw(screen, maxLabel, s.labelFace, maxPos.X, maxPos.Y+ascent, s.labelColor)
+	}
+	text.Draw(screen, valueLabel, s.labelFace, valuePos.X, valuePos.Y+ascent, s.labelColor)
 }
 
 func (s *Slider) fireEvents() {
@@ -228,7 +384,7 @@ func (s *Slider) updateHandleSize(handleLength float64) {
 		l = s.handleSize
 	}
 
-	rect := s.widget.Rect
+	rect := s.trackRect()
 
 	var p img.Point
 	if s.direction == DirectionHorizontal {
@@ -242,8 +398,6 @@ func (s *Slider) updateHandleSize(handleLength float64) {
 
 func (s *Slider) updateHandleLocation(handleLength float64, trackLength float64) {
 	internalTrackLength := int(math.Ceil(trackLength - handleLength))
-	internalTrackStart := int(math.Floor(handleLength / 2))
-	internalTrackEnd := internalTrackStart + internalTrackLength
 
 	var i float64
 	if s.dragging {
@@ -269,13 +423,18 @@ func (s *Slider) updateHandleLocation(handleLength float64, trackLength float64)
 			i = 1
 		}
 		s.Current = s.internalToCurrent(i)
+
+		if s.snapStrength > 0 && s.tickInterval > 0 {
+			s.Current = s.snapToTick(s.Current)
+			i = s.currentToInternal(s.Current)
+		}
 	} else {
 		i = s.currentToInternal(s.Current)
 	}
 
-	off := int(math.Round(float64(internalTrackStart)*(1-i)+float64(internalTrackEnd)*i) - handleLength/2)
+	off := int(s.handleCenter(i, handleLength, trackLength) - handleLength/2)
 
-	rect := s.widget.Rect
+	rect := s.trackRect()
 	if s.direction == DirectionHorizontal {
 		rect.Min = rect.Min.Add(img.Point{off + s.trackPadding.Left, s.trackPadding.Top})
 	} else {
@@ -284,18 +443,48 @@ func (s *Slider) updateHandleLocation(handleLength float64, trackLength float64)
 	s.handle.GetWidget().Rect = rect
 }
 
+// handleCenter returns the position of the center of the handle along the track, relative to the start of
+// the track after padding, for internal value i.
+func (s *Slider) handleCenter(i float64, handleLength float64, trackLength float64) float64 {
+	internalTrackLength := int(math.Ceil(trackLength - handleLength))
+	internalTrackStart := int(math.Floor(handleLength / 2))
+	internalTrackEnd := internalTrackStart + internalTrackLength
+
+	return math.Round(float64(internalTrackStart)*(1-i) + float64(internalTrackEnd)*i)
+}
+
+// snapToTick returns the value of the tick mark nearest to c if it is close enough according to the snap
+// strength, otherwise c.
+func (s *Slider) snapToTick(c int) int {
+	t := s.Min + int(math.Round(float64(c-s.Min)/float64(s.tickInterval)))*s.tickInterval
+	if t > s.Max {
+		t -= s.tickInterval
+	}
+
+	if math.Abs(float64(c-t)) <= s.snapStrength*float64(s.tickInterval)/2 {
+		return t
+	}
+	return c
+}
+
 func (s *Slider) handleLengthAndTrackLength() (float64, float64) {
+	r := s.trackRect()
+
 	var trackLength float64
 	if s.direction == DirectionHorizontal {
-		trackLength = float64(s.widget.Rect.Dx()) - float64(s.trackPadding.Left) - float64(s.trackPadding.Right)
+		trackLength = float64(r.Dx()) - float64(s.trackPadding.Left) - float64(s.trackPadding.Right)
 	} else {
-		trackLength = float64(s.widget.Rect.Dy()) - float64(s.trackPadding.Top) - float64(s.trackPadding.Bottom)
+		trackLength = float64(r.Dy()) - float64(s.trackPadding.Top) - float64(s.trackPadding.Bottom)
 	}
 
-	length := float64(s.Max - s.Min + 1)
-
-	ps := s.pageSizeFunc()
-	handleLength := float64(ps) / length * trackLength
+	var handleLength float64
+	if s.handleLengthRatioFunc != nil {
+		handleLength = s.handleLengthRatioFunc() * trackLength
+	} else {
+		length := float64(s.Max - s.Min + 1)
+		ps := s.pageSizeFunc()
+		handleLength = float64(ps) / length * trackLength
+	}
 	if handleLength < float64(s.handleSize) {
 		handleLength = float64(s.handleSize)
 	}
@@ -1,6 +1,7 @@
 package widget
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/blizzy78/ebitenui/event"
@@ -21,6 +22,36 @@ func TestSlider_Current_Initial(t *testing.T) {
 	is.Equal(eventArgs.Current, 10)
 }
 
+func TestSlider_SnapToTick(t *testing.T) {
+	is := is.New(t)
+
+	s := newSlider(t,
+		SliderOpts.MinMax(0, 100),
+		SliderOpts.Ticks(10, newNineSliceEmpty(t), 1, 5),
+		SliderOpts.SnapToTicks(0.5))
+
+	is.Equal(s.snapToTick(21), 20)
+	is.Equal(s.snapToTick(24), 24)
+	is.Equal(s.snapToTick(98), 100)
+
+	s.snapStrength = 1
+	is.Equal(s.snapToTick(24), 20)
+	is.Equal(s.snapToTick(25), 30)
+}
+
+func TestSlider_PreferredSize_Labels(t *testing.T) {
+	is := is.New(t)
+
+	s := newSlider(t)
+	_, h := s.PreferredSize()
+
+	ls := newSlider(t,
+		SliderOpts.Labels(loadFont(t), color.White, nil))
+	_, lh := ls.PreferredSize()
+
+	is.True(lh > h)
+}
+
 func newSlider(t *testing.T, opts ...SliderOpt) *Slider {
 	s := NewSlider(append(opts, SliderOpts.Images(&SliderTrackImage{
 		Idle: newNineSliceEmpty(t),
@@ -0,0 +1,35 @@
+package widget
+
+import "github.com/blizzy78/ebitenui/image"
+
+// Stats contains counts of the work done to render widgets. Counts are cumulative, the work done for a single
+// frame can be determined by subtracting the Stats from before rendering the frame.
+type Stats struct {
+	// Widgets is the number of widgets rendered.
+	Widgets int
+
+	// Relayouts is the number of times a container has layouted its children.
+	Relayouts int
+
+	// Draws is the number of draw calls made to draw images.
+	Draws int
+}
+
+// stats holds the counts of Stats, except Draws.
+var stats Stats
+
+// CurrentStats returns the cumulative Stats so far.
+func CurrentStats() Stats {
+	s := stats
+	s.Draws = image.DrawCount()
+	return s
+}
+
+// Sub returns the difference between s and o.
+func (s Stats) Sub(o Stats) Stats {
+	return Stats{
+		Widgets:   s.Widgets - o.Widgets,
+		Relayouts: s.Relayouts - o.Relayouts,
+		Draws:     s.Draws - o.Draws,
+	}
+}
@@ -0,0 +1,29 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCurrentStats(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t, ContainerOpts.Layout(NewRowLayout()))
+	c.AddChild(newContainer(t))
+	c.AddChild(newContainer(t))
+
+	start := CurrentStats()
+	render(c, t)
+	s := CurrentStats().Sub(start)
+
+	is.Equal(s.Widgets, 3)
+	is.Equal(s.Relayouts, 1)
+
+	start = CurrentStats()
+	render(c, t)
+	s = CurrentStats().Sub(start)
+
+	is.Equal(s.Widgets, 3)
+	is.Equal(s.Relayouts, 0)
+}
@@ -2,6 +2,7 @@ package widget
 
 import (
 	"image"
+	"math"
 
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/input"
@@ -10,32 +11,55 @@ import (
 )
 
 type TabBook struct {
-	TabSelectedEvent *event.Event
-
-	tabs          []*TabBookTab
-	containerOpts []ContainerOpt
-	buttonOpts    []StateButtonOpt
-	buttonImages  map[interface{}]*ButtonImage
-	buttonFace    font.Face
-	buttonColor   *ButtonTextColor
-	flipBookOpts  []FlipBookOpt
-	buttonSpacing int
-	spacing       int
-
-	init        *MultiOnce
-	container   *Container
-	tabToButton map[*TabBookTab]*StateButton
-	flipBook    *FlipBook
-	tab         *TabBookTab
+	TabSelectedEvent   *event.Event
+	TabDeselectedEvent *event.Event
+	TabClosingEvent    *event.Event
+	TabMovedEvent      *event.Event
+
+	tabs            []*TabBookTab
+	containerOpts   []ContainerOpt
+	buttonOpts      []StateButtonOpt
+	buttonImages    map[interface{}]*ButtonImage
+	buttonFace      font.Face
+	buttonColor     *ButtonTextColor
+	flipBookOpts    []FlipBookOpt
+	buttonSpacing   int
+	spacing         int
+	closeButtonOpts []ButtonOpt
+	closable        bool
+	reorderable     bool
+	tabPosition     TabBookTabPosition
+
+	init             *MultiOnce
+	container        *Container
+	buttonsContainer *Container
+	tabToButton      map[*TabBookTab]*StateButton
+	tabToHeader      map[*TabBookTab]PreferredSizeLocateableWidget
+	removeHeaders    []RemoveChildFunc
+	flipBook         *FlipBook
+	tab              *TabBookTab
+	dragTab          *TabBookTab
+	dragging         bool
+	dragStartX       int
+	dragStartY       int
+	droppedTab       *TabBookTab
+	closing          []*TabBookTabClosingEventArgs
 }
 
 type TabBookTab struct {
 	Disabled bool
 
-	label  string
-	widget PreferredSizeLocateableWidget
+	label       string
+	widget      PreferredSizeLocateableWidget
+	contentFunc TabBookTabContentFunc
 }
 
+// TabBookTabPosition specifies the side of a TabBook that tab buttons are placed on.
+type TabBookTabPosition int
+
+// TabBookTabContentFunc is a function that creates the content widget of a tab.
+type TabBookTabContentFunc func() PreferredSizeLocateableWidget
+
 type TabBookOpt func(t *TabBook)
 
 type TabBookTabSelectedEventArgs struct {
@@ -44,19 +68,66 @@ type TabBookTabSelectedEventArgs struct {
 	PreviousTab *TabBookTab
 }
 
+type TabBookTabDeselectedEventArgs struct {
+	TabBook *TabBook
+	Tab     *TabBookTab
+	NextTab *TabBookTab
+}
+
+// TabBookTabClosingEventArgs are the arguments for tab closing events. Handlers may set Cancel
+// to prevent the tab from being closed.
+type TabBookTabClosingEventArgs struct {
+	TabBook *TabBook
+	Tab     *TabBookTab
+	Cancel  bool
+}
+
+type TabBookTabMovedEventArgs struct {
+	TabBook       *TabBook
+	Tab           *TabBookTab
+	Index         int
+	PreviousIndex int
+}
+
 type TabBookTabSelectedHandlerFunc func(args *TabBookTabSelectedEventArgs)
 
+type TabBookTabDeselectedHandlerFunc func(args *TabBookTabDeselectedEventArgs)
+
+type TabBookTabClosingHandlerFunc func(args *TabBookTabClosingEventArgs)
+
+type TabBookTabMovedHandlerFunc func(args *TabBookTabMovedEventArgs)
+
 type TabBookOptions struct {
 }
 
 var TabBookOpts TabBookOptions
 
+const tabBookDragStartDistance = 10
+
+const (
+	// TabBookTabPositionTop places tab buttons in a row above the tab contents.
+	TabBookTabPositionTop = TabBookTabPosition(iota)
+
+	// TabBookTabPositionBottom places tab buttons in a row below the tab contents.
+	TabBookTabPositionBottom
+
+	// TabBookTabPositionLeft places tab buttons in a column left of the tab contents.
+	TabBookTabPositionLeft
+
+	// TabBookTabPositionRight places tab buttons in a column right of the tab contents.
+	TabBookTabPositionRight
+)
+
 func NewTabBook(opts ...TabBookOpt) *TabBook {
 	t := &TabBook{
-		TabSelectedEvent: &event.Event{},
+		TabSelectedEvent:   &event.Event{},
+		TabDeselectedEvent: &event.Event{},
+		TabClosingEvent:    &event.Event{},
+		TabMovedEvent:      &event.Event{},
 
 		init:        &MultiOnce{},
 		tabToButton: map[*TabBookTab]*StateButton{},
+		tabToHeader: map[*TabBookTab]PreferredSizeLocateableWidget{},
 	}
 
 	t.init.Append(t.createWidget)
@@ -75,6 +146,15 @@ func NewTabBookTab(label string, widget PreferredSizeLocateableWidget) *TabBookT
 	}
 }
 
+// NewTabBookTabLazy constructs a new tab whose content widget is created by f when the tab is
+// selected for the first time.
+func NewTabBookTabLazy(label string, f TabBookTabContentFunc) *TabBookTab {
+	return &TabBookTab{
+		label:       label,
+		contentFunc: f,
+	}
+}
+
 func (o TabBookOptions) ContainerOpts(opts ...ContainerOpt) TabBookOpt {
 	return func(t *TabBook) {
 		t.containerOpts = append(t.containerOpts, opts...)
@@ -135,11 +215,65 @@ func (o TabBookOptions) TabSelectedHandler(f TabBookTabSelectedHandlerFunc) TabB
 	}
 }
 
+func (o TabBookOptions) TabDeselectedHandler(f TabBookTabDeselectedHandlerFunc) TabBookOpt {
+	return func(t *TabBook) {
+		t.TabDeselectedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TabBookTabDeselectedEventArgs))
+		})
+	}
+}
+
+// TabPosition configures a TabBook to place tab buttons on side p. When placed on the left or right side,
+// tab buttons are stacked vertically and stretched to the same width.
+func (o TabBookOptions) TabPosition(p TabBookTabPosition) TabBookOpt {
+	return func(t *TabBook) {
+		t.tabPosition = p
+	}
+}
+
+// ClosableTabs configures a TabBook to render a close button configured with opts next to each tab button.
+// Clicking a close button fires a tab closing event, and closes the tab unless a handler cancels it.
+func (o TabBookOptions) ClosableTabs(opts ...ButtonOpt) TabBookOpt {
+	return func(t *TabBook) {
+		t.closeButtonOpts = append(t.closeButtonOpts, opts...)
+		t.closable = true
+	}
+}
+
+// ReorderableTabs configures a TabBook to allow reordering tabs by dragging their tab buttons.
+func (o TabBookOptions) ReorderableTabs() TabBookOpt {
+	return func(t *TabBook) {
+		t.reorderable = true
+	}
+}
+
+func (o TabBookOptions) TabClosingHandler(f TabBookTabClosingHandlerFunc) TabBookOpt {
+	return func(t *TabBook) {
+		t.TabClosingEvent.AddHandler(func(args interface{}) {
+			f(args.(*TabBookTabClosingEventArgs))
+		})
+	}
+}
+
+func (o TabBookOptions) TabMovedHandler(f TabBookTabMovedHandlerFunc) TabBookOpt {
+	return func(t *TabBook) {
+		t.TabMovedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TabBookTabMovedEventArgs))
+		})
+	}
+}
+
 func (t *TabBook) GetWidget() *Widget {
 	t.init.Do()
 	return t.container.GetWidget()
 }
 
+// ContainedWidgets implements Containerer.
+func (t *TabBook) ContainedWidgets() []HasWidget {
+	t.init.Do()
+	return containedWidgets(t.container)
+}
+
 func (t *TabBook) PreferredSize() (int, int) {
 	t.init.Do()
 	return t.container.PreferredSize()
@@ -155,6 +289,12 @@ func (t *TabBook) RequestRelayout() {
 	t.container.RequestRelayout()
 }
 
+// FocusOrder implements FocusContainer.
+func (t *TabBook) FocusOrder() []HasWidget {
+	t.init.Do()
+	return t.container.FocusOrder()
+}
+
 func (t *TabBook) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 	t.init.Do()
 	t.container.SetupInputLayer(def)
@@ -163,54 +303,307 @@ func (t *TabBook) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 func (t *TabBook) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	t.init.Do()
 
+	t.removeClosedTabs()
+
 	d := t.container.GetWidget().Disabled
-	for tab, b := range t.tabToButton {
-		b.GetWidget().Disabled = d || tab.Disabled
+	for tab, h := range t.tabToHeader {
+		h.GetWidget().Disabled = d || tab.Disabled
 	}
 
+	t.updateDrag()
+
 	t.container.Render(screen, def)
 }
 
 func (t *TabBook) createWidget() {
-	t.container = NewContainer(append(t.containerOpts, []ContainerOpt{
-		ContainerOpts.Layout(NewGridLayout(
+	var layout Layouter
+	switch t.tabPosition {
+	case TabBookTabPositionBottom:
+		layout = NewGridLayout(
+			GridLayoutOpts.Columns(1),
+			GridLayoutOpts.Stretch([]bool{true}, []bool{true, false}),
+			GridLayoutOpts.Spacing(0, t.spacing))
+	case TabBookTabPositionLeft:
+		layout = NewGridLayout(
+			GridLayoutOpts.Columns(2),
+			GridLayoutOpts.Stretch([]bool{false, true}, []bool{true}),
+			GridLayoutOpts.Spacing(t.spacing, 0))
+	case TabBookTabPositionRight:
+		layout = NewGridLayout(
+			GridLayoutOpts.Columns(2),
+			GridLayoutOpts.Stretch([]bool{true, false}, []bool{true}),
+			GridLayoutOpts.Spacing(t.spacing, 0))
+	default:
+		layout = NewGridLayout(
 			GridLayoutOpts.Columns(1),
 			GridLayoutOpts.Stretch([]bool{true}, []bool{false, true}),
-			GridLayoutOpts.Spacing(0, t.spacing))),
+			GridLayoutOpts.Spacing(0, t.spacing))
+	}
+
+	t.container = NewContainer(append(t.containerOpts, []ContainerOpt{
+		ContainerOpts.Layout(layout),
 		ContainerOpts.AutoDisableChildren(),
 	}...)...)
 	t.containerOpts = nil
 
-	buttonsContainer := NewContainer(
+	direction := DirectionHorizontal
+	if t.vertical() {
+		direction = DirectionVertical
+	}
+
+	t.buttonsContainer = NewContainer(
 		ContainerOpts.Layout(NewRowLayout(
+			RowLayoutOpts.Direction(direction),
 			RowLayoutOpts.Spacing(t.buttonSpacing))))
-	t.container.AddChild(buttonsContainer)
 
 	for _, tab := range t.tabs {
-		tab := tab
-		b := NewStateButton(append(t.buttonOpts, []StateButtonOpt{
-			StateButtonOpts.StateImages(t.buttonImages),
-			StateButtonOpts.ButtonOpts(
-				ButtonOpts.Text(tab.label, t.buttonFace, t.buttonColor),
-				ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
-					t.SetTab(tab)
-				})),
-		}...)...)
-		buttonsContainer.AddChild(b)
-
-		t.tabToButton[tab] = b
+		t.tabToHeader[tab] = t.createHeader(tab)
 	}
 	t.buttonOpts = nil
 	t.buttonImages = nil
+	t.closeButtonOpts = nil
+
+	t.addHeaders()
 
 	t.flipBook = NewFlipBook(append(t.flipBookOpts,
 		FlipBookOpts.ContainerOpts(ContainerOpts.AutoDisableChildren()))...)
-	t.container.AddChild(t.flipBook)
 	t.flipBookOpts = nil
 
+	if t.tabPosition == TabBookTabPositionBottom || t.tabPosition == TabBookTabPositionRight {
+		t.container.AddChild(t.flipBook)
+		t.container.AddChild(t.buttonsContainer)
+	} else {
+		t.container.AddChild(t.buttonsContainer)
+		t.container.AddChild(t.flipBook)
+	}
+
 	t.setTab(t.tabs[0], false)
 }
 
+func (t *TabBook) createHeader(tab *TabBookTab) PreferredSizeLocateableWidget {
+	b := NewStateButton(append(t.buttonOpts, []StateButtonOpt{
+		StateButtonOpts.StateImages(t.buttonImages),
+		StateButtonOpts.ButtonOpts(
+			ButtonOpts.Text(tab.label, t.buttonFace, t.buttonColor),
+			ButtonOpts.PressedHandler(func(args *ButtonPressedEventArgs) {
+				t.droppedTab = nil
+				if t.reorderable {
+					t.dragTab = tab
+					t.dragStartX, t.dragStartY = input.CursorPosition()
+				}
+			}),
+			ButtonOpts.ReleasedHandler(func(args *ButtonReleasedEventArgs) {
+				t.drop()
+			}),
+			ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+				if t.droppedTab == tab {
+					t.droppedTab = nil
+					return
+				}
+				t.SetTab(tab)
+			})),
+	}...)...)
+
+	t.tabToButton[tab] = b
+
+	var ld interface{}
+	if t.vertical() {
+		ld = RowLayoutData{
+			Stretch: true,
+		}
+	}
+
+	if !t.closable {
+		b.GetWidget().LayoutData = ld
+		return b
+	}
+
+	c := NewContainer(
+		ContainerOpts.WidgetOpts(WidgetOpts.LayoutData(ld)),
+		ContainerOpts.Layout(NewRowLayout()),
+		ContainerOpts.AutoDisableChildren())
+
+	b.GetWidget().LayoutData = RowLayoutData{
+		Stretch: true,
+	}
+	c.AddChild(b)
+
+	c.AddChild(NewButton(append(t.closeButtonOpts, []ButtonOpt{
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+			Stretch: true,
+		})),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			t.closeTab(tab)
+		}),
+	}...)...))
+
+	return c
+}
+
+func (t *TabBook) addHeaders() {
+	for _, r := range t.removeHeaders {
+		r()
+	}
+	t.removeHeaders = t.removeHeaders[:0]
+
+	for _, tab := range t.tabs {
+		t.removeHeaders = append(t.removeHeaders, t.buttonsContainer.AddChild(t.tabToHeader[tab]))
+	}
+}
+
+func (t *TabBook) closeTab(tab *TabBookTab) {
+	args := &TabBookTabClosingEventArgs{
+		TabBook: t,
+		Tab:     tab,
+	}
+
+	t.TabClosingEvent.Fire(args)
+
+	// handlers are called deferred, so check for cancellation when rendering the next time
+	t.closing = append(t.closing, args)
+}
+
+func (t *TabBook) removeClosedTabs() {
+	if len(t.closing) == 0 {
+		return
+	}
+
+	closing := t.closing
+	t.closing = nil
+
+	for _, args := range closing {
+		if !args.Cancel {
+			t.RemoveTab(args.Tab)
+		}
+	}
+}
+
+// RemoveTab removes tab from t. If tab is the current tab, a neighboring tab will become
+// the current tab.
+func (t *TabBook) RemoveTab(tab *TabBookTab) {
+	t.init.Do()
+
+	index := t.tabIndex(tab)
+	if index < 0 {
+		return
+	}
+
+	t.tabs = append(t.tabs[:index], t.tabs[index+1:]...)
+	delete(t.tabToButton, tab)
+	delete(t.tabToHeader, tab)
+	if t.dragTab == tab {
+		t.dragTab = nil
+		t.dragging = false
+	}
+
+	t.addHeaders()
+
+	if tab != t.tab {
+		return
+	}
+
+	if len(t.tabs) == 0 {
+		t.setTab(nil, true)
+		return
+	}
+
+	if index >= len(t.tabs) {
+		index = len(t.tabs) - 1
+	}
+	t.setTab(t.tabs[index], true)
+}
+
+// MoveTab moves tab to position index, shifting other tabs as necessary.
+func (t *TabBook) MoveTab(tab *TabBookTab, index int) {
+	t.init.Do()
+
+	prev := t.tabIndex(tab)
+	if prev < 0 || index == prev {
+		return
+	}
+
+	if index < 0 {
+		index = 0
+	} else if index >= len(t.tabs) {
+		index = len(t.tabs) - 1
+	}
+
+	t.tabs = append(t.tabs[:prev], t.tabs[prev+1:]...)
+	t.tabs = append(t.tabs[:index], append([]*TabBookTab{tab}, t.tabs[index:]...)...)
+
+	t.addHeaders()
+
+	t.TabMovedEvent.Fire(&TabBookTabMovedEventArgs{
+		TabBook:       t,
+		Tab:           tab,
+		Index:         index,
+		PreviousIndex: prev,
+	})
+}
+
+// Tabs returns the tabs of t in the order they are displayed.
+func (t *TabBook) Tabs() []*TabBookTab {
+	return t.tabs
+}
+
+func (t *TabBook) tabIndex(tab *TabBookTab) int {
+	for i, tb := range t.tabs {
+		if tb == tab {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *TabBook) vertical() bool {
+	return t.tabPosition == TabBookTabPositionLeft || t.tabPosition == TabBookTabPositionRight
+}
+
+func (t *TabBook) updateDrag() {
+	if t.dragTab == nil || t.dragging {
+		return
+	}
+
+	x, y := input.CursorPosition()
+	dx, dy := float64(x-t.dragStartX), float64(y-t.dragStartY)
+	if math.Sqrt(dx*dx+dy*dy) >= tabBookDragStartDistance {
+		t.dragging = true
+	}
+}
+
+func (t *TabBook) drop() {
+	tab, dragging := t.dragTab, t.dragging
+	t.dragTab = nil
+	t.dragging = false
+
+	if tab == nil || !dragging {
+		return
+	}
+
+	// the tab button will be clicked after being released, which must not select the tab
+	t.droppedTab = tab
+
+	x, y := input.CursorPosition()
+
+	index := 0
+	for _, tb := range t.tabs {
+		if tb == tab {
+			continue
+		}
+
+		r := t.tabToHeader[tb].GetWidget().Rect
+		if t.vertical() {
+			if y >= (r.Min.Y+r.Max.Y)/2 {
+				index++
+			}
+		} else if x >= (r.Min.X+r.Max.X)/2 {
+			index++
+		}
+	}
+
+	t.MoveTab(tab, index)
+}
+
 func (t *TabBook) SetTab(tab *TabBookTab) {
 	t.setTab(tab, true)
 }
@@ -221,13 +614,25 @@ func (t *TabBook) setTab(tab *TabBookTab, fireEvent bool) {
 
 		t.tab = tab
 
-		t.flipBook.SetPage(tab.widget)
+		if tab != nil {
+			t.flipBook.SetPage(tab.content())
+		} else {
+			t.flipBook.SetPage(nil)
+		}
 
 		for bt, b := range t.tabToButton {
 			b.State = bt == tab
 		}
 
 		if fireEvent {
+			if previousTab != nil {
+				t.TabDeselectedEvent.Fire(&TabBookTabDeselectedEventArgs{
+					TabBook: t,
+					Tab:     previousTab,
+					NextTab: tab,
+				})
+			}
+
 			t.TabSelectedEvent.Fire(&TabBookTabSelectedEventArgs{
 				TabBook:     t,
 				Tab:         tab,
@@ -240,3 +645,13 @@ func (t *TabBook) setTab(tab *TabBookTab, fireEvent bool) {
 func (t *TabBook) Tab() *TabBookTab {
 	return t.tab
 }
+
+// content returns the content widget of tab, creating it first if necessary.
+func (tab *TabBookTab) content() PreferredSizeLocateableWidget {
+	if tab.widget == nil && tab.contentFunc != nil {
+		tab.widget = tab.contentFunc()
+		tab.contentFunc = nil
+	}
+
+	return tab.widget
+}
@@ -1,10 +1,12 @@
 package widget
 
 import (
+	"image"
 	"image/color"
 	"testing"
 
 	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
 	"github.com/matryer/is"
 )
 
@@ -77,6 +79,175 @@ func TestTabBook_TabSelectedEvent_User(t *testing.T) {
 	is.Equal(numEvents, 1)
 }
 
+func TestTabBook_TabDeselectedEvent(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TabBookTabDeselectedEventArgs
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTab("Tab 2", newSimpleWidget(50, 50, nil))
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2),
+		TabBookOpts.TabDeselectedHandler(func(args *TabBookTabDeselectedEventArgs) {
+			eventArgs = args
+		}))
+
+	tb.SetTab(tab2)
+	event.ExecuteDeferred()
+
+	is.Equal(eventArgs.Tab, tab1)
+	is.Equal(eventArgs.NextTab, tab2)
+}
+
+func TestTabBook_Lazy(t *testing.T) {
+	is := is.New(t)
+
+	numCreated := 0
+	content := newSimpleWidget(50, 50, nil)
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTabLazy("Tab 2", func() PreferredSizeLocateableWidget {
+		numCreated++
+		return content
+	})
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2))
+
+	is.Equal(numCreated, 0)
+
+	tb.SetTab(tab2)
+	event.ExecuteDeferred()
+
+	is.Equal(numCreated, 1)
+	is.Equal(content.GetWidget().Parent(), tb.flipBook.container.GetWidget())
+
+	tb.SetTab(tab1)
+	tb.SetTab(tab2)
+	event.ExecuteDeferred()
+
+	is.Equal(numCreated, 1)
+}
+
+func TestTabBook_CloseTab_User(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TabBookTabClosingEventArgs
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTab("Tab 2", newSimpleWidget(50, 50, nil))
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2),
+		TabBookOpts.ClosableTabs(ButtonOpts.Image(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		})),
+		TabBookOpts.TabClosingHandler(func(args *TabBookTabClosingEventArgs) {
+			eventArgs = args
+		}))
+
+	leftMouseButtonClick(tabBookCloseButtons(tb)[0], t)
+	render(tb, t)
+
+	is.Equal(eventArgs.Tab, tab1)
+	is.Equal(tb.Tabs(), []*TabBookTab{tab2})
+	is.Equal(tb.Tab(), tab2)
+}
+
+func TestTabBook_CloseTab_User_Cancel(t *testing.T) {
+	is := is.New(t)
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTab("Tab 2", newSimpleWidget(50, 50, nil))
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2),
+		TabBookOpts.ClosableTabs(ButtonOpts.Image(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		})),
+		TabBookOpts.TabClosingHandler(func(args *TabBookTabClosingEventArgs) {
+			args.Cancel = true
+		}))
+
+	leftMouseButtonClick(tabBookCloseButtons(tb)[0], t)
+	render(tb, t)
+
+	is.Equal(tb.Tabs(), []*TabBookTab{tab1, tab2})
+	is.Equal(tb.Tab(), tab1)
+}
+
+func TestTabBook_MoveTab(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TabBookTabMovedEventArgs
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTab("Tab 2", newSimpleWidget(50, 50, nil))
+	tab3 := NewTabBookTab("Tab 3", newSimpleWidget(50, 50, nil))
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2, tab3),
+		TabBookOpts.TabMovedHandler(func(args *TabBookTabMovedEventArgs) {
+			eventArgs = args
+		}))
+
+	tb.MoveTab(tab1, 2)
+	event.ExecuteDeferred()
+
+	is.Equal(tb.Tabs(), []*TabBookTab{tab2, tab3, tab1})
+	is.Equal(eventArgs.Tab, tab1)
+	is.Equal(eventArgs.PreviousIndex, 0)
+	is.Equal(eventArgs.Index, 2)
+	is.Equal(tb.buttonsContainer.children[2], PreferredSizeLocateableWidget(tb.tabToHeader[tab1]))
+}
+
+func TestTabBook_Drag_NoClick(t *testing.T) {
+	is := is.New(t)
+
+	defer func() {
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTab("Tab 2", newSimpleWidget(50, 50, nil))
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2),
+		TabBookOpts.ReorderableTabs())
+
+	internalinput.CursorX, internalinput.CursorY = 0, 0
+	leftMouseButtonPress(tabBookButtons(tb)[1], t)
+
+	internalinput.CursorX = tabBookDragStartDistance
+	render(tb, t)
+
+	leftMouseButtonRelease(tabBookButtons(tb)[1], t)
+
+	is.Equal(tb.Tab(), tab1)
+}
+
+func TestTabBook_TabPosition_Left(t *testing.T) {
+	is := is.New(t)
+
+	tab1 := NewTabBookTab("Tab 1", newSimpleWidget(50, 50, nil))
+	tab2 := NewTabBookTab("Tab 2", newSimpleWidget(50, 50, nil))
+
+	tb := newTabBook(t,
+		TabBookOpts.Tabs(tab1, tab2),
+		TabBookOpts.TabPosition(TabBookTabPositionLeft))
+
+	tb.SetLocation(image.Rect(0, 0, 400, 300))
+	tb.RequestRelayout()
+	render(tb, t)
+
+	b1 := tabBookButtons(tb)[0].GetWidget().Rect
+	b2 := tabBookButtons(tb)[1].GetWidget().Rect
+	is.Equal(b1.Min.X, b2.Min.X)
+	is.True(b2.Min.Y >= b1.Max.Y)
+	is.True(tb.flipBook.GetWidget().Rect.Min.X >= b1.Max.X)
+}
+
 func newTabBook(t *testing.T, opts ...TabBookOpt) *TabBook {
 	t.Helper()
 
@@ -104,3 +275,11 @@ func tabBookButtons(t *TabBook) []*StateButton {
 	}
 	return buttons
 }
+
+func tabBookCloseButtons(t *TabBook) []*Button {
+	buttons := []*Button{}
+	for _, tab := range t.tabs {
+		buttons = append(buttons, t.tabToHeader[tab].(*Container).children[1].(*Button))
+	}
+	return buttons
+}
@@ -1,16 +1,25 @@
 package widget
 
 import (
-	"bufio"
-	"image"
+	img "image"
 	"image/color"
 	"math"
+	"math/rand"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blizzy78/ebitenui/clipboard"
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+	"github.com/blizzy78/ebitenui/textutil"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
-	"golang.org/x/image/math/fixed"
 )
 
 type Text struct {
@@ -18,13 +27,40 @@ type Text struct {
 	Face  font.Face
 	Color color.Color
 
+	RevealCompletedEvent *event.Event
+
 	widgetOpts         []WidgetOpt
 	horizontalPosition TextPosition
 	verticalPosition   TextPosition
+	wordWrap           bool
+	maxWidth           int
+	ellipsis           string
+	selectionColor     color.Color
+	revealRate         float64
+	glyphFunc          TextGlyphFunc
+	bbCode             *TextBBCode
+	skeleton           *Skeleton
 
-	init         *MultiOnce
-	widget       *Widget
-	measurements textMeasurements
+	init           *MultiOnce
+	widget         *Widget
+	measurements   textMeasurements
+	measureVersion int
+	layout         textLayout
+	cache          textCache
+	selectionStart int
+	selectionEnd   int
+	selecting      bool
+	copyKeyPressed bool
+	revealLabel    string
+	revealStart    time.Time
+	revealed       int
+	revealSkipped  bool
+	revealDone     bool
+	parsedLabel    string
+	plainText      string
+	styles         []*textStyle
+	drawnLabel     string
+	drawnColor     color.Color
 }
 
 type TextOpt func(t *Text)
@@ -40,11 +76,73 @@ const (
 type TextOptions struct {
 }
 
-type textMeasurements struct {
-	label string
+// TextBBCode configures the processing of BBCode-style tags in a Text's label. The following tags are supported:
+//
+//	[color=#rrggbb]...[/color] draws text in a color, which may be given as #rgb, #rrggbb, #rrggbbaa, or as a name in Colors.
+//	[b]...[/b] draws text using BoldFace.
+//	[icon=name] draws the image named name in Icons, scaled to the height of the font.
+//	[shake]...[/shake] draws text shaking.
+//
+// Color tags with an unknown color, bold tags without BoldFace, and icon tags with an unknown icon are ignored.
+// All other tags, such as [wave]...[/wave], are not drawn and can be used to implement custom effects using
+// TextOpts.GlyphFunc, by inspecting TextGlyph.Tags. A literal "[" can be written as "[[".
+type TextBBCode struct {
+	BoldFace font.Face
+	Icons    map[string]*ebiten.Image
+	Colors   map[string]color.Color
+}
+
+type TextRevealCompletedEventArgs struct {
+	Text *Text
+}
+
+type TextRevealCompletedHandlerFunc func(args *TextRevealCompletedEventArgs)
+
+// TextGlyph describes a single glyph about to be drawn by a Text.
+type TextGlyph struct {
+	// Index is the index of the glyph's rune in Text.Label, with BBCode tags removed.
+	Index int
+
+	// Rune is the glyph's rune.
+	Rune rune
+
+	// X and Y are the position of the glyph's dot, that is, the start of the glyph on the baseline.
+	X float64
+	Y float64
+
+	// Color is the color to draw the glyph with. It is initially set to Text.Color, or the color set using
+	// a BBCode tag.
+	Color color.Color
+
+	// Tags contains the names of the BBCode tags the glyph is enclosed in, excluding tags that only
+	// change its color or face.
+	Tags []string
+}
+
+// TextGlyphFunc is a function that is called for each glyph that a Text draws. It may modify g.X, g.Y,
+// and g.Color to change how the glyph is drawn, for example to implement wavy or rainbow-colored text.
+type TextGlyphFunc func(g *TextGlyph)
+
+type textStyle struct {
 	face  font.Face
+	color color.Color
+	icon  *ebiten.Image
+	shake bool
+	tags  []string
+}
+
+type textTag struct {
+	name  string
+	apply func(s *textStyle)
+}
+
+type textMeasurements struct {
+	label     string
+	face      font.Face
+	wrapWidth int
 
 	lines             []string
+	lineStarts        []int
 	lineWidths        []float64
 	lineHeight        float64
 	ascent            float64
@@ -52,10 +150,46 @@ type textMeasurements struct {
 	boundingBoxHeight float64
 }
 
+// textLine is a line of text laid out inside a Text's widget, with positions relative to the widget's Rect.
+type textLine struct {
+	text      string
+	start     int
+	x         int
+	y         int
+	width     float64
+	truncated bool
+	ellipsisX int
+}
+
+// textLayout caches the lines of a Text laid out inside its widget, which only changes when the measurements
+// or the widget's size change.
+type textLayout struct {
+	version int
+	size    img.Point
+	lines   []textLine
+	bounds  img.Rectangle
+	shake   bool
+}
+
+// textCache caches the rendered lines of a Text.
+type textCache struct {
+	version int
+	size    img.Point
+	color   color.Color
+	image   *ebiten.Image
+}
+
+const textShakeAmplitude = 1.5
+
+// textIconRune is the rune that represents an icon in a Text's label with BBCode tags removed.
+const textIconRune = '\uFFFC'
+
 var TextOpts TextOptions
 
 func NewText(opts ...TextOpt) *Text {
 	t := &Text{
+		RevealCompletedEvent: &event.Event{},
+
 		init: &MultiOnce{},
 	}
 
@@ -89,95 +223,897 @@ func (o TextOptions) Position(h TextPosition, v TextPosition) TextOpt {
 	}
 }
 
+// WordWrap configures a Text to wrap lines at word boundaries so that they do not exceed the width
+// of the Text's widget. If maxWidth is greater than 0, lines are also wrapped so that they do not
+// exceed maxWidth, and the preferred size is calculated using maxWidth. If maxWidth is 0, the preferred
+// size is calculated using the width the Text was last laid out with, and the parent container is asked
+// to layout again whenever that width changes.
+func (o TextOptions) WordWrap(maxWidth int) TextOpt {
+	return func(t *Text) {
+		t.wordWrap = true
+		t.maxWidth = maxWidth
+	}
+}
+
+// Ellipsis configures a Text to truncate lines that do not fit into the width of the Text's widget,
+// and to end truncated lines with s, for example "...".
+func (o TextOptions) Ellipsis(s string) TextOpt {
+	return func(t *Text) {
+		t.ellipsis = s
+	}
+}
+
+// Selectable configures a Text to allow selecting its text using the mouse, drawing selected text
+// highlighted in color c. Selected text can be copied to the clipboard by pressing Ctrl+C.
+func (o TextOptions) Selectable(c color.Color) TextOpt {
+	return func(t *Text) {
+		t.selectionColor = c
+	}
+}
+
+// Reveal configures a Text to reveal its text progressively, at a rate of runesPerSecond. The reveal
+// starts over whenever the text changes. Lines are wrapped according to the full text, so that words
+// do not jump from one line to the next while being revealed.
+func (o TextOptions) Reveal(runesPerSecond float64) TextOpt {
+	return func(t *Text) {
+		t.revealRate = runesPerSecond
+	}
+}
+
+// GlyphFunc configures a Text to call f for each glyph before drawing it.
+func (o TextOptions) GlyphFunc(f TextGlyphFunc) TextOpt {
+	return func(t *Text) {
+		t.glyphFunc = f
+	}
+}
+
+// BBCode configures a Text to process BBCode-style tags in its label, according to b.
+func (o TextOptions) BBCode(b *TextBBCode) TextOpt {
+	return func(t *Text) {
+		t.bbCode = b
+	}
+}
+
+// Skeleton configures a Text to draw a placeholder block according to s while its label is empty, for example
+// while the label is being loaded.
+func (o TextOptions) Skeleton(s *Skeleton) TextOpt {
+	return func(t *Text) {
+		t.skeleton = s
+	}
+}
+
+func (o TextOptions) RevealCompletedHandler(f TextRevealCompletedHandlerFunc) TextOpt {
+	return func(t *Text) {
+		t.RevealCompletedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TextRevealCompletedEventArgs))
+		})
+	}
+}
+
 func (t *Text) GetWidget() *Widget {
 	t.init.Do()
 	return t.widget
 }
 
-func (t *Text) SetLocation(rect image.Rectangle) {
+func (t *Text) SetLocation(rect img.Rectangle) {
 	t.init.Do()
+
+	resized := rect.Dx() != t.widget.Rect.Dx()
 	t.widget.Rect = rect
+
+	if resized && t.wordWrap && t.maxWidth <= 0 {
+		t.widget.requestParentRelayout()
+	}
 }
 
 func (t *Text) PreferredSize() (int, int) {
 	t.init.Do()
-	t.measure()
+
+	if t.Loading() {
+		return t.skeleton.Width, int(math.Ceil(textutil.LineHeight(t.Face)))
+	}
+
+	t.measure(t.preferredWrapWidth())
 	return int(math.Ceil(t.measurements.boundingBoxWidth)), int(math.Ceil(t.measurements.boundingBoxHeight))
 }
 
 func (t *Text) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	t.init.Do()
 	t.widget.Render(screen, def)
+
+	t.drawnLabel = t.Label
+	t.drawnColor = t.Color
+
+	if t.Loading() {
+		t.drawSkeleton(screen)
+		return
+	}
+
+	if t.selectionColor != nil {
+		t.handleSelection()
+	}
+
+	if t.revealRate > 0 {
+		t.updateReveal()
+	}
+
 	t.draw(screen)
 }
 
+func (t *Text) renderChanged() bool {
+	return t.Label != t.drawnLabel || t.Color != t.drawnColor || t.selecting || (t.revealRate > 0 && !t.revealDone)
+}
+
+// Loading returns whether t draws a placeholder block because its label is empty.
+func (t *Text) Loading() bool {
+	return t.skeleton != nil && t.Label == ""
+}
+
+// drawSkeleton draws t's placeholder block, one line high.
+func (t *Text) drawSkeleton(screen *ebiten.Image) {
+	r := t.widget.Rect
+	h := int(math.Ceil(textutil.LineHeight(t.Face)))
+
+	if h < r.Dy() {
+		y := r.Min.Y
+		switch t.verticalPosition {
+		case TextPositionCenter:
+			y += (r.Dy() - h) / 2
+		case TextPositionEnd:
+			y = r.Max.Y - h
+		}
+		r = img.Rect(r.Min.X, y, r.Max.X, y+h)
+	}
+
+	t.skeleton.draw(screen, t.skeleton.blockRect(r, t.horizontalPosition))
+}
+
+func (t *Text) updateReveal() {
+	if t.Label != t.revealLabel {
+		t.revealLabel = t.Label
+		t.revealStart = Now()
+		t.revealSkipped = false
+		t.revealDone = false
+	}
+
+	total := utf8.RuneCountInString(t.text())
+
+	if t.revealSkipped || motionDisabled() {
+		t.revealed = total
+	} else {
+		t.revealed = int(Now().Sub(t.revealStart).Seconds() * t.revealRate / motionScale)
+		if t.revealed > total {
+			t.revealed = total
+		}
+	}
+
+	if t.revealed == total && !t.revealDone {
+		t.revealDone = true
+
+		t.RevealCompletedEvent.Fire(&TextRevealCompletedEventArgs{
+			Text: t,
+		})
+	}
+}
+
+// SkipReveal reveals the full text immediately if it is currently being revealed progressively.
+func (t *Text) SkipReveal() {
+	t.revealSkipped = true
+}
+
+// RestartReveal starts revealing the text progressively from the beginning.
+func (t *Text) RestartReveal() {
+	t.revealLabel = t.Label
+	t.revealStart = Now()
+	t.revealed = 0
+	t.revealSkipped = false
+	t.revealDone = false
+}
+
+// Revealing returns whether the text is currently being revealed progressively.
+func (t *Text) Revealing() bool {
+	return t.revealRate > 0 && !t.revealDone
+}
+
+func (t *Text) handleSelection() {
+	x, y := input.CursorPosition()
+	layer := t.widget.EffectiveInputLayer()
+
+	if input.MouseButtonJustPressedLayer(ebiten.MouseButtonLeft, layer) && !(img.Point{x, y}).In(t.widget.Rect) {
+		t.selectionStart, t.selectionEnd = 0, 0
+	}
+
+	if t.selecting {
+		if input.MouseButtonPressed(ebiten.MouseButtonLeft) {
+			t.selectionEnd = t.runeIndexAt(x, y)
+		} else {
+			t.selecting = false
+		}
+	}
+
+	p := input.KeyPressed(ebiten.KeyControl) && input.KeyPressed(ebiten.KeyC)
+	if p && !t.copyKeyPressed && t.selectionStart != t.selectionEnd {
+		_ = clipboard.WriteText(t.SelectedText())
+	}
+	t.copyKeyPressed = p
+}
+
+// SetSelection selects the text between rune indexes start and end.
+func (t *Text) SetSelection(start int, end int) {
+	t.selectionStart, t.selectionEnd = start, end
+}
+
+// Selection returns the rune indexes of the start and end of the selected text. If no text is selected,
+// start and end are equal.
+func (t *Text) Selection() (int, int) {
+	start, end := t.selectionStart, t.selectionEnd
+	if start > end {
+		start, end = end, start
+	}
+
+	l := utf8.RuneCountInString(t.text())
+	if start > l {
+		start = l
+	}
+	if end > l {
+		end = l
+	}
+
+	return start, end
+}
+
+// SelectedText returns the selected text. If BBCode processing is enabled, BBCode tags are not included.
+func (t *Text) SelectedText() string {
+	start, end := t.Selection()
+	return string([]rune(t.text())[start:end])
+}
+
+// text returns t.Label, with BBCode tags removed if BBCode processing is enabled.
+func (t *Text) text() string {
+	if t.bbCode == nil {
+		t.styles = nil
+		return t.Label
+	}
+
+	if t.styles == nil || t.Label != t.parsedLabel {
+		t.parsedLabel = t.Label
+		t.plainText, t.styles = parseBBCode(t.Label, t.bbCode)
+	}
+
+	return t.plainText
+}
+
 func (t *Text) draw(screen *ebiten.Image) {
-	t.measure()
+	t.measure(t.drawWrapWidth())
+
+	if t.selectionColor != nil {
+		t.drawSelection(screen, t.textOrigin())
+	}
+
+	t.updateLayout()
+
+	if t.cacheable() {
+		t.drawCached(screen)
+		return
+	}
+
+	t.drawLines(screen, t.widget.Rect.Min)
+}
+
+// updateLayout lays out t's lines inside t.widget.Rect, unless they are already laid out.
+func (t *Text) updateLayout() {
+	size := t.widget.Rect.Size()
+	if t.layout.lines != nil && t.layout.version == t.measureVersion && t.layout.size == size {
+		return
+	}
+
+	w := size.X
+	p := t.textOrigin().Sub(t.widget.Rect.Min)
+	m := t.Face.Metrics()
+	ascent, descent := textutil.Fixed26_6ToFloat64(m.Ascent), textutil.Fixed26_6ToFloat64(m.Descent)
+
+	t.layout = textLayout{
+		version: t.measureVersion,
+		size:    size,
+		lines:   make([]textLine, 0, len(t.measurements.lines)),
+	}
+
+	for _, st := range t.styles {
+		if st.shake {
+			t.layout.shake = true
+			break
+		}
+	}
 
+	for i, line := range t.measurements.lines {
+		l := textLine{
+			text:  line,
+			start: t.measurements.lineStarts[i],
+			width: t.measurements.lineWidths[i],
+		}
+
+		if t.ellipsis != "" && w > 0 && l.width > float64(w) {
+			l.text, l.width = t.truncateLine(line, l.start, float64(w))
+			l.truncated = true
+		}
+
+		l.x = t.lineX(p, l.width)
+		l.y = int(math.Round(float64(p.Y) + t.measurements.lineHeight*float64(i) + t.measurements.ascent))
+
+		if l.truncated {
+			l.ellipsisX = l.x + int(math.Round(t.textWidth(l.text, l.start)))
+		}
+
+		t.layout.lines = append(t.layout.lines, l)
+
+		// leave room for glyphs extending beyond their advance
+		pad := int(math.Ceil(ascent / 2))
+		b := img.Rect(l.x-pad, l.y-int(math.Ceil(ascent))-pad, l.x+int(math.Ceil(l.width))+pad, l.y+int(math.Ceil(descent))+pad)
+		t.layout.bounds = t.layout.bounds.Union(b)
+	}
+}
+
+// cacheable returns whether t's lines can be drawn from a cached image, that is, they look the same in each frame.
+func (t *Text) cacheable() bool {
+	return t.glyphFunc == nil && !t.layout.shake && (t.revealRate <= 0 || t.revealDone)
+}
+
+// drawCached draws t's lines from a cached image, drawing the image first if necessary.
+func (t *Text) drawCached(screen *ebiten.Image) {
+	b := t.layout.bounds
+	if b.Empty() {
+		return
+	}
+
+	c := &t.cache
+	if c.image == nil || c.version != t.layout.version || c.size != t.layout.size || c.color != t.Color {
+		if c.image != nil && c.image.Bounds().Size() == b.Size() {
+			c.image.Clear()
+		} else {
+			if c.image != nil {
+				c.image.Dispose()
+			}
+			c.image = ebiten.NewImage(b.Dx(), b.Dy())
+		}
+
+		c.version, c.size, c.color = t.layout.version, t.layout.size, t.Color
+
+		t.drawLines(c.image, b.Min.Mul(-1))
+	}
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(t.widget.Rect.Min.X+b.Min.X), float64(t.widget.Rect.Min.Y+b.Min.Y))
+	screen.DrawImage(c.image, &opts)
+}
+
+// drawLines draws t's laid out lines onto screen, offset by o.
+func (t *Text) drawLines(screen *ebiten.Image, o img.Point) {
+	for _, l := range t.layout.lines {
+		line, truncated := l.text, l.truncated
+
+		if t.revealRate > 0 {
+			v := t.revealed - l.start
+			if v <= 0 {
+				break
+			}
+
+			if r := []rune(line); v < len(r) {
+				line = string(r[:v])
+				truncated = false
+			}
+		}
+
+		t.drawLine(screen, line, l.start, o.X+l.x, o.Y+l.y)
+
+		if truncated {
+			text.Draw(screen, t.ellipsis, t.Face, o.X+l.ellipsisX, o.Y+l.y, t.Color)
+		}
+	}
+}
+
+func (t *Text) drawLine(screen *ebiten.Image, line string, start int, x int, y int) {
+	if t.glyphFunc == nil && t.styles == nil {
+		text.Draw(screen, line, t.Face, x, y, t.Color)
+		return
+	}
+
+	g := TextGlyph{}
+	dot := float64(x)
+	var prevFace font.Face
+	prev := rune(-1)
+	i := 0
+	for _, r := range line {
+		s := t.style(start + i)
+		f, c := t.styleFace(s), t.Color
+		if s.color != nil {
+			c = s.color
+		}
+
+		if prev >= 0 && f == prevFace && s.icon == nil {
+			dot += textutil.Fixed26_6ToFloat64(f.Kern(prev, r))
+		}
+
+		g.Index = start + i
+		g.Rune = r
+		g.X = dot
+		g.Y = float64(y)
+		g.Color = c
+		g.Tags = s.tags
+
+		if s.shake {
+			g.X += (rand.Float64()*2 - 1) * textShakeAmplitude
+			g.Y += (rand.Float64()*2 - 1) * textShakeAmplitude
+		}
+
+		if t.glyphFunc != nil {
+			t.glyphFunc(&g)
+		}
+
+		i++
+
+		if s.icon != nil {
+			t.drawIcon(screen, s.icon, g.X, g.Y, g.Color, s.color != nil || g.Color != c)
+			dot += t.iconWidth(s.icon)
+			prev = -1
+			continue
+		}
+
+		text.Draw(screen, string(r), f, int(math.Round(g.X)), int(math.Round(g.Y)), g.Color)
+
+		a, _ := f.GlyphAdvance(r)
+		dot += textutil.Fixed26_6ToFloat64(a)
+		prev, prevFace = r, f
+	}
+}
+
+// drawIcon draws icon with its left edge at x, aligned to the baseline y, scaled to the height of t.Face.
+// If tint is true, icon is drawn tinted in c.
+func (t *Text) drawIcon(screen *ebiten.Image, icon *ebiten.Image, x float64, y float64, c color.Color, tint bool) {
+	m := t.Face.Metrics()
+	_, h := icon.Size()
+	scale := textutil.Fixed26_6ToFloat64(m.Ascent+m.Descent) / float64(h)
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(scale, scale)
+	opts.GeoM.Translate(math.Round(x), math.Round(y-textutil.Fixed26_6ToFloat64(m.Ascent)))
+	opts.Filter = ebiten.FilterLinear
+
+	if tint {
+		nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+		opts.ColorM.Scale(float64(nc.R)/255, float64(nc.G)/255, float64(nc.B)/255, float64(nc.A)/255)
+	}
+
+	screen.DrawImage(icon, &opts)
+}
+
+// iconWidth returns the width of icon when drawn scaled to the height of t.Face.
+func (t *Text) iconWidth(icon *ebiten.Image) float64 {
+	m := t.Face.Metrics()
+	w, h := icon.Size()
+	return float64(w) * textutil.Fixed26_6ToFloat64(m.Ascent+m.Descent) / float64(h)
+}
+
+var textStyleDefault = &textStyle{}
+
+// style returns the style of the rune at index i in t.text().
+func (t *Text) style(i int) *textStyle {
+	if i < 0 || i >= len(t.styles) {
+		return textStyleDefault
+	}
+	return t.styles[i]
+}
+
+func (t *Text) styleFace(s *textStyle) font.Face {
+	if s.face != nil {
+		return s.face
+	}
+	return t.Face
+}
+
+// textWidth returns the width of s when drawn, where start is the index of the first rune of s in t.text().
+func (t *Text) textWidth(s string, start int) float64 {
+	if t.styles == nil {
+		return textutil.Fixed26_6ToFloat64(font.MeasureString(t.Face, s))
+	}
+
+	w := 0.0
+	var prevFace font.Face
+	prev := rune(-1)
+	i := start
+	for _, r := range s {
+		st := t.style(i)
+		i++
+
+		if st.icon != nil {
+			w += t.iconWidth(st.icon)
+			prev = -1
+			continue
+		}
+
+		f := t.styleFace(st)
+		if prev >= 0 && f == prevFace {
+			w += textutil.Fixed26_6ToFloat64(f.Kern(prev, r))
+		}
+
+		a, _ := f.GlyphAdvance(r)
+		w += textutil.Fixed26_6ToFloat64(a)
+		prev, prevFace = r, f
+	}
+
+	return w
+}
+
+// textIndexAt returns an index into r that corresponds closest to pixel position x when r is drawn,
+// where start is the index of r[0] in t.text(). Pixel position x==0 corresponds to r[0].
+func (t *Text) textIndexAt(r []rune, start int, x int) int {
+	if t.styles == nil {
+		return textutil.IndexAt(r, t.Face, x)
+	}
+
+	best, bestDist := 0, math.MaxFloat64
+	for i := 0; i <= len(r); i++ {
+		d := math.Abs(float64(x) - t.textWidth(string(r[:i]), start))
+		if d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return best
+}
+
+// truncateLine returns the longest prefix of line that, together with t.ellipsis appended, is no wider
+// than width when drawn, as well as the width of the prefix together with t.ellipsis. start is the index
+// of the first rune of line in t.text().
+func (t *Text) truncateLine(line string, start int, width float64) (string, float64) {
+	ew := textutil.Fixed26_6ToFloat64(font.MeasureString(t.Face, t.ellipsis))
+
+	r := []rune(line)
+	for i := len(r) - 1; i > 0; i-- {
+		sw := t.textWidth(string(r[:i]), start) + ew
+		if sw <= width {
+			return string(r[:i]), sw
+		}
+	}
+
+	return "", ew
+}
+
+func (t *Text) drawSelection(screen *ebiten.Image, p img.Point) {
+	start, end := t.Selection()
+	if start == end {
+		return
+	}
+
+	i := image.NewNineSliceColor(t.selectionColor)
+
+	for l, line := range t.measurements.lines {
+		r := []rune(line)
+		ls := t.measurements.lineStarts[l]
+
+		s, e := start-ls, end-ls
+		if e <= 0 || s >= len(r) {
+			continue
+		}
+		if s < 0 {
+			s = 0
+		}
+		if e > len(r) {
+			e = len(r)
+		}
+
+		lx := t.lineX(p, t.measurements.lineWidths[l])
+		sx := lx + int(math.Round(t.textWidth(string(r[:s]), ls)))
+		ex := lx + int(math.Round(t.textWidth(string(r[:e]), ls)))
+		ly := int(math.Round(float64(p.Y) + t.measurements.lineHeight*float64(l)))
+
+		i.Draw(screen, ex-sx, int(math.Round(t.measurements.lineHeight)), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(sx), float64(ly))
+		})
+	}
+}
+
+// textOrigin returns the position of the top left corner of the first line.
+func (t *Text) textOrigin() img.Point {
 	r := t.widget.Rect
-	w := r.Dx()
 	p := r.Min
 
 	switch t.verticalPosition {
 	case TextPositionCenter:
-		p = p.Add(image.Point{0, int((float64(r.Dy()) - t.measurements.boundingBoxHeight) / 2)})
+		p = p.Add(img.Point{0, int((float64(r.Dy()) - t.measurements.boundingBoxHeight) / 2)})
 	case TextPositionEnd:
-		p = p.Add(image.Point{0, int((float64(r.Dy()) - t.measurements.boundingBoxHeight))})
+		p = p.Add(img.Point{0, int((float64(r.Dy()) - t.measurements.boundingBoxHeight))})
 	}
 
-	for i, line := range t.measurements.lines {
-		lx := p.X
-		switch t.horizontalPosition {
-		case TextPositionCenter:
-			lx += int(math.Round((float64(w) - t.measurements.lineWidths[i]) / 2))
-		case TextPositionEnd:
-			lx += int(math.Ceil(float64(w) - t.measurements.lineWidths[i]))
-		}
+	return p
+}
+
+// lineX returns the horizontal position of a line of width lw, relative to origin p.
+func (t *Text) lineX(p img.Point, lw float64) int {
+	w := t.widget.Rect.Dx()
+
+	lx := p.X
+	switch t.horizontalPosition {
+	case TextPositionCenter:
+		lx += int(math.Round((float64(w) - lw) / 2))
+	case TextPositionEnd:
+		lx += int(math.Ceil(float64(w) - lw))
+	}
+
+	return lx
+}
+
+// runeIndexAt returns the index of the rune in t.text() that is closest to screen position x,y.
+func (t *Text) runeIndexAt(x int, y int) int {
+	t.measure(t.drawWrapWidth())
+
+	if len(t.measurements.lines) == 0 {
+		return 0
+	}
 
-		ly := int(math.Round(float64(p.Y) + t.measurements.lineHeight*float64(i) + t.measurements.ascent))
+	p := t.textOrigin()
 
-		text.Draw(screen, line, t.Face, lx, ly, t.Color)
+	l := int(math.Floor(float64(y-p.Y) / t.measurements.lineHeight))
+	if l < 0 {
+		l = 0
+	} else if l >= len(t.measurements.lines) {
+		l = len(t.measurements.lines) - 1
 	}
+
+	lx := t.lineX(p, t.measurements.lineWidths[l])
+	ls := t.measurements.lineStarts[l]
+	return ls + t.textIndexAt([]rune(t.measurements.lines[l]), ls, x-lx)
 }
 
-func (t *Text) measure() {
-	if t.Label == t.measurements.label && t.Face == t.measurements.face {
+// drawWrapWidth returns the width to wrap lines at when drawing, or 0 if lines should not be wrapped.
+func (t *Text) drawWrapWidth() int {
+	if !t.wordWrap {
+		return 0
+	}
+
+	w := t.widget.Rect.Dx()
+	if t.maxWidth > 0 && (w <= 0 || t.maxWidth < w) {
+		w = t.maxWidth
+	}
+	return w
+}
+
+// preferredWrapWidth returns the width to wrap lines at when calculating the preferred size.
+func (t *Text) preferredWrapWidth() int {
+	if t.maxWidth > 0 {
+		return t.maxWidth
+	}
+	return t.widget.Rect.Dx()
+}
+
+func (t *Text) measure(wrapWidth int) {
+	if !t.wordWrap {
+		wrapWidth = 0
+	}
+
+	if t.Label == t.measurements.label && t.Face == t.measurements.face && wrapWidth == t.measurements.wrapWidth {
 		return
 	}
 
 	m := t.Face.Metrics()
 
+	t.measureVersion++
+
 	t.measurements = textMeasurements{
-		label:  t.Label,
-		face:   t.Face,
-		ascent: fixedInt26_6ToFloat64(m.Ascent),
+		label:     t.Label,
+		face:      t.Face,
+		wrapWidth: wrapWidth,
+		ascent:    textutil.Fixed26_6ToFloat64(m.Ascent),
 	}
 
-	fh := fixedInt26_6ToFloat64(m.Ascent + m.Descent)
-	t.measurements.lineHeight = fixedInt26_6ToFloat64(m.Height)
+	fh := textutil.Fixed26_6ToFloat64(m.Ascent + m.Descent)
+	t.measurements.lineHeight = textutil.Fixed26_6ToFloat64(m.Height)
 	ld := t.measurements.lineHeight - fh
 
-	s := bufio.NewScanner(strings.NewReader(t.Label))
-	for s.Scan() {
-		line := s.Text()
-		t.measurements.lines = append(t.measurements.lines, line)
+	s := t.text()
+
+	hardLines, hardLineStarts := textutil.SplitLines(s)
+	for hl, hardLine := range hardLines {
+		hardStart := utf8.RuneCountInString(s[:hardLineStarts[hl]])
 
-		lw := fixedInt26_6ToFloat64(font.MeasureString(t.Face, line))
-		t.measurements.lineWidths = append(t.measurements.lineWidths, lw)
+		lines := textutil.WrapLine(hardLine, wrapWidth, func(start int, end int) float64 {
+			return t.textWidth(hardLine[start:end], hardStart+utf8.RuneCountInString(hardLine[:start]))
+		})
 
-		if lw > t.measurements.boundingBoxWidth {
-			t.measurements.boundingBoxWidth = lw
+		off := 0
+		for _, line := range lines {
+			off += strings.Index(hardLine[off:], line)
+			start := hardStart + utf8.RuneCountInString(hardLine[:off])
+
+			t.measurements.lines = append(t.measurements.lines, line)
+			t.measurements.lineStarts = append(t.measurements.lineStarts, start)
+
+			lw := t.textWidth(line, start)
+			t.measurements.lineWidths = append(t.measurements.lineWidths, lw)
+
+			if lw > t.measurements.boundingBoxWidth {
+				t.measurements.boundingBoxWidth = lw
+			}
 		}
 	}
 
 	t.measurements.boundingBoxHeight = float64(len(t.measurements.lines))*t.measurements.lineHeight - ld
 }
 
-func (t *Text) createWidget() {
-	t.widget = NewWidget(t.widgetOpts...)
-	t.widgetOpts = nil
+// parseBBCode parses BBCode-style tags in s according to b, and returns s with tags removed, as well as
+// the style of each rune in the returned string. Icons are represented by textIconRune.
+func parseBBCode(s string, b *TextBBCode) (string, []*textStyle) {
+	sb := strings.Builder{}
+	styles := []*textStyle{}
+
+	stack := []textTag{}
+	cur := textStyleDefault
+
+	for len(s) > 0 {
+		if strings.HasPrefix(s, "[[") {
+			sb.WriteRune('[')
+			styles = append(styles, cur)
+			s = s[2:]
+			continue
+		}
+
+		if s[0] == '[' {
+			if end := strings.IndexByte(s, ']'); end > 0 {
+				name, value, closing, ok := parseBBCodeTag(s[1:end])
+				if ok {
+					s = s[end+1:]
+
+					switch {
+					case closing:
+						for i := len(stack) - 1; i >= 0; i-- {
+							if stack[i].name == name {
+								stack = append(stack[:i], stack[i+1:]...)
+								break
+							}
+						}
+
+					case name == "icon":
+						if icon, ok := b.Icons[value]; ok {
+							st := *cur
+							st.icon = icon
+							sb.WriteRune(textIconRune)
+							styles = append(styles, &st)
+						}
+						continue
+
+					default:
+						tag, ok := newBBCodeTag(name, value, b)
+						if !ok {
+							continue
+						}
+						stack = append(stack, tag)
+					}
+
+					cur = &textStyle{}
+					for _, tag := range stack {
+						tag.apply(cur)
+					}
+
+					continue
+				}
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		sb.WriteRune(r)
+		styles = append(styles, cur)
+		s = s[size:]
+	}
+
+	return sb.String(), styles
+}
+
+// parseBBCodeTag parses tag, which is the text between "[" and "]", and returns the tag's name and value,
+// and whether it is a closing tag. ok is false if tag is not a valid tag.
+func parseBBCodeTag(tag string) (name string, value string, closing bool, ok bool) {
+	if strings.HasPrefix(tag, "/") {
+		tag = tag[1:]
+		closing = true
+	}
+
+	name = tag
+	if i := strings.IndexByte(tag, '='); i >= 0 && !closing {
+		name, value = tag[:i], tag[i+1:]
+	}
+
+	if name == "" {
+		return "", "", false, false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) {
+			return "", "", false, false
+		}
+	}
+
+	return name, value, closing, true
+}
+
+// newBBCodeTag returns the tag with name and value. ok is false if the tag cannot be applied, for example
+// because it uses an unknown color.
+func newBBCodeTag(name string, value string, b *TextBBCode) (textTag, bool) {
+	var apply func(s *textStyle)
+
+	switch name {
+	case "color":
+		c, ok := parseBBCodeColor(value, b)
+		if !ok {
+			return textTag{}, false
+		}
+		apply = func(s *textStyle) {
+			s.color = c
+		}
+
+	case "b":
+		if b.BoldFace == nil {
+			return textTag{}, false
+		}
+		apply = func(s *textStyle) {
+			s.face = b.BoldFace
+		}
+
+	case "shake":
+		apply = func(s *textStyle) {
+			s.shake = true
+			s.tags = append(s.tags, name)
+		}
+
+	default:
+		apply = func(s *textStyle) {
+			s.tags = append(s.tags, name)
+		}
+	}
+
+	return textTag{
+		name:  name,
+		apply: apply,
+	}, true
 }
 
-func fixedInt26_6ToFloat64(i fixed.Int26_6) float64 {
-	return float64(i) / (1 << 6)
+// parseBBCodeColor parses s as a color in the form #rgb, #rrggbb, or #rrggbbaa, or as a color name in b.Colors.
+func parseBBCodeColor(s string, b *TextBBCode) (color.Color, bool) {
+	if c, ok := b.Colors[s]; ok {
+		return c, true
+	}
+
+	if !strings.HasPrefix(s, "#") {
+		return nil, false
+	}
+	s = s[1:]
+
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return nil, false
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	return color.NRGBA{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}, true
+}
+
+func (t *Text) createWidget() {
+	t.widget = NewWidget(append(t.widgetOpts, []WidgetOpt{
+		WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+			if t.selectionColor == nil || args.Button != ebiten.MouseButtonLeft {
+				return
+			}
+
+			p := t.widget.Rect.Min.Add(img.Point{args.OffsetX, args.OffsetY})
+			t.selectionStart = t.runeIndexAt(p.X, p.Y)
+			t.selectionEnd = t.selectionStart
+			t.selecting = true
+		}),
+	}...)...)
+	t.widgetOpts = nil
 }
@@ -0,0 +1,289 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/clipboard"
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+	"golang.org/x/image/font"
+)
+
+func TestText_PreferredSize_WordWrap(t *testing.T) {
+	is := is.New(t)
+
+	label := "the quick brown fox jumps over the lazy dog"
+
+	unwrapped := newText(t,
+		TextOpts.Text(label, loadFont(t), color.White))
+	uw, uh := unwrapped.PreferredSize()
+
+	wrapped := newText(t,
+		TextOpts.Text(label, loadFont(t), color.White),
+		TextOpts.WordWrap(uw/2))
+	ww, wh := wrapped.PreferredSize()
+
+	is.True(ww <= uw/2)
+	is.True(wh > uh)
+	is.True(len(wrapped.measurements.lines) > 1)
+}
+
+func TestText_PreferredSize_WordWrap_LaidOutWidth(t *testing.T) {
+	is := is.New(t)
+
+	label := "the quick brown fox jumps over the lazy dog"
+
+	tx := newText(t,
+		TextOpts.Text(label, loadFont(t), color.White),
+		TextOpts.WordWrap(0))
+	uw, uh := tx.PreferredSize()
+
+	tx.SetLocation(img.Rect(0, 0, uw/2, uh))
+	ww, wh := tx.PreferredSize()
+
+	is.True(ww <= uw/2)
+	is.True(wh > uh)
+}
+
+func TestText_TruncateLine(t *testing.T) {
+	is := is.New(t)
+
+	face := loadFont(t)
+	w := textutil.Fixed26_6ToFloat64(font.MeasureString(face, "foo..."))
+
+	tx := newText(t,
+		TextOpts.Text("foobarbaz", face, color.White),
+		TextOpts.Ellipsis("..."))
+
+	s, sw := tx.truncateLine("foobarbaz", 0, w)
+	is.Equal(s, "foo")
+	is.True(sw <= w)
+}
+
+func TestText_SelectedText(t *testing.T) {
+	is := is.New(t)
+
+	tx := newText(t,
+		TextOpts.Text("foo bar baz", loadFont(t), color.White),
+		TextOpts.Selectable(color.Black))
+
+	tx.SetSelection(7, 4)
+
+	start, end := tx.Selection()
+	is.Equal(start, 4)
+	is.Equal(end, 7)
+	is.Equal(tx.SelectedText(), "bar")
+}
+
+func TestText_SelectedText_User(t *testing.T) {
+	is := is.New(t)
+
+	tx := newText(t,
+		TextOpts.Text("foo bar baz", loadFont(t), color.White),
+		TextOpts.Selectable(color.Black))
+
+	leftMouseButtonPress(tx, t)
+
+	is.True(tx.selecting)
+	is.Equal(tx.selectionStart, 0)
+}
+
+func TestText_Copy(t *testing.T) {
+	is := is.New(t)
+
+	clipboard.SetClipboard(&clipboard.MemoryClipboard{})
+
+	tx := newText(t,
+		TextOpts.Text("foo bar baz", loadFont(t), color.White),
+		TextOpts.Selectable(color.Black))
+
+	tx.SetSelection(4, 7)
+
+	internalinput.KeyPressed[ebiten.KeyControl] = true
+	internalinput.KeyPressed[ebiten.KeyC] = true
+	defer func() {
+		internalinput.KeyPressed[ebiten.KeyControl] = false
+		internalinput.KeyPressed[ebiten.KeyC] = false
+	}()
+
+	render(tx, t)
+
+	s, err := clipboard.ReadText()
+	is.NoErr(err)
+	is.Equal(s, "bar")
+}
+
+func TestText_Reveal(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TextRevealCompletedEventArgs
+
+	tx := newText(t,
+		TextOpts.Text("foo bar baz", loadFont(t), color.White),
+		TextOpts.Reveal(0.001),
+		TextOpts.RevealCompletedHandler(func(args *TextRevealCompletedEventArgs) {
+			eventArgs = args
+		}))
+
+	is.True(tx.Revealing())
+	is.Equal(tx.revealed, 0)
+
+	tx.SkipReveal()
+	render(tx, t)
+
+	is.True(!tx.Revealing())
+	is.Equal(tx.revealed, 11)
+	is.True(eventArgs != nil)
+
+	tx.Label = "qux"
+	render(tx, t)
+
+	is.True(tx.Revealing())
+	is.Equal(tx.revealed, 0)
+}
+
+func TestText_Reveal_MotionDisabled(t *testing.T) {
+	is := is.New(t)
+
+	SetMotionScale(0)
+	t.Cleanup(func() {
+		SetMotionScale(1)
+	})
+
+	tx := newText(t,
+		TextOpts.Text("foo bar baz", loadFont(t), color.White),
+		TextOpts.Reveal(0.001))
+
+	is.True(!tx.Revealing())
+	is.Equal(tx.revealed, 11)
+}
+
+func TestText_GlyphFunc(t *testing.T) {
+	is := is.New(t)
+
+	indexes := []int{}
+	runes := []rune{}
+
+	newText(t,
+		TextOpts.Text("foo\nbar", loadFont(t), color.White),
+		TextOpts.GlyphFunc(func(g *TextGlyph) {
+			indexes = append(indexes, g.Index)
+			runes = append(runes, g.Rune)
+			is.Equal(g.Color, color.White)
+		}))
+
+	is.Equal(indexes, []int{0, 1, 2, 4, 5, 6})
+	is.Equal(string(runes), "foobar")
+}
+
+func TestParseBBCode(t *testing.T) {
+	is := is.New(t)
+
+	bold := loadFont(t)
+	icon := ebiten.NewImage(10, 10)
+
+	s, styles := parseBBCode("[color=#f00]foo[/color] [b]bar[/b] [icon=x][[a] [wave]baz[/wave] [foo", &TextBBCode{
+		BoldFace: bold,
+		Icons: map[string]*ebiten.Image{
+			"x": icon,
+		},
+	})
+
+	is.Equal(s, "foo bar \uFFFC[a] baz [foo")
+	is.Equal(len(styles), len([]rune(s)))
+	is.Equal(styles[0].color, color.NRGBA{255, 0, 0, 255})
+	is.Equal(styles[3].color, nil)
+	is.Equal(styles[4].face, bold)
+	is.Equal(styles[8].icon, icon)
+	is.Equal(styles[14].tags, []string{"wave"})
+	is.Equal(styles[17].tags, nil)
+}
+
+func TestParseBBCode_InvalidTags(t *testing.T) {
+	is := is.New(t)
+
+	s, styles := parseBBCode("[color=#f00][color=nope]foo[/color] [b]bar[/b]", &TextBBCode{})
+
+	is.Equal(s, "foo bar")
+	is.Equal(styles[0].color, color.NRGBA{255, 0, 0, 255})
+	is.Equal(styles[0].tags, nil)
+	is.Equal(styles[4].face, nil)
+	is.Equal(styles[4].tags, nil)
+}
+
+func TestText_BBCode_GlyphFunc(t *testing.T) {
+	is := is.New(t)
+
+	glyphs := []TextGlyph{}
+
+	tx := newText(t,
+		TextOpts.Text("foo [color=#00ff00][shake]bar[/shake][/color]", loadFont(t), color.White),
+		TextOpts.BBCode(&TextBBCode{}),
+		TextOpts.GlyphFunc(func(g *TextGlyph) {
+			glyphs = append(glyphs, *g)
+		}))
+
+	is.Equal(len(glyphs), 7)
+	is.Equal(glyphs[0].Color, color.White)
+	is.Equal(glyphs[4].Index, 4)
+	is.Equal(glyphs[4].Rune, 'b')
+	is.Equal(glyphs[4].Color, color.NRGBA{0, 255, 0, 255})
+	is.Equal(glyphs[4].Tags, []string{"shake"})
+
+	tx.SetSelection(4, 7)
+	is.Equal(tx.SelectedText(), "bar")
+}
+
+func TestText_Cache(t *testing.T) {
+	is := is.New(t)
+
+	tx := newText(t, TextOpts.Text("foo", loadFont(t), color.White))
+	tx.SetLocation(img.Rect(0, 0, 100, 30))
+
+	render(tx, t)
+	cached := tx.cache.image
+	is.True(cached != nil)
+	version := tx.measureVersion
+
+	render(tx, t)
+	is.Equal(tx.cache.image, cached)
+	is.Equal(tx.measureVersion, version)
+
+	tx.Label = "bar"
+	render(tx, t)
+	is.True(tx.measureVersion != version)
+	is.Equal(tx.cache.version, tx.measureVersion)
+}
+
+func TestText_Skeleton(t *testing.T) {
+	is := is.New(t)
+
+	tx := newText(t,
+		TextOpts.Text("", loadFont(t), color.White),
+		TextOpts.Skeleton(&Skeleton{Color: color.White, Width: 80}))
+
+	is.True(tx.Loading())
+	w, h := tx.PreferredSize()
+	is.Equal(w, 80)
+	is.True(h > 0)
+
+	tx.Label = "loaded"
+	is.True(!tx.Loading())
+	w, _ = tx.PreferredSize()
+	is.True(w != 80)
+}
+
+func newText(t *testing.T, opts ...TextOpt) *Text {
+	t.Helper()
+
+	tx := NewText(opts...)
+	event.ExecuteDeferred()
+	render(tx, t)
+	return tx
+}
@@ -3,20 +3,21 @@ package widget
 import (
 	img "image"
 	"image/color"
-	"math"
 	"strings"
-	"sync/atomic"
 	"time"
 
+	"github.com/blizzy78/ebitenui/clipboard"
 	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/image"
 	"github.com/blizzy78/ebitenui/input"
+	"github.com/blizzy78/ebitenui/textutil"
 	"github.com/hajimehoshi/ebiten/v2"
 	"golang.org/x/image/font"
 )
 
 type TextInput struct {
-	ChangedEvent *event.Event
+	ChangedEvent  *event.Event
+	RevealedEvent *event.Event
 
 	InputText string
 
@@ -30,21 +31,33 @@ type TextInput struct {
 	repeatInterval  time.Duration
 	validationFunc  TextInputValidationFunc
 	placeholderText string
+	prefixes        []PreferredSizeLocateableWidget
+	suffixes        []PreferredSizeLocateableWidget
+	adornmentSpace  int
+	clearButtonOpts []ButtonOpt
+	revealBtnOpts   []ButtonOpt
+	mentions        *textInputMentions
 
 	init            *MultiOnce
 	commandToFunc   map[textInputControlCommand]textInputCommandFunc
 	widget          *Widget
 	caret           *Caret
 	text            *Text
-	renderBuf       *image.MaskedRenderBuffer
-	mask            *image.NineSlice
+	renderBuf       *image.ClipRenderBuffer
 	cursorPosition  int
+	selectionAnchor int
+	dragging        bool
+	updatingInput   bool
 	state           textInputState
 	scrollOffset    int
 	focused         bool
 	lastInputText   string
 	secure          bool
 	secureInputText string
+	revealed        bool
+	clearButton     *Button
+	revealButton    *Button
+	tokens          []TextInputToken
 }
 
 type TextInputOpt func(t *TextInput)
@@ -59,6 +72,13 @@ type TextInputChangedEventArgs struct {
 
 type TextInputChangedHandlerFunc func(args *TextInputChangedEventArgs)
 
+type TextInputRevealedEventArgs struct {
+	TextInput *TextInput
+	Revealed  bool
+}
+
+type TextInputRevealedHandlerFunc func(args *TextInputRevealedEventArgs)
+
 type TextInputImage struct {
 	Idle     *image.NineSlice
 	Disabled *image.NineSlice
@@ -69,6 +89,12 @@ type TextInputColor struct {
 	Disabled      color.Color
 	Caret         color.Color
 	DisabledCaret color.Color
+
+	// Selection is the color to highlight selected text with. If it is nil, selected text is not highlighted.
+	Selection color.Color
+
+	// Token is the color to highlight tokens with. If it is nil, tokens are not highlighted.
+	Token color.Color
 }
 
 type TextInputValidationFunc func(newInputText string) bool
@@ -88,6 +114,10 @@ const (
 	textInputGoEnd
 	textInputBackspace
 	textInputDelete
+	textInputSelectAll
+	textInputCopy
+	textInputCut
+	textInputPaste
 )
 
 var textInputKeyToCommand = map[ebiten.Key]textInputControlCommand{
@@ -99,16 +129,25 @@ var textInputKeyToCommand = map[ebiten.Key]textInputControlCommand{
 	ebiten.KeyDelete:    textInputDelete,
 }
 
+var textInputCtrlKeyToCommand = map[ebiten.Key]textInputControlCommand{
+	ebiten.KeyA: textInputSelectAll,
+	ebiten.KeyC: textInputCopy,
+	ebiten.KeyX: textInputCut,
+	ebiten.KeyV: textInputPaste,
+}
+
 func NewTextInput(opts ...TextInputOpt) *TextInput {
 	t := &TextInput{
-		ChangedEvent: &event.Event{},
+		ChangedEvent:  &event.Event{},
+		RevealedEvent: &event.Event{},
 
 		repeatDelay:    300 * time.Millisecond,
 		repeatInterval: 35 * time.Millisecond,
+		adornmentSpace: 4,
 
 		init:          &MultiOnce{},
 		commandToFunc: map[textInputControlCommand]textInputCommandFunc{},
-		renderBuf:     image.NewMaskedRenderBuffer(),
+		renderBuf:     image.NewClipRenderBuffer(),
 	}
 	t.state = t.idleState(true)
 
@@ -118,6 +157,10 @@ func NewTextInput(opts ...TextInputOpt) *TextInput {
 	t.commandToFunc[textInputGoEnd] = t.doGoEnd
 	t.commandToFunc[textInputBackspace] = t.doBackspace
 	t.commandToFunc[textInputDelete] = t.doDelete
+	t.commandToFunc[textInputSelectAll] = t.SelectAll
+	t.commandToFunc[textInputCopy] = t.doCopy
+	t.commandToFunc[textInputCut] = t.doCut
+	t.commandToFunc[textInputPaste] = t.doPaste
 
 	t.init.Append(t.createWidget)
 
@@ -196,6 +239,56 @@ func (o TextInputOptions) Secure(b bool) TextInputOpt {
 	}
 }
 
+func (o TextInputOptions) RevealedHandler(f TextInputRevealedHandlerFunc) TextInputOpt {
+	return func(t *TextInput) {
+		t.RevealedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TextInputRevealedEventArgs))
+		})
+	}
+}
+
+// Prefix configures a TextInput to display w left of the input text, for example a currency symbol or an icon.
+// w is not part of the input text, and is drawn inside the TextInput's image and padding. Multiple prefixes
+// are displayed in the order they were added.
+func (o TextInputOptions) Prefix(w PreferredSizeLocateableWidget) TextInputOpt {
+	return func(t *TextInput) {
+		t.prefixes = append(t.prefixes, w)
+	}
+}
+
+// Suffix configures a TextInput to display w right of the input text, for example a unit label or an inline
+// button. w is not part of the input text, and is drawn inside the TextInput's image and padding. Multiple
+// suffixes are displayed in the order they were added.
+func (o TextInputOptions) Suffix(w PreferredSizeLocateableWidget) TextInputOpt {
+	return func(t *TextInput) {
+		t.suffixes = append(t.suffixes, w)
+	}
+}
+
+// ClearButton configures a TextInput to display a button constructed using opts as a suffix, which clears
+// the input text when clicked. The button is hidden while the input text is empty.
+func (o TextInputOptions) ClearButton(opts ...ButtonOpt) TextInputOpt {
+	return func(t *TextInput) {
+		t.clearButtonOpts = append(t.clearButtonOpts, opts...)
+	}
+}
+
+// RevealButton configures a TextInput to display a button constructed using opts as a suffix, which toggles
+// whether secure input text is revealed when clicked.
+func (o TextInputOptions) RevealButton(opts ...ButtonOpt) TextInputOpt {
+	return func(t *TextInput) {
+		t.revealBtnOpts = append(t.revealBtnOpts, opts...)
+	}
+}
+
+// AdornmentSpacing configures a TextInput to leave s pixels between the input text and its prefix or suffix.
+// The default is 4.
+func (o TextInputOptions) AdornmentSpacing(s int) TextInputOpt {
+	return func(t *TextInput) {
+		t.adornmentSpace = s
+	}
+}
+
 func (t *TextInput) GetWidget() *Widget {
 	t.init.Do()
 	return t.widget
@@ -208,33 +301,61 @@ func (t *TextInput) SetLocation(rect img.Rectangle) {
 
 func (t *TextInput) PreferredSize() (int, int) {
 	t.init.Do()
+	w := 50
 	_, h := t.caret.PreferredSize()
-	return 50, h + t.padding.Top + t.padding.Bottom
+
+	for _, a := range t.adornments() {
+		aw, ah := a.PreferredSize()
+		w += aw + t.adornmentSpace
+		if ah > h {
+			h = ah
+		}
+	}
+
+	return w, h + t.padding.Top + t.padding.Bottom
 }
 
-func (t *TextInput) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+func (t *TextInput) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 	t.init.Do()
 
-	t.text.GetWidget().Disabled = t.widget.Disabled
-
-	if t.cursorPosition > len([]rune(t.InputText)) {
-		t.cursorPosition = len([]rune(t.InputText))
+	for _, a := range t.adornments() {
+		if l, ok := a.(input.Layerer); ok {
+			l.SetupInputLayer(def)
+		}
 	}
 
-	for {
-		newState, rerun := t.state()
-		if newState != nil {
-			t.state = newState
-		}
-		if !rerun {
-			break
-		}
+	if t.SuggestionsOpen() {
+		def(func(def input.DeferredSetupInputLayerFunc) {
+			t.mentions.setupInputLayer()
+		})
 	}
+}
+
+func (t *TextInput) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	t.init.Do()
+
+	t.text.GetWidget().Disabled = t.widget.Disabled
+
+	t.processInput()
 
 	defer func() {
 		t.lastInputText = t.InputText
 	}()
 
+	t.validateTokens()
+
+	if t.mentions != nil {
+		t.mentions.update()
+	}
+
+	if t.clearButton != nil {
+		if t.InputText == "" {
+			t.clearButton.GetWidget().SetVisibility(VisibilityHidden)
+		} else {
+			t.clearButton.GetWidget().SetVisibility(VisibilityVisible)
+		}
+	}
+
 	if t.InputText != t.lastInputText {
 		t.ChangedEvent.Fire(&TextInputChangedEventArgs{
 			TextInput: t,
@@ -249,7 +370,42 @@ func (t *TextInput) Render(screen *ebiten.Image, def DeferredRenderFunc) {
 	t.widget.Render(screen, def)
 
 	t.renderImage(screen)
+	t.renderAdornments(screen, def)
 	t.renderTextAndCaret(screen, def)
+
+	if t.SuggestionsOpen() {
+		def(t.mentions.render)
+	}
+}
+
+// UpdateInput implements InputUpdater. It processes input characters and commands, but not mouse input,
+// which is still processed when t is rendered.
+func (t *TextInput) UpdateInput() {
+	t.init.Do()
+
+	t.updatingInput = true
+	t.processInput()
+	t.updatingInput = false
+}
+
+// processInput runs the input state machine until it settles.
+func (t *TextInput) processInput() {
+	if t.cursorPosition > len([]rune(t.InputText)) {
+		t.cursorPosition = len([]rune(t.InputText))
+	}
+	if t.selectionAnchor > len([]rune(t.InputText)) {
+		t.selectionAnchor = len([]rune(t.InputText))
+	}
+
+	for {
+		newState, rerun := t.state()
+		if newState != nil {
+			t.state = newState
+		}
+		if !rerun {
+			break
+		}
+	}
 }
 
 func (t *TextInput) idleState(newKeyOrCommand bool) textInputState {
@@ -268,8 +424,17 @@ func (t *TextInput) idleState(newKeyOrCommand bool) textInputState {
 			return st, true
 		}
 
-		if input.MouseButtonJustPressedLayer(ebiten.MouseButtonLeft, t.widget.EffectiveInputLayer()) {
-			t.doGoXY(input.CursorPosition())
+		if t.updatingInput {
+			return t.idleState(true), false
+		}
+
+		switch {
+		case input.MouseButtonJustPressedLayer(ebiten.MouseButtonLeft, t.widget.EffectiveInputLayer()):
+			t.dragging = t.doGoXY(input.CursorPosition())
+		case t.dragging && input.MouseButtonPressed(ebiten.MouseButtonLeft):
+			t.doDragXY(input.CursorPosition())
+		default:
+			t.dragging = false
 		}
 
 		return t.idleState(true), false
@@ -277,19 +442,27 @@ func (t *TextInput) idleState(newKeyOrCommand bool) textInputState {
 }
 
 func textInputCheckForCommand(t *TextInput, newKeyOrCommand bool) textInputState {
+	var delay time.Duration
+	if newKeyOrCommand {
+		delay = t.repeatDelay
+	} else {
+		delay = t.repeatInterval
+	}
+
 	for key, cmd := range textInputKeyToCommand {
-		if !input.KeyPressed(key) {
-			continue
+		if input.KeyPressed(key) {
+			return t.commandState(cmd, key, delay, time.Time{})
 		}
+	}
 
-		var delay time.Duration
-		if newKeyOrCommand {
-			delay = t.repeatDelay
-		} else {
-			delay = t.repeatInterval
-		}
+	if !input.KeyPressed(ebiten.KeyControl) {
+		return nil
+	}
 
-		return t.commandState(cmd, key, delay, nil, nil)
+	for key, cmd := range textInputCtrlKeyToCommand {
+		if input.KeyPressed(key) {
+			return t.commandState(cmd, key, delay, time.Time{})
+		}
 	}
 
 	return nil
@@ -299,6 +472,10 @@ func (t *TextInput) charsInputState(c []rune) textInputState {
 	return func() (textInputState, bool) {
 		if !t.widget.Disabled {
 			t.doInsert(c)
+
+			if t.mentions != nil {
+				t.mentions.checkTrigger(c)
+			}
 		}
 
 		t.caret.ResetBlinking()
@@ -307,99 +484,232 @@ func (t *TextInput) charsInputState(c []rune) textInputState {
 	}
 }
 
-func (t *TextInput) commandState(cmd textInputControlCommand, key ebiten.Key, delay time.Duration, timer *time.Timer, expired *atomic.Value) textInputState {
+func (t *TextInput) commandState(cmd textInputControlCommand, key ebiten.Key, delay time.Duration, deadline time.Time) textInputState {
 	return func() (textInputState, bool) {
 		if !input.KeyPressed(key) {
 			return t.idleState(true), true
 		}
 
-		if timer != nil && expired.Load().(bool) {
+		if !deadline.IsZero() && !Now().Before(deadline) {
 			return t.idleState(false), true
 		}
 
-		if timer == nil {
+		if deadline.IsZero() {
 			t.commandToFunc[cmd]()
-
-			expired = &atomic.Value{}
-			expired.Store(false)
-
-			timer = time.AfterFunc(delay, func() {
-				expired.Store(true)
-			})
-
-			return t.commandState(cmd, key, delay, timer, expired), false
+			return t.commandState(cmd, key, delay, Now().Add(delay)), false
 		}
 
 		return nil, false
 	}
 }
 
-func (t *TextInput) doInsert(c []rune) {
-	s := string(insertChars([]rune(t.InputText), c, t.cursorPosition))
+// doInsert inserts c at the cursor position, replacing the selected text. It returns whether the text has been
+// inserted.
+func (t *TextInput) doInsert(c []rune) bool {
+	start, end := t.expandToTokens(t.Selection())
+	r := []rune(t.InputText)
+	s := string(insertChars(removeChars(r, start, end), c, start))
 
 	if t.validationFunc != nil && !t.validationFunc(s) {
-		return
+		return false
 	}
 
 	t.InputText = s
-	t.cursorPosition += len(c)
+	t.shiftTokens(start, end, len(c))
+	t.cursorPosition = start + len(c)
+	t.selectionAnchor = t.cursorPosition
+	return true
 }
 
 func (t *TextInput) doGoLeft() {
-	if t.cursorPosition > 0 {
-		t.cursorPosition--
+	start, end := t.Selection()
+	switch {
+	case start != end && !input.KeyPressed(ebiten.KeyShift):
+		t.moveCursor(start)
+	case t.cursorPosition > 0:
+		t.moveCursor(t.tokenBoundary(t.cursorPosition-1, false))
+	default:
+		t.moveCursor(t.cursorPosition)
 	}
-	t.caret.ResetBlinking()
 }
 
 func (t *TextInput) doGoRight() {
-	if t.cursorPosition < len([]rune(t.InputText)) {
-		t.cursorPosition++
+	start, end := t.Selection()
+	switch {
+	case start != end && !input.KeyPressed(ebiten.KeyShift):
+		t.moveCursor(end)
+	case t.cursorPosition < len([]rune(t.InputText)):
+		t.moveCursor(t.tokenBoundary(t.cursorPosition+1, true))
+	default:
+		t.moveCursor(t.cursorPosition)
 	}
-	t.caret.ResetBlinking()
 }
 
 func (t *TextInput) doGoStart() {
-	t.cursorPosition = 0
-	t.caret.ResetBlinking()
+	t.moveCursor(0)
 }
 
 func (t *TextInput) doGoEnd() {
-	t.cursorPosition = len([]rune(t.InputText))
-	t.caret.ResetBlinking()
+	t.moveCursor(len([]rune(t.InputText)))
 }
 
-func (t *TextInput) doGoXY(x int, y int) {
+// doGoXY moves the cursor to the position closest to x,y. It returns whether x,y is inside t.
+func (t *TextInput) doGoXY(x int, y int) bool {
 	p := img.Point{x, y}
-	if p.In(t.widget.Rect) {
-		tr := t.padding.Apply(t.widget.Rect)
-		if x < tr.Min.X {
-			x = tr.Min.X
-		}
-		if x > tr.Max.X {
-			x = tr.Max.X
-		}
+	if !p.In(t.widget.Rect) || t.adornmentAt(p) {
+		return false
+	}
 
-		t.cursorPosition = fontStringIndex([]rune(t.InputText), t.face, x-t.scrollOffset-tr.Min.X)
-		t.caret.ResetBlinking()
+	t.moveCursor(t.nearestTokenBoundary(t.cursorIndexAt(x)))
+	return true
+}
+
+// doDragXY moves the cursor to the position closest to x,y while keeping the selection anchor, to select
+// text by dragging with the mouse.
+func (t *TextInput) doDragXY(x int, y int) {
+	t.cursorPosition = t.cursorIndexAt(x)
+	t.caret.ResetBlinking()
+}
+
+// cursorIndexAt returns the cursor position closest to pixel position x.
+func (t *TextInput) cursorIndexAt(x int) int {
+	tr := t.textRect()
+	if x < tr.Min.X {
+		x = tr.Min.X
+	}
+	if x > tr.Max.X {
+		x = tr.Max.X
+	}
+
+	return textutil.IndexAt([]rune(t.displayText()), t.face, x-t.scrollOffset-tr.Min.X)
+}
+
+// moveCursor moves the cursor to pos. If Shift is pressed, the selection is extended, otherwise it is removed.
+func (t *TextInput) moveCursor(pos int) {
+	t.cursorPosition = pos
+	if !input.KeyPressed(ebiten.KeyShift) {
+		t.selectionAnchor = pos
 	}
+	t.caret.ResetBlinking()
 }
 
 func (t *TextInput) doBackspace() {
-	if !t.widget.Disabled && t.cursorPosition > 0 {
+	if !t.widget.Disabled && !t.deleteSelection() && !t.deleteToken(t.cursorPosition-1) && t.cursorPosition > 0 {
 		t.InputText = string(removeChar([]rune(t.InputText), t.cursorPosition-1))
+		t.shiftTokens(t.cursorPosition-1, t.cursorPosition, 0)
 		t.cursorPosition--
 	}
+	t.selectionAnchor = t.cursorPosition
 	t.caret.ResetBlinking()
 }
 
 func (t *TextInput) doDelete() {
-	if !t.widget.Disabled && t.cursorPosition < len([]rune(t.InputText)) {
+	if !t.widget.Disabled && !t.deleteSelection() && !t.deleteToken(t.cursorPosition) && t.cursorPosition < len([]rune(t.InputText)) {
 		t.InputText = string(removeChar([]rune(t.InputText), t.cursorPosition))
+		t.shiftTokens(t.cursorPosition, t.cursorPosition+1, 0)
 	}
+	t.selectionAnchor = t.cursorPosition
 	t.caret.ResetBlinking()
 }
 
+func (t *TextInput) doCopy() {
+	if t.secure && !t.revealed {
+		return
+	}
+
+	if s := t.SelectedText(); s != "" {
+		_ = clipboard.WriteText(s)
+	}
+}
+
+func (t *TextInput) doCut() {
+	if t.widget.Disabled {
+		return
+	}
+
+	t.doCopy()
+
+	if !t.secure || t.revealed {
+		t.deleteSelection()
+		t.caret.ResetBlinking()
+	}
+}
+
+func (t *TextInput) doPaste() {
+	if t.widget.Disabled {
+		return
+	}
+
+	s, err := clipboard.ReadText()
+	if err != nil {
+		return
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, s)
+
+	if s != "" {
+		t.doInsert([]rune(s))
+		t.caret.ResetBlinking()
+	}
+}
+
+// deleteSelection removes the selected text. It returns whether any text was selected.
+func (t *TextInput) deleteSelection() bool {
+	start, end := t.Selection()
+	if start == end {
+		return false
+	}
+
+	start, end = t.expandToTokens(start, end)
+
+	t.InputText = string(removeChars([]rune(t.InputText), start, end))
+	t.shiftTokens(start, end, 0)
+	t.cursorPosition = start
+	t.selectionAnchor = start
+	return true
+}
+
+// Selection returns the start and end positions of the selected text, in runes.
+func (t *TextInput) Selection() (int, int) {
+	start, end := t.selectionAnchor, t.cursorPosition
+	if start > end {
+		start, end = end, start
+	}
+
+	l := len([]rune(t.InputText))
+	if end > l {
+		end = l
+	}
+	if start > end {
+		start = end
+	}
+
+	return start, end
+}
+
+// SelectedText returns the currently selected text.
+func (t *TextInput) SelectedText() string {
+	start, end := t.Selection()
+	return string([]rune(t.InputText)[start:end])
+}
+
+// SetSelection selects the text between rune positions start and end, and moves the cursor to end.
+func (t *TextInput) SetSelection(start int, end int) {
+	t.selectionAnchor = start
+	t.cursorPosition = end
+}
+
+// SelectAll selects the whole input text.
+func (t *TextInput) SelectAll() {
+	t.selectionAnchor = 0
+	t.cursorPosition = len([]rune(t.InputText))
+}
+
 func insertChars(r []rune, c []rune, pos int) []rune {
 	res := make([]rune, len(r)+len(c))
 	copy(res, r[:pos])
@@ -409,9 +719,13 @@ func insertChars(r []rune, c []rune, pos int) []rune {
 }
 
 func removeChar(r []rune, pos int) []rune {
-	res := make([]rune, len(r)-1)
-	copy(res, r[:pos])
-	copy(res[pos:], r[pos+1:])
+	return removeChars(r, pos, pos+1)
+}
+
+func removeChars(r []rune, start int, end int) []rune {
+	res := make([]rune, len(r)-(end-start))
+	copy(res, r[:start])
+	copy(res[start:], r[end:])
 	return res
 }
 
@@ -429,42 +743,155 @@ func (t *TextInput) renderImage(screen *ebiten.Image) {
 	}
 }
 
+// Insert inserts s at the cursor position, replacing the selected text, as if it had been typed. It does
+// nothing if t is disabled, or if t's validation function rejects the resulting text.
+func (t *TextInput) Insert(s string) {
+	t.init.Do()
+
+	if t.widget.Disabled {
+		return
+	}
+
+	t.doInsert([]rune(s))
+}
+
+// Clear clears the input text.
+func (t *TextInput) Clear() {
+	t.init.Do()
+
+	if t.widget.Disabled {
+		return
+	}
+
+	t.InputText = ""
+	t.tokens = nil
+	t.cursorPosition = 0
+	t.selectionAnchor = 0
+	t.scrollOffset = 0
+	t.caret.ResetBlinking()
+}
+
+// SetRevealed sets whether secure input text is displayed in clear text.
+func (t *TextInput) SetRevealed(r bool) {
+	if r == t.revealed {
+		return
+	}
+
+	t.revealed = r
+
+	t.RevealedEvent.Fire(&TextInputRevealedEventArgs{
+		TextInput: t,
+		Revealed:  r,
+	})
+}
+
+// Revealed returns whether secure input text is displayed in clear text.
+func (t *TextInput) Revealed() bool {
+	return t.revealed
+}
+
+// adornments returns t's prefix and suffix widgets.
+func (t *TextInput) adornments() []PreferredSizeLocateableWidget {
+	a := make([]PreferredSizeLocateableWidget, 0, len(t.prefixes)+len(t.suffixes))
+	a = append(a, t.prefixes...)
+	return append(a, t.suffixes...)
+}
+
+// adornmentsWidth returns the width taken up by adornments a, including spacing to the input text.
+func (t *TextInput) adornmentsWidth(a []PreferredSizeLocateableWidget) int {
+	width := 0
+	for _, w := range a {
+		aw, _ := w.PreferredSize()
+		width += aw + t.adornmentSpace
+	}
+	return width
+}
+
+// adornmentAt returns whether p is inside t's prefix or suffix widget.
+func (t *TextInput) adornmentAt(p img.Point) bool {
+	for _, a := range t.adornments() {
+		if p.In(a.GetWidget().Rect) {
+			return true
+		}
+	}
+	return false
+}
+
+// textRect returns the rectangle that the input text is displayed in, which excludes padding and adornments.
+func (t *TextInput) textRect() img.Rectangle {
+	r := t.padding.Apply(t.widget.Rect)
+
+	r.Min.X += t.adornmentsWidth(t.prefixes)
+	r.Max.X -= t.adornmentsWidth(t.suffixes)
+
+	if r.Max.X < r.Min.X {
+		r.Max.X = r.Min.X
+	}
+
+	return r
+}
+
+func (t *TextInput) renderAdornments(screen *ebiten.Image, def DeferredRenderFunc) {
+	r := t.padding.Apply(t.widget.Rect)
+
+	x := r.Min.X
+	for _, a := range t.prefixes {
+		w, h := a.PreferredSize()
+		y := r.Min.Y + (r.Dy()-h)/2
+		t.renderAdornment(screen, a, img.Rect(x, y, x+w, y+h), def)
+		x += w + t.adornmentSpace
+	}
+
+	x = r.Max.X - t.adornmentsWidth(t.suffixes) + t.adornmentSpace
+	for _, a := range t.suffixes {
+		w, h := a.PreferredSize()
+		y := r.Min.Y + (r.Dy()-h)/2
+		t.renderAdornment(screen, a, img.Rect(x, y, x+w, y+h), def)
+		x += w + t.adornmentSpace
+	}
+}
+
+func (t *TextInput) renderAdornment(screen *ebiten.Image, a PreferredSizeLocateableWidget, rect img.Rectangle, def DeferredRenderFunc) {
+	a.GetWidget().Disabled = t.widget.Disabled
+
+	if rect != a.GetWidget().Rect {
+		a.SetLocation(rect)
+		if r, ok := a.(Relayoutable); ok {
+			r.RequestRelayout()
+		}
+	}
+
+	if !a.GetWidget().Visible() {
+		return
+	}
+
+	if r, ok := a.(Renderer); ok {
+		renderWithHandlers(r, screen, def)
+	}
+}
+
 func (t *TextInput) renderTextAndCaret(screen *ebiten.Image, def DeferredRenderFunc) {
-	t.renderBuf.Draw(screen,
-		func(buf *ebiten.Image) {
-			t.drawTextAndCaret(buf, def)
-		},
-		func(buf *ebiten.Image) {
-			rect := t.widget.Rect
-			t.mask.Draw(buf, rect.Dx()-t.padding.Left-t.padding.Right, rect.Dy()-t.padding.Top-t.padding.Bottom,
-				func(opts *ebiten.DrawImageOptions) {
-					opts.GeoM.Translate(float64(rect.Min.X+t.padding.Left), float64(rect.Min.Y+t.padding.Top))
-					opts.CompositeMode = ebiten.CompositeModeCopy
-				})
-		})
+	t.renderBuf.Draw(screen, t.textRect(), func(buf *ebiten.Image) {
+		t.drawTextAndCaret(buf, def)
+	})
 }
 
 func (t *TextInput) drawTextAndCaret(screen *ebiten.Image, def DeferredRenderFunc) {
-	rect := t.widget.Rect
-	tr := rect
-	tr = tr.Add(img.Point{t.padding.Left, t.padding.Top})
+	tr := t.textRect()
 
-	inputStr := t.InputText
-	if t.secure {
-		inputStr = t.secureInputText
-	}
+	inputStr := t.displayText()
 
 	cx := 0
 	if t.focused {
 		sub := string([]rune(inputStr)[:t.cursorPosition])
-		cx = fontAdvance(sub, t.face)
+		cx = textutil.Advance(sub, t.face)
 
-		dx := tr.Min.X + t.scrollOffset + cx + t.caret.Width + t.padding.Right - rect.Max.X
+		dx := t.scrollOffset + cx + t.caret.Width - tr.Dx()
 		if dx > 0 {
 			t.scrollOffset -= dx
 		}
 
-		dx = tr.Min.X + t.scrollOffset + cx - t.padding.Left - rect.Min.X
+		dx = t.scrollOffset + cx
 		if dx < 0 {
 			t.scrollOffset -= dx
 		}
@@ -472,6 +899,14 @@ func (t *TextInput) drawTextAndCaret(screen *ebiten.Image, def DeferredRenderFun
 
 	tr = tr.Add(img.Point{t.scrollOffset, 0})
 
+	if t.color.Token != nil {
+		t.drawTokens(screen)
+	}
+
+	if t.focused && t.color.Selection != nil {
+		t.drawSelection(screen)
+	}
+
 	t.text.SetLocation(tr)
 	if len([]rune(t.InputText)) > 0 {
 		t.text.Label = inputStr
@@ -499,11 +934,84 @@ func (t *TextInput) drawTextAndCaret(screen *ebiten.Image, def DeferredRenderFun
 	}
 }
 
+func (t *TextInput) drawSelection(screen *ebiten.Image) {
+	start, end := t.Selection()
+	if start == end {
+		return
+	}
+
+	r := t.textRangeRect(start, end)
+
+	image.NewNineSliceColor(t.color.Selection).Draw(screen, r.Dx(), r.Dy(), func(opts *ebiten.DrawImageOptions) {
+		opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	})
+}
+
+// CaretRect returns the on-screen rectangle of t's caret, as of the last time t was rendered. It can be used
+// to position overlays such as IME candidate windows next to the insertion point.
+func (t *TextInput) CaretRect() img.Rectangle {
+	t.init.Do()
+
+	r := t.textRangeRect(t.cursorPosition, t.cursorPosition)
+	r.Max.X += t.caret.Width
+	return r
+}
+
+// SelectionRect returns the on-screen rectangle of t's selected text, as of the last time t was rendered,
+// clipped to the visible part of the text. If no text is selected, or if the selected text is not visible,
+// an empty rectangle is returned.
+func (t *TextInput) SelectionRect() img.Rectangle {
+	t.init.Do()
+
+	start, end := t.Selection()
+	if start == end {
+		return img.Rectangle{}
+	}
+
+	return t.textRangeRect(start, end).Intersect(t.textRect())
+}
+
+// textRangeRect returns the on-screen rectangle of the displayed text between rune positions start and end.
+func (t *TextInput) textRangeRect(start int, end int) img.Rectangle {
+	r := []rune(t.displayText())
+	start, end = clampInt(start, 0, len(r)), clampInt(end, 0, len(r))
+
+	tr := t.textRect().Add(img.Point{t.scrollOffset, 0})
+	x := tr.Min.X + textutil.Advance(string(r[:start]), t.face)
+	w := textutil.Advance(string(r[start:end]), t.face)
+	_, h := t.caret.PreferredSize()
+
+	return img.Rect(x, tr.Min.Y, x+w, tr.Min.Y+h)
+}
+
+// displayText returns the text to display, which is masked if t is secure and not revealed.
+func (t *TextInput) displayText() string {
+	if t.secure && !t.revealed {
+		return t.secureInputText
+	}
+	return t.InputText
+}
+
+// ConsumesNav implements NavConsumer. The left and right arrow keys move the caret, and the up and down arrow keys
+// move through suggestions while they are open.
+func (t *TextInput) ConsumesNav(d NavDirection) bool {
+	switch d {
+	case NavDirectionLeft, NavDirectionRight:
+		return true
+	default:
+		return t.SuggestionsOpen()
+	}
+}
+
 func (t *TextInput) Focus(focused bool) {
 	t.init.Do()
 	WidgetFireFocusEvent(t.widget, focused)
 	t.caret.resetBlinking()
 	t.focused = focused
+
+	if !focused {
+		t.CloseSuggestions()
+	}
 }
 
 func (t *TextInput) createWidget() {
@@ -515,56 +1023,33 @@ func (t *TextInput) createWidget() {
 
 	t.text = NewText(TextOpts.Text("", t.face, color.White))
 
-	t.mask = image.NewNineSliceColor(color.RGBA{255, 0, 255, 255})
-}
-
-func fontAdvance(s string, f font.Face) int {
-	_, a := font.BoundString(f, s)
-	return int(math.Round(fixedInt26_6ToFloat64(a)))
-}
-
-// fontStringIndex returns an index into r that corresponds closest to pixel position x
-// when string(r) is drawn using f. Pixel position x==0 corresponds to r[0].
-func fontStringIndex(r []rune, f font.Face, x int) int {
-	start := 0
-	end := len(r)
-	p := 0
-loop:
-	for {
-		p = start + (end-start)/2
-		sub := string(r[:p])
-		a := fontAdvance(sub, f)
-
-		switch {
-		// x is right of advance
-		case x > a:
-			if p == start {
-				break loop
-			}
-
-			start = p
-
-		// x is left of advance
-		case x < a:
-			if end == p {
-				break loop
-			}
-
-			end = p
+	if len(t.clearButtonOpts) > 0 {
+		t.clearButton = NewButton(append(t.clearButtonOpts, ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			t.Clear()
+		}))...)
+		t.clearButtonOpts = nil
+		t.suffixes = append(t.suffixes, t.clearButton)
+	}
 
-		// x matches advance exactly
-		default:
-			return p
-		}
+	if len(t.revealBtnOpts) > 0 {
+		t.revealButton = NewButton(append(t.revealBtnOpts, ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			t.SetRevealed(!t.revealed)
+		}))...)
+		t.revealBtnOpts = nil
+		t.suffixes = append(t.suffixes, t.revealButton)
 	}
 
-	if len(r) > 0 {
-		a1 := fontAdvance(string(r[:p]), f)
-		a2 := fontAdvance(string(r[:p+1]), f)
-		if math.Abs(float64(x-a2)) < math.Abs(float64(x-a1)) {
-			p++
-		}
+	for _, a := range t.adornments() {
+		a.GetWidget().parent = t.widget
 	}
+}
 
-	return p
+func clampInt(i int, min int, max int) int {
+	if i < min {
+		return min
+	}
+	if i > max {
+		return max
+	}
+	return i
 }
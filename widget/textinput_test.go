@@ -1,10 +1,15 @@
 package widget
 
 import (
+	img "image"
 	"image/color"
 	"testing"
 
+	"github.com/blizzy78/ebitenui/clipboard"
 	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+	"github.com/blizzy78/ebitenui/textutil"
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/matryer/is"
 )
 
@@ -115,6 +120,280 @@ func TestTextInput_DoInsert(t *testing.T) {
 	is.Equal(ti.cursorPosition, 5)
 }
 
+func TestTextInput_Adornments(t *testing.T) {
+	is := is.New(t)
+
+	prefix := newSimpleWidget(10, 8, nil)
+	suffix := newSimpleWidget(20, 12, nil)
+
+	ti := newTextInput(t,
+		TextInputOpts.Padding(Insets{Left: 5, Right: 5, Top: 2, Bottom: 2}),
+		TextInputOpts.Prefix(prefix),
+		TextInputOpts.Suffix(suffix),
+		TextInputOpts.AdornmentSpacing(3))
+
+	ti.SetLocation(img.Rect(0, 0, 200, 24))
+	render(ti, t)
+
+	is.Equal(prefix.GetWidget().Rect, img.Rect(5, 8, 15, 16))
+	is.Equal(suffix.GetWidget().Rect, img.Rect(175, 6, 195, 18))
+	is.Equal(ti.textRect(), img.Rect(18, 2, 172, 22))
+	is.Equal(prefix.GetWidget().Parent(), ti.GetWidget())
+}
+
+func TestTextInput_ClearButton(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TextInputChangedEventArgs
+	ti := newTextInput(t,
+		TextInputOpts.ClearButton(ButtonOpts.Image(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		})),
+		TextInputOpts.ChangedHandler(func(args *TextInputChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	is.True(!ti.clearButton.GetWidget().Visible())
+
+	ti.InputText = "foo"
+	ti.cursorPosition = 3
+	render(ti, t)
+	is.True(ti.clearButton.GetWidget().Visible())
+
+	ti.clearButton.ClickedEvent.Fire(&ButtonClickedEventArgs{
+		Button: ti.clearButton,
+	})
+	event.ExecuteDeferred()
+	render(ti, t)
+
+	is.Equal(ti.InputText, "")
+	is.Equal(ti.cursorPosition, 0)
+	is.Equal(eventArgs.InputText, "")
+	is.True(!ti.clearButton.GetWidget().Visible())
+}
+
+func TestTextInput_RevealButton(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TextInputRevealedEventArgs
+	ti := newTextInput(t,
+		TextInputOpts.Secure(true),
+		TextInputOpts.RevealButton(ButtonOpts.Image(&ButtonImage{
+			Idle: newNineSliceEmpty(t),
+		})),
+		TextInputOpts.RevealedHandler(func(args *TextInputRevealedEventArgs) {
+			eventArgs = args
+		}))
+
+	ti.InputText = "foo"
+	render(ti, t)
+	is.Equal(ti.text.Label, "***")
+
+	ti.revealButton.ClickedEvent.Fire(&ButtonClickedEventArgs{
+		Button: ti.revealButton,
+	})
+	event.ExecuteDeferred()
+	render(ti, t)
+
+	is.True(ti.Revealed())
+	is.Equal(eventArgs.Revealed, true)
+	is.Equal(ti.text.Label, "foo")
+}
+
+func TestTextInput_DoInsert_ReplacesSelection(t *testing.T) {
+	is := is.New(t)
+
+	ti := newTextInput(t)
+	ti.InputText = "foo bar baz"
+	ti.SetSelection(4, 7)
+
+	ti.doInsert([]rune("qux"))
+
+	is.Equal(ti.InputText, "foo qux baz")
+	is.Equal(ti.cursorPosition, 7)
+	is.Equal(ti.SelectedText(), "")
+}
+
+func TestTextInput_DoGoLeft_Shift(t *testing.T) {
+	is := is.New(t)
+
+	ti := newTextInput(t)
+	ti.InputText = "foo"
+	ti.SetSelection(3, 3)
+
+	internalinput.KeyPressed[ebiten.KeyShift] = true
+	ti.doGoLeft()
+	ti.doGoLeft()
+	internalinput.KeyPressed[ebiten.KeyShift] = false
+
+	is.Equal(ti.SelectedText(), "oo")
+
+	ti.doGoLeft()
+
+	start, end := ti.Selection()
+	is.Equal(start, 1)
+	is.Equal(end, 1)
+}
+
+func TestTextInput_DoBackspace_Selection(t *testing.T) {
+	is := is.New(t)
+
+	ti := newTextInput(t)
+	ti.InputText = "foo bar"
+	ti.SetSelection(7, 3)
+
+	ti.doBackspace()
+
+	is.Equal(ti.InputText, "foo")
+	is.Equal(ti.cursorPosition, 3)
+}
+
+func TestTextInput_CutPaste(t *testing.T) {
+	is := is.New(t)
+
+	clipboard.SetClipboard(&clipboard.MemoryClipboard{})
+
+	ti := newTextInput(t)
+	ti.InputText = "foo bar"
+	ti.SetSelection(0, 4)
+
+	ti.doCut()
+	is.Equal(ti.InputText, "bar")
+
+	ti.SetSelection(3, 3)
+	ti.doPaste()
+	is.Equal(ti.InputText, "barfoo ")
+
+	s, err := clipboard.ReadText()
+	is.NoErr(err)
+	is.Equal(s, "foo ")
+}
+
+func TestTextInput_Copy_Secure(t *testing.T) {
+	is := is.New(t)
+
+	clipboard.SetClipboard(&clipboard.MemoryClipboard{})
+
+	ti := newTextInput(t, TextInputOpts.Secure(true))
+	ti.InputText = "secret"
+	ti.SelectAll()
+
+	ti.doCopy()
+
+	s, err := clipboard.ReadText()
+	is.NoErr(err)
+	is.Equal(s, "")
+}
+
+func TestTextInput_CaretRect_SelectionRect(t *testing.T) {
+	is := is.New(t)
+
+	ti := newTextInput(t,
+		TextInputOpts.Padding(Insets{Left: 5, Top: 2}))
+	ti.SetLocation(img.Rect(0, 0, 200, 40))
+	ti.InputText = "foobar"
+	ti.Focus(true)
+	ti.SetSelection(1, 4)
+	render(ti, t)
+
+	face := loadFont(t)
+	_, h := ti.caret.PreferredSize()
+
+	x := 5 + textutil.Advance("foob", face)
+	is.Equal(ti.CaretRect(), img.Rect(x, 2, x+1, 2+h))
+
+	x = 5 + textutil.Advance("f", face)
+	is.Equal(ti.SelectionRect(), img.Rect(x, 2, x+textutil.Advance("oob", face), 2+h))
+
+	ti.SetSelection(2, 2)
+	is.Equal(ti.SelectionRect(), img.Rectangle{})
+}
+
+func TestTextInput_Tokens(t *testing.T) {
+	is := is.New(t)
+
+	ti := newTextInput(t)
+	ti.Insert("hi ")
+	ti.InsertToken("@bob", 42)
+	ti.Insert("!")
+	render(ti, t)
+
+	is.Equal(ti.InputText, "hi @bob!")
+	is.Equal(ti.Tokens(), []TextInputToken{{Start: 3, End: 7, Text: "@bob", Data: 42}})
+
+	ti.doGoLeft()
+	ti.doGoLeft()
+	is.Equal(ti.cursorPosition, 3)
+
+	ti.doDelete()
+	is.Equal(ti.InputText, "hi !")
+	is.Equal(len(ti.Tokens()), 0)
+
+	ti.InsertToken("@bob", nil)
+	ti.doBackspace()
+	is.Equal(ti.InputText, "hi !")
+
+	ti.InsertToken("@bob", nil)
+	ti.InputText = "changed"
+	render(ti, t)
+	is.Equal(len(ti.Tokens()), 0)
+}
+
+func TestTextInput_Suggestions(t *testing.T) {
+	is := is.New(t)
+
+	var queries []string
+	ti := newTextInput(t,
+		TextInputOpts.Suggestions("@", func(trigger rune, query string) []*TextInputSuggestion {
+			queries = append(queries, query)
+			return []*TextInputSuggestion{
+				{Label: "Alice", Text: "@alice", Data: 1},
+			}
+		}))
+
+	ti.doInsert([]rune("x@"))
+	ti.mentions.checkTrigger([]rune("@"))
+	is.True(!ti.SuggestionsOpen())
+
+	ti.Clear()
+	ti.doInsert([]rune("@"))
+	ti.mentions.checkTrigger([]rune("@"))
+	is.True(ti.SuggestionsOpen())
+
+	ti.doInsert([]rune("al"))
+	render(ti, t)
+	is.Equal(queries, []string{"", "al"})
+	is.Equal(len(ti.Suggestions()), 1)
+
+	ti.AcceptSuggestion(0)
+	is.True(!ti.SuggestionsOpen())
+	is.Equal(ti.InputText, "@alice ")
+	is.Equal(ti.Tokens(), []TextInputToken{{Start: 0, End: 6, Text: "@alice", Data: 1}})
+
+	ti.doInsert([]rune("@"))
+	ti.mentions.checkTrigger([]rune("@"))
+	is.True(ti.HandleBack())
+	is.True(!ti.SuggestionsOpen())
+}
+
+func TestTextInput_UpdateInput(t *testing.T) {
+	is := is.New(t)
+
+	ti := newTextInput(t)
+	ti.Focus(true)
+
+	internalinput.InputChars = []rune("ab")
+	defer func() {
+		internalinput.InputChars = nil
+	}()
+
+	c := NewContainer(ContainerOpts.Layout(NewRowLayout()))
+	c.AddChild(ti)
+
+	UpdateInput(c)
+	is.Equal(ti.InputText, "ab")
+}
+
 func newTextInput(t *testing.T, opts ...TextInputOpt) *TextInput {
 	ti := NewTextInput(append(opts, []TextInputOpt{
 		TextInputOpts.Face(loadFont(t)),
@@ -0,0 +1,473 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// TextInputToken is an atomic part of a TextInput's text, such as a mention of a user or a chat command.
+// Tokens are deleted as a unit, the cursor cannot be placed inside them, and they are highlighted using
+// TextInputColor.Token.
+type TextInputToken struct {
+	// Start and End are the rune positions of the token in the input text.
+	Start int
+	End   int
+
+	// Text is the text of the token.
+	Text string
+
+	// Data is optional data associated with the token, such as the ID of a mentioned user.
+	Data interface{}
+}
+
+// TextInputSuggestion is a suggestion offered by a TextInput after a trigger character has been typed.
+type TextInputSuggestion struct {
+	// Label is the text displayed in the list of suggestions.
+	Label string
+
+	// Text is the text of the token inserted when the suggestion is accepted, including the trigger
+	// character, for example "@alice".
+	Text string
+
+	// Data is associated with the inserted token.
+	Data interface{}
+}
+
+// TextInputSuggestFunc returns the suggestions to offer after trigger has been typed, followed by query.
+type TextInputSuggestFunc func(trigger rune, query string) []*TextInputSuggestion
+
+// TextInputSuggestionStyle specifies how the list of suggestions of a TextInput is drawn.
+type TextInputSuggestionStyle struct {
+	// Background is drawn behind the list of suggestions. It may be nil.
+	Background *image.NineSlice
+
+	// Highlight is drawn behind the highlighted suggestion. It may be nil.
+	Highlight *image.NineSlice
+
+	Color   color.Color
+	Padding Insets
+
+	// MaxEntries is the maximum number of suggestions displayed. If it is 0, all suggestions are displayed.
+	MaxEntries int
+}
+
+type textInputMentions struct {
+	triggers    string
+	suggestFunc TextInputSuggestFunc
+	style       *TextInputSuggestionStyle
+
+	input       *TextInput
+	open        bool
+	trigger     rune
+	start       int
+	query       string
+	suggestions []*TextInputSuggestion
+	highlighted int
+	rect        img.Rectangle
+	layer       *input.Layer
+	lastUp      bool
+	lastDown    bool
+	lastAccept  bool
+}
+
+// Suggestions configures a TextInput to offer suggestions when any of the characters in triggers is typed
+// at the start of a word, for example "@" to mention users, or "/" for chat commands. f is called to get the
+// suggestions whenever the text following the trigger character changes. Suggestions are highlighted using
+// the Up and Down keys, and accepted by pressing Enter or clicking them. An accepted suggestion replaces the
+// trigger character and the text following it with a token.
+func (o TextInputOptions) Suggestions(triggers string, f TextInputSuggestFunc) TextInputOpt {
+	return func(t *TextInput) {
+		t.ensureMentions()
+		t.mentions.triggers = triggers
+		t.mentions.suggestFunc = f
+	}
+}
+
+func (o TextInputOptions) SuggestionStyle(s *TextInputSuggestionStyle) TextInputOpt {
+	return func(t *TextInput) {
+		t.ensureMentions()
+		t.mentions.style = s
+	}
+}
+
+func (t *TextInput) ensureMentions() {
+	if t.mentions == nil {
+		t.mentions = &textInputMentions{
+			input: t,
+			style: &TextInputSuggestionStyle{
+				Color: color.White,
+			},
+		}
+	}
+}
+
+// Tokens returns the tokens in t's input text, ordered by position.
+func (t *TextInput) Tokens() []TextInputToken {
+	t.init.Do()
+	return append([]TextInputToken(nil), t.tokens...)
+}
+
+// InsertToken inserts s as a token at the cursor position, replacing the selected text. It does nothing if t is
+// disabled, or if t's validation function rejects the resulting text.
+func (t *TextInput) InsertToken(s string, data interface{}) {
+	t.init.Do()
+
+	if t.widget.Disabled {
+		return
+	}
+
+	start, end := t.expandToTokens(t.Selection())
+	t.selectionAnchor, t.cursorPosition = start, end
+
+	if !t.doInsert([]rune(s)) {
+		return
+	}
+
+	t.addToken(TextInputToken{
+		Start: start,
+		End:   start + len([]rune(s)),
+		Text:  s,
+		Data:  data,
+	})
+}
+
+// SuggestionsOpen returns whether the list of suggestions is currently open.
+func (t *TextInput) SuggestionsOpen() bool {
+	return t.mentions != nil && t.mentions.open
+}
+
+// Suggestions returns the suggestions currently offered.
+func (t *TextInput) Suggestions() []*TextInputSuggestion {
+	if !t.SuggestionsOpen() {
+		return nil
+	}
+	return t.mentions.visible()
+}
+
+// AcceptSuggestion replaces the trigger character and the text following it with the text of suggestion i as
+// a token, followed by a space, and closes the list of suggestions.
+func (t *TextInput) AcceptSuggestion(i int) {
+	t.init.Do()
+
+	if !t.SuggestionsOpen() {
+		return
+	}
+
+	sugg := t.mentions.visible()
+	if i < 0 || i >= len(sugg) {
+		return
+	}
+
+	t.selectionAnchor = t.mentions.start
+	t.CloseSuggestions()
+
+	t.InsertToken(sugg[i].Text, sugg[i].Data)
+	t.doInsert([]rune{' '})
+	t.caret.ResetBlinking()
+}
+
+// CloseSuggestions closes the list of suggestions.
+func (t *TextInput) CloseSuggestions() {
+	if t.mentions == nil {
+		return
+	}
+
+	t.mentions.open = false
+	t.mentions.suggestions = nil
+}
+
+// HandleBack implements BackHandler. While the list of suggestions is open, it closes the list.
+func (t *TextInput) HandleBack() bool {
+	if !t.SuggestionsOpen() {
+		return false
+	}
+
+	t.CloseSuggestions()
+	return true
+}
+
+// PopupAt implements FocusPopup.
+func (t *TextInput) PopupAt(x int, y int) bool {
+	return t.SuggestionsOpen() && img.Pt(x, y).In(t.mentions.rect)
+}
+
+// expandToTokens returns the range start,end expanded to include all tokens it overlaps.
+func (t *TextInput) expandToTokens(start int, end int) (int, int) {
+	for _, tok := range t.tokens {
+		if tok.Start < end && tok.End > start {
+			if tok.Start < start {
+				start = tok.Start
+			}
+			if tok.End > end {
+				end = tok.End
+			}
+		}
+	}
+	return start, end
+}
+
+// shiftTokens updates the positions of tokens after the runes between start and end have been replaced by
+// n runes. Tokens overlapping the replaced runes are removed.
+func (t *TextInput) shiftTokens(start int, end int, n int) {
+	d := n - (end - start)
+
+	tokens := t.tokens[:0]
+	for _, tok := range t.tokens {
+		switch {
+		case tok.End <= start:
+		case tok.Start >= end:
+			tok.Start += d
+			tok.End += d
+		default:
+			continue
+		}
+
+		tokens = append(tokens, tok)
+	}
+	t.tokens = tokens
+}
+
+func (t *TextInput) addToken(tok TextInputToken) {
+	i := 0
+	for i < len(t.tokens) && t.tokens[i].Start < tok.Start {
+		i++
+	}
+
+	t.tokens = append(t.tokens, TextInputToken{})
+	copy(t.tokens[i+1:], t.tokens[i:])
+	t.tokens[i] = tok
+}
+
+// validateTokens removes tokens that do not match the input text anymore, for example because the input text
+// has been changed directly.
+func (t *TextInput) validateTokens() {
+	if len(t.tokens) == 0 {
+		return
+	}
+
+	r := []rune(t.InputText)
+
+	tokens := t.tokens[:0]
+	for _, tok := range t.tokens {
+		if tok.End <= len(r) && string(r[tok.Start:tok.End]) == tok.Text {
+			tokens = append(tokens, tok)
+		}
+	}
+	t.tokens = tokens
+}
+
+// deleteToken deletes the token that contains the rune at position i, and moves the cursor to where the token
+// started. It returns whether there was such a token.
+func (t *TextInput) deleteToken(i int) bool {
+	for _, tok := range t.tokens {
+		if i >= tok.Start && i < tok.End {
+			t.InputText = string(removeChars([]rune(t.InputText), tok.Start, tok.End))
+			t.shiftTokens(tok.Start, tok.End, 0)
+			t.cursorPosition = tok.Start
+			return true
+		}
+	}
+	return false
+}
+
+// tokenBoundary returns pos, or the end of the token containing pos if forward is true, or the start of the
+// token if forward is false.
+func (t *TextInput) tokenBoundary(pos int, forward bool) int {
+	for _, tok := range t.tokens {
+		if pos > tok.Start && pos < tok.End {
+			if forward {
+				return tok.End
+			}
+			return tok.Start
+		}
+	}
+	return pos
+}
+
+// nearestTokenBoundary returns pos, or the closest start or end of the token containing pos.
+func (t *TextInput) nearestTokenBoundary(pos int) int {
+	for _, tok := range t.tokens {
+		if pos > tok.Start && pos < tok.End {
+			if pos-tok.Start < tok.End-pos {
+				return tok.Start
+			}
+			return tok.End
+		}
+	}
+	return pos
+}
+
+func (t *TextInput) drawTokens(screen *ebiten.Image) {
+	bg := image.NewNineSliceColor(t.color.Token)
+
+	for _, tok := range t.tokens {
+		r := t.textRangeRect(tok.Start, tok.End)
+		bg.Draw(screen, r.Dx(), r.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+		})
+	}
+}
+
+// checkTrigger opens the list of suggestions if c is a single trigger character typed at the start of a word.
+func (m *textInputMentions) checkTrigger(c []rune) {
+	if len(c) != 1 || !strings.ContainsRune(m.triggers, c[0]) {
+		return
+	}
+
+	t := m.input
+	r := []rune(t.InputText)
+	pos := t.cursorPosition - 1
+	if pos < 0 || r[pos] != c[0] || (pos > 0 && !unicode.IsSpace(r[pos-1])) {
+		return
+	}
+
+	m.open = true
+	m.trigger = c[0]
+	m.start = pos
+	m.query = ""
+	m.highlighted = 0
+	m.suggestions = m.suggestFunc(m.trigger, m.query)
+}
+
+// update updates the list of suggestions according to the text following the trigger character, closes it if
+// the trigger character has been removed or the cursor has moved away, and handles keyboard and mouse input.
+func (m *textInputMentions) update() {
+	if !m.open {
+		return
+	}
+
+	t := m.input
+
+	r := []rune(t.InputText)
+	start, end := t.Selection()
+	if start != end || m.start >= len(r) || r[m.start] != m.trigger || t.cursorPosition <= m.start {
+		t.CloseSuggestions()
+		return
+	}
+
+	q := string(r[m.start+1 : t.cursorPosition])
+	if strings.IndexFunc(q, unicode.IsSpace) >= 0 {
+		t.CloseSuggestions()
+		return
+	}
+
+	if q != m.query {
+		m.query = q
+		m.highlighted = 0
+		m.suggestions = m.suggestFunc(m.trigger, m.query)
+	}
+
+	up := input.KeyPressed(ebiten.KeyUp)
+	down := input.KeyPressed(ebiten.KeyDown)
+	accept := input.ActionPressed(input.ActionAccept)
+	defer func() {
+		m.lastUp, m.lastDown, m.lastAccept = up, down, accept
+	}()
+
+	n := len(m.visible())
+	if n == 0 {
+		return
+	}
+
+	switch {
+	case up && !m.lastUp:
+		m.highlighted = (m.highlighted - 1 + n) % n
+	case down && !m.lastDown:
+		m.highlighted = (m.highlighted + 1) % n
+	case accept && !m.lastAccept:
+		t.AcceptSuggestion(m.highlighted)
+	case m.layer != nil && input.MouseButtonJustPressedLayer(ebiten.MouseButtonLeft, m.layer):
+		if i := m.indexAt(input.CursorPosition()); i >= 0 {
+			t.AcceptSuggestion(i)
+		}
+	}
+}
+
+func (m *textInputMentions) visible() []*TextInputSuggestion {
+	if m.style.MaxEntries > 0 && len(m.suggestions) > m.style.MaxEntries {
+		return m.suggestions[:m.style.MaxEntries]
+	}
+	return m.suggestions
+}
+
+func (m *textInputMentions) setupInputLayer() {
+	m.layer = &input.Layer{
+		DebugLabel: "text input suggestions",
+		EventTypes: input.LayerEventTypeAll,
+		BlockLower: true,
+		RectFunc: func() img.Rectangle {
+			return m.rect
+		},
+	}
+	input.AddLayer(m.layer)
+}
+
+func (m *textInputMentions) lineHeight() int {
+	return int(math.Round(textutil.Fixed26_6ToFloat64(m.input.face.Metrics().Height)))
+}
+
+// indexAt returns the index of the suggestion at x,y, or -1 if there is none.
+func (m *textInputMentions) indexAt(x int, y int) int {
+	if !img.Pt(x, y).In(m.rect) {
+		return -1
+	}
+
+	i := (y - m.rect.Min.Y - m.style.Padding.Top) / m.lineHeight()
+	if i < 0 || i >= len(m.visible()) {
+		return -1
+	}
+	return i
+}
+
+// render draws the list of suggestions below the caret.
+func (m *textInputMentions) render(screen *ebiten.Image, def DeferredRenderFunc) {
+	sugg := m.visible()
+	if len(sugg) == 0 {
+		m.rect = img.Rectangle{}
+		return
+	}
+
+	t := m.input
+	lh := m.lineHeight()
+
+	w := 0
+	for _, s := range sugg {
+		if sw := textutil.Advance(s.Label, t.face); sw > w {
+			w = sw
+		}
+	}
+
+	x := t.textRangeRect(m.start, m.start).Min.X
+	y := t.widget.Rect.Max.Y
+	m.rect = img.Rect(x, y, x+w+m.style.Padding.Dx(), y+len(sugg)*lh+m.style.Padding.Dy())
+
+	if m.style.Background != nil {
+		m.style.Background.Draw(screen, m.rect.Dx(), m.rect.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(m.rect.Min.X), float64(m.rect.Min.Y))
+		})
+	}
+
+	ascent := int(math.Round(textutil.Fixed26_6ToFloat64(t.face.Metrics().Ascent)))
+
+	for i, s := range sugg {
+		ly := m.rect.Min.Y + m.style.Padding.Top + i*lh
+
+		if i == m.highlighted && m.style.Highlight != nil {
+			m.style.Highlight.Draw(screen, m.rect.Dx(), lh, func(opts *ebiten.DrawImageOptions) {
+				opts.GeoM.Translate(float64(m.rect.Min.X), float64(ly))
+			})
+		}
+
+		text.Draw(screen, s.Label, t.face, m.rect.Min.X+m.style.Padding.Left, ly+ascent, m.style.Color)
+	}
+}
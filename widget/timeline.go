@@ -0,0 +1,579 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+	"math"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+	"github.com/blizzy78/ebitenui/textutil"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Timeline displays a horizontal time axis from 0 to its duration, with keyframe markers and a playhead.
+//
+// Pressing the left mouse button on a marker and dragging moves the marker. Pressing the left mouse button
+// anywhere else moves the playhead. Pressing the right mouse button adds a marker, or deletes the marker
+// under the cursor. The mouse wheel zooms, horizontal mouse wheel movement pans.
+type Timeline struct {
+	PlayheadChangedEvent *event.Event
+	MarkerAddedEvent     *event.Event
+	MarkerMovedEvent     *event.Event
+	MarkerDeletedEvent   *event.Event
+
+	widgetOpts   []WidgetOpt
+	duration     float64
+	image        *TimelineImage
+	markerWidth  int
+	markerHeight int
+	tickInterval float64
+	tickImage    *image.NineSlice
+	tickWidth    int
+	tickLength   int
+	labelFace    font.Face
+	labelColor   color.Color
+	labelFunc    TimelineLabelFunc
+	minZoom      float64
+	maxZoom      float64
+
+	init         *MultiOnce
+	widget       *Widget
+	markers      []*TimelineMarker
+	playhead     float64
+	zoom         float64
+	viewStart    float64
+	dragMarker   *TimelineMarker
+	dragOffset   int
+	dragPrevTime float64
+	scrubbing    bool
+	clipBuf      *image.ClipRenderBuffer
+}
+
+type TimelineOpt func(t *Timeline)
+
+// TimelineImage contains the images used to draw a Timeline.
+type TimelineImage struct {
+	Track          *image.NineSlice
+	Marker         *image.NineSlice
+	MarkerDragging *image.NineSlice
+	Playhead       *image.NineSlice
+}
+
+// TimelineMarker is a keyframe marker on a Timeline.
+type TimelineMarker struct {
+	Time float64
+	Data interface{}
+}
+
+// TimelineLabelFunc is a function that returns the label to display at time t.
+type TimelineLabelFunc func(t float64) string
+
+type TimelinePlayheadChangedEventArgs struct {
+	Timeline *Timeline
+	Time     float64
+}
+
+type TimelineMarkerAddedEventArgs struct {
+	Timeline *Timeline
+	Marker   *TimelineMarker
+}
+
+type TimelineMarkerMovedEventArgs struct {
+	Timeline     *Timeline
+	Marker       *TimelineMarker
+	PreviousTime float64
+}
+
+type TimelineMarkerDeletedEventArgs struct {
+	Timeline *Timeline
+	Marker   *TimelineMarker
+}
+
+type TimelinePlayheadChangedHandlerFunc func(args *TimelinePlayheadChangedEventArgs)
+
+type TimelineMarkerAddedHandlerFunc func(args *TimelineMarkerAddedEventArgs)
+
+type TimelineMarkerMovedHandlerFunc func(args *TimelineMarkerMovedEventArgs)
+
+type TimelineMarkerDeletedHandlerFunc func(args *TimelineMarkerDeletedEventArgs)
+
+type TimelineOptions struct {
+}
+
+const (
+	// timelineZoomStep is the factor the zoom is multiplied with per mouse wheel step.
+	timelineZoomStep = 1.2
+
+	// timelinePanStep is the number of pixels to pan per horizontal mouse wheel step.
+	timelinePanStep = 20
+
+	timelineLabelSpacing = 4
+)
+
+var TimelineOpts TimelineOptions
+
+func NewTimeline(opts ...TimelineOpt) *Timeline {
+	t := &Timeline{
+		PlayheadChangedEvent: &event.Event{},
+		MarkerAddedEvent:     &event.Event{},
+		MarkerMovedEvent:     &event.Event{},
+		MarkerDeletedEvent:   &event.Event{},
+
+		duration:     10,
+		image:        &TimelineImage{},
+		markerWidth:  8,
+		markerHeight: 12,
+		labelFunc: func(t float64) string {
+			return locale.FormatFloat(t, -1)
+		},
+		maxZoom: 1000,
+
+		init: &MultiOnce{},
+	}
+
+	t.init.Append(t.createWidget)
+
+	for _, o := range opts {
+		o(t)
+	}
+
+	return t
+}
+
+func (o TimelineOptions) WidgetOpts(opts ...WidgetOpt) TimelineOpt {
+	return func(t *Timeline) {
+		t.widgetOpts = append(t.widgetOpts, opts...)
+	}
+}
+
+// Duration configures a Timeline to display times from 0 to d.
+func (o TimelineOptions) Duration(d float64) TimelineOpt {
+	return func(t *Timeline) {
+		t.duration = d
+	}
+}
+
+func (o TimelineOptions) Images(i *TimelineImage) TimelineOpt {
+	return func(t *Timeline) {
+		t.image = i
+	}
+}
+
+func (o TimelineOptions) MarkerSize(w int, h int) TimelineOpt {
+	return func(t *Timeline) {
+		t.markerWidth = w
+		t.markerHeight = h
+	}
+}
+
+// Ticks configures a Timeline to draw tick marks using i every interval time units along the bottom edge.
+func (o TimelineOptions) Ticks(interval float64, i *image.NineSlice, width int, length int) TimelineOpt {
+	return func(t *Timeline) {
+		t.tickInterval = interval
+		t.tickImage = i
+		t.tickWidth = width
+		t.tickLength = length
+	}
+}
+
+// Labels configures a Timeline to draw labels at tick marks, as long as they do not overlap. If f is nil,
+// times are formatted as plain numbers.
+func (o TimelineOptions) Labels(face font.Face, c color.Color, f TimelineLabelFunc) TimelineOpt {
+	return func(t *Timeline) {
+		t.labelFace = face
+		t.labelColor = c
+		if f != nil {
+			t.labelFunc = f
+		}
+	}
+}
+
+// Zoom configures a Timeline to allow zoom factors (in pixels per time unit) between min and max. Zooming
+// out is further restricted so that the full duration fits into the Timeline.
+func (o TimelineOptions) Zoom(min float64, max float64) TimelineOpt {
+	return func(t *Timeline) {
+		t.minZoom = min
+		t.maxZoom = max
+	}
+}
+
+func (o TimelineOptions) Markers(m ...*TimelineMarker) TimelineOpt {
+	return func(t *Timeline) {
+		t.markers = append(t.markers, m...)
+	}
+}
+
+func (o TimelineOptions) PlayheadChangedHandler(f TimelinePlayheadChangedHandlerFunc) TimelineOpt {
+	return func(t *Timeline) {
+		t.PlayheadChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TimelinePlayheadChangedEventArgs))
+		})
+	}
+}
+
+func (o TimelineOptions) MarkerAddedHandler(f TimelineMarkerAddedHandlerFunc) TimelineOpt {
+	return func(t *Timeline) {
+		t.MarkerAddedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TimelineMarkerAddedEventArgs))
+		})
+	}
+}
+
+func (o TimelineOptions) MarkerMovedHandler(f TimelineMarkerMovedHandlerFunc) TimelineOpt {
+	return func(t *Timeline) {
+		t.MarkerMovedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TimelineMarkerMovedEventArgs))
+		})
+	}
+}
+
+func (o TimelineOptions) MarkerDeletedHandler(f TimelineMarkerDeletedHandlerFunc) TimelineOpt {
+	return func(t *Timeline) {
+		t.MarkerDeletedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TimelineMarkerDeletedEventArgs))
+		})
+	}
+}
+
+func (t *Timeline) GetWidget() *Widget {
+	t.init.Do()
+	return t.widget
+}
+
+func (t *Timeline) SetLocation(rect img.Rectangle) {
+	t.init.Do()
+	t.widget.Rect = rect
+}
+
+func (t *Timeline) PreferredSize() (int, int) {
+	h := t.markerHeight + t.tickLength
+	if t.labelFace != nil {
+		m := t.labelFace.Metrics()
+		h += int(math.Ceil(textutil.Fixed26_6ToFloat64(m.Ascent + m.Descent)))
+	}
+
+	return 200, h
+}
+
+// Playhead returns the time of the playhead.
+func (t *Timeline) Playhead() float64 {
+	return t.playhead
+}
+
+// SetPlayhead moves the playhead to time tm.
+func (t *Timeline) SetPlayhead(tm float64) {
+	tm = t.clampTime(tm)
+	if tm == t.playhead {
+		return
+	}
+
+	t.playhead = tm
+
+	t.PlayheadChangedEvent.Fire(&TimelinePlayheadChangedEventArgs{
+		Timeline: t,
+		Time:     tm,
+	})
+}
+
+// Markers returns the markers of t.
+func (t *Timeline) Markers() []*TimelineMarker {
+	return append([]*TimelineMarker(nil), t.markers...)
+}
+
+// AddMarker adds a new marker at time tm, with user data d.
+func (t *Timeline) AddMarker(tm float64, d interface{}) *TimelineMarker {
+	m := &TimelineMarker{
+		Time: t.clampTime(tm),
+		Data: d,
+	}
+	t.markers = append(t.markers, m)
+
+	t.MarkerAddedEvent.Fire(&TimelineMarkerAddedEventArgs{
+		Timeline: t,
+		Marker:   m,
+	})
+
+	return m
+}
+
+// RemoveMarker removes marker m.
+func (t *Timeline) RemoveMarker(m *TimelineMarker) {
+	for i, tm := range t.markers {
+		if tm != m {
+			continue
+		}
+
+		t.markers = append(t.markers[:i], t.markers[i+1:]...)
+		if t.dragMarker == m {
+			t.dragMarker = nil
+		}
+
+		t.MarkerDeletedEvent.Fire(&TimelineMarkerDeletedEventArgs{
+			Timeline: t,
+			Marker:   m,
+		})
+
+		return
+	}
+}
+
+// TimeAt returns the time at screen position x.
+func (t *Timeline) TimeAt(x int) float64 {
+	t.init.Do()
+	t.updateZoom()
+
+	if t.zoom <= 0 {
+		return t.viewStart
+	}
+	return t.viewStart + float64(x-t.widget.Rect.Min.X)/t.zoom
+}
+
+// PositionOf returns the screen position of time tm.
+func (t *Timeline) PositionOf(tm float64) int {
+	t.init.Do()
+	t.updateZoom()
+	return t.widget.Rect.Min.X + int(math.Round((tm-t.viewStart)*t.zoom))
+}
+
+func (t *Timeline) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	t.init.Do()
+	t.updateZoom()
+
+	t.widget.Render(screen, def)
+
+	t.handleRightClick()
+
+	if t.dragMarker != nil {
+		t.updateMarkerDrag()
+	}
+	if t.scrubbing {
+		t.updateScrub()
+	}
+
+	if t.clipBuf == nil {
+		t.clipBuf = image.NewClipRenderBuffer()
+	}
+
+	t.clipBuf.Draw(screen, t.widget.Rect, t.draw)
+}
+
+func (t *Timeline) draw(screen *ebiten.Image) {
+	r := t.widget.Rect
+
+	if t.image.Track != nil {
+		t.image.Track.Draw(screen, r.Dx(), r.Dy(), t.widget.drawImageOptions)
+	}
+
+	if t.tickImage != nil || t.labelFace != nil {
+		t.drawTicks(screen)
+	}
+
+	for _, m := range t.markers {
+		i := t.image.Marker
+		if m == t.dragMarker && t.image.MarkerDragging != nil {
+			i = t.image.MarkerDragging
+		}
+
+		if i != nil {
+			drawNineSliceAt(screen, i, t.markerRect(m))
+		}
+	}
+
+	if t.image.Playhead != nil {
+		x := t.PositionOf(t.playhead)
+		drawNineSliceAt(screen, t.image.Playhead, img.Rect(x-1, r.Min.Y, x+1, r.Max.Y))
+	}
+}
+
+func (t *Timeline) drawTicks(screen *ebiten.Image) {
+	if t.tickInterval <= 0 || t.zoom <= 0 {
+		return
+	}
+
+	r := t.widget.Rect
+
+	var ascent, lineHeight int
+	if t.labelFace != nil {
+		m := t.labelFace.Metrics()
+		ascent = int(math.Round(textutil.Fixed26_6ToFloat64(m.Ascent)))
+		lineHeight = int(math.Round(textutil.Fixed26_6ToFloat64(m.Ascent + m.Descent)))
+	}
+
+	// skip tick marks to keep them at least 4 pixels apart when zoomed out
+	interval := t.tickInterval
+	for interval*t.zoom < 4 {
+		interval *= 2
+	}
+
+	labelEnd := math.MinInt32
+	start := math.Floor(t.viewStart/interval) * interval
+	for tm := math.Max(start, 0); tm <= t.duration; tm += interval {
+		x := t.PositionOf(tm)
+		if x > r.Max.X {
+			break
+		}
+
+		if t.tickImage != nil {
+			drawNineSliceAt(screen, t.tickImage, img.Rect(x-t.tickWidth/2, r.Max.Y-t.tickLength, x-t.tickWidth/2+t.tickWidth, r.Max.Y))
+		}
+
+		if t.labelFace != nil && x >= labelEnd {
+			l := t.labelFunc(tm)
+			text.Draw(screen, l, t.labelFace, x+timelineLabelSpacing/2, r.Max.Y-t.tickLength-lineHeight+ascent, t.labelColor)
+			labelEnd = x + textutil.Advance(l, t.labelFace) + timelineLabelSpacing
+		}
+	}
+}
+
+func (t *Timeline) markerRect(m *TimelineMarker) img.Rectangle {
+	x := t.PositionOf(m.Time) - t.markerWidth/2
+	y := t.widget.Rect.Min.Y
+	return img.Rect(x, y, x+t.markerWidth, y+t.markerHeight)
+}
+
+// markerAt returns the marker at screen position x,y, or nil if there is none.
+func (t *Timeline) markerAt(x int, y int) *TimelineMarker {
+	p := img.Point{x, y}
+
+	// later markers are drawn on top
+	for i := len(t.markers) - 1; i >= 0; i-- {
+		m := t.markers[i]
+		if p.In(t.markerRect(m)) {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func (t *Timeline) handleRightClick() {
+	if t.widget.Disabled || !input.MouseButtonJustPressedLayer(ebiten.MouseButtonRight, t.widget.EffectiveInputLayer()) {
+		return
+	}
+
+	x, y := input.CursorPosition()
+	if !t.widget.In(x, y) {
+		return
+	}
+
+	if m := t.markerAt(x, y); m != nil {
+		t.RemoveMarker(m)
+		return
+	}
+
+	t.AddMarker(t.TimeAt(x), nil)
+}
+
+func (t *Timeline) updateMarkerDrag() {
+	x, _ := input.CursorPosition()
+
+	if input.MouseButtonPressed(ebiten.MouseButtonLeft) {
+		t.dragMarker.Time = t.clampTime(t.TimeAt(x - t.dragOffset))
+		return
+	}
+
+	m := t.dragMarker
+	t.dragMarker = nil
+
+	if m.Time != t.dragPrevTime {
+		t.MarkerMovedEvent.Fire(&TimelineMarkerMovedEventArgs{
+			Timeline:     t,
+			Marker:       m,
+			PreviousTime: t.dragPrevTime,
+		})
+	}
+}
+
+func (t *Timeline) updateScrub() {
+	if !input.MouseButtonPressed(ebiten.MouseButtonLeft) {
+		t.scrubbing = false
+		return
+	}
+
+	x, _ := input.CursorPosition()
+	t.SetPlayhead(t.TimeAt(x))
+}
+
+// updateZoom clamps the zoom so that the full duration fits into t at most. If the zoom has not been set
+// yet, the full duration is made visible.
+func (t *Timeline) updateZoom() {
+	t.zoom = t.clampZoom(t.zoom)
+	if t.zoom > 0 {
+		t.setViewStart(t.viewStart)
+	}
+}
+
+// zoomAt changes the zoom by steps mouse wheel steps, keeping the time at screen position x in place.
+func (t *Timeline) zoomAt(x int, steps float64) {
+	tm := t.TimeAt(x)
+	t.zoom = t.clampZoom(t.zoom * math.Pow(timelineZoomStep, steps))
+	t.setViewStart(tm - float64(x-t.widget.Rect.Min.X)/t.zoom)
+}
+
+func (t *Timeline) clampZoom(z float64) float64 {
+	min := t.minZoom
+	if t.duration > 0 && t.widget.Rect.Dx() > 0 {
+		min = math.Max(min, float64(t.widget.Rect.Dx())/t.duration)
+	}
+
+	return math.Max(min, math.Min(t.maxZoom, z))
+}
+
+func (t *Timeline) setViewStart(s float64) {
+	max := math.Max(t.duration-float64(t.widget.Rect.Dx())/t.zoom, 0)
+	t.viewStart = math.Max(0, math.Min(max, s))
+}
+
+func (t *Timeline) clampTime(tm float64) float64 {
+	return math.Max(0, math.Min(t.duration, tm))
+}
+
+func (t *Timeline) createWidget() {
+	t.widget = NewWidget(append(t.widgetOpts, []WidgetOpt{
+		WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+			if t.widget.Disabled || args.Button != ebiten.MouseButtonLeft {
+				return
+			}
+
+			p := t.widget.Rect.Min.Add(img.Point{args.OffsetX, args.OffsetY})
+
+			if m := t.markerAt(p.X, p.Y); m != nil {
+				t.dragMarker = m
+				t.dragOffset = p.X - t.PositionOf(m.Time)
+				t.dragPrevTime = m.Time
+				return
+			}
+
+			t.scrubbing = true
+			t.SetPlayhead(t.TimeAt(p.X))
+		}),
+
+		WidgetOpts.ScrolledHandler(func(args *WidgetScrolledEventArgs) {
+			if t.widget.Disabled {
+				return
+			}
+
+			if args.Y != 0 {
+				x, _ := input.CursorPosition()
+				t.zoomAt(x, args.Y)
+			}
+
+			if args.X != 0 {
+				t.setViewStart(t.viewStart - args.X*timelinePanStep/t.zoom)
+			}
+		}),
+	}...)...)
+	t.widgetOpts = nil
+}
+
+// drawNineSliceAt draws i onto screen, filling rect.
+func drawNineSliceAt(screen *ebiten.Image, i *image.NineSlice, rect img.Rectangle) {
+	i.Draw(screen, rect.Dx(), rect.Dy(), func(opts *ebiten.DrawImageOptions) {
+		opts.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	})
+}
@@ -0,0 +1,131 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/matryer/is"
+)
+
+func TestTimeline_Scrub(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TimelinePlayheadChangedEventArgs
+
+	tl := newTimeline(t,
+		TimelineOpts.PlayheadChangedHandler(func(args *TimelinePlayheadChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.CursorX, internalinput.CursorY = 0, 20
+	leftMouseButtonPress(tl, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX = 30
+	render(tl, t)
+
+	is.Equal(tl.Playhead(), 3.0)
+	is.Equal(eventArgs.Time, 3.0)
+
+	internalinput.LeftMouseButtonPressed = false
+	render(tl, t)
+	is.True(!tl.scrubbing)
+}
+
+func TestTimeline_MoveMarker(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *TimelineMarkerMovedEventArgs
+
+	m := &TimelineMarker{}
+	tl := newTimeline(t,
+		TimelineOpts.Markers(m),
+		TimelineOpts.MarkerMovedHandler(func(args *TimelineMarkerMovedEventArgs) {
+			eventArgs = args
+		}))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	leftMouseButtonPress(tl, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX = 50
+	render(tl, t)
+
+	is.Equal(m.Time, 5.0)
+	is.Equal(tl.Playhead(), 0.0)
+
+	internalinput.LeftMouseButtonPressed = false
+	render(tl, t)
+
+	is.Equal(eventArgs.Marker, m)
+	is.Equal(eventArgs.PreviousTime, 0.0)
+}
+
+func TestTimeline_AddDeleteMarker(t *testing.T) {
+	is := is.New(t)
+
+	var addedArgs *TimelineMarkerAddedEventArgs
+	var deletedArgs *TimelineMarkerDeletedEventArgs
+
+	tl := newTimeline(t,
+		TimelineOpts.MarkerAddedHandler(func(args *TimelineMarkerAddedEventArgs) {
+			addedArgs = args
+		}),
+		TimelineOpts.MarkerDeletedHandler(func(args *TimelineMarkerDeletedEventArgs) {
+			deletedArgs = args
+		}))
+
+	defer func() {
+		internalinput.RightMouseButtonJustPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.RightMouseButtonJustPressed = true
+	internalinput.CursorX, internalinput.CursorY = 70, 20
+	render(tl, t)
+
+	is.Equal(len(tl.Markers()), 1)
+	is.Equal(addedArgs.Marker.Time, 7.0)
+
+	internalinput.CursorY = 5
+	render(tl, t)
+
+	is.Equal(len(tl.Markers()), 0)
+	is.Equal(deletedArgs.Marker, addedArgs.Marker)
+}
+
+func TestTimeline_ZoomAt(t *testing.T) {
+	is := is.New(t)
+
+	tl := newTimeline(t, TimelineOpts.Zoom(0, 20))
+
+	tl.zoomAt(50, 1)
+	is.Equal(tl.zoom, 12.0)
+	is.Equal(tl.PositionOf(5), 50)
+
+	tl.zoomAt(50, -5)
+	is.Equal(tl.zoom, 10.0)
+	is.Equal(tl.viewStart, 0.0)
+}
+
+func newTimeline(t *testing.T, opts ...TimelineOpt) *Timeline {
+	t.Helper()
+
+	tl := NewTimeline(append(opts, TimelineOpts.Duration(10))...)
+	event.ExecuteDeferred()
+	tl.SetLocation(image.Rect(0, 0, 100, 30))
+	render(tl, t)
+	return tl
+}
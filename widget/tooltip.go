@@ -3,7 +3,6 @@ package widget
 import (
 	"image"
 	img "image"
-	"sync/atomic"
 	"time"
 
 	"github.com/blizzy78/ebitenui/input"
@@ -118,11 +117,11 @@ func (t *ToolTip) idleState() toolTipState {
 			return t.showingState(w, x, y, nil), true
 		}
 
-		return t.armedState(w, x, y, nil, nil), true
+		return t.armedState(w, x, y, time.Time{}), true
 	}
 }
 
-func (t *ToolTip) armedState(srcWidget HasWidget, srcX int, srcY int, timer *time.Timer, expired *atomic.Value) toolTipState {
+func (t *ToolTip) armedState(srcWidget HasWidget, srcX int, srcY int, deadline time.Time) toolTipState {
 	return func(screen *ebiten.Image, def DeferredRenderFunc) (toolTipState, bool) {
 		x, y := input.CursorPosition()
 		w := t.container.WidgetAt(x, y)
@@ -134,19 +133,12 @@ func (t *ToolTip) armedState(srcWidget HasWidget, srcX int, srcY int, timer *tim
 			return t.idleState(), false
 		}
 
-		if timer != nil && expired.Load().(bool) {
+		if !deadline.IsZero() && !Now().Before(deadline) {
 			return t.showingState(srcWidget, x, y, nil), true
 		}
 
-		if timer == nil {
-			expired = &atomic.Value{}
-			expired.Store(false)
-
-			timer = time.AfterFunc(t.Delay, func() {
-				expired.Store(true)
-			})
-
-			return t.armedState(srcWidget, srcX, srcY, timer, expired), false
+		if deadline.IsZero() {
+			return t.armedState(srcWidget, srcX, srcY, Now().Add(t.Delay)), false
 		}
 
 		return nil, false
@@ -0,0 +1,380 @@
+package widget
+
+import (
+	img "image"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// TransferList is a pair of lists with buttons between them to move entries from a source list to a target
+// list and back, for building a selection from a pool of entries, such as a mod load order or a squad. Entries
+// in the source list are always shown in the order of the pool, while entries in the target list can be
+// reordered.
+type TransferList struct {
+	// ChangedEvent fires an event with *TransferListChangedEventArgs when the entries of the target list have
+	// changed, including their order.
+	ChangedEvent *event.Event
+
+	containerOpts []ContainerOpt
+	listOpts      []ListOpt
+	buttonOpts    []ButtonOpt
+	buttonFace    font.Face
+	buttonColor   *ButtonTextColor
+	labels        *TransferListLabels
+	spacing       int
+	pool          []interface{}
+	target        []interface{}
+
+	init            *MultiOnce
+	container       *Container
+	sourceList      *List
+	targetList      *List
+	addButton       *Button
+	addAllButton    *Button
+	removeButton    *Button
+	removeAllButton *Button
+	upButton        *Button
+	downButton      *Button
+}
+
+// TransferListLabels specifies the labels of the buttons of a TransferList.
+type TransferListLabels struct {
+	Add       string
+	AddAll    string
+	Remove    string
+	RemoveAll string
+	MoveUp    string
+	MoveDown  string
+}
+
+type TransferListOpt func(t *TransferList)
+
+type TransferListChangedEventArgs struct {
+	TransferList *TransferList
+	Target       []interface{}
+}
+
+type TransferListChangedHandlerFunc func(args *TransferListChangedEventArgs)
+
+type TransferListOptions struct {
+}
+
+var TransferListOpts TransferListOptions
+
+func NewTransferList(opts ...TransferListOpt) *TransferList {
+	t := &TransferList{
+		ChangedEvent: &event.Event{},
+
+		labels: &TransferListLabels{
+			Add:       ">",
+			AddAll:    ">>",
+			Remove:    "<",
+			RemoveAll: "<<",
+			MoveUp:    "Up",
+			MoveDown:  "Down",
+		},
+
+		init: &MultiOnce{},
+	}
+
+	t.init.Append(t.createWidget)
+
+	for _, o := range opts {
+		o(t)
+	}
+
+	return t
+}
+
+func (o TransferListOptions) ContainerOpts(opts ...ContainerOpt) TransferListOpt {
+	return func(t *TransferList) {
+		t.containerOpts = append(t.containerOpts, opts...)
+	}
+}
+
+// ListOpts configures a TransferList to construct both lists using opts.
+func (o TransferListOptions) ListOpts(opts ...ListOpt) TransferListOpt {
+	return func(t *TransferList) {
+		t.listOpts = append(t.listOpts, opts...)
+	}
+}
+
+// ButtonOpts configures a TransferList to construct all buttons using opts.
+func (o TransferListOptions) ButtonOpts(opts ...ButtonOpt) TransferListOpt {
+	return func(t *TransferList) {
+		t.buttonOpts = append(t.buttonOpts, opts...)
+	}
+}
+
+// ButtonText configures a TransferList to label its buttons using face and color c.
+func (o TransferListOptions) ButtonText(face font.Face, c *ButtonTextColor) TransferListOpt {
+	return func(t *TransferList) {
+		t.buttonFace = face
+		t.buttonColor = c
+	}
+}
+
+// ButtonLabels configures a TransferList to label its buttons according to l. The defaults are ">", ">>", "<",
+// "<<", "Up", and "Down".
+func (o TransferListOptions) ButtonLabels(l *TransferListLabels) TransferListOpt {
+	return func(t *TransferList) {
+		t.labels = l
+	}
+}
+
+func (o TransferListOptions) Spacing(s int) TransferListOpt {
+	return func(t *TransferList) {
+		t.spacing = s
+	}
+}
+
+// Entries configures a TransferList to choose from the entries in pool. The entries in target are shown in
+// the target list initially. They must also be contained in pool.
+func (o TransferListOptions) Entries(pool []interface{}, target []interface{}) TransferListOpt {
+	return func(t *TransferList) {
+		t.pool = pool
+		t.target = target
+	}
+}
+
+func (o TransferListOptions) ChangedHandler(f TransferListChangedHandlerFunc) TransferListOpt {
+	return func(t *TransferList) {
+		t.ChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*TransferListChangedEventArgs))
+		})
+	}
+}
+
+func (t *TransferList) GetWidget() *Widget {
+	t.init.Do()
+	return t.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (t *TransferList) ContainedWidgets() []HasWidget {
+	t.init.Do()
+	return containedWidgets(t.container)
+}
+
+func (t *TransferList) PreferredSize() (int, int) {
+	t.init.Do()
+	return t.container.PreferredSize()
+}
+
+func (t *TransferList) SetLocation(rect img.Rectangle) {
+	t.init.Do()
+	t.container.SetLocation(rect)
+}
+
+func (t *TransferList) RequestRelayout() {
+	t.init.Do()
+	t.container.RequestRelayout()
+}
+
+func (t *TransferList) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	t.init.Do()
+	t.container.SetupInputLayer(def)
+}
+
+func (t *TransferList) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	t.init.Do()
+
+	d := t.container.GetWidget().Disabled
+	s := t.sourceList.SelectedEntry()
+	e := t.targetList.SelectedEntry()
+	i := t.targetIndex(e)
+
+	t.addButton.GetWidget().Disabled = d || s == nil
+	t.addAllButton.GetWidget().Disabled = d || len(t.target) == len(t.pool)
+	t.removeButton.GetWidget().Disabled = d || e == nil
+	t.removeAllButton.GetWidget().Disabled = d || len(t.target) == 0
+	t.upButton.GetWidget().Disabled = d || i <= 0
+	t.downButton.GetWidget().Disabled = d || i < 0 || i >= len(t.target)-1
+
+	t.container.Render(screen, def)
+}
+
+// Source returns the entries of the source list, which are the entries of the pool that are not in the
+// target list.
+func (t *TransferList) Source() []interface{} {
+	source := make([]interface{}, 0, len(t.pool))
+	for _, e := range t.pool {
+		if t.targetIndex(e) < 0 {
+			source = append(source, e)
+		}
+	}
+	return source
+}
+
+// Target returns the entries of the target list.
+func (t *TransferList) Target() []interface{} {
+	return t.target
+}
+
+// SetEntries replaces the pool of t with pool, and the entries of the target list with target. The entries
+// in target must also be contained in pool.
+func (t *TransferList) SetEntries(pool []interface{}, target []interface{}) {
+	t.init.Do()
+
+	t.pool = pool
+	t.setTarget(target)
+}
+
+// Add moves e from the source list to the end of the target list, and selects it there.
+func (t *TransferList) Add(e interface{}) {
+	t.init.Do()
+
+	if e == nil || t.targetIndex(e) >= 0 {
+		return
+	}
+
+	t.setTarget(append(t.target[:len(t.target):len(t.target)], e))
+	t.targetList.SetSelectedEntry(e)
+}
+
+// AddAll moves all entries from the source list to the end of the target list.
+func (t *TransferList) AddAll() {
+	t.init.Do()
+
+	source := t.Source()
+	if len(source) == 0 {
+		return
+	}
+
+	t.setTarget(append(t.target[:len(t.target):len(t.target)], source...))
+}
+
+// Remove moves e from the target list back to the source list, and selects it there.
+func (t *TransferList) Remove(e interface{}) {
+	t.init.Do()
+
+	i := t.targetIndex(e)
+	if i < 0 {
+		return
+	}
+
+	target := make([]interface{}, 0, len(t.target)-1)
+	target = append(target, t.target[:i]...)
+	t.setTarget(append(target, t.target[i+1:]...))
+	t.sourceList.SetSelectedEntry(e)
+}
+
+// RemoveAll moves all entries from the target list back to the source list.
+func (t *TransferList) RemoveAll() {
+	t.init.Do()
+
+	if len(t.target) == 0 {
+		return
+	}
+
+	t.setTarget(nil)
+}
+
+// Move moves e in the target list by delta positions, for example -1 to move it up by one position. It
+// does nothing if e is not in the target list. The new position is clamped to the bounds of the list.
+func (t *TransferList) Move(e interface{}, delta int) {
+	t.init.Do()
+
+	i := t.targetIndex(e)
+	if i < 0 {
+		return
+	}
+
+	j := clampInt(i+delta, 0, len(t.target)-1)
+	if j == i {
+		return
+	}
+
+	target := make([]interface{}, 0, len(t.target))
+	target = append(target, t.target[:i]...)
+	target = append(target, t.target[i+1:]...)
+	target = append(target[:j], append([]interface{}{e}, target[j:]...)...)
+	t.setTarget(target)
+}
+
+func (t *TransferList) setTarget(target []interface{}) {
+	t.target = target
+
+	t.sourceList.SetEntries(t.Source())
+	t.targetList.SetEntries(target)
+
+	t.ChangedEvent.Fire(&TransferListChangedEventArgs{
+		TransferList: t,
+		Target:       target,
+	})
+}
+
+func (t *TransferList) targetIndex(e interface{}) int {
+	if e == nil {
+		return -1
+	}
+
+	for i, te := range t.target {
+		if te == e {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *TransferList) createWidget() {
+	t.container = NewContainer(append(t.containerOpts, []ContainerOpt{
+		ContainerOpts.Layout(NewGridLayout(
+			GridLayoutOpts.Columns(3),
+			GridLayoutOpts.Stretch([]bool{true, false, true}, []bool{true}),
+			GridLayoutOpts.Spacing(t.spacing, 0))),
+		ContainerOpts.AutoDisableChildren(),
+	}...)...)
+	t.containerOpts = nil
+
+	sourceOpts := append([]ListOpt(nil), t.listOpts...)
+	t.sourceList = NewList(append(sourceOpts, ListOpts.Entries(t.Source()))...)
+	t.container.AddChild(t.sourceList)
+
+	buttons := NewContainer(ContainerOpts.Layout(NewRowLayout(
+		RowLayoutOpts.Direction(DirectionVertical),
+		RowLayoutOpts.Spacing(t.spacing))))
+	t.container.AddChild(buttons)
+
+	t.addButton = t.newButton(buttons, t.labels.Add, func() {
+		t.Add(t.sourceList.SelectedEntry())
+	})
+	t.addAllButton = t.newButton(buttons, t.labels.AddAll, t.AddAll)
+	t.removeButton = t.newButton(buttons, t.labels.Remove, func() {
+		t.Remove(t.targetList.SelectedEntry())
+	})
+	t.removeAllButton = t.newButton(buttons, t.labels.RemoveAll, t.RemoveAll)
+	t.upButton = t.newButton(buttons, t.labels.MoveUp, func() {
+		t.Move(t.targetList.SelectedEntry(), -1)
+	})
+	t.downButton = t.newButton(buttons, t.labels.MoveDown, func() {
+		t.Move(t.targetList.SelectedEntry(), 1)
+	})
+	t.buttonOpts = nil
+
+	targetOpts := append([]ListOpt(nil), t.listOpts...)
+	t.targetList = NewList(append(targetOpts, ListOpts.Entries(t.target))...)
+	t.container.AddChild(t.targetList)
+	t.listOpts = nil
+}
+
+// newButton adds a button labeled label to c that calls f when clicked.
+func (t *TransferList) newButton(c *Container, label string, f func()) *Button {
+	opts := append([]ButtonOpt(nil), t.buttonOpts...)
+	b := NewButton(append(opts, []ButtonOpt{
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(RowLayoutData{
+			Stretch: true,
+		})),
+		ButtonOpts.Text(label, t.buttonFace, t.buttonColor),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			f()
+		}),
+	}...)...)
+
+	c.AddChild(b)
+	return b
+}
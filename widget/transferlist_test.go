@@ -0,0 +1,95 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestTransferList_AddRemove(t *testing.T) {
+	is := is.New(t)
+
+	pool := []interface{}{"a", "b", "c", "d"}
+
+	var target []interface{}
+	tl := newTransferList(t,
+		TransferListOpts.Entries(pool, []interface{}{"c"}),
+		TransferListOpts.ChangedHandler(func(args *TransferListChangedEventArgs) {
+			target = args.Target
+		}))
+
+	is.Equal(tl.Source(), []interface{}{"a", "b", "d"})
+
+	tl.sourceList.SetSelectedEntry("a")
+	render(tl, t)
+	leftMouseButtonClick(tl.addButton, t)
+	is.Equal(target, []interface{}{"c", "a"})
+	is.Equal(tl.Source(), []interface{}{"b", "d"})
+	is.Equal(tl.targetList.SelectedEntry(), "a")
+
+	tl.Remove("c")
+	is.Equal(tl.Target(), []interface{}{"a"})
+	is.Equal(tl.Source(), []interface{}{"b", "c", "d"})
+
+	tl.AddAll()
+	is.Equal(tl.Target(), []interface{}{"a", "b", "c", "d"})
+	is.Equal(tl.Source(), []interface{}{})
+
+	tl.RemoveAll()
+	is.Equal(len(tl.Target()), 0)
+	is.Equal(tl.Source(), pool)
+}
+
+func TestTransferList_Move(t *testing.T) {
+	is := is.New(t)
+
+	pool := []interface{}{"a", "b", "c"}
+	tl := newTransferList(t, TransferListOpts.Entries(pool, []interface{}{"a", "b", "c"}))
+
+	tl.Move("c", -1)
+	is.Equal(tl.Target(), []interface{}{"a", "c", "b"})
+
+	tl.Move("a", 5)
+	is.Equal(tl.Target(), []interface{}{"c", "b", "a"})
+
+	tl.targetList.SetSelectedEntry("b")
+	render(tl, t)
+	leftMouseButtonClick(tl.upButton, t)
+	is.Equal(tl.Target(), []interface{}{"b", "c", "a"})
+}
+
+func newTransferList(t *testing.T, opts ...TransferListOpt) *TransferList {
+	t.Helper()
+
+	tl := NewTransferList(append(opts, []TransferListOpt{
+		TransferListOpts.ListOpts(
+			ListOpts.ScrollContainerOpts(ScrollContainerOpts.Image(&ScrollContainerImage{
+				Idle:     newNineSliceEmpty(t),
+				Disabled: newNineSliceEmpty(t),
+				Mask:     newNineSliceEmpty(t),
+			})),
+			ListOpts.SliderOpts(SliderOpts.Images(&SliderTrackImage{}, &ButtonImage{
+				Idle: newNineSliceEmpty(t),
+			})),
+			ListOpts.EntryFontFace(loadFont(t)),
+			ListOpts.EntryColor(&ListEntryColor{
+				Unselected:                 color.Transparent,
+				Selected:                   color.Transparent,
+				DisabledUnselected:         color.Transparent,
+				DisabledSelected:           color.Transparent,
+				SelectedBackground:         color.Transparent,
+				DisabledSelectedBackground: color.Transparent,
+			}),
+			ListOpts.EntryLabelFunc(func(e interface{}) string {
+				return e.(string)
+			})),
+		TransferListOpts.ButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})),
+		TransferListOpts.ButtonText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+	}...)...)
+	event.ExecuteDeferred()
+	render(tl, t)
+	return tl
+}
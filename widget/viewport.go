@@ -0,0 +1,376 @@
+package widget
+
+import (
+	img "image"
+	"math"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Viewport displays content, such as a world map, that can be panned by dragging with the mouse and zoomed
+// using the mouse wheel. Content is either an image, or drawn by a function. Positions in content space are
+// reported by ClickedEvent.
+type Viewport struct {
+	// Image is the image to display. It is ignored if a draw function has been configured.
+	Image *ebiten.Image
+
+	ClickedEvent     *event.Event
+	ViewChangedEvent *event.Event
+
+	widgetOpts     []WidgetOpt
+	drawFunc       ViewportDrawFunc
+	contentWidth   int
+	contentHeight  int
+	minZoom        float64
+	maxZoom        float64
+	zoomStep       float64
+	indicatorImage *image.NineSlice
+
+	init             *MultiOnce
+	widget           *Widget
+	zoom             float64
+	centerX          float64
+	centerY          float64
+	centered         bool
+	indicatorRect    img.Rectangle
+	dragging         bool
+	dragMoved        bool
+	dragStartCursor  img.Point
+	dragStartCenterX float64
+	dragStartCenterY float64
+	clipBuf          *image.ClipRenderBuffer
+}
+
+type ViewportOpt func(v *Viewport)
+
+// ViewportDrawFunc is a function that draws the content of a Viewport onto screen. geoM transforms from
+// content space to screen space, and should be applied to everything drawn.
+type ViewportDrawFunc func(screen *ebiten.Image, geoM ebiten.GeoM)
+
+type ViewportClickedEventArgs struct {
+	Viewport *Viewport
+
+	// X and Y are the position that has been clicked, in content space.
+	X float64
+	Y float64
+}
+
+type ViewportViewChangedEventArgs struct {
+	Viewport *Viewport
+	CenterX  float64
+	CenterY  float64
+	Zoom     float64
+}
+
+type ViewportClickedHandlerFunc func(args *ViewportClickedEventArgs)
+
+type ViewportViewChangedHandlerFunc func(args *ViewportViewChangedEventArgs)
+
+type ViewportOptions struct {
+}
+
+// viewportDragThreshold is the distance in pixels the cursor must move while pressing the mouse button
+// before a click turns into a drag.
+const viewportDragThreshold = 4
+
+var ViewportOpts ViewportOptions
+
+func NewViewport(opts ...ViewportOpt) *Viewport {
+	v := &Viewport{
+		ClickedEvent:     &event.Event{},
+		ViewChangedEvent: &event.Event{},
+
+		minZoom:  0.25,
+		maxZoom:  4,
+		zoomStep: 0.1,
+
+		init: &MultiOnce{},
+		zoom: 1,
+	}
+
+	v.init.Append(v.createWidget)
+
+	for _, o := range opts {
+		o(v)
+	}
+
+	return v
+}
+
+func (o ViewportOptions) WidgetOpts(opts ...WidgetOpt) ViewportOpt {
+	return func(v *Viewport) {
+		v.widgetOpts = append(v.widgetOpts, opts...)
+	}
+}
+
+// Image configures a Viewport to display i.
+func (o ViewportOptions) Image(i *ebiten.Image) ViewportOpt {
+	return func(v *Viewport) {
+		v.Image = i
+	}
+}
+
+// DrawFunc configures a Viewport to display content of size width x height drawn by f.
+func (o ViewportOptions) DrawFunc(f ViewportDrawFunc, width int, height int) ViewportOpt {
+	return func(v *Viewport) {
+		v.drawFunc = f
+		v.contentWidth = width
+		v.contentHeight = height
+	}
+}
+
+// Zoom configures a Viewport to allow zooming between min and max, changing the zoom factor by step
+// (as a fraction of the current zoom factor) per mouse wheel step.
+func (o ViewportOptions) Zoom(min float64, max float64, step float64) ViewportOpt {
+	return func(v *Viewport) {
+		v.minZoom = min
+		v.maxZoom = max
+		v.zoomStep = step
+	}
+}
+
+// IndicatorImage configures a Viewport to draw the region set using SetIndicatorRect using i, for example
+// to show the region of the world that is currently visible in a minimap.
+func (o ViewportOptions) IndicatorImage(i *image.NineSlice) ViewportOpt {
+	return func(v *Viewport) {
+		v.indicatorImage = i
+	}
+}
+
+func (o ViewportOptions) ClickedHandler(f ViewportClickedHandlerFunc) ViewportOpt {
+	return func(v *Viewport) {
+		v.ClickedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ViewportClickedEventArgs))
+		})
+	}
+}
+
+func (o ViewportOptions) ViewChangedHandler(f ViewportViewChangedHandlerFunc) ViewportOpt {
+	return func(v *Viewport) {
+		v.ViewChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*ViewportViewChangedEventArgs))
+		})
+	}
+}
+
+func (v *Viewport) GetWidget() *Widget {
+	v.init.Do()
+	return v.widget
+}
+
+func (v *Viewport) SetLocation(rect img.Rectangle) {
+	v.init.Do()
+	v.widget.Rect = rect
+}
+
+func (v *Viewport) PreferredSize() (int, int) {
+	v.init.Do()
+	return v.contentSize()
+}
+
+// SetIndicatorRect sets the region to draw using the indicator image to r, in content space.
+func (v *Viewport) SetIndicatorRect(r img.Rectangle) {
+	v.indicatorRect = r
+}
+
+// CenterOn pans v so that content position x,y is displayed at the center of v.
+func (v *Viewport) CenterOn(x float64, y float64) {
+	v.init.Do()
+	v.centered = true
+	v.setView(x, y, v.zoom)
+}
+
+// Center returns the content position that is displayed at the center of v.
+func (v *Viewport) Center() (float64, float64) {
+	return v.centerX, v.centerY
+}
+
+// SetZoom sets the zoom factor of v to z, clamped to the allowed range.
+func (v *Viewport) SetZoom(z float64) {
+	v.init.Do()
+	v.setView(v.centerX, v.centerY, z)
+}
+
+// ZoomFactor returns the zoom factor of v.
+func (v *Viewport) ZoomFactor() float64 {
+	return v.zoom
+}
+
+// ScreenToContent returns the content position for screen position x,y.
+func (v *Viewport) ScreenToContent(x int, y int) (float64, float64) {
+	v.init.Do()
+
+	cx, cy := v.screenCenter()
+	return (float64(x)-cx)/v.zoom + v.centerX, (float64(y)-cy)/v.zoom + v.centerY
+}
+
+// ContentToScreen returns the screen position for content position x,y.
+func (v *Viewport) ContentToScreen(x float64, y float64) (float64, float64) {
+	v.init.Do()
+
+	cx, cy := v.screenCenter()
+	return (x-v.centerX)*v.zoom + cx, (y-v.centerY)*v.zoom + cy
+}
+
+func (v *Viewport) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	v.init.Do()
+
+	if !v.centered {
+		w, h := v.contentSize()
+		v.centerX, v.centerY = float64(w)/2, float64(h)/2
+		v.centered = true
+	}
+
+	v.widget.Render(screen, def)
+
+	if v.dragging {
+		v.updateDrag()
+	}
+
+	if v.clipBuf == nil {
+		v.clipBuf = image.NewClipRenderBuffer()
+	}
+
+	v.clipBuf.Draw(screen, v.widget.Rect, v.draw)
+}
+
+func (v *Viewport) draw(screen *ebiten.Image) {
+	geoM := v.geoM()
+
+	switch {
+	case v.drawFunc != nil:
+		v.drawFunc(screen, geoM)
+
+	case v.Image != nil:
+		opts := ebiten.DrawImageOptions{
+			GeoM: geoM,
+		}
+		screen.DrawImage(v.Image, &opts)
+	}
+
+	if v.indicatorImage != nil && !v.indicatorRect.Empty() {
+		x0, y0 := v.ContentToScreen(float64(v.indicatorRect.Min.X), float64(v.indicatorRect.Min.Y))
+		x1, y1 := v.ContentToScreen(float64(v.indicatorRect.Max.X), float64(v.indicatorRect.Max.Y))
+		r := img.Rect(int(math.Round(x0)), int(math.Round(y0)), int(math.Round(x1)), int(math.Round(y1)))
+
+		v.indicatorImage.Draw(screen, r.Dx(), r.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+		})
+	}
+}
+
+// geoM returns a transformation from content space to screen space.
+func (v *Viewport) geoM() ebiten.GeoM {
+	cx, cy := v.screenCenter()
+
+	g := ebiten.GeoM{}
+	g.Translate(-v.centerX, -v.centerY)
+	g.Scale(v.zoom, v.zoom)
+	g.Translate(cx, cy)
+	return g
+}
+
+func (v *Viewport) screenCenter() (float64, float64) {
+	r := v.widget.Rect
+	return float64(r.Min.X) + float64(r.Dx())/2, float64(r.Min.Y) + float64(r.Dy())/2
+}
+
+func (v *Viewport) contentSize() (int, int) {
+	if v.drawFunc == nil && v.Image != nil {
+		return v.Image.Size()
+	}
+	return v.contentWidth, v.contentHeight
+}
+
+func (v *Viewport) updateDrag() {
+	if !input.MouseButtonPressed(ebiten.MouseButtonLeft) {
+		v.dragging = false
+		return
+	}
+
+	x, y := input.CursorPosition()
+	d := img.Point{x, y}.Sub(v.dragStartCursor)
+
+	if !v.dragMoved && abs(d.X) < viewportDragThreshold && abs(d.Y) < viewportDragThreshold {
+		return
+	}
+	v.dragMoved = true
+
+	v.setView(v.dragStartCenterX-float64(d.X)/v.zoom, v.dragStartCenterY-float64(d.Y)/v.zoom, v.zoom)
+}
+
+// zoomAt changes the zoom factor by steps mouse wheel steps, keeping the content position under screen
+// position x,y in place.
+func (v *Viewport) zoomAt(x int, y int, steps float64) {
+	z := v.zoom * math.Pow(1+v.zoomStep, steps)
+	z = math.Max(v.minZoom, math.Min(v.maxZoom, z))
+
+	px, py := v.ScreenToContent(x, y)
+	cx, cy := v.screenCenter()
+
+	v.setView(px-(float64(x)-cx)/z, py-(float64(y)-cy)/z, z)
+}
+
+func (v *Viewport) setView(x float64, y float64, zoom float64) {
+	w, h := v.contentSize()
+	x = math.Max(0, math.Min(float64(w), x))
+	y = math.Max(0, math.Min(float64(h), y))
+	zoom = math.Max(v.minZoom, math.Min(v.maxZoom, zoom))
+
+	if x == v.centerX && y == v.centerY && zoom == v.zoom {
+		return
+	}
+
+	v.centerX, v.centerY, v.zoom = x, y, zoom
+
+	v.ViewChangedEvent.Fire(&ViewportViewChangedEventArgs{
+		Viewport: v,
+		CenterX:  x,
+		CenterY:  y,
+		Zoom:     zoom,
+	})
+}
+
+func (v *Viewport) createWidget() {
+	v.widget = NewWidget(append(v.widgetOpts, []WidgetOpt{
+		WidgetOpts.MouseButtonPressedHandler(func(args *WidgetMouseButtonPressedEventArgs) {
+			if v.widget.Disabled || args.Button != ebiten.MouseButtonLeft {
+				return
+			}
+
+			v.dragging = true
+			v.dragMoved = false
+			v.dragStartCursor = v.widget.Rect.Min.Add(img.Point{args.OffsetX, args.OffsetY})
+			v.dragStartCenterX, v.dragStartCenterY = v.centerX, v.centerY
+		}),
+
+		WidgetOpts.MouseButtonReleasedHandler(func(args *WidgetMouseButtonReleasedEventArgs) {
+			if v.widget.Disabled || args.Button != ebiten.MouseButtonLeft || !args.Inside || v.dragMoved {
+				return
+			}
+
+			p := v.widget.Rect.Min.Add(img.Point{args.OffsetX, args.OffsetY})
+			x, y := v.ScreenToContent(p.X, p.Y)
+
+			v.ClickedEvent.Fire(&ViewportClickedEventArgs{
+				Viewport: v,
+				X:        x,
+				Y:        y,
+			})
+		}),
+
+		WidgetOpts.ScrolledHandler(func(args *WidgetScrolledEventArgs) {
+			if v.widget.Disabled || args.Y == 0 {
+				return
+			}
+
+			x, y := input.CursorPosition()
+			v.zoomAt(x, y, args.Y)
+		}),
+	}...)...)
+	v.widgetOpts = nil
+}
@@ -0,0 +1,92 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestViewport_ClickedEvent(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *ViewportClickedEventArgs
+
+	v := newViewport(t,
+		ViewportOpts.ClickedHandler(func(args *ViewportClickedEventArgs) {
+			eventArgs = args
+		}))
+
+	leftMouseButtonClick(v, t)
+
+	is.Equal(eventArgs.X, 50.0)
+	is.Equal(eventArgs.Y, 0.0)
+}
+
+func TestViewport_Drag(t *testing.T) {
+	is := is.New(t)
+
+	var clicked bool
+
+	v := newViewport(t,
+		ViewportOpts.ClickedHandler(func(args *ViewportClickedEventArgs) {
+			clicked = true
+		}))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	leftMouseButtonPress(v, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX, internalinput.CursorY = 20, 10
+	render(v, t)
+
+	x, y := v.Center()
+	is.Equal(x, 80.0)
+	is.Equal(y, 40.0)
+
+	leftMouseButtonRelease(v, t)
+	is.True(!clicked)
+}
+
+func TestViewport_ZoomAt(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *ViewportViewChangedEventArgs
+
+	v := newViewport(t,
+		ViewportOpts.Zoom(0.5, 2, 1),
+		ViewportOpts.ViewChangedHandler(func(args *ViewportViewChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	v.zoomAt(0, 0, 1)
+	event.ExecuteDeferred()
+
+	is.Equal(v.ZoomFactor(), 2.0)
+	is.Equal(eventArgs.Zoom, 2.0)
+
+	x, y := v.ScreenToContent(0, 0)
+	is.Equal(x, 50.0)
+	is.Equal(y, 0.0)
+
+	v.zoomAt(0, 0, 5)
+	is.Equal(v.ZoomFactor(), 2.0)
+}
+
+func newViewport(t *testing.T, opts ...ViewportOpt) *Viewport {
+	t.Helper()
+
+	v := NewViewport(append(opts, ViewportOpts.DrawFunc(func(screen *ebiten.Image, geoM ebiten.GeoM) {}, 200, 100))...)
+	event.ExecuteDeferred()
+	v.SetLocation(image.Rect(0, 0, 100, 100))
+	render(v, t)
+	return v
+}
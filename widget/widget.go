@@ -16,6 +16,9 @@ type Widget struct {
 	// used to set the position in relation to other widgets or the space available.
 	Rect image.Rectangle
 
+	// ID optionally identifies the widget, for example in errors reported in DevelopmentMode.
+	ID string
+
 	// LayoutData specifies additional optional data for a Layouter that is used to layout this widget's
 	// parent container. The exact type depends on the layout being used, for example, GridLayout requires
 	// GridLayoutData to be used.
@@ -29,6 +32,29 @@ type Widget struct {
 	// the user's perspective, scrolling does not change state, but only the display of that state.
 	Disabled bool
 
+	// Visibility specifies whether the widget is visible. Widgets that are not visible are not rendered and
+	// do not react to user input. Collapsed widgets are also excluded from their parent container's layout.
+	// It should be changed using SetVisibility, so that the parent container's layout is updated.
+	Visibility Visibility
+
+	// Margin specifies space around the widget that layouts keep free, outside of the widget's Rect.
+	// Layout data such as maximum sizes and stretching apply to the widget's size including its margin.
+	Margin Insets
+
+	// TabOrder specifies the widget's position in focus traversal order among its siblings. Widgets with lower
+	// values come first, widgets with equal values are traversed in layout order.
+	TabOrder int
+
+	// SkipFocus specifies whether the widget, and any widgets it contains, are excluded from focus traversal.
+	SkipFocus bool
+
+	// NavUp, NavDown, NavLeft, and NavRight specify the widgets to move focus to when navigating in the
+	// respective direction from this widget, overriding spatial navigation. They may be nil.
+	NavUp    HasWidget
+	NavDown  HasWidget
+	NavLeft  HasWidget
+	NavRight HasWidget
+
 	// CursorEnterEvent fires an event with *WidgetCursorEnterEventArgs when the cursor enters the widget's Rect.
 	CursorEnterEvent *event.Event
 
@@ -54,8 +80,38 @@ type Widget struct {
 	lastUpdateMouseLeftPressed bool
 	mouseLeftPressedInside     bool
 	inputLayer                 *input.Layer
+	hitTest                    WidgetHitTestFunc
+	preRenderHandlers          []WidgetRenderHandlerFunc
+	postRenderHandlers         []WidgetRenderHandlerFunc
+	addedStack                 []byte
+	focusIndicator             *FocusIndicator
+	renderInvalidated          bool
+	relayoutRequested          bool
+	eventsFrame                int
+	cachedDisabled             bool
+	cachedHidden               bool
 }
 
+// WidgetHitTestFunc is a function that returns whether position x,y, relative to a widget's Rect, is considered
+// to be inside the widget.
+type WidgetHitTestFunc func(x int, y int) bool //nolint:golint
+
+// Visibility specifies whether a Widget is visible, and whether it takes up space in its parent container's layout.
+type Visibility int
+
+const (
+	// VisibilityVisible specifies that a widget is visible.
+	VisibilityVisible = Visibility(iota)
+
+	// VisibilityHidden specifies that a widget is not visible, but still takes up space in its parent
+	// container's layout.
+	VisibilityHidden
+
+	// VisibilityCollapsed specifies that a widget is not visible, and is excluded from its parent
+	// container's layout.
+	VisibilityCollapsed
+)
+
 // WidgetOpt is a function that configures w.
 type WidgetOpt func(w *Widget) //nolint:golint
 
@@ -74,6 +130,26 @@ type Focuser interface {
 	Focus(focused bool)
 }
 
+// FocusPopup is implemented by focusable widgets that show popups outside of their Rect, such as lists of
+// suggestions. Clicking inside a popup of the focused widget does not move focus away from the widget.
+type FocusPopup interface {
+	PopupAt(x int, y int) bool
+}
+
+// Containerer may be implemented by composite widgets that are made up of other widgets, such as List or
+// ScrollContainer, to return the widgets they are made of. Walks over the whole widget tree, such as
+// Container.SetDisabledRecursive, use it to reach into composite widgets.
+type Containerer interface {
+	ContainedWidgets() []HasWidget
+}
+
+// InputUpdater may be implemented by concrete widget types that process buffered keyboard input, such as text
+// entry. If UI.ProcessInputInUpdate is set, UpdateInput is called in the Ebiten Update function, so that input is
+// not delayed or dropped when rendering is skipped or throttled.
+type InputUpdater interface {
+	UpdateInput()
+}
+
 // RenderFunc is a function that renders a widget onto screen. def may be called to defer
 // additional rendering.
 type RenderFunc func(screen *ebiten.Image, def DeferredRenderFunc)
@@ -150,6 +226,9 @@ type WidgetMouseButtonReleasedHandlerFunc func(args *WidgetMouseButtonReleasedEv
 // WidgetScrolledHandlerFunc is a function that handles mouse wheel scroll events.
 type WidgetScrolledHandlerFunc func(args *WidgetScrolledEventArgs) //nolint:golint
 
+// WidgetRenderHandlerFunc is a function that draws onto screen beneath or above widget w.
+type WidgetRenderHandlerFunc func(w *Widget, screen *ebiten.Image) //nolint:golint
+
 type WidgetOptions struct { //nolint:golint
 }
 
@@ -176,6 +255,13 @@ func NewWidget(opts ...WidgetOpt) *Widget {
 	return w
 }
 
+// ID configures a Widget with ID id.
+func (o WidgetOptions) ID(id string) WidgetOpt {
+	return func(w *Widget) {
+		w.ID = id
+	}
+}
+
 // WithLayoutData configures a Widget with layout data ld.
 func (o WidgetOptions) LayoutData(ld interface{}) WidgetOpt {
 	return func(w *Widget) {
@@ -183,6 +269,80 @@ func (o WidgetOptions) LayoutData(ld interface{}) WidgetOpt {
 	}
 }
 
+// Visibility configures a Widget with visibility v.
+func (o WidgetOptions) Visibility(v Visibility) WidgetOpt {
+	return func(w *Widget) {
+		w.Visibility = v
+	}
+}
+
+// Margin configures a Widget with margin i.
+func (o WidgetOptions) Margin(i Insets) WidgetOpt {
+	return func(w *Widget) {
+		w.Margin = i
+	}
+}
+
+// TabOrder configures a Widget with tab order to.
+func (o WidgetOptions) TabOrder(to int) WidgetOpt {
+	return func(w *Widget) {
+		w.TabOrder = to
+	}
+}
+
+// SkipFocus configures a Widget to be excluded from focus traversal.
+func (o WidgetOptions) SkipFocus() WidgetOpt {
+	return func(w *Widget) {
+		w.SkipFocus = true
+	}
+}
+
+// Nav configures a Widget to move focus to up, down, left, or right when navigating in the respective direction.
+// Any of them may be nil to use spatial navigation instead.
+func (o WidgetOptions) Nav(up HasWidget, down HasWidget, left HasWidget, right HasWidget) WidgetOpt {
+	return func(w *Widget) {
+		w.NavUp = up
+		w.NavDown = down
+		w.NavLeft = left
+		w.NavRight = right
+	}
+}
+
+// HitTest configures a Widget to use f to determine whether a position inside its Rect is actually inside
+// the widget, for example for irregularly shaped widgets.
+func (o WidgetOptions) HitTest(f WidgetHitTestFunc) WidgetOpt {
+	return func(w *Widget) {
+		w.hitTest = f
+	}
+}
+
+// HitTestAlphaMask configures a Widget to only consider positions inside of it where mask is not fully
+// transparent. mask is scaled to the size of the widget's Rect. mask is sampled once when the option is
+// applied, so later changes to mask have no effect.
+func (o WidgetOptions) HitTestAlphaMask(mask image.Image) WidgetOpt {
+	return func(w *Widget) {
+		b := mask.Bounds()
+
+		opaque := make([]bool, b.Dx()*b.Dy())
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				_, _, _, a := mask.At(x, y).RGBA()
+				opaque[(y-b.Min.Y)*b.Dx()+x-b.Min.X] = a > 0
+			}
+		}
+
+		w.hitTest = func(x int, y int) bool {
+			if w.Rect.Dx() <= 0 || w.Rect.Dy() <= 0 || len(opaque) == 0 {
+				return false
+			}
+
+			mx := x * b.Dx() / w.Rect.Dx()
+			my := y * b.Dy() / w.Rect.Dy()
+			return opaque[my*b.Dx()+mx]
+		}
+	}
+}
+
 // WithCursorEnterHandler configures a Widget with cursor enter event handler f.
 func (o WidgetOptions) CursorEnterHandler(f WidgetCursorEnterHandlerFunc) WidgetOpt {
 	return func(w *Widget) {
@@ -228,6 +388,20 @@ func (o WidgetOptions) ScrolledHandler(f WidgetScrolledHandlerFunc) WidgetOpt {
 	}
 }
 
+// PreRenderHandler configures a Widget to call f each frame before the widget is rendered, to draw beneath it.
+func (o WidgetOptions) PreRenderHandler(f WidgetRenderHandlerFunc) WidgetOpt {
+	return func(w *Widget) {
+		w.preRenderHandlers = append(w.preRenderHandlers, f)
+	}
+}
+
+// PostRenderHandler configures a Widget to call f each frame after the widget has been rendered, to draw above it.
+func (o WidgetOptions) PostRenderHandler(f WidgetRenderHandlerFunc) WidgetOpt {
+	return func(w *Widget) {
+		w.postRenderHandlers = append(w.postRenderHandlers, f)
+	}
+}
+
 func (w *Widget) drawImageOptions(opts *ebiten.DrawImageOptions) {
 	opts.GeoM.Translate(float64(w.Rect.Min.X), float64(w.Rect.Min.Y))
 }
@@ -256,14 +430,25 @@ func (w *Widget) EffectiveInputLayer() *input.Layer {
 // anything, but it is still responsible for firing events. Concrete widget implementations should
 // always call this method first before rendering themselves.
 func (w *Widget) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	stats.Widgets++
 	w.fireEvents()
 }
 
+// fireEvents fires w's input events, at most once per frame. If any event is fired, w's rendering is
+// invalidated, since handlers usually change w's visual state.
 func (w *Widget) fireEvents() {
+	if w.eventsFrame == renderFrame {
+		return
+	}
+
+	w.eventsFrame = renderFrame
+
+	fired := false
+
 	x, y := input.CursorPosition()
 	p := image.Point{x, y}
 	layer := w.EffectiveInputLayer()
-	inside := p.In(w.Rect)
+	inside := w.In(x, y)
 
 	entered := inside && layer.ActiveFor(x, y, input.LayerEventTypeAny)
 	if entered != w.lastUpdateCursorEntered {
@@ -278,11 +463,13 @@ func (w *Widget) fireEvents() {
 		}
 
 		w.lastUpdateCursorEntered = entered
+		fired = true
 	}
 
 	if inside && input.MouseButtonJustPressedLayer(ebiten.MouseButtonLeft, layer) {
 		w.lastUpdateMouseLeftPressed = true
 		w.mouseLeftPressedInside = inside
+		fired = true
 
 		off := p.Sub(w.Rect.Min)
 		w.MouseButtonPressedEvent.Fire(&WidgetMouseButtonPressedEventArgs{
@@ -295,6 +482,7 @@ func (w *Widget) fireEvents() {
 
 	if w.lastUpdateMouseLeftPressed && !input.MouseButtonPressedLayer(ebiten.MouseButtonLeft, layer) {
 		w.lastUpdateMouseLeftPressed = false
+		fired = true
 
 		off := p.Sub(w.Rect.Min)
 		w.MouseButtonReleasedEvent.Fire(&WidgetMouseButtonReleasedEventArgs{
@@ -308,12 +496,33 @@ func (w *Widget) fireEvents() {
 
 	scrollX, scrollY := input.WheelLayer(layer)
 	if inside && (scrollX != 0 || scrollY != 0) {
+		fired = true
 		w.ScrolledEvent.Fire(&WidgetScrolledEventArgs{
 			Widget: w,
 			X:      scrollX,
 			Y:      scrollY,
 		})
 	}
+
+	if fired {
+		w.InvalidateRender()
+	}
+}
+
+// In returns whether screen position x,y is inside w. If w has been configured with a hit test function,
+// it is used to further determine whether the position is inside w.
+func (w *Widget) In(x int, y int) bool {
+	p := image.Point{x, y}
+	if !p.In(w.Rect) {
+		return false
+	}
+
+	if w.hitTest == nil {
+		return true
+	}
+
+	p = p.Sub(w.Rect.Min)
+	return w.hitTest(p.X, p.Y)
 }
 
 // SetLocation sets w's position to rect. This is usually not called directly, but by a layout.
@@ -327,26 +536,185 @@ func (w *Widget) ElevateToNewInputLayer(l *input.Layer) {
 	w.inputLayer = l
 }
 
+// SetVisibility sets w's visibility to v. If w is collapsed or uncollapsed, w's ancestor containers
+// will layout their children again.
+func (w *Widget) SetVisibility(v Visibility) {
+	if v == w.Visibility {
+		return
+	}
+
+	collapsed := (v == VisibilityCollapsed) != (w.Visibility == VisibilityCollapsed)
+	w.Visibility = v
+
+	if collapsed {
+		w.requestParentRelayout()
+		return
+	}
+
+	w.InvalidateRender()
+}
+
+// Visible returns whether w is visible.
+func (w *Widget) Visible() bool {
+	return w.Visibility == VisibilityVisible
+}
+
 func (w *Widget) Parent() *Widget {
 	return w.parent
 }
 
+// InvalidateRender marks the rendering of w and all of its ancestors as outdated. This causes containers
+// that cache the rendering of their descendants to render them again. Widgets should call it whenever their
+// visual state changes while they are inside such a container.
+func (w *Widget) InvalidateRender() {
+	for ; w != nil; w = w.parent {
+		w.renderInvalidated = true
+	}
+}
+
+// requestParentRelayout causes all of w's ancestor containers to layout their children again, for example
+// because w's preferred size has changed.
+func (w *Widget) requestParentRelayout() {
+	for p := w.parent; p != nil; p = p.parent {
+		p.relayoutRequested = true
+	}
+
+	w.InvalidateRender()
+}
+
+// resourceReleaser may be implemented by widgets that hold resources such as buffered images, which are released
+// when the widgets are removed from their containers. Released resources must be created again when needed, so
+// that widgets can be added to a container again.
+type resourceReleaser interface {
+	releaseResources()
+}
+
+// detach removes w from its parent, resets the input state of w and all of its descendants, so that they
+// do not keep stale state such as a hovered or pressed state after being removed, and releases their resources.
+func detach(w HasWidget) {
+	wi := w.GetWidget()
+	wi.parent = nil
+	wi.addedStack = nil
+
+	release(w)
+	walkWidgetTree(w, release)
+}
+
+// release resets w's input state and releases its resources.
+func release(w HasWidget) {
+	w.GetWidget().resetInput()
+
+	if r, ok := w.(resourceReleaser); ok {
+		r.releaseResources()
+	}
+}
+
+// Dispose releases the resources of w and all of its descendants, including all handlers of their Widget events,
+// for example the handlers added using WidgetOpts.CursorEnterHandler. It should be called for widgets that have
+// been removed from their containers and are not used again. Widgets that may be added to a container again, for
+// example pages of a FlipBook, or widgets recycled using a WidgetPool, must not be disposed, since their handlers
+// cannot be restored.
+func Dispose(w HasWidget) {
+	dispose := func(d HasWidget) {
+		release(d)
+
+		dw := d.GetWidget()
+		dw.CursorEnterEvent.RemoveAllHandlers()
+		dw.CursorExitEvent.RemoveAllHandlers()
+		dw.MouseButtonPressedEvent.RemoveAllHandlers()
+		dw.MouseButtonReleasedEvent.RemoveAllHandlers()
+		dw.ScrolledEvent.RemoveAllHandlers()
+		dw.FocusEvent.RemoveAllHandlers()
+		dw.preRenderHandlers = nil
+		dw.postRenderHandlers = nil
+	}
+
+	dispose(w)
+	walkWidgetTree(w, dispose)
+}
+
+// resetInput resets w's input state. If the cursor was inside w, a cursor exit event is fired.
+func (w *Widget) resetInput() {
+	w.inputLayer = nil
+	w.lastUpdateMouseLeftPressed = false
+	w.mouseLeftPressedInside = false
+
+	if w.lastUpdateCursorEntered {
+		w.lastUpdateCursorEntered = false
+
+		w.CursorExitEvent.Fire(&WidgetCursorExitEventArgs{
+			Widget: w,
+		})
+	}
+}
+
 func WidgetFireFocusEvent(w *Widget, focused bool) { //nolint:golint
+	w.InvalidateRender()
+
 	w.FocusEvent.Fire(&WidgetFocusEventArgs{
 		Widget:  w,
 		Focused: focused,
 	})
 }
 
+// renderFrame counts calls to RenderWithDeferred, so that widgets fire their events at most once per frame.
+var renderFrame = 1
+
 // RenderWithDeferred renders r to screen. This function should not be called directly.
 func RenderWithDeferred(screen *ebiten.Image, rs []Renderer) {
+	renderFrame++
+
 	for _, r := range rs {
-		appendToDeferredRenderQueue(r.Render)
+		r := r
+		appendToDeferredRenderQueue(func(screen *ebiten.Image, def DeferredRenderFunc) {
+			renderWithHandlers(r, screen, def)
+		})
 	}
 
 	renderDeferredRenderQueue(screen)
 }
 
+// UpdateInput calls UpdateInput on all InputUpdaters in the widget trees roots. This function should not be
+// called directly.
+func UpdateInput(roots ...Locater) {
+	for _, r := range roots {
+		if u, ok := r.(InputUpdater); ok {
+			u.UpdateInput()
+		}
+
+		walkDescendants(r, func(w HasWidget) {
+			if u, ok := w.(InputUpdater); ok {
+				u.UpdateInput()
+			}
+		})
+	}
+}
+
+// renderWithHandlers renders r, calling the pre- and post-render handlers of r's widget if r has a widget.
+func renderWithHandlers(r Renderer, screen *ebiten.Image, def DeferredRenderFunc) {
+	hw, ok := r.(HasWidget)
+	if !ok {
+		r.Render(screen, def)
+		return
+	}
+
+	w := hw.GetWidget()
+
+	for _, h := range w.preRenderHandlers {
+		h(w, screen)
+	}
+
+	if w.focusIndicator != nil {
+		w.focusIndicator.draw(screen)
+	}
+
+	r.Render(screen, def)
+
+	for _, h := range w.postRenderHandlers {
+		h(w, screen)
+	}
+}
+
 func renderDeferredRenderQueue(screen *ebiten.Image) {
 	defer func(d []RenderFunc) {
 		deferredRenders = d[:0]
@@ -0,0 +1,66 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/matryer/is"
+)
+
+func TestWidget_In_HitTest(t *testing.T) {
+	is := is.New(t)
+
+	w := NewWidget(WidgetOpts.HitTest(func(x int, y int) bool {
+		return x < 5
+	}))
+	w.Rect = image.Rect(10, 10, 20, 20)
+
+	is.True(w.In(12, 12))
+	is.True(!w.In(17, 12))
+	is.True(!w.In(5, 5))
+}
+
+func TestWidget_In_HitTestAlphaMask(t *testing.T) {
+	is := is.New(t)
+
+	mask := image.NewAlpha(image.Rect(0, 0, 2, 2))
+	mask.SetAlpha(0, 0, color.Alpha{255})
+
+	w := NewWidget(WidgetOpts.HitTestAlphaMask(mask))
+	w.Rect = image.Rect(10, 10, 30, 30)
+
+	is.True(w.In(15, 15))
+	is.True(!w.In(25, 15))
+	is.True(!w.In(15, 25))
+
+	// the mask has been sampled already
+	mask.SetAlpha(1, 0, color.Alpha{255})
+	is.True(!w.In(25, 15))
+}
+
+func TestWidget_RenderHandlers(t *testing.T) {
+	is := is.New(t)
+
+	calls := []string{}
+
+	g := NewGraphic(GraphicOpts.WidgetOpts(
+		WidgetOpts.PreRenderHandler(func(w *Widget, screen *ebiten.Image) {
+			calls = append(calls, "pre")
+		}),
+		WidgetOpts.PostRenderHandler(func(w *Widget, screen *ebiten.Image) {
+			calls = append(calls, "post")
+		}),
+	))
+
+	c := newContainer(t,
+		ContainerOpts.WidgetOpts(WidgetOpts.PostRenderHandler(func(w *Widget, screen *ebiten.Image) {
+			calls = append(calls, "container post")
+		})))
+	c.AddChild(g)
+
+	render(c, t)
+
+	is.Equal(calls, []string{"pre", "post", "container post"})
+}
@@ -2,7 +2,9 @@ package widget
 
 import (
 	"image"
+	"math"
 
+	"github.com/blizzy78/ebitenui/event"
 	"github.com/blizzy78/ebitenui/input"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -10,23 +12,115 @@ import (
 type Window struct {
 	Modal bool
 
-	contents *Container
+	// CloseOnBack specifies that the UI removes the window when the cancel action is pressed, and no widget
+	// has handled it.
+	CloseOnBack bool
+
+	DockedEvent          *event.Event
+	UndockedEvent        *event.Event
+	GeometryChangedEvent *event.Event
+
+	contents      *Container
+	draggable     bool
+	dragHandle    HasWidget
+	snapThreshold int
+	dockZones     []*WindowDockZone
+	snapTargets   WindowSnapTargetsFunc
+	minSize       image.Point
+	maxSize       image.Point
+	aspectRatio   float64
+	placement     WindowPlacement
+	placementRect image.Rectangle
+	placed        bool
+
+	dragging     bool
+	dragOffset   image.Point
+	screenRect   image.Rectangle
+	dockedZone   *WindowDockZone
+	undockedSize image.Point
 }
 
 type WindowOpt func(w *Window)
 
+// WindowPlacement specifies how a Window is initially placed on screen.
+type WindowPlacement int
+
+const (
+	// WindowPlacementNone specifies that a Window is not placed automatically, but by calling SetLocation.
+	WindowPlacementNone = WindowPlacement(iota)
+
+	// WindowPlacementCentered specifies that a Window is placed centered on screen, using its preferred size.
+	WindowPlacementCentered
+
+	// WindowPlacementCascade specifies that a Window is placed offset from the most recently added other window,
+	// using its preferred size.
+	WindowPlacementCascade
+
+	// WindowPlacementRemembered specifies that a Window is placed at a previously remembered position and size.
+	WindowPlacementRemembered
+)
+
+const windowCascadeOffset = 24
+
 type WindowOptions struct {
 }
 
+// WindowDockZone is an area that a dragged Window docks into when it is dropped while the cursor is inside the area.
+type WindowDockZone struct {
+	// Rect is the area of the dock zone. A docked Window is resized to fill Rect.
+	Rect image.Rectangle
+}
+
+// WindowSnapTargetsFunc is a function that returns the rectangles of other windows that a dragged Window snaps to.
+type WindowSnapTargetsFunc func() []image.Rectangle
+
+type WindowDockedEventArgs struct {
+	Window *Window
+	Zone   *WindowDockZone
+}
+
+type WindowUndockedEventArgs struct {
+	Window *Window
+	Zone   *WindowDockZone
+}
+
+type WindowGeometryChangedEventArgs struct {
+	Window *Window
+	Rect   image.Rectangle
+}
+
+type WindowDockedHandlerFunc func(args *WindowDockedEventArgs)
+
+type WindowUndockedHandlerFunc func(args *WindowUndockedEventArgs)
+
+type WindowGeometryChangedHandlerFunc func(args *WindowGeometryChangedEventArgs)
+
 var WindowOpts WindowOptions
 
 func NewWindow(opts ...WindowOpt) *Window {
-	w := &Window{}
+	w := &Window{
+		DockedEvent:          &event.Event{},
+		UndockedEvent:        &event.Event{},
+		GeometryChangedEvent: &event.Event{},
+	}
 
 	for _, o := range opts {
 		o(w)
 	}
 
+	if w.draggable {
+		h := w.dragHandle
+		if h == nil {
+			h = w.contents
+		}
+
+		h.GetWidget().MouseButtonPressedEvent.AddHandler(func(args interface{}) {
+			if a := args.(*WidgetMouseButtonPressedEventArgs); a.Button == ebiten.MouseButtonLeft {
+				w.startDrag()
+			}
+		})
+	}
+
 	return w
 }
 
@@ -42,14 +136,137 @@ func (o WindowOptions) Modal() WindowOpt {
 	}
 }
 
+// CloseOnBack configures a Window to be removed by the UI when the cancel action is pressed, for example Escape.
+func (o WindowOptions) CloseOnBack() WindowOpt {
+	return func(w *Window) {
+		w.CloseOnBack = true
+	}
+}
+
+// Draggable configures a Window to be draggable using the mouse by pressing on handle. If handle is nil,
+// the Window can be dragged by pressing anywhere on its contents.
+func (o WindowOptions) Draggable(handle HasWidget) WindowOpt {
+	return func(w *Window) {
+		w.draggable = true
+		w.dragHandle = handle
+	}
+}
+
+// Snap configures a dragged Window to snap to the edges and corners of the screen, and to the edges of
+// other windows, when they are within threshold pixels.
+func (o WindowOptions) Snap(threshold int) WindowOpt {
+	return func(w *Window) {
+		w.snapThreshold = threshold
+	}
+}
+
+// DockZones configures a dragged Window to dock into zones when dropped onto them.
+func (o WindowOptions) DockZones(zones ...*WindowDockZone) WindowOpt {
+	return func(w *Window) {
+		w.dockZones = append(w.dockZones, zones...)
+	}
+}
+
+// MinSize configures a Window to be at least width x height pixels in size.
+func (o WindowOptions) MinSize(width int, height int) WindowOpt {
+	return func(w *Window) {
+		w.minSize = image.Point{width, height}
+	}
+}
+
+// MaxSize configures a Window to be at most width x height pixels in size. A width or height of 0 means
+// no maximum.
+func (o WindowOptions) MaxSize(width int, height int) WindowOpt {
+	return func(w *Window) {
+		w.maxSize = image.Point{width, height}
+	}
+}
+
+// LockAspectRatio configures a Window to keep the ratio of width to height at ratio, by adjusting its height,
+// or its width if the adjusted height would violate the minimum or maximum size.
+func (o WindowOptions) LockAspectRatio(ratio float64) WindowOpt {
+	return func(w *Window) {
+		w.aspectRatio = ratio
+	}
+}
+
+// Placement configures a Window to be placed on screen initially according to p. For WindowPlacementRemembered,
+// rect specifies the remembered position and size, for example as reported by a previous geometry changed event.
+// For other placements, rect is ignored.
+func (o WindowOptions) Placement(p WindowPlacement, rect image.Rectangle) WindowOpt {
+	return func(w *Window) {
+		w.placement = p
+		w.placementRect = rect
+	}
+}
+
+func (o WindowOptions) DockedHandler(f WindowDockedHandlerFunc) WindowOpt {
+	return func(w *Window) {
+		w.DockedEvent.AddHandler(func(args interface{}) {
+			f(args.(*WindowDockedEventArgs))
+		})
+	}
+}
+
+func (o WindowOptions) UndockedHandler(f WindowUndockedHandlerFunc) WindowOpt {
+	return func(w *Window) {
+		w.UndockedEvent.AddHandler(func(args interface{}) {
+			f(args.(*WindowUndockedEventArgs))
+		})
+	}
+}
+
+func (o WindowOptions) GeometryChangedHandler(f WindowGeometryChangedHandlerFunc) WindowOpt {
+	return func(w *Window) {
+		w.GeometryChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*WindowGeometryChangedEventArgs))
+		})
+	}
+}
+
+func (w *Window) GetWidget() *Widget {
+	return w.contents.GetWidget()
+}
+
+// SetLocation sets w's position and size to rect, constrained by w's minimum and maximum size and its
+// aspect ratio.
 func (w *Window) SetLocation(rect image.Rectangle) {
-	w.contents.SetLocation(rect)
+	w.placed = true
+	w.move(rect)
 }
 
 func (w *Window) RequestRelayout() {
 	w.contents.RequestRelayout()
 }
 
+// WidgetAt implements Locater.
+func (w *Window) WidgetAt(x int, y int) HasWidget {
+	return w.contents.WidgetAt(x, y)
+}
+
+// FocusOrder implements FocusContainer.
+func (w *Window) FocusOrder() []HasWidget {
+	return w.contents.FocusOrder()
+}
+
+// SetDisabledRecursive disables or enables the contents of w and all of their descendants. See
+// Container.SetDisabledRecursive.
+func (w *Window) SetDisabledRecursive(disabled bool) {
+	w.contents.SetDisabledRecursive(disabled)
+}
+
+// SetSnapTargetsFunc sets the function that returns the rectangles of other windows that w snaps to while
+// being dragged, and that w is placed relative to when using WindowPlacementCascade. It is usually not called
+// directly, but by the UI.
+func (w *Window) SetSnapTargetsFunc(f WindowSnapTargetsFunc) {
+	w.snapTargets = f
+}
+
+// DockedZone returns the dock zone w is currently docked into, or nil.
+func (w *Window) DockedZone() *WindowDockZone {
+	return w.dockedZone
+}
+
 func (w *Window) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 	if w.Modal {
 		w.contents.GetWidget().ElevateToNewInputLayer(&input.Layer{
@@ -62,5 +279,214 @@ func (w *Window) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
 }
 
 func (w *Window) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	w.screenRect = screen.Bounds()
+
+	if !w.placed {
+		w.place()
+	}
+
+	if w.dragging {
+		w.updateDrag()
+	}
+
 	w.contents.Render(screen, def)
 }
+
+func (w *Window) startDrag() {
+	x, y := input.CursorPosition()
+	r := w.contents.GetWidget().Rect
+
+	w.dragging = true
+	w.dragOffset = image.Point{x, y}.Sub(r.Min)
+
+	if w.dockedZone == nil {
+		return
+	}
+
+	z := w.dockedZone
+	w.dockedZone = nil
+
+	if w.dragOffset.X >= w.undockedSize.X {
+		w.dragOffset.X = w.undockedSize.X / 2
+	}
+	if w.dragOffset.Y >= w.undockedSize.Y {
+		w.dragOffset.Y = w.undockedSize.Y / 2
+	}
+
+	w.move(image.Rectangle{r.Min, r.Min.Add(w.undockedSize)})
+
+	w.UndockedEvent.Fire(&WindowUndockedEventArgs{
+		Window: w,
+		Zone:   z,
+	})
+}
+
+func (w *Window) updateDrag() {
+	x, y := input.CursorPosition()
+	p := image.Point{x, y}
+
+	if !input.MouseButtonPressed(ebiten.MouseButtonLeft) {
+		w.dragging = false
+		w.drop(p)
+		return
+	}
+
+	r := w.contents.GetWidget().Rect
+	r = r.Add(p.Sub(w.dragOffset).Sub(r.Min))
+
+	if w.snapThreshold > 0 {
+		var others []image.Rectangle
+		if w.snapTargets != nil {
+			others = w.snapTargets()
+		}
+		r = snapRect(r, w.screenRect, others, w.snapThreshold)
+	}
+
+	w.move(r)
+}
+
+func (w *Window) drop(p image.Point) {
+	for _, z := range w.dockZones {
+		if !p.In(z.Rect) {
+			continue
+		}
+
+		w.undockedSize = w.contents.GetWidget().Rect.Size()
+		w.dockedZone = z
+		w.move(z.Rect)
+
+		w.DockedEvent.Fire(&WindowDockedEventArgs{
+			Window: w,
+			Zone:   z,
+		})
+
+		return
+	}
+}
+
+func (w *Window) place() {
+	w.placed = true
+
+	if w.placement == WindowPlacementNone {
+		return
+	}
+
+	if w.placement == WindowPlacementRemembered {
+		w.move(w.placementRect)
+		return
+	}
+
+	pw, ph := w.contents.PreferredSize()
+	size := w.constrainSize(image.Point{pw, ph})
+
+	var p image.Point
+
+	switch w.placement {
+	case WindowPlacementCentered:
+		p = w.screenRect.Min.Add(w.screenRect.Size().Sub(size).Div(2))
+
+	case WindowPlacementCascade:
+		p = w.screenRect.Min
+		if w.snapTargets != nil {
+			if others := w.snapTargets(); len(others) > 0 {
+				p = others[len(others)-1].Min
+			}
+		}
+		p = p.Add(image.Point{windowCascadeOffset, windowCascadeOffset})
+	}
+
+	w.move(image.Rectangle{p, p.Add(size)})
+}
+
+// move sets w's position and size to r, constrained by w's minimum and maximum size and its aspect ratio.
+func (w *Window) move(r image.Rectangle) {
+	r.Max = r.Min.Add(w.constrainSize(r.Size()))
+
+	if r == w.contents.GetWidget().Rect {
+		return
+	}
+
+	w.contents.SetLocation(r)
+	w.contents.RequestRelayout()
+
+	w.GeometryChangedEvent.Fire(&WindowGeometryChangedEventArgs{
+		Window: w,
+		Rect:   r,
+	})
+}
+
+func (w *Window) constrainSize(s image.Point) image.Point {
+	clamp := func(v int, min int, max int) int {
+		if max > 0 && v > max {
+			v = max
+		}
+		if v < min {
+			v = min
+		}
+		return v
+	}
+
+	s.X = clamp(s.X, w.minSize.X, w.maxSize.X)
+	s.Y = clamp(s.Y, w.minSize.Y, w.maxSize.Y)
+
+	if w.aspectRatio > 0 {
+		s.Y = int(math.Round(float64(s.X) / w.aspectRatio))
+
+		if y := clamp(s.Y, w.minSize.Y, w.maxSize.Y); y != s.Y {
+			s.Y = y
+			s.X = clamp(int(math.Round(float64(s.Y)*w.aspectRatio)), w.minSize.X, w.maxSize.X)
+		}
+	}
+
+	return s
+}
+
+// snapRect returns r moved so that its edges snap to the edges of bounds, or to the edges of others,
+// if they are within threshold pixels. r does not snap to the edges of a rectangle in others that it
+// is not next to.
+func snapRect(r image.Rectangle, bounds image.Rectangle, others []image.Rectangle, threshold int) image.Rectangle {
+	dx, dy := threshold+1, threshold+1
+
+	snap := func(d *int, from int, to int) {
+		if v := to - from; abs(v) < abs(*d) {
+			*d = v
+		}
+	}
+
+	snap(&dx, r.Min.X, bounds.Min.X)
+	snap(&dx, r.Max.X, bounds.Max.X)
+	snap(&dy, r.Min.Y, bounds.Min.Y)
+	snap(&dy, r.Max.Y, bounds.Max.Y)
+
+	for _, o := range others {
+		if r.Min.Y <= o.Max.Y+threshold && r.Max.Y >= o.Min.Y-threshold {
+			snap(&dx, r.Min.X, o.Max.X)
+			snap(&dx, r.Max.X, o.Min.X)
+			snap(&dx, r.Min.X, o.Min.X)
+			snap(&dx, r.Max.X, o.Max.X)
+		}
+
+		if r.Min.X <= o.Max.X+threshold && r.Max.X >= o.Min.X-threshold {
+			snap(&dy, r.Min.Y, o.Max.Y)
+			snap(&dy, r.Max.Y, o.Min.Y)
+			snap(&dy, r.Min.Y, o.Min.Y)
+			snap(&dy, r.Max.Y, o.Max.Y)
+		}
+	}
+
+	if abs(dx) > threshold {
+		dx = 0
+	}
+	if abs(dy) > threshold {
+		dy = 0
+	}
+
+	return r.Add(image.Point{dx, dy})
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
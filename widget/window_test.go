@@ -0,0 +1,136 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+	internalinput "github.com/blizzy78/ebitenui/internal/input"
+
+	"github.com/matryer/is"
+)
+
+func TestWindow_Drag(t *testing.T) {
+	is := is.New(t)
+
+	var dockedArgs *WindowDockedEventArgs
+	var undockedArgs *WindowUndockedEventArgs
+
+	zone := &WindowDockZone{
+		Rect: image.Rect(200, 0, 300, 300),
+	}
+
+	c := newContainer(t)
+	w := newWindow(t,
+		WindowOpts.Contents(c),
+		WindowOpts.Draggable(nil),
+		WindowOpts.DockZones(zone),
+		WindowOpts.DockedHandler(func(args *WindowDockedEventArgs) {
+			dockedArgs = args
+		}),
+		WindowOpts.UndockedHandler(func(args *WindowUndockedEventArgs) {
+			undockedArgs = args
+		}))
+
+	w.SetLocation(image.Rect(10, 10, 60, 60))
+
+	defer func() {
+		internalinput.LeftMouseButtonPressed = false
+		internalinput.CursorX, internalinput.CursorY = 0, 0
+	}()
+
+	internalinput.CursorX, internalinput.CursorY = 20, 20
+	leftMouseButtonPress(c, t)
+
+	internalinput.LeftMouseButtonPressed = true
+	internalinput.CursorX = 120
+	render(w, t)
+
+	is.Equal(c.GetWidget().Rect, image.Rect(110, 10, 160, 60))
+
+	internalinput.LeftMouseButtonPressed = false
+	internalinput.CursorX = 250
+	render(w, t)
+
+	is.Equal(c.GetWidget().Rect, zone.Rect)
+	is.Equal(w.DockedZone(), zone)
+	is.True(dockedArgs != nil)
+
+	leftMouseButtonPress(c, t)
+
+	is.Equal(c.GetWidget().Rect.Size(), image.Point{50, 50})
+	is.True(w.DockedZone() == nil)
+	is.True(undockedArgs != nil)
+}
+
+func TestWindow_SetLocation_Constraints(t *testing.T) {
+	is := is.New(t)
+
+	var eventArgs *WindowGeometryChangedEventArgs
+
+	c := newContainer(t)
+	w := newWindow(t,
+		WindowOpts.Contents(c),
+		WindowOpts.MinSize(20, 20),
+		WindowOpts.MaxSize(100, 0),
+		WindowOpts.LockAspectRatio(2),
+		WindowOpts.GeometryChangedHandler(func(args *WindowGeometryChangedEventArgs) {
+			eventArgs = args
+		}))
+
+	w.SetLocation(image.Rect(10, 10, 210, 50))
+	event.ExecuteDeferred()
+
+	is.Equal(c.GetWidget().Rect, image.Rect(10, 10, 110, 60))
+	is.Equal(eventArgs.Rect, image.Rect(10, 10, 110, 60))
+
+	w.SetLocation(image.Rect(10, 10, 12, 12))
+	is.Equal(c.GetWidget().Rect, image.Rect(10, 10, 50, 30))
+}
+
+func TestWindow_Placement(t *testing.T) {
+	is := is.New(t)
+
+	c := newContainer(t)
+	w := newWindow(t,
+		WindowOpts.Contents(c),
+		WindowOpts.Placement(WindowPlacementRemembered, image.Rect(5, 6, 70, 80)))
+
+	render(w, t)
+
+	is.Equal(c.GetWidget().Rect, image.Rect(5, 6, 70, 80))
+
+	c = newContainer(t)
+	w = newWindow(t,
+		WindowOpts.Contents(c),
+		WindowOpts.Placement(WindowPlacementCascade, image.Rectangle{}))
+	w.SetSnapTargetsFunc(func() []image.Rectangle {
+		return []image.Rectangle{image.Rect(100, 100, 200, 200)}
+	})
+
+	render(w, t)
+
+	is.Equal(c.GetWidget().Rect.Min, image.Point{100 + windowCascadeOffset, 100 + windowCascadeOffset})
+}
+
+func TestSnapRect(t *testing.T) {
+	is := is.New(t)
+
+	bounds := image.Rect(0, 0, 500, 500)
+	others := []image.Rectangle{
+		image.Rect(200, 200, 300, 300),
+	}
+
+	is.Equal(snapRect(image.Rect(3, 4, 53, 54), bounds, others, 5), image.Rect(0, 0, 50, 50))
+	is.Equal(snapRect(image.Rect(446, 447, 496, 497), bounds, others, 5), image.Rect(450, 450, 500, 500))
+	is.Equal(snapRect(image.Rect(303, 210, 353, 260), bounds, others, 5), image.Rect(300, 210, 350, 260))
+	is.Equal(snapRect(image.Rect(100, 100, 150, 150), bounds, others, 5), image.Rect(100, 100, 150, 150))
+}
+
+func newWindow(t *testing.T, opts ...WindowOpt) *Window {
+	t.Helper()
+
+	w := NewWindow(opts...)
+	event.ExecuteDeferred()
+	return w
+}
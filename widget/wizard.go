@@ -0,0 +1,430 @@
+package widget
+
+import (
+	img "image"
+	"image/color"
+
+	"github.com/blizzy78/ebitenui/event"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// Wizard shows a sequence of steps one at a time, with buttons to go back to the previous step, and to go
+// on to the next step or finish on the last step, such as for character creation or first-run setup. Each
+// step may validate its input before the Wizard moves past it. An optional progress indicator shows the
+// titles of all steps, highlighting the current one.
+type Wizard struct {
+	// StepChangedEvent fires an event with *WizardStepChangedEventArgs when the current step has changed.
+	StepChangedEvent *event.Event
+
+	// FinishedEvent fires an event with *WizardFinishedEventArgs when the last step has been finished.
+	FinishedEvent *event.Event
+
+	containerOpts []ContainerOpt
+	flipBookOpts  []FlipBookOpt
+	buttonOpts    []ButtonOpt
+	steps         []*WizardStep
+	buttonFace    font.Face
+	buttonColor   *ButtonTextColor
+	backLabel     string
+	nextLabel     string
+	finishLabel   string
+	progressFace  font.Face
+	progressColor *WizardProgressColor
+	spacing       int
+
+	init        *MultiOnce
+	container   *Container
+	progress    []*Text
+	flipBook    *FlipBook
+	backButton  *Button
+	nextButton  *Button
+	step        int
+	finishShown bool
+	stepShown   bool
+}
+
+// WizardStep is a step in a Wizard.
+type WizardStep struct {
+	// Title is the title of the step, shown in the progress indicator.
+	Title string
+
+	// Widget is the widget shown while the step is current.
+	Widget PreferredSizeLocateableWidget
+
+	// Validate is called to determine whether the Wizard may move past the step. If nil, the step is
+	// always valid.
+	Validate WizardStepValidateFunc
+}
+
+// WizardStepValidateFunc is a function that returns whether a step of a Wizard is complete.
+type WizardStepValidateFunc func() bool
+
+// WizardProgressColor specifies the colors of step titles in the progress indicator of a Wizard.
+type WizardProgressColor struct {
+	Done     color.Color
+	Current  color.Color
+	Upcoming color.Color
+}
+
+type WizardOpt func(w *Wizard)
+
+type WizardStepChangedEventArgs struct {
+	Wizard        *Wizard
+	Step          *WizardStep
+	Index         int
+	PreviousStep  *WizardStep
+	PreviousIndex int
+}
+
+type WizardStepChangedHandlerFunc func(args *WizardStepChangedEventArgs)
+
+type WizardFinishedEventArgs struct {
+	Wizard *Wizard
+}
+
+type WizardFinishedHandlerFunc func(args *WizardFinishedEventArgs)
+
+type WizardOptions struct {
+}
+
+var WizardOpts WizardOptions
+
+func NewWizard(opts ...WizardOpt) *Wizard {
+	w := &Wizard{
+		StepChangedEvent: &event.Event{},
+		FinishedEvent:    &event.Event{},
+
+		backLabel:   "Back",
+		nextLabel:   "Next",
+		finishLabel: "Finish",
+
+		init: &MultiOnce{},
+	}
+
+	w.init.Append(w.createWidget)
+
+	for _, o := range opts {
+		o(w)
+	}
+
+	return w
+}
+
+func (o WizardOptions) ContainerOpts(opts ...ContainerOpt) WizardOpt {
+	return func(w *Wizard) {
+		w.containerOpts = append(w.containerOpts, opts...)
+	}
+}
+
+func (o WizardOptions) FlipBookOpts(opts ...FlipBookOpt) WizardOpt {
+	return func(w *Wizard) {
+		w.flipBookOpts = append(w.flipBookOpts, opts...)
+	}
+}
+
+// ButtonOpts configures a Wizard to construct its back and next buttons using opts.
+func (o WizardOptions) ButtonOpts(opts ...ButtonOpt) WizardOpt {
+	return func(w *Wizard) {
+		w.buttonOpts = append(w.buttonOpts, opts...)
+	}
+}
+
+// ButtonText configures a Wizard to label its buttons using face and color c.
+func (o WizardOptions) ButtonText(face font.Face, c *ButtonTextColor) WizardOpt {
+	return func(w *Wizard) {
+		w.buttonFace = face
+		w.buttonColor = c
+	}
+}
+
+// ButtonLabels configures a Wizard to use the labels back, next, and finish for its buttons. The defaults
+// are "Back", "Next", and "Finish".
+func (o WizardOptions) ButtonLabels(back string, next string, finish string) WizardOpt {
+	return func(w *Wizard) {
+		w.backLabel = back
+		w.nextLabel = next
+		w.finishLabel = finish
+	}
+}
+
+func (o WizardOptions) Steps(s ...*WizardStep) WizardOpt {
+	return func(w *Wizard) {
+		w.steps = s
+	}
+}
+
+// Progress configures a Wizard to show a progress indicator above the current step, which shows the titles
+// of all steps using face and colors c.
+func (o WizardOptions) Progress(face font.Face, c *WizardProgressColor) WizardOpt {
+	return func(w *Wizard) {
+		w.progressFace = face
+		w.progressColor = c
+	}
+}
+
+func (o WizardOptions) Spacing(s int) WizardOpt {
+	return func(w *Wizard) {
+		w.spacing = s
+	}
+}
+
+func (o WizardOptions) StepChangedHandler(f WizardStepChangedHandlerFunc) WizardOpt {
+	return func(w *Wizard) {
+		w.StepChangedEvent.AddHandler(func(args interface{}) {
+			f(args.(*WizardStepChangedEventArgs))
+		})
+	}
+}
+
+func (o WizardOptions) FinishedHandler(f WizardFinishedHandlerFunc) WizardOpt {
+	return func(w *Wizard) {
+		w.FinishedEvent.AddHandler(func(args interface{}) {
+			f(args.(*WizardFinishedEventArgs))
+		})
+	}
+}
+
+func (w *Wizard) GetWidget() *Widget {
+	w.init.Do()
+	return w.container.GetWidget()
+}
+
+// ContainedWidgets implements Containerer.
+func (w *Wizard) ContainedWidgets() []HasWidget {
+	w.init.Do()
+	return containedWidgets(w.container)
+}
+
+func (w *Wizard) PreferredSize() (int, int) {
+	w.init.Do()
+	return w.container.PreferredSize()
+}
+
+func (w *Wizard) SetLocation(rect img.Rectangle) {
+	w.init.Do()
+	w.container.SetLocation(rect)
+}
+
+func (w *Wizard) RequestRelayout() {
+	w.init.Do()
+	w.container.RequestRelayout()
+}
+
+// FocusOrder implements FocusContainer.
+func (w *Wizard) FocusOrder() []HasWidget {
+	w.init.Do()
+	return w.container.FocusOrder()
+}
+
+func (w *Wizard) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	w.init.Do()
+	w.container.SetupInputLayer(def)
+}
+
+func (w *Wizard) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	w.init.Do()
+
+	d := w.container.GetWidget().Disabled
+	w.backButton.GetWidget().Disabled = d || w.step == 0
+	w.nextButton.GetWidget().Disabled = d || !w.StepValid()
+
+	w.container.Render(screen, def)
+}
+
+// Steps returns the steps of w.
+func (w *Wizard) Steps() []*WizardStep {
+	return w.steps
+}
+
+// Step returns the current step.
+func (w *Wizard) Step() *WizardStep {
+	return w.steps[w.step]
+}
+
+// StepIndex returns the index of the current step.
+func (w *Wizard) StepIndex() int {
+	return w.step
+}
+
+// StepValid returns whether the current step is valid, so that the Wizard may move past it.
+func (w *Wizard) StepValid() bool {
+	return w.valid(w.step)
+}
+
+func (w *Wizard) valid(i int) bool {
+	v := w.steps[i].Validate
+	return v == nil || v()
+}
+
+// Next moves to the next step, or fires FinishedEvent if the current step is the last step. It does nothing
+// if the current step is not valid. It returns whether the current step was valid.
+func (w *Wizard) Next() bool {
+	w.init.Do()
+
+	if !w.StepValid() {
+		return false
+	}
+
+	if w.step == len(w.steps)-1 {
+		w.FinishedEvent.Fire(&WizardFinishedEventArgs{
+			Wizard: w,
+		})
+		return true
+	}
+
+	w.setStep(w.step + 1)
+	return true
+}
+
+// Back moves to the previous step. It does nothing if the current step is the first step.
+func (w *Wizard) Back() {
+	w.init.Do()
+
+	if w.step > 0 {
+		w.setStep(w.step - 1)
+	}
+}
+
+// SetStepIndex moves to the step at index i. Moving forward skips over the steps in between, which must
+// all be valid. It returns whether the step at index i is now current.
+func (w *Wizard) SetStepIndex(i int) bool {
+	w.init.Do()
+
+	if i < 0 || i >= len(w.steps) {
+		return false
+	}
+
+	for j := w.step; j < i; j++ {
+		if !w.valid(j) {
+			return false
+		}
+	}
+
+	w.setStep(i)
+	return true
+}
+
+func (w *Wizard) setStep(i int) {
+	if i == w.step && w.stepShown {
+		return
+	}
+
+	prev := w.step
+	w.step = i
+	w.stepShown = true
+
+	w.flipBook.SetPage(w.steps[i].Widget)
+	w.updateProgress()
+
+	if finish := i == len(w.steps)-1; finish != w.finishShown {
+		w.finishShown = finish
+
+		label := w.nextLabel
+		if finish {
+			label = w.finishLabel
+		}
+		w.nextButton.Text().Label = label
+	}
+
+	w.container.RequestRelayout()
+
+	if prev == i {
+		return
+	}
+
+	w.StepChangedEvent.Fire(&WizardStepChangedEventArgs{
+		Wizard:        w,
+		Step:          w.steps[i],
+		Index:         i,
+		PreviousStep:  w.steps[prev],
+		PreviousIndex: prev,
+	})
+}
+
+// updateProgress updates the colors of the step titles in the progress indicator.
+func (w *Wizard) updateProgress() {
+	for i, t := range w.progress {
+		switch {
+		case i < w.step:
+			t.Color = w.progressColor.Done
+		case i == w.step:
+			t.Color = w.progressColor.Current
+		default:
+			t.Color = w.progressColor.Upcoming
+		}
+	}
+}
+
+func (w *Wizard) createWidget() {
+	if len(w.steps) == 0 {
+		panic("wizard has no steps")
+	}
+
+	rowStretch := []bool{true, false}
+	if w.progressFace != nil {
+		rowStretch = []bool{false, true, false}
+	}
+
+	w.container = NewContainer(append(w.containerOpts, []ContainerOpt{
+		ContainerOpts.Layout(NewGridLayout(
+			GridLayoutOpts.Columns(1),
+			GridLayoutOpts.Stretch([]bool{true}, rowStretch),
+			GridLayoutOpts.Spacing(0, w.spacing))),
+		ContainerOpts.AutoDisableChildren(),
+	}...)...)
+	w.containerOpts = nil
+
+	if w.progressFace != nil {
+		progress := NewContainer(ContainerOpts.Layout(NewRowLayout(
+			RowLayoutOpts.Spacing(w.spacing))))
+
+		w.progress = make([]*Text, len(w.steps))
+		for i, s := range w.steps {
+			w.progress[i] = NewText(TextOpts.Text(s.Title, w.progressFace, w.progressColor.Upcoming))
+			progress.AddChild(w.progress[i])
+		}
+
+		w.container.AddChild(progress)
+	}
+
+	w.flipBook = NewFlipBook(append(w.flipBookOpts,
+		FlipBookOpts.ContainerOpts(ContainerOpts.AutoDisableChildren()))...)
+	w.flipBookOpts = nil
+	w.container.AddChild(w.flipBook)
+
+	buttons := NewContainer(ContainerOpts.Layout(NewGridLayout(
+		GridLayoutOpts.Columns(2),
+		GridLayoutOpts.Stretch([]bool{true, true}, []bool{false}))))
+
+	backOpts := append([]ButtonOpt(nil), w.buttonOpts...)
+	w.backButton = NewButton(append(backOpts, []ButtonOpt{
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(GridLayoutData{
+			HorizontalPosition: GridLayoutPositionStart,
+		})),
+		ButtonOpts.Text(w.backLabel, w.buttonFace, w.buttonColor),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			w.Back()
+		}),
+	}...)...)
+	buttons.AddChild(w.backButton)
+
+	nextOpts := append([]ButtonOpt(nil), w.buttonOpts...)
+	w.nextButton = NewButton(append(nextOpts, []ButtonOpt{
+		ButtonOpts.WidgetOpts(WidgetOpts.LayoutData(GridLayoutData{
+			HorizontalPosition: GridLayoutPositionEnd,
+		})),
+		ButtonOpts.Text(w.nextLabel, w.buttonFace, w.buttonColor),
+		ButtonOpts.ClickedHandler(func(args *ButtonClickedEventArgs) {
+			w.Next()
+		}),
+	}...)...)
+	buttons.AddChild(w.nextButton)
+	w.buttonOpts = nil
+
+	w.container.AddChild(buttons)
+
+	w.setStep(0)
+}
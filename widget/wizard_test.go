@@ -0,0 +1,91 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/blizzy78/ebitenui/event"
+
+	"github.com/matryer/is"
+)
+
+func TestWizard_Next_Back(t *testing.T) {
+	is := is.New(t)
+
+	valid := false
+	steps := []*WizardStep{
+		{Title: "Name", Widget: newSimpleWidget(10, 10, nil), Validate: func() bool { return valid }},
+		{Title: "Class", Widget: newSimpleWidget(10, 10, nil)},
+	}
+
+	var eventArgs *WizardStepChangedEventArgs
+	finished := false
+	w := newWizard(t,
+		WizardOpts.Steps(steps...),
+		WizardOpts.StepChangedHandler(func(args *WizardStepChangedEventArgs) {
+			eventArgs = args
+		}),
+		WizardOpts.FinishedHandler(func(args *WizardFinishedEventArgs) {
+			finished = true
+		}))
+
+	is.Equal(w.Step(), steps[0])
+	is.True(w.backButton.GetWidget().Disabled)
+	is.True(w.nextButton.GetWidget().Disabled)
+
+	is.True(!w.Next())
+	is.Equal(w.StepIndex(), 0)
+
+	valid = true
+	render(w, t)
+	is.True(!w.nextButton.GetWidget().Disabled)
+
+	leftMouseButtonClick(w.nextButton, t)
+	is.Equal(w.Step(), steps[1])
+	is.Equal(eventArgs.PreviousIndex, 0)
+	is.Equal(eventArgs.Index, 1)
+	is.Equal(w.nextButton.Text().Label, "Finish")
+
+	is.True(w.Next())
+	is.True(finished)
+
+	w.Back()
+	is.Equal(w.Step(), steps[0])
+	is.Equal(w.nextButton.Text().Label, "Next")
+}
+
+func TestWizard_SetStepIndex(t *testing.T) {
+	is := is.New(t)
+
+	steps := []*WizardStep{
+		{Title: "1", Widget: newSimpleWidget(10, 10, nil)},
+		{Title: "2", Widget: newSimpleWidget(10, 10, nil), Validate: func() bool { return false }},
+		{Title: "3", Widget: newSimpleWidget(10, 10, nil)},
+	}
+
+	w := newWizard(t,
+		WizardOpts.Steps(steps...),
+		WizardOpts.Progress(loadFont(t), &WizardProgressColor{
+			Done:     color.White,
+			Current:  color.Black,
+			Upcoming: color.Transparent,
+		}))
+
+	is.True(!w.SetStepIndex(2))
+	is.True(w.SetStepIndex(1))
+	is.Equal(w.progress[0].Color, color.White)
+	is.Equal(w.progress[1].Color, color.Black)
+	is.Equal(w.progress[2].Color, color.Transparent)
+}
+
+func newWizard(t *testing.T, opts ...WizardOpt) *Wizard {
+	t.Helper()
+
+	w := NewWizard(append(opts, []WizardOpt{
+		WizardOpts.ButtonOpts(ButtonOpts.Image(&ButtonImage{Idle: newNineSliceEmpty(t)})),
+		WizardOpts.ButtonText(loadFont(t), &ButtonTextColor{Idle: color.White}),
+	}...)...)
+	event.ExecuteDeferred()
+	render(w, t)
+	return w
+}
@@ -0,0 +1,212 @@
+package widget
+
+import (
+	img "image"
+	"math"
+
+	"github.com/blizzy78/ebitenui/image"
+	"github.com/blizzy78/ebitenui/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// WorldAnchor decorates another widget, such as a nameplate, health bar, or interaction prompt, by displaying
+// it at the screen position of a position in the game world, so that it follows a game entity. The screen
+// position is determined each frame using a projection function.
+//
+// WorldAnchor positions itself and ignores locations set by its parent container's layout. It should be
+// added to a Container that does not use a layout.
+type WorldAnchor struct {
+	widget      PreferredSizeLocateableWidget
+	projectFunc WorldAnchorProjectFunc
+	offsetX     int
+	offsetY     int
+	pivotX      float64
+	pivotY      float64
+	clamp       bool
+	clampInsets Insets
+	fadeStart   float64
+	fadeEnd     float64
+
+	alpha     float64
+	visible   bool
+	renderBuf *image.BufferedImage
+}
+
+type WorldAnchorOpt func(w *WorldAnchor)
+
+// WorldAnchorProjectFunc is a function that returns the screen position of a position in the game world, its
+// distance from the camera, and whether it should be displayed at all, for example if it is behind the camera.
+type WorldAnchorProjectFunc func() (x float64, y float64, distance float64, visible bool)
+
+type WorldAnchorOptions struct {
+}
+
+var WorldAnchorOpts WorldAnchorOptions
+
+// NewWorldAnchor constructs a new WorldAnchor that displays w at the screen position returned by f.
+func NewWorldAnchor(w PreferredSizeLocateableWidget, f WorldAnchorProjectFunc, opts ...WorldAnchorOpt) *WorldAnchor {
+	a := &WorldAnchor{
+		widget:      w,
+		projectFunc: f,
+		pivotX:      0.5,
+		pivotY:      1,
+
+		alpha: 1,
+	}
+
+	for _, o := range opts {
+		o(a)
+	}
+
+	return a
+}
+
+// Offset configures a WorldAnchor to move the widget by x,y pixels from the projected screen position.
+func (o WorldAnchorOptions) Offset(x int, y int) WorldAnchorOpt {
+	return func(w *WorldAnchor) {
+		w.offsetX = x
+		w.offsetY = y
+	}
+}
+
+// Pivot configures a WorldAnchor to place the point of the widget at fractions x,y of its size at the projected
+// screen position. The default is 0.5,1, which places the widget's bottom center at the position.
+func (o WorldAnchorOptions) Pivot(x float64, y float64) WorldAnchorOpt {
+	return func(w *WorldAnchor) {
+		w.pivotX = x
+		w.pivotY = y
+	}
+}
+
+// ClampToScreen configures a WorldAnchor to keep the widget inside the screen, inset by i, even if the projected
+// screen position is off-screen.
+func (o WorldAnchorOptions) ClampToScreen(i Insets) WorldAnchorOpt {
+	return func(w *WorldAnchor) {
+		w.clamp = true
+		w.clampInsets = i
+	}
+}
+
+// FadeByDistance configures a WorldAnchor to fade out the widget between distances start and end. The widget is
+// fully visible up to start, and not displayed at all from end on.
+func (o WorldAnchorOptions) FadeByDistance(start float64, end float64) WorldAnchorOpt {
+	return func(w *WorldAnchor) {
+		w.fadeStart = start
+		w.fadeEnd = end
+	}
+}
+
+func (w *WorldAnchor) GetWidget() *Widget {
+	return w.widget.GetWidget()
+}
+
+func (w *WorldAnchor) PreferredSize() (int, int) {
+	return w.widget.PreferredSize()
+}
+
+// SetLocation implements Locateable. It does nothing, since w positions itself.
+func (w *WorldAnchor) SetLocation(rect img.Rectangle) {
+}
+
+func (w *WorldAnchor) RequestRelayout() {
+	if r, ok := w.widget.(Relayoutable); ok {
+		r.RequestRelayout()
+	}
+}
+
+func (w *WorldAnchor) SetupInputLayer(def input.DeferredSetupInputLayerFunc) {
+	if !w.visible {
+		return
+	}
+
+	if l, ok := w.widget.(input.Layerer); ok {
+		l.SetupInputLayer(def)
+	}
+}
+
+func (w *WorldAnchor) Render(screen *ebiten.Image, def DeferredRenderFunc) {
+	x, y, dist, visible := w.projectFunc()
+
+	w.alpha = w.fadeAlpha(dist)
+	w.visible = visible && w.alpha > 0
+	if !w.visible {
+		return
+	}
+
+	rect := w.rect(x, y, screen.Bounds())
+	if rect != w.widget.GetWidget().Rect {
+		w.widget.SetLocation(rect)
+		w.RequestRelayout()
+	}
+
+	r, ok := w.widget.(Renderer)
+	if !ok {
+		return
+	}
+
+	if w.alpha >= 1 {
+		r.Render(screen, def)
+		return
+	}
+
+	if w.renderBuf == nil {
+		w.renderBuf = &image.BufferedImage{}
+	}
+
+	b := screen.Bounds()
+	w.renderBuf.Width, w.renderBuf.Height = b.Max.X, b.Max.Y
+	buf := w.renderBuf.Image()
+	buf.Clear()
+
+	r.Render(buf, def)
+
+	rect = rect.Intersect(b)
+	if rect.Empty() {
+		return
+	}
+
+	opts := ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	opts.ColorM.Scale(1, 1, 1, w.alpha)
+	screen.DrawImage(buf.SubImage(rect).(*ebiten.Image), &opts)
+}
+
+// rect returns the rectangle to display w's widget in, for projected screen position x,y.
+func (w *WorldAnchor) rect(x float64, y float64, screen img.Rectangle) img.Rectangle {
+	ww, wh := w.widget.PreferredSize()
+
+	rx := int(math.Round(x-float64(ww)*w.pivotX)) + w.offsetX
+	ry := int(math.Round(y-float64(wh)*w.pivotY)) + w.offsetY
+	rect := img.Rect(rx, ry, rx+ww, ry+wh)
+
+	if !w.clamp {
+		return rect
+	}
+
+	bounds := w.clampInsets.Apply(screen)
+
+	if rect.Max.X > bounds.Max.X {
+		rect = rect.Sub(img.Point{rect.Max.X - bounds.Max.X, 0})
+	}
+	if rect.Min.X < bounds.Min.X {
+		rect = rect.Add(img.Point{bounds.Min.X - rect.Min.X, 0})
+	}
+	if rect.Max.Y > bounds.Max.Y {
+		rect = rect.Sub(img.Point{0, rect.Max.Y - bounds.Max.Y})
+	}
+	if rect.Min.Y < bounds.Min.Y {
+		rect = rect.Add(img.Point{0, bounds.Min.Y - rect.Min.Y})
+	}
+
+	return rect
+}
+
+// fadeAlpha returns the alpha value to display w's widget with at distance dist.
+func (w *WorldAnchor) fadeAlpha(dist float64) float64 {
+	if w.fadeEnd <= w.fadeStart {
+		return 1
+	}
+
+	return math.Max(0, math.Min(1, (w.fadeEnd-dist)/(w.fadeEnd-w.fadeStart)))
+}
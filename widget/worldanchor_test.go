@@ -0,0 +1,38 @@
+package widget
+
+import (
+	img "image"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWorldAnchor_Rect(t *testing.T) {
+	is := is.New(t)
+
+	a := NewWorldAnchor(newSimpleWidget(20, 10, nil), nil, WorldAnchorOpts.Offset(0, -5))
+
+	is.Equal(a.rect(100, 100, img.Rect(0, 0, 200, 200)), img.Rect(90, 85, 110, 95))
+}
+
+func TestWorldAnchor_Rect_Clamp(t *testing.T) {
+	is := is.New(t)
+
+	a := NewWorldAnchor(newSimpleWidget(20, 10, nil), nil,
+		WorldAnchorOpts.Pivot(0, 0),
+		WorldAnchorOpts.ClampToScreen(NewInsetsSimple(5)))
+
+	screen := img.Rect(0, 0, 200, 200)
+	is.Equal(a.rect(-50, 300, screen), img.Rect(5, 185, 25, 195))
+	is.Equal(a.rect(190, -10, screen), img.Rect(175, 5, 195, 15))
+}
+
+func TestWorldAnchor_FadeAlpha(t *testing.T) {
+	is := is.New(t)
+
+	a := NewWorldAnchor(newSimpleWidget(20, 10, nil), nil, WorldAnchorOpts.FadeByDistance(100, 200))
+
+	is.Equal(a.fadeAlpha(50), 1.0)
+	is.Equal(a.fadeAlpha(150), 0.5)
+	is.Equal(a.fadeAlpha(250), 0.0)
+}